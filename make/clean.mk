@@ -48,6 +48,15 @@ clean-toolchain-namespaces-in-dev:
 ##    * cluster-wide config
 clean-dev-resources: clean-users clean-toolchain-namespaces-in-dev clean-cluster-wide-config
 
+.PHONY: clean-e2e-run
+## Delete the resources created by a previous e2e run (eg, if the test binary was killed before the end of the tests),
+## in the host and member clusters. The ID of the run must be specified with RUN_ID=<id> (it is logged at the beginning of the tests)
+clean-e2e-run:
+ifeq ($(strip $(RUN_ID)),)
+	$(error RUN_ID must be set, eg. make clean-e2e-run RUN_ID=<id>)
+endif
+	$(Q)go run setup/main.go cleanup --run-id=${RUN_ID} --interactive=false $(if $(MEMBER_KUBECONFIG),--member-kubeconfig=${MEMBER_KUBECONFIG})
+
 .PHONY: clean-e2e-files
 ## Remove files and directories used during e2e test setup
 clean-e2e-files:
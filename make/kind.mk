@@ -0,0 +1,45 @@
+###########################################################
+#
+# Single-cluster (kind) Development Mode
+#
+###########################################################
+
+KIND_CLUSTER_NAME ?= toolchain-e2e
+KIND_HOST_NS ?= toolchain-host-operator
+KIND_MEMBER_NS ?= toolchain-member-operator
+OLM_VERSION ?= v0.24.0
+
+.PHONY: kind-dev-deploy
+## Create a kind cluster and deploy the host and a single member operator in it, from the given HOST_INDEX_IMAGE and MEMBER_INDEX_IMAGE
+kind-dev-deploy:
+ifeq ($(strip $(HOST_INDEX_IMAGE)),)
+	$(error HOST_INDEX_IMAGE is not set)
+endif
+ifeq ($(strip $(MEMBER_INDEX_IMAGE)),)
+	$(error MEMBER_INDEX_IMAGE is not set)
+endif
+	@-kind get clusters | grep -q "^${KIND_CLUSTER_NAME}$$" || kind create cluster --name ${KIND_CLUSTER_NAME}
+	@-kubectl get ns olm >/dev/null 2>&1 || operator-sdk olm install --version ${OLM_VERSION}
+	go run setup/main.go deploy --host-ns ${KIND_HOST_NS} --member-ns ${KIND_MEMBER_NS} --host-index-image ${HOST_INDEX_IMAGE} --member-index-image ${MEMBER_INDEX_IMAGE} --environment e2e-tests
+	$(MAKE) setup-toolchainclusters e2e-service-account HOST_NS=${KIND_HOST_NS} MEMBER_NS=${KIND_MEMBER_NS} SECOND_MEMBER_MODE=false E2E_TEST_EXECUTION=true
+	@echo "The operators are deployed in the '${KIND_CLUSTER_NAME}' kind cluster."
+	@echo "Run 'make kind-port-forward' and then 'make test-e2e-kind' to run the e2e tests."
+
+.PHONY: kind-port-forward
+## Forward the local ports to the registration service, the API proxy and the metrics services of the operators deployed with 'make kind-dev-deploy'
+kind-port-forward:
+	kubectl port-forward -n ${KIND_HOST_NS} svc/registration-service 8080:$$(kubectl get svc registration-service -n ${KIND_HOST_NS} -o jsonpath='{.spec.ports[0].port}') >/dev/null &
+	kubectl port-forward -n ${KIND_HOST_NS} svc/api 8081:$$(kubectl get svc api -n ${KIND_HOST_NS} -o jsonpath='{.spec.ports[0].port}') >/dev/null &
+	kubectl port-forward -n ${KIND_HOST_NS} svc/host-operator-metrics-service 8443:https >/dev/null &
+	kubectl port-forward -n ${KIND_MEMBER_NS} svc/member-operator-metrics-service 8444:https >/dev/null &
+	@echo "The ports are forwarded in the background. Run 'pkill -f \"kubectl port-forward\"' to stop them."
+
+.PHONY: test-e2e-kind
+## Run the subset of the e2e tests which does not require two member operators nor OpenShift routes, against the operators deployed with 'make kind-dev-deploy'
+test-e2e-kind:
+	E2E_SINGLE_CLUSTER=true $(MAKE) execute-tests HOST_NS=${KIND_HOST_NS} MEMBER_NS=${KIND_MEMBER_NS} MEMBER_NS_2= REGISTRATION_SERVICE_NS=${KIND_HOST_NS} TESTS_TO_EXECUTE="./test/e2e/parallel ./test/e2e" E2E_PARALLELISM=10
+
+.PHONY: kind-delete
+## Delete the kind cluster created with 'make kind-dev-deploy'
+kind-delete:
+	kind delete cluster --name ${KIND_CLUSTER_NAME}
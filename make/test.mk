@@ -36,16 +36,18 @@ endif
 
 E2E_PARALLELISM=1
 
+SOAK_DURATION ?= 1h
+
 .PHONY: test-e2e
 ## Run the e2e tests
 test-e2e: INSTALL_OPERATOR=true
-test-e2e: prepare-e2e verify-migration-and-deploy-e2e e2e-run-parallel e2e-run
+test-e2e: prepare-e2e verify-migration-and-deploy-e2e e2e-preflight e2e-run-parallel e2e-run
 	@echo "The tests successfully finished"
 	@echo "To clean the cluster run 'make clean-e2e-resources'"
 
 .PHONY: test-e2e-without-migration
 ## Run the e2e tests without migration tests
-test-e2e-without-migration: prepare-e2e deploy-e2e e2e-run-parallel e2e-run
+test-e2e-without-migration: prepare-e2e deploy-e2e e2e-preflight e2e-run-parallel e2e-run
 	@echo "To clean the cluster run 'make clean-e2e-resources'"
 
 .PHONY: verify-migration-and-deploy-e2e
@@ -111,6 +113,13 @@ test-e2e-host-local:
 test-e2e-registration-local:
 	$(MAKE) test-e2e REG_REPO_PATH=${PWD}/../registration-service
 
+.PHONY: e2e-preflight
+## Validate the environment (cluster connectivity, CRDs, operators, webhooks and registration service) before running the e2e tests
+e2e-preflight:
+	@echo "Running preflight checks..."
+	MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} go test ./test/preflight -count=1 -v -timeout=5m
+	@echo "The preflight checks successfully passed"
+
 .PHONY: e2e-run-parallel
 e2e-run-parallel:
 	@echo "Running e2e tests in parallel..."
@@ -123,6 +132,13 @@ e2e-run:
 	$(MAKE) execute-tests MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} TESTS_TO_EXECUTE="./test/e2e ./test/metrics"
 	@echo "The e2e tests successfully finished"
 
+.PHONY: e2e-run-soak
+## Run the soak test for the given SOAK_DURATION (eg, `make e2e-run-soak SOAK_DURATION=4h`) against an existing e2e environment
+e2e-run-soak:
+	@echo "Running soak test for ${SOAK_DURATION}..."
+	MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} go test ./test/soak -count=1 -v -timeout=0 -soak-duration=${SOAK_DURATION}
+	@echo "The soak test successfully finished"
+
 .PHONY: execute-tests
 execute-tests:
 	@echo "Present Spaces"
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupRunID             string
+	cleanupMemberKubeconfigs []string
+	cleanupTimeout           time.Duration
+)
+
+// newCleanupCmd returns the command to delete the resources created by a previous e2e suite run, whose cleaning tasks
+// were not performed (eg, because the test binary was OOM-killed)
+func newCleanupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "cleanup",
+		Short:         "delete the resources created by a previous e2e suite run (identified by its run ID) in the host and member clusters",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		Run:           cleanupRun,
+	}
+	cmd.Flags().StringVar(&cleanupRunID, "run-id", "", fmt.Sprintf("the ID of the e2e suite run (ie, the value of the '%s' env var or of the '%s' label of the resources)", wait.RunIDVar, wait.RunIDLabelKey))
+	cmd.Flags().StringSliceVar(&cleanupMemberKubeconfigs, "member-kubeconfig", []string{}, "the absolute paths to the kubeconfig files of the member clusters, if they are not the cluster of the --kubeconfig flag")
+	cmd.Flags().DurationVar(&cleanupTimeout, "timeout", 5*time.Minute, "the maximum duration to wait until all the resources of a cluster are deleted")
+	_ = cmd.MarkFlagRequired("run-id")
+	return cmd
+}
+
+func cleanupRun(cmd *cobra.Command, _ []string) {
+	cmd.SilenceUsage = true
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+
+	runID := wait.SanitizeLabelValue(cleanupRunID)
+	if runID == "" {
+		term.Fatalf(fmt.Errorf("value must not be empty"), "invalid run-id value '%s'", cleanupRunID)
+	}
+	// the host cluster first, then the member clusters which are not the same as the host cluster
+	kubeconfigs := []string{kubeconfig}
+	for _, path := range cleanupMemberKubeconfigs {
+		if path != kubeconfig {
+			kubeconfigs = append(kubeconfigs, path)
+		}
+	}
+	if interactive && !term.PromptBoolf("🧹 delete the resources of the e2e run '%s' in %d cluster(s)", runID, len(kubeconfigs)) {
+		return
+	}
+	for _, path := range kubeconfigs {
+		cl, config, _, err := cfg.NewClient(term, path)
+		if err != nil {
+			term.Fatalf(err, "cannot create client")
+		}
+		count, err := cleanup.DeleteLabelled(context.TODO(), cl, map[string]string{wait.RunIDLabelKey: runID}, cleanupTimeout, func(kind, namespace, name string) {
+			term.Debugf("deleted %s '%s' in namespace '%s'", kind, name, namespace)
+		})
+		if err != nil {
+			term.Fatalf(err, "failed to delete the resources of the e2e run '%s' on %s", runID, config.Host)
+		}
+		term.Infof("🗑  deleted %d resource(s) on %s", count, config.Host)
+	}
+	term.Infof("✅ all resources of the e2e run '%s' deleted", runID)
+}
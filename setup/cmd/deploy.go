@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/deploy"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+
+	"github.com/spf13/cobra"
+)
+
+var deployOpts = deploy.Options{}
+
+// newDeployCmd returns the command to deploy the host and member operators (and the registration service) with OLM,
+// as an alternative to `make dev-deploy-e2e` which can be run from any platform or IDE
+func newDeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "deploy",
+		Short:         "deploy the host and member operators (and the registration service) from the given index images with OLM",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		Run:           deployOperators,
+	}
+	cmd.Flags().StringVar(&deployOpts.HostNamespace, "host-ns", cfg.DefaultHostNS, "the namespace of Host operator (and of the registration service)")
+	cmd.Flags().StringSliceVar(&deployOpts.MemberNamespaces, "member-ns", []string{cfg.DefaultMemberNS}, "the namespaces of the Member operators, eg. \"--member-ns toolchain-member-operator,toolchain-member2-operator\"")
+	cmd.Flags().StringVar(&deployOpts.HostIndexImage, "host-index-image", "", "the index image with the bundles of the Host operator")
+	cmd.Flags().StringVar(&deployOpts.MemberIndexImage, "member-index-image", "", "the index image with the bundles of the Member operator")
+	cmd.Flags().StringVar(&deployOpts.Channel, "channel", "staging", "the channel of the subscriptions of the operators")
+	cmd.Flags().StringVar(&deployOpts.Environment, "environment", "dev", "the name of the directory of 'deploy/host-operator' with the host resources to create (eg, 'dev' or 'e2e-tests')")
+	_ = cmd.MarkFlagRequired("host-index-image")
+	_ = cmd.MarkFlagRequired("member-index-image")
+	return cmd
+}
+
+func deployOperators(cmd *cobra.Command, _ []string) {
+	cmd.SilenceUsage = true
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+
+	term.Infof("Host Operator Namespace:    '%s'", deployOpts.HostNamespace)
+	term.Infof("Member Operator Namespaces: '%v'", deployOpts.MemberNamespaces)
+	term.Infof("Host Index Image:           '%s'", deployOpts.HostIndexImage)
+	term.Infof("Member Index Image:         '%s'", deployOpts.MemberIndexImage)
+	term.Infof("Channel:                    '%s'", deployOpts.Channel)
+	term.Infof("Environment:                '%s'\n", deployOpts.Environment)
+
+	cl, config, scheme, err := cfg.NewClient(term, kubeconfig)
+	if err != nil {
+		term.Fatalf(err, "cannot create client")
+	}
+	if interactive && !term.PromptBoolf("🚀 deploy the host operator and %d member operators on %s", len(deployOpts.MemberNamespaces), config.Host) {
+		return
+	}
+	err = deploy.Deploy(cl, scheme, deployOpts, func(format string, args ...interface{}) {
+		term.Infof("⏳ "+format, args...)
+	})
+	if err != nil {
+		term.Fatalf(err, "failed to deploy the operators")
+	}
+	term.Infof("✅ the operators are deployed")
+	for _, ns := range deployOpts.MemberNamespaces {
+		term.Infof("register the member operator of namespace '%s' with 'make setup-toolchainclusters HOST_NS=%s MEMBER_NS=%s'", ns, deployOpts.HostNamespace, ns)
+	}
+}
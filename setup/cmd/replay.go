@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+
+	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	replayer "github.com/codeready-toolchain/toolchain-e2e/setup/replay"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var clusterKubeconfigs map[string]string
+
+// newReplayCmd returns the command to replay a script recorded by a failed e2e test (see `testsupport.RecordScenario`)
+func newReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "replay <script>",
+		Short:         "replay the mutating API calls recorded by a failed e2e test against a dev cluster",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run:           replay,
+	}
+	cmd.Flags().StringToStringVar(&clusterKubeconfigs, "cluster-kubeconfig", map[string]string{}, "the absolute paths to the kubeconfig files of the clusters of the script, eg. \"--cluster-kubeconfig host=/path/to/host,member-cluster=/path/to/member\" (by default, the kubeconfig of the --kubeconfig flag is used for all the clusters)")
+	return cmd
+}
+
+func replay(cmd *cobra.Command, args []string) {
+	cmd.SilenceUsage = true
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+
+	script, err := wait.LoadReplayScript(args[0])
+	if err != nil {
+		term.Fatalf(err, "cannot load the replay script '%s'", args[0])
+	}
+	term.Infof("Test:  '%s'", script.Test)
+	term.Infof("Steps: '%d'\n", len(script.Steps))
+
+	clients, err := replayer.NewClients(script, clusterKubeconfigs, kubeconfig, func(path string) (client.Client, error) {
+		cl, _, _, err := cfg.NewClient(term, path)
+		return cl, err
+	})
+	if err != nil {
+		term.Fatalf(err, "cannot create the clients")
+	}
+
+	if interactive && !term.PromptBoolf("▶️  replay the %d steps of test '%s'", len(script.Steps), script.Test) {
+		return
+	}
+	err = wait.Replay(context.TODO(), clients, script, func(i int, step wait.ReplayStep) {
+		term.Infof("[%d/%d] %s", i+1, len(script.Steps), step)
+	})
+	if err != nil {
+		term.Fatalf(err, "replay failed")
+	}
+	term.Infof("✅ all steps replayed")
+}
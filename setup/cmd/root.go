@@ -12,16 +12,21 @@ import (
 
 	"github.com/codeready-toolchain/toolchain-e2e/setup/auth"
 	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/dashboard"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/idlers"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/metrics"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/metrics/queries"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/operators"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/profiles"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/resources"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/templates"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/users"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/wait"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/gosuri/uiprogress"
 	"github.com/gosuri/uitable/util/strutil"
@@ -44,6 +49,15 @@ var (
 	idlerTimeout         string
 	token                string
 	workloads            []string
+	cpuLimit             string
+	memoryLimit          string
+	templateConcurrency  int
+	showDashboard        bool
+	memberWeights        map[string]int
+	weightsTolerance     float64
+	profilesInterval     time.Duration
+	profilesDir          string
+	pprofPort            int
 )
 
 var (
@@ -64,8 +78,8 @@ func Execute() {
 	}
 
 	cmd.Flags().StringVar(&usernamePrefix, "username", usernamePrefix, "the prefix used for usersignup names")
-	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "absolute path to the kubeconfig file")
-	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "if 'debug' traces should be displayed in the console")
+	cmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "absolute path to the kubeconfig file")
+	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "if 'debug' traces should be displayed in the console")
 	cmd.Flags().IntVarP(&numberOfUsers, "users", "u", 2000, "the number of user accounts to provision")
 	cmd.Flags().IntVarP(&userBatches, "batch", "b", 25, "create user accounts in batches of N, increasing batch size may cause performance problems")
 	cmd.Flags().StringVar(&cfg.HostOperatorNamespace, "host-ns", cfg.DefaultHostNS, "the namespace of Host operator")
@@ -75,11 +89,25 @@ func Execute() {
 	cmd.Flags().IntVarP(&customTemplateUsers, cfg.CustomTemplateUsersParam, "c", 2000, "how many users will have the custom user workloads template applied")
 	cmd.Flags().BoolVar(&skipCSVGen, "skip-csvgen", false, "if an all-namespaces operator should be installed to generate a CSV resource in each namespace")
 	cmd.Flags().BoolVar(&skipIdlerSetup, "skip-idler", false, "if the idler timeout should be modified for each user")
-	cmd.Flags().BoolVar(&interactive, "interactive", true, "if user is prompted to confirm all actions")
+	cmd.PersistentFlags().BoolVar(&interactive, "interactive", true, "if user is prompted to confirm all actions")
 	cmd.Flags().IntVar(&operatorsLimit, "operators-limit", len(operators.Templates), "can be specified to limit the number of additional operators to install (by default all operators are installed to simulate cluster load in production)")
 	cmd.Flags().StringVarP(&idlerTimeout, "idler-timeout", "i", "15s", "overrides the default idler timeout")
 	cmd.Flags().StringVarP(&token, "token", "t", "", "Openshift API token")
+	cmd.Flags().StringVar(&cpuLimit, "cpu-limit", "", "the maximum CPU usage of the host and member operators during the setup (eg, '500m' or '1'): the run fails as soon as it is exceeded")
+	cmd.Flags().StringVar(&memoryLimit, "memory-limit", "", "the maximum memory usage of the host and member operators during the setup (eg, '512Mi'): the run fails as soon as it is exceeded")
+	cmd.Flags().IntVar(&templateConcurrency, "template-concurrency", 0, "the maximum number of objects of the templates applied concurrently for each user (by default, all the objects are applied concurrently)")
+	cmd.Flags().BoolVar(&showDashboard, "dashboard", false, "if a live dashboard with the progress and timing of each phase, the error counts and the resource usage of the operators should be displayed instead of the progress bars")
+	cmd.Flags().StringToIntVar(&memberWeights, "member-weights", map[string]int{}, "the weights of the member clusters (by ToolchainCluster name) in which the users are provisioned, eg. \"--member-weights member-a=70,member-b=30\" (by default, all users are provisioned in the cluster of the member operator namespace)")
+	cmd.Flags().Float64Var(&weightsTolerance, "member-weights-tolerance", 0.05, "the maximum difference between the expected and actual ratio of users provisioned in each member cluster (eg, 0.05 for 5 percentage points)")
+	cmd.Flags().DurationVar(&profilesInterval, "profiles-interval", 0, "the interval at which the CPU and heap pprof profiles of the host and member operators are captured during the setup, eg. '5m' (disabled by default)")
+	cmd.Flags().StringVar(&profilesDir, "profiles-dir", "profiles", "the directory in which the pprof profiles are stored, in a sub-directory named after the username prefix and the start time of the run")
+	cmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "the port on which the host and member operators serve the 'net/http/pprof' endpoints")
 	cmd.Flags().StringSliceVar(&workloads, "workloads", []string{}, "workload namespace:name pairs that should have metrics collected during the setup. all values are comma-separated eg. \"--workloads service-binding-operator:service-binding-operator,rhoas-operator:rhoas-operator\"")
+	cmd.AddCommand(newReplayCmd())
+	cmd.AddCommand(newSeedCmd())
+	cmd.AddCommand(newUnseedCmd())
+	cmd.AddCommand(newDeployCmd())
+	cmd.AddCommand(newCleanupCmd())
 
 	if err := cmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -96,7 +124,9 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 	term.Infof("Custom Template Users:     '%d'", customTemplateUsers)
 	term.Infof("User Batch Size:           '%d'", userBatches)
 	term.Infof("Host Operator Namespace:   '%s'", cfg.HostOperatorNamespace)
-	term.Infof("Member Operator Namespace: '%s'\n", cfg.MemberOperatorNamespace)
+	term.Infof("Member Operator Namespace: '%s'", cfg.MemberOperatorNamespace)
+	term.Infof("Operators CPU Limit:       '%s'", cpuLimit)
+	term.Infof("Operators Memory Limit:    '%s'\n", memoryLimit)
 
 	// validate params
 	if numberOfUsers < 1 {
@@ -129,6 +159,31 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 		}
 	}
 
+	var cpuGate, memoryGate *resource.Quantity
+	if cpuLimit != "" {
+		q, err := resource.ParseQuantity(cpuLimit)
+		if err != nil {
+			term.Fatalf(err, "invalid cpu-limit value '%s'", cpuLimit)
+		}
+		cpuGate = &q
+	}
+	if memoryLimit != "" {
+		q, err := resource.ParseQuantity(memoryLimit)
+		if err != nil {
+			term.Fatalf(err, "invalid memory-limit value '%s'", memoryLimit)
+		}
+		memoryGate = &q
+	}
+
+	if profilesInterval < 0 {
+		term.Fatalf(fmt.Errorf("value must be 0 or more"), "invalid profiles-interval value '%s'", profilesInterval)
+	}
+
+	if templateConcurrency < 0 {
+		term.Fatalf(fmt.Errorf("value must be 0 or more"), "invalid template-concurrency value '%d'", templateConcurrency)
+	}
+	templates.Concurrency = templateConcurrency
+
 	// add the default user-workloads.yaml file automatically
 	defaultTemplatePath := "setup/resources/user-workloads.yaml"
 
@@ -138,6 +193,17 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 		term.Fatalf(err, "cannot create client")
 	}
 
+	var placement *users.Placement
+	if len(memberWeights) > 0 {
+		if placement, err = users.NewPlacement(memberWeights); err != nil {
+			term.Fatalf(err, "invalid member-weights value '%v'", memberWeights)
+		}
+		if err := users.VerifyMemberClusters(cl, cfg.HostOperatorNamespace, placement.Clusters()); err != nil {
+			term.Fatalf(err, "invalid member-weights value '%v'", memberWeights)
+		}
+		term.Infof("⚖️  member cluster weights: %v", memberWeights)
+	}
+
 	if len(token) == 0 {
 		token, err = auth.GetTokenFromOC()
 		if err != nil {
@@ -210,23 +276,58 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 		)
 	}
 
+	// fail the run if the operators exceed the CPU/memory limits
+	for _, workload := range []string{cfg.HostOperatorWorkload, cfg.MemberOperatorWorkload} {
+		if cpuGate != nil {
+			metricsInstance.AddGate(queries.WorkloadCPUUsageName(workload), cpuGate.AsApproximateFloat64())
+		}
+		if memoryGate != nil {
+			metricsInstance.AddGate(queries.WorkloadMemoryUsageName(workload), memoryGate.AsApproximateFloat64())
+		}
+	}
+
+	// ensure the breakdown of the errors is printed if there's a fatal error
+	term.AddPreFatalExitHook(printTemplateErrors(term))
+
 	// start gathering metrics
 	stopMetrics := metricsInstance.StartGathering()
 
-	uip := uiprogress.New()
-	uip.Start()
+	// start capturing the profiles of the operators
+	var profilesCapturer *profiles.Capturer
+	if profilesInterval > 0 {
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			term.Fatalf(err, "cannot create clientset")
+		}
+		runDir := filepath.Join(profilesDir, fmt.Sprintf("%s-%s", usernamePrefix, time.Now().Format("20060102-150405")))
+		profilesCapturer = profiles.New(term, clientset, runDir, pprofPort, profilesInterval)
+		profilesCapturer.AddWorkload(cfg.HostOperatorNamespace, cfg.HostOperatorWorkload)
+		profilesCapturer.AddWorkload(cfg.MemberOperatorNamespace, cfg.MemberOperatorWorkload)
+		term.AddPreFatalExitHook(profilesCapturer.PrintResults)
+		if stopProfiles := profilesCapturer.StartCapturing(); stopProfiles != nil {
+			defer close(stopProfiles)
+		}
+	}
+
+	addBar, stopProgress := startProgress(cmd, metricsInstance)
 
 	// start the progress bars in go routines
 	var wg sync.WaitGroup
-	usersignupBar := uip.AddBar(numberOfUsers).AppendCompleted().PrependFunc(func(b *uiprogress.Bar) string {
-		return strutil.PadLeft(fmt.Sprintf("user signups (%d/%d)", b.Current(), numberOfUsers), 25, ' ')
-	})
+	usersignupBar := addBar("user signups", numberOfUsers)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		for usersignupBar.Incr() {
 			username := fmt.Sprintf("%s-%04d", usernamePrefix, usersignupBar.Current())
-			if err := users.Create(cl, username, cfg.HostOperatorNamespace, cfg.MemberOperatorNamespace); err != nil {
+			createUser := func() error {
+				return users.Create(cl, username, cfg.HostOperatorNamespace, cfg.MemberOperatorNamespace)
+			}
+			if placement != nil {
+				createUser = func() error {
+					return users.CreateInCluster(cl, username, cfg.HostOperatorNamespace, placement.Next())
+				}
+			}
+			if err := createUser(); err != nil {
 				term.Fatalf(err, "failed to provision user '%s'", username)
 			}
 			time.Sleep(time.Millisecond * 20)
@@ -245,9 +346,7 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 	}()
 
 	if !skipIdlerSetup {
-		idlerBar := uip.AddBar(numberOfUsers).AppendCompleted().PrependFunc(func(b *uiprogress.Bar) string {
-			return strutil.PadLeft(fmt.Sprintf("idler setup (%d/%d)", b.Current(), numberOfUsers), 25, ' ')
-		})
+		idlerBar := addBar("idler setup", numberOfUsers)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -267,9 +366,7 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 	}
 
 	if defaultTemplateUsers > 0 {
-		defaultUserSetupBar := uip.AddBar(numberOfUsers).AppendCompleted().PrependFunc(func(b *uiprogress.Bar) string {
-			return strutil.PadLeft(fmt.Sprintf("setup users with default template (%d/%d)", b.Current(), numberOfUsers), 25, ' ')
-		})
+		defaultUserSetupBar := addBar("setup users with default template", numberOfUsers)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -291,9 +388,7 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 	}
 
 	if customTemplateUsers > 0 && len(customTemplatePaths) > 0 {
-		customUserSetupBar := uip.AddBar(numberOfUsers).AppendCompleted().PrependFunc(func(b *uiprogress.Bar) string {
-			return strutil.PadLeft(fmt.Sprintf("setup users with custom templates (%d/%d)", b.Current(), numberOfUsers), 25, ' ')
-		})
+		customUserSetupBar := addBar("setup users with custom templates", numberOfUsers)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -316,10 +411,18 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 
 	defer close(stopMetrics)
 	wg.Wait()
-	uip.Stop()
+	stopProgress()
 
 	term.Infof("🏁 done provisioning users")
 
+	if placement != nil {
+		counts, err := placement.VerifyDistribution(cl, cfg.HostOperatorNamespace, usernamePrefix, weightsTolerance)
+		term.Infof("Spaces by member cluster: %v", counts)
+		if err != nil {
+			term.Fatalf(err, "unexpected distribution of the users across the member clusters")
+		}
+	}
+
 	// continue gathering metrics for some time after creating all users and resources since memory usage was observed to continue changing
 	additionalMetricsDuration := 15 * time.Minute
 	term.Infof("Continuing to gather metrics for %s...", additionalMetricsDuration)
@@ -329,9 +432,62 @@ func setup(cmd *cobra.Command, _ []string) { // nolint:gocyclo
 	term.Infof("Average Idler Update Time: %.2f s", AverageIdlerUpdateTime.Seconds()/float64(numberOfUsers))
 	term.Infof("Average Time Per User: %.2f s", AverageTimePerUser.Seconds()/float64(numberOfUsers))
 	metricsInstance.PrintResults()
+	if profilesCapturer != nil {
+		profilesCapturer.PrintResults()
+	}
+	printTemplateErrors(term)()
 	term.Infof("👋 have fun!")
 }
 
+// printTemplateErrors returns a func which prints the breakdown by class of the errors which occurred while applying the templates
+func printTemplateErrors(term terminal.Terminal) func() {
+	return func() {
+		lines := templates.Errors.Lines()
+		if len(lines) == 0 {
+			term.Infof("Template Errors: none")
+			return
+		}
+		term.Infof("Template Errors:")
+		for _, l := range lines {
+			term.Infof(" - %s", l)
+		}
+	}
+}
+
+// progressBar the progress of a phase of the setup
+type progressBar interface {
+	Incr() bool
+	Current() int
+}
+
+// startProgress starts rendering the progress of the phases of the setup, either with progress bars or with the dashboard,
+// and returns the funcs to add a phase and to stop the rendering
+func startProgress(cmd *cobra.Command, metricsInstance *metrics.Gatherer) (func(name string, total int) progressBar, func()) {
+	if showDashboard {
+		d := dashboard.New(cmd.OutOrStdout(), time.Second)
+		d.AddSection("Template Errors", templates.Errors.Lines)
+		d.AddSection("Operators Resource Usage", func() []string {
+			return metricsInstance.Latest(
+				queries.WorkloadCPUUsageName(cfg.HostOperatorWorkload),
+				queries.WorkloadMemoryUsageName(cfg.HostOperatorWorkload),
+				queries.WorkloadCPUUsageName(cfg.MemberOperatorWorkload),
+				queries.WorkloadMemoryUsageName(cfg.MemberOperatorWorkload),
+			)
+		})
+		d.Start()
+		return func(name string, total int) progressBar {
+			return d.AddPhase(name, total)
+		}, d.Stop
+	}
+	uip := uiprogress.New()
+	uip.Start()
+	return func(name string, total int) progressBar {
+		return uip.AddBar(total).AppendCompleted().PrependFunc(func(b *uiprogress.Bar) string {
+			return strutil.PadLeft(fmt.Sprintf("%s (%d/%d)", name, b.Current(), total), 25, ' ')
+		})
+	}, uip.Stop
+}
+
 func usersWithinBounds(term terminal.Terminal, value int, templateType string) {
 	if value < 0 || value > numberOfUsers {
 		term.Fatalf(fmt.Errorf("value must be between 0 and %d", numberOfUsers), "invalid '%s' users value '%d'", templateType, value)
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/operators"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/seed"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+
+	"github.com/spf13/cobra"
+)
+
+var seedTemplatePaths []string
+
+// newSeedCmd returns the command to provision a small set of demo users with workloads, to explore a new build of the operators
+func newSeedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "seed",
+		Short:         "provision a small set of demo users with workloads for manual exploratory testing (existing demo users are left unchanged)",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		Run:           seedDemoUsers,
+	}
+	cmd.Flags().StringVar(&cfg.HostOperatorNamespace, "host-ns", cfg.DefaultHostNS, "the namespace of Host operator")
+	cmd.Flags().StringVar(&cfg.MemberOperatorNamespace, "member-ns", cfg.DefaultMemberNS, "the namespace of the Member operator")
+	cmd.Flags().StringSliceVar(&seedTemplatePaths, "template", []string{"setup/resources/user-workloads.yaml"}, "the path to the OpenShift templates of the workloads to apply for the demo users")
+	return cmd
+}
+
+// newUnseedCmd returns the command to delete the demo users provisioned by the `seed` command
+func newUnseedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "unseed",
+		Short:         "delete the demo users provisioned by the 'seed' command",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		Run:           unseedDemoUsers,
+	}
+	cmd.Flags().StringVar(&cfg.HostOperatorNamespace, "host-ns", cfg.DefaultHostNS, "the namespace of Host operator")
+	return cmd
+}
+
+func seedDemoUsers(cmd *cobra.Command, _ []string) {
+	cmd.SilenceUsage = true
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+
+	cl, config, scheme, err := cfg.NewClient(term, kubeconfig)
+	if err != nil {
+		term.Fatalf(err, "cannot create client")
+	}
+	if interactive && !term.PromptBoolf("🌱 provision %d demo users on %s", len(seed.DemoUsers), config.Host) {
+		return
+	}
+	if err := operators.VerifySandboxOperatorsInstalled(cl); err != nil {
+		term.Fatalf(err, "ensure the sandbox host and member operators are installed successfully before seeding the demo users")
+	}
+	err = seed.Seed(cl, scheme, cfg.HostOperatorNamespace, cfg.MemberOperatorNamespace, seedTemplatePaths, func(user seed.DemoUser, created bool) {
+		if created {
+			term.Infof("👤 demo user '%s' provisioned", user.Name)
+		} else {
+			term.Infof("👤 demo user '%s' already exists", user.Name)
+		}
+	})
+	if err != nil {
+		term.Fatalf(err, "failed to seed the demo users")
+	}
+	term.Infof("✅ all demo users seeded")
+}
+
+func unseedDemoUsers(cmd *cobra.Command, _ []string) {
+	cmd.SilenceUsage = true
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+
+	cl, config, _, err := cfg.NewClient(term, kubeconfig)
+	if err != nil {
+		term.Fatalf(err, "cannot create client")
+	}
+	if interactive && !term.PromptBoolf("🧹 delete the %d demo users on %s", len(seed.DemoUsers), config.Host) {
+		return
+	}
+	err = seed.Unseed(cl, cfg.HostOperatorNamespace, func(user seed.DemoUser, deleted bool) {
+		if deleted {
+			term.Infof("👤 demo user '%s' deleted", user.Name)
+		} else {
+			term.Infof("👤 demo user '%s' does not exist", user.Name)
+		}
+	})
+	if err != nil {
+		term.Fatalf(err, "failed to unseed the demo users")
+	}
+	term.Infof("✅ all demo users unseeded")
+}
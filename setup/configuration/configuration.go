@@ -15,6 +15,7 @@ import (
 	templatev1 "github.com/openshift/api/template/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -84,6 +85,7 @@ func NewScheme() (*runtime.Scheme, error) {
 		templatev1.Install,
 		routev1.Install,
 		appsv1.AddToScheme,
+		corev1.AddToScheme,
 	)
 	err := builder.AddToScheme(s)
 	return s, err
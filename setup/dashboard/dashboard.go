@@ -0,0 +1,182 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// clearScreen the ANSI escape sequence which moves the cursor to the top left corner and clears the screen
+	clearScreen = "\033[H\033[2J"
+	barWidth    = 30
+)
+
+// Dashboard renders the live progress of the setup: the progress and timing of each phase (eg, the creation of the user signups),
+// followed by sections of lines which are refreshed at each rendering (eg, the error counts or the resource usage of the operators)
+type Dashboard struct {
+	out             io.Writer
+	refreshInterval time.Duration
+	start           time.Time
+	lock            sync.Mutex
+	phases          []*Phase
+	sections        []section
+	stop            chan struct{}
+	done            chan struct{}
+}
+
+type section struct {
+	title string
+	lines func() []string
+}
+
+// New returns a new Dashboard which renders to the given writer at the given interval
+func New(out io.Writer, refreshInterval time.Duration) *Dashboard {
+	return &Dashboard{
+		out:             out,
+		refreshInterval: refreshInterval,
+		start:           time.Now(),
+	}
+}
+
+// AddPhase adds a phase with the given name and total number of steps
+func (d *Dashboard) AddPhase(name string, total int) *Phase {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	p := &Phase{
+		name:  name,
+		total: total,
+	}
+	d.phases = append(d.phases, p)
+	return p
+}
+
+// AddSection adds a section with the given title, whose lines are retrieved with the given func at each rendering
+func (d *Dashboard) AddSection(title string, lines func() []string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.sections = append(d.sections, section{
+		title: title,
+		lines: lines,
+	})
+}
+
+// Start starts rendering the dashboard in the background until Stop is called
+func (d *Dashboard) Start() {
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(d.refreshInterval)
+		defer ticker.Stop()
+		for {
+			d.render()
+			select {
+			case <-ticker.C:
+			case <-d.stop:
+				d.render()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops rendering the dashboard, after a last rendering
+func (d *Dashboard) Stop() {
+	close(d.stop)
+	<-d.done
+}
+
+func (d *Dashboard) render() {
+	buf := &bytes.Buffer{}
+	buf.WriteString(clearScreen)
+	buf.WriteString(d.String())
+	_, _ = d.out.Write(buf.Bytes())
+}
+
+// String returns the content of the dashboard
+func (d *Dashboard) String() string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "Setup running for %s\n\n", time.Since(d.start).Round(time.Second))
+	nameWidth := 0
+	for _, p := range d.phases {
+		if len(p.name) > nameWidth {
+			nameWidth = len(p.name)
+		}
+	}
+	for _, p := range d.phases {
+		fmt.Fprintf(buf, "%-*s %s\n", nameWidth, p.name, p.String())
+	}
+	for _, s := range d.sections {
+		fmt.Fprintf(buf, "\n%s:\n", s.title)
+		lines := s.lines()
+		if len(lines) == 0 {
+			buf.WriteString("  none\n")
+		}
+		for _, l := range lines {
+			fmt.Fprintf(buf, "  %s\n", l)
+		}
+	}
+	return buf.String()
+}
+
+// Phase a phase of the setup, with a number of steps
+type Phase struct {
+	lock    sync.Mutex
+	name    string
+	total   int
+	current int
+	started time.Time
+	ended   time.Time
+}
+
+// Incr increments the number of completed steps of the phase, and returns false if the phase was already completed.
+// The timing of the phase starts with the first step.
+func (p *Phase) Incr() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.current == p.total {
+		if p.ended.IsZero() {
+			p.ended = time.Now()
+		}
+		return false
+	}
+	if p.started.IsZero() {
+		p.started = time.Now()
+	}
+	p.current++
+	return true
+}
+
+// Current returns the number of completed steps of the phase
+func (p *Phase) Current() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.current
+}
+
+// String returns the progress bar, the number of completed steps and the duration of the phase
+func (p *Phase) String() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	completed := 0
+	if p.total > 0 {
+		completed = barWidth * p.current / p.total
+	}
+	bar := "[" + strings.Repeat("=", completed) + strings.Repeat("-", barWidth-completed) + "]"
+	var duration time.Duration
+	switch {
+	case p.started.IsZero():
+		return fmt.Sprintf("%s %d/%d (not started)", bar, p.current, p.total)
+	case p.ended.IsZero():
+		duration = time.Since(p.started)
+	default:
+		duration = p.ended.Sub(p.started)
+	}
+	return fmt.Sprintf("%s %d/%d (%s)", bar, p.current, p.total, duration.Round(time.Second))
+}
@@ -0,0 +1,86 @@
+package dashboard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhase(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		// given
+		p := &Phase{name: "user signups", total: 4}
+
+		// then
+		assert.Equal(t, 0, p.Current())
+		assert.Equal(t, "[------------------------------] 0/4 (not started)", p.String())
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		// given
+		p := &Phase{name: "user signups", total: 4}
+
+		// when
+		ok := p.Incr()
+
+		// then
+		assert.True(t, ok)
+		assert.Equal(t, 1, p.Current())
+		assert.True(t, strings.HasPrefix(p.String(), "[=======-----------------------] 1/4 ("))
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		// given
+		p := &Phase{name: "user signups", total: 2}
+
+		// when
+		count := 0
+		for p.Incr() {
+			count++
+		}
+
+		// then
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 2, p.Current())
+		assert.False(t, p.ended.IsZero())
+		assert.False(t, p.Incr())
+	})
+}
+
+func TestDashboard(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	d := New(out, time.Millisecond)
+	signups := d.AddPhase("user signups", 2)
+	d.AddPhase("idler setup", 2)
+	d.AddSection("Template Errors", func() []string {
+		return []string{"conflict: 2 retried, 0 failed"}
+	})
+	d.AddSection("Operators Resource Usage", func() []string {
+		return nil
+	})
+	signups.Incr()
+
+	// when
+	content := d.String()
+
+	// then
+	assert.Contains(t, content, "user signups [===============---------------] 1/2 (")
+	assert.Contains(t, content, "idler setup  [------------------------------] 0/2 (not started)")
+	assert.Contains(t, content, "Template Errors:\n  conflict: 2 retried, 0 failed\n")
+	assert.Contains(t, content, "Operators Resource Usage:\n  none\n")
+
+	t.Run("start and stop", func(t *testing.T) {
+		// when
+		d.Start()
+		d.Stop()
+
+		// then
+		require.True(t, strings.HasPrefix(out.String(), clearScreen))
+		assert.Contains(t, out.String(), "user signups")
+	})
+}
@@ -0,0 +1,145 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	ctemplate "github.com/codeready-toolchain/toolchain-common/pkg/template"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/operators"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/templates"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/wait"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperatorTemplatePath the path of the template to install the host or member operator with OLM
+	OperatorTemplatePath = "setup/deploy/templates/toolchain-operator.yaml"
+	// NetworkPoliciesTemplatePath the path of the template of the default network policies of the namespaces of the operators
+	NetworkPoliciesTemplatePath = "make/resources/default-network-policies.yaml"
+	// HostResourcesDir the path of the directory with the host resources of each environment
+	HostResourcesDir = "deploy/host-operator"
+
+	hostOperatorName              = "host-operator"
+	memberOperatorName            = "member-operator"
+	hostOperatorDeployment        = "host-operator-controller-manager"
+	memberOperatorDeployment      = "member-operator-controller-manager"
+	registrationServiceDeployment = "registration-service"
+)
+
+// Options the options of the deployment of the operators
+type Options struct {
+	HostNamespace    string
+	MemberNamespaces []string
+	// HostIndexImage the image of the index (catalog) of the host operator bundles
+	HostIndexImage string
+	// MemberIndexImage the image of the index (catalog) of the member operator bundles
+	MemberIndexImage string
+	// Channel the channel of the subscriptions of the operators
+	Channel string
+	// Environment the name of the directory of `deploy/host-operator` with the host resources (eg, `dev` or `e2e-tests`)
+	Environment string
+}
+
+// Deploy installs the host operator (which deploys the registration service) and the member operators with OLM, creates the
+// host resources of the environment, and waits until all the deployments are ready. As with `make dev-deploy-e2e`,
+// the host operator is installed first, so that the ToolchainConfig exists when the member operators start.
+// It can be run several times: the existing resources are updated (or left unchanged for the host resources).
+// The given func is called before each step.
+func Deploy(cl client.Client, s *runtime.Scheme, opts Options, progress func(format string, args ...interface{})) error {
+	progress("installing the host operator in namespace '%s'", opts.HostNamespace)
+	if err := installOperator(cl, s, hostOperatorName, opts.HostNamespace, opts.HostIndexImage, opts.Channel); err != nil {
+		return err
+	}
+	progress("creating the host resources of environment '%s'", opts.Environment)
+	if err := CreateHostResources(cl, opts.HostNamespace, filepath.Join(HostResourcesDir, opts.Environment)); err != nil {
+		return err
+	}
+	for _, ns := range opts.MemberNamespaces {
+		progress("installing the member operator in namespace '%s'", ns)
+		if err := installOperator(cl, s, memberOperatorName, ns, opts.MemberIndexImage, opts.Channel); err != nil {
+			return err
+		}
+	}
+
+	progress("waiting for the deployments to be ready")
+	if err := wait.ForDeploymentReady(cl, hostOperatorDeployment, opts.HostNamespace); err != nil {
+		return err
+	}
+	if err := wait.ForDeploymentReady(cl, registrationServiceDeployment, opts.HostNamespace); err != nil {
+		return err
+	}
+	for _, ns := range opts.MemberNamespaces {
+		if err := wait.ForDeploymentReady(cl, memberOperatorDeployment, ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installOperator installs the operator with the given name in the given namespace (which is created if needed) with OLM,
+// and applies the default network policies in the namespace
+func installOperator(cl client.Client, s *runtime.Scheme, name, namespace, indexImage, channel string) error {
+	if err := operators.EnsureOperatorInstalled(cl, s, OperatorTemplatePath, map[string]string{
+		"NAMESPACE":     namespace,
+		"OPERATOR_NAME": name,
+		"INDEX_IMAGE":   indexImage,
+		"CHANNEL":       channel,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to install the %s in namespace '%s'", name, namespace)
+	}
+	tmpl, err := templates.GetTemplateFromFile(NetworkPoliciesTemplatePath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid template file: '%s'", NetworkPoliciesTemplatePath)
+	}
+	objs, err := ctemplate.NewProcessor(s).Process(tmpl, map[string]string{
+		"NAMESPACE": namespace,
+	})
+	if err != nil {
+		return err
+	}
+	return templates.ApplyObjects(cl, objs)
+}
+
+// CreateHostResources creates the resources of the YAML files of the given directory in the given namespace,
+// unless they already exist (eg, the NSTemplateTiers which may have already been created by the host operator)
+func CreateHostResources(cl client.Client, namespace, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return errors.Wrapf(err, "invalid resource file: '%s'", f)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			obj.SetNamespace(namespace)
+			if err := cl.Create(context.TODO(), obj); err != nil && !k8serrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "unable to create %s '%s' of file '%s'", obj.GetKind(), obj.GetName(), f)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCreateHostResources(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toolchainconfig.yaml"), []byte(`apiVersion: toolchain.dev.openshift.com/v1alpha1
+kind: ToolchainConfig
+metadata:
+  name: config
+spec:
+  host:
+    automaticApproval:
+      enabled: true
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: host-operator-secret
+type: Opaque
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: toolchain-e2e-secret
+type: Opaque
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(`not a resource`), 0600))
+	cl := test.NewFakeClient(t)
+
+	// when
+	err := CreateHostResources(cl, "toolchain-host-operator", dir)
+
+	// then
+	require.NoError(t, err)
+	config := &toolchainv1alpha1.ToolchainConfig{}
+	require.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Namespace: "toolchain-host-operator", Name: "config"}, config))
+	require.NotNil(t, config.Spec.Host.AutomaticApproval.Enabled)
+	assert.True(t, *config.Spec.Host.AutomaticApproval.Enabled)
+	for _, name := range []string{"host-operator-secret", "toolchain-e2e-secret"} {
+		require.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Namespace: "toolchain-host-operator", Name: name}, &corev1.Secret{}))
+	}
+
+	t.Run("existing resources are left unchanged", func(t *testing.T) {
+		// given
+		disabled := false
+		config.Spec.Host.AutomaticApproval.Enabled = &disabled
+		require.NoError(t, cl.Update(context.TODO(), config))
+
+		// when
+		err := CreateHostResources(cl, "toolchain-host-operator", dir)
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Namespace: "toolchain-host-operator", Name: "config"}, config))
+		assert.False(t, *config.Spec.Host.AutomaticApproval.Enabled)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		// given
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte(`{not: [valid`), 0600))
+
+		// when
+		err := CreateHostResources(cl, "toolchain-host-operator", dir)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid resource file")
+	})
+}
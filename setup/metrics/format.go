@@ -13,3 +13,15 @@ func bytesToMBString(bytes float64) string {
 func simple(value float64) string {
 	return fmt.Sprintf("%.4f", value)
 }
+
+// format returns the provided value as a string formatted according to the given result type
+func format(resultType string, value float64) string {
+	switch resultType {
+	case "percentage":
+		return fmt.Sprintf("%.2f %%", value*100)
+	case "memory":
+		return bytesToMBString(value)
+	default:
+		return simple(value)
+	}
+}
@@ -53,3 +53,17 @@ func TestSimple(t *testing.T) {
 		})
 	})
 }
+
+func TestFormat(t *testing.T) {
+	t.Run("percentage", func(t *testing.T) {
+		require.Equal(t, "12.35 %", format("percentage", 0.123456))
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		require.Equal(t, "117.74 MB", format("memory", 123456789))
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		require.Equal(t, "0.5000", format("simple", 0.5))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/codeready-toolchain/toolchain-e2e/setup/auth"
@@ -33,13 +34,16 @@ type Gatherer struct {
 	queryInterval time.Duration
 	mqueries      []queries.Query
 	results       map[string]aggregateResult
+	gates         map[string]float64
 	term          terminal.Terminal
+	lock          sync.RWMutex
 }
 
 type aggregateResult struct {
 	sampleCount int
 	max         float64
 	sum         float64
+	last        float64
 }
 
 // New creates a new gatherer with default queries
@@ -88,6 +92,15 @@ func (g *Gatherer) AddQueries(queries ...queries.Query) {
 	g.mqueries = append(g.mqueries, queries...)
 }
 
+// AddGate sets the limit of the values returned by the query with the given name (eg, the CPU usage of the host operator in cores).
+// A sample exceeding the limit fails the run.
+func (g *Gatherer) AddGate(queryName string, limit float64) {
+	if g.gates == nil {
+		g.gates = map[string]float64{}
+	}
+	g.gates[queryName] = limit
+}
+
 func (g *Gatherer) StartGathering() chan struct{} {
 	if len(g.mqueries) == 0 {
 		g.term.Infof("Metrics gatherer has no queries defined, skipping metrics gathering...")
@@ -138,16 +151,46 @@ func (g *Gatherer) sample(q queries.Query) error {
 	}
 	datapoint := vectorSum / float64(len(vector))
 
+	g.lock.Lock()
 	r := g.results[q.Name()]
 	r.max = math.Max(r.max, datapoint)
 	r.sum += datapoint
+	r.last = datapoint
 	r.sampleCount++
 	g.results[q.Name()] = r
+	g.lock.Unlock()
+
+	if limit, found := g.gates[q.Name()]; found && datapoint > limit {
+		return fmt.Errorf("%s exceeded the limit of %s: %s", q.Name(), format(q.ResultType(), limit), format(q.ResultType(), datapoint))
+	}
 	return nil
 }
 
+// Latest returns the latest value of each of the queries with the given names, formatted according to their result type
+// (eg, to display the current resource usage of the operators while the setup is running)
+func (g *Gatherer) Latest(queryNames ...string) []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	lines := []string{}
+	for _, q := range g.mqueries {
+		for _, name := range queryNames {
+			if q.Name() != name {
+				continue
+			}
+			if r, found := g.results[name]; found {
+				lines = append(lines, fmt.Sprintf("%s: %s (max %s)", name, format(q.ResultType(), r.last), format(q.ResultType(), r.max)))
+			} else {
+				lines = append(lines, fmt.Sprintf("%s: n/a", name))
+			}
+		}
+	}
+	return lines
+}
+
 // PrintResults iterates through each query and prints the aggregated results to the terminal
 func (g *Gatherer) PrintResults() {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
 	for _, q := range g.mqueries {
 		switch q.ResultType() {
 		case "percentage":
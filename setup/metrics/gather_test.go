@@ -153,6 +153,70 @@ func TestExecuteQueryAndProcessResult(t *testing.T) {
 	}
 }
 
+func TestSampleWithGate(t *testing.T) {
+	query := testQuery{
+		name: "host-operator-controller-manager Memory Usage",
+		sample: queryResult{
+			val: model.Vector{
+				&model.Sample{
+					Value:     model.SampleValue(200 * MB),
+					Timestamp: model.Now(),
+				},
+			},
+		},
+	}
+
+	t.Run("below the limit", func(t *testing.T) {
+		// given
+		g := &Gatherer{
+			k8sClient: test.NewFakeClient(t),
+			mqueries:  []queries.Query{query},
+			results:   map[string]aggregateResult{},
+		}
+		g.AddGate(query.name, 256*MB)
+
+		// when
+		err := g.sample(query)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("above the limit", func(t *testing.T) {
+		// given
+		g := &Gatherer{
+			k8sClient: test.NewFakeClient(t),
+			mqueries:  []queries.Query{query},
+			results:   map[string]aggregateResult{},
+		}
+		g.AddGate(query.name, 128*MB)
+
+		// when
+		err := g.sample(query)
+
+		// then
+		require.EqualError(t, err, "host-operator-controller-manager Memory Usage exceeded the limit of 128.00 MB: 200.00 MB")
+		// the sample is still part of the results
+		require.Equal(t, float64(200*MB), g.results[query.name].max)
+	})
+
+	t.Run("other query", func(t *testing.T) {
+		// given
+		g := &Gatherer{
+			k8sClient: test.NewFakeClient(t),
+			mqueries:  []queries.Query{query},
+			results:   map[string]aggregateResult{},
+		}
+		g.AddGate("member-operator-controller-manager Memory Usage", 128*MB)
+
+		// when
+		err := g.sample(query)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
 type testcase struct {
 	query testQuery
 	exp   expected
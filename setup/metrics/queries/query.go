@@ -78,6 +78,16 @@ func QueryClusterMemoryUtilisation(apiClient prometheus.API) *BaseQuery {
 	}
 }
 
+// WorkloadCPUUsageName returns the name of the query of the CPU usage of the workload with the given name
+func WorkloadCPUUsageName(name string) string {
+	return fmt.Sprintf("%s CPU Usage", name)
+}
+
+// WorkloadMemoryUsageName returns the name of the query of the memory usage of the workload with the given name
+func WorkloadMemoryUsageName(name string) string {
+	return fmt.Sprintf("%s Memory Usage", name)
+}
+
 func QueryWorkloadCPUUsage(apiClient prometheus.API, namespace, name string) *BaseQuery {
 	query := fmt.Sprintf(`sum(
 		node_namespace_pod_container:container_cpu_usage_seconds_total:sum_irate{cluster="", namespace="%[1]s"}
@@ -86,7 +96,7 @@ func QueryWorkloadCPUUsage(apiClient prometheus.API, namespace, name string) *Ba
 	) by (pod)`, namespace, name)
 	return &BaseQuery{
 		apiClient:  apiClient,
-		name:       fmt.Sprintf("%s CPU Usage", name),
+		name:       WorkloadCPUUsageName(name),
 		query:      query,
 		resultType: Simple,
 	}
@@ -100,7 +110,7 @@ func QueryWorkloadMemoryUsage(apiClient prometheus.API, namespace, name string)
 	) by (pod)`, namespace, name)
 	return &BaseQuery{
 		apiClient:  apiClient,
-		name:       fmt.Sprintf("%s Memory Usage", name),
+		name:       WorkloadMemoryUsageName(name),
 		query:      query,
 		resultType: Memory,
 	}
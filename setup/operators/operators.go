@@ -61,77 +61,85 @@ func VerifySandboxOperatorsInstalled(cl client.Client) error {
 
 func EnsureOperatorsInstalled(cl client.Client, s *runtime.Scheme, templatePaths []string) error {
 	for _, templatePath := range templatePaths {
-
-		tmpl, err := templates.GetTemplateFromFile(templatePath)
-		if err != nil {
-			return errors.Wrapf(err, "invalid template file: '%s'", templatePath)
-		}
-
-		processor := ctemplate.NewProcessor(s)
-		objsToProcess, err := processor.Process(tmpl.DeepCopy(), map[string]string{})
-		if err != nil {
+		if err := EnsureOperatorInstalled(cl, s, templatePath, map[string]string{}); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// find the subscription resource
-		var subscriptionResource runtimeclient.Object
-		foundSub := false
-		for _, obj := range objsToProcess {
-			if obj.GetObjectKind().GroupVersionKind().Kind == "Subscription" {
-				subscriptionResource = obj
-				foundSub = true
-			}
+// EnsureOperatorInstalled applies the objects of the given template processed with the given parameters, and waits until the CSV
+// of the subscription of the template has succeeded
+func EnsureOperatorInstalled(cl client.Client, s *runtime.Scheme, templatePath string, params map[string]string) error {
+	tmpl, err := templates.GetTemplateFromFile(templatePath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid template file: '%s'", templatePath)
+	}
+
+	processor := ctemplate.NewProcessor(s)
+	objsToProcess, err := processor.Process(tmpl.DeepCopy(), params)
+	if err != nil {
+		return err
+	}
+
+	// find the subscription resource
+	var subscriptionResource runtimeclient.Object
+	foundSub := false
+	for _, obj := range objsToProcess {
+		if obj.GetObjectKind().GroupVersionKind().Kind == "Subscription" {
+			subscriptionResource = obj
+			foundSub = true
 		}
-		if !foundSub {
-			return fmt.Errorf("a subscription was not found in template file '%s'", templatePath)
+	}
+	if !foundSub {
+		return fmt.Errorf("a subscription was not found in template file '%s'", templatePath)
+	}
+
+	if err := templates.ApplyObjects(cl, objsToProcess); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+
+	// wait for operator installation to succeed
+	var csverr error
+	var currentCSV string
+	var lastCSVs []string
+	err = wait.ForSubscriptionWithCriteria(cl, subscriptionResource.GetName(), subscriptionResource.GetNamespace(), func(subscription *v1alpha1.Subscription) bool {
+		currentCSV = subscription.Status.CurrentCSV
+		if currentCSV == "" {
+			return false
 		}
 
-		if err := templates.ApplyObjects(cl, objsToProcess); err != nil {
-			return err
+		if len(lastCSVs) == 0 || currentCSV != lastCSVs[len(lastCSVs)-1] { // subscription's current CSV has changed
+			lastCSVs = append(lastCSVs, currentCSV)
+			fmt.Printf("CurrentCSV of subscription: '%s'\n", currentCSV)
 		}
 
-		startTime := time.Now()
-
-		// wait for operator installation to succeed
-		var csverr error
-		var currentCSV string
-		var lastCSVs []string
-		err = wait.ForSubscriptionWithCriteria(cl, subscriptionResource.GetName(), subscriptionResource.GetNamespace(), func(subscription *v1alpha1.Subscription) bool {
-			currentCSV = subscription.Status.CurrentCSV
-			if currentCSV == "" {
-				return false
-			}
-
-			if len(lastCSVs) == 0 || currentCSV != lastCSVs[len(lastCSVs)-1] { // subscription's current CSV has changed
-				lastCSVs = append(lastCSVs, currentCSV)
-				fmt.Printf("CurrentCSV of subscription: '%s'\n", currentCSV)
-			}
-
-			// wait for the CurrentCSV to reach Succeeded status
-			csverr = wait.ForCSVWithCriteria(cl, currentCSV, subscriptionResource.GetNamespace(), csvTimeout, func(csv *v1alpha1.ClusterServiceVersion) bool {
-				return csv.Status.Phase == "Succeeded"
-			})
-			if csverr != nil {
-				return false
-			}
-
-			time.Sleep(5 * time.Second) // wait a few seconds and then check if there's another CSV to wait for
-			currentCSV = subscription.Status.CurrentCSV
-			return currentCSV == lastCSVs[len(lastCSVs)-1] // return true only if the CurrentCSV has not changed. ie. no upgrade needed
+		// wait for the CurrentCSV to reach Succeeded status
+		csverr = wait.ForCSVWithCriteria(cl, currentCSV, subscriptionResource.GetNamespace(), csvTimeout, func(csv *v1alpha1.ClusterServiceVersion) bool {
+			return csv.Status.Phase == "Succeeded"
 		})
-		if len(lastCSVs) > 1 {
-			fmt.Printf("\nATTENTION! Update subscription '%s' StartingCSV to %s to speed up future installations\n\n", subscriptionResource.GetName(), lastCSVs[len(lastCSVs)-1])
-		}
-		installDuration := time.Since(startTime)
 		if csverr != nil {
-			return errors.Wrapf(csverr, "failed to find CSV '%s' with Phase 'Succeeded'", currentCSV)
-		}
-		if err != nil {
-			return errors.Wrapf(err, "failed to verify installation of operator with subscription '%s' after %s", subscriptionResource.GetName(), installDuration.String())
+			return false
 		}
 
-		fmt.Printf("Verified installation of operator with subscription '%s' completed in %s\n\n", subscriptionResource.GetName(), installDuration.String())
+		time.Sleep(5 * time.Second) // wait a few seconds and then check if there's another CSV to wait for
+		currentCSV = subscription.Status.CurrentCSV
+		return currentCSV == lastCSVs[len(lastCSVs)-1] // return true only if the CurrentCSV has not changed. ie. no upgrade needed
+	})
+	if len(lastCSVs) > 1 {
+		fmt.Printf("\nATTENTION! Update subscription '%s' StartingCSV to %s to speed up future installations\n\n", subscriptionResource.GetName(), lastCSVs[len(lastCSVs)-1])
 	}
+	installDuration := time.Since(startTime)
+	if csverr != nil {
+		return errors.Wrapf(csverr, "failed to find CSV '%s' with Phase 'Succeeded'", currentCSV)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify installation of operator with subscription '%s' after %s", subscriptionResource.GetName(), installDuration.String())
+	}
+
+	fmt.Printf("Verified installation of operator with subscription '%s' completed in %s\n\n", subscriptionResource.GetName(), installDuration.String())
 
 	return nil
 }
@@ -0,0 +1,205 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sutil "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kind a kind of pprof profile, served by the `net/http/pprof` endpoints of the operators
+type Kind struct {
+	// Name the name of the profile, used in the name of the captured files
+	Name string
+	// Path the path of the endpoint serving the profile
+	Path string
+	// sampled true if the profile is sampled over a duration (ie, the `seconds` param of the endpoint)
+	sampled bool
+}
+
+var (
+	// CPU the CPU profile, sampled over the CPU duration of the Capturer
+	CPU = Kind{Name: "cpu", Path: "debug/pprof/profile", sampled: true}
+	// Heap the heap profile, ie, the memory allocations of the live objects
+	Heap = Kind{Name: "heap", Path: "debug/pprof/heap"}
+)
+
+// Workload a deployment of an operator from which the profiles are captured
+type Workload struct {
+	Namespace string
+	Name      string
+}
+
+// Capturer captures the pprof profiles of the pods of some workloads at regular intervals, and stores them in a directory
+// so that they can be analyzed offline (eg, `go tool pprof -top <file>`) after a performance regression. The profiles are fetched
+// via the proxy of the API server to the pods, ie, the same as a port-forward to the pprof port of the pods, but without any
+// local port to manage.
+type Capturer struct {
+	term        terminal.Terminal
+	clientset   kubernetes.Interface
+	dir         string
+	port        int
+	interval    time.Duration
+	cpuDuration time.Duration
+	kinds       []Kind
+	workloads   []Workload
+	lock        sync.RWMutex
+	files       []string
+	failures    int
+	now         func() time.Time
+}
+
+// New creates a new capturer of the CPU and heap profiles served on the given port by the pods of the workloads, which stores
+// the profiles in the given directory. The CPU profiles are sampled for 10s, or for the interval if it is shorter.
+func New(t terminal.Terminal, clientset kubernetes.Interface, dir string, port int, interval time.Duration) *Capturer {
+	cpuDuration := 10 * time.Second
+	if interval < cpuDuration {
+		cpuDuration = interval
+	}
+	return &Capturer{
+		term:        t,
+		clientset:   clientset,
+		dir:         dir,
+		port:        port,
+		interval:    interval,
+		cpuDuration: cpuDuration,
+		kinds:       []Kind{CPU, Heap},
+		now:         time.Now,
+	}
+}
+
+// AddWorkload adds a deployment whose pods are profiled
+func (c *Capturer) AddWorkload(namespace, name string) {
+	c.workloads = append(c.workloads, Workload{Namespace: namespace, Name: name})
+}
+
+// StartCapturing captures the profiles of all the workloads immediately and then at every interval, until the returned channel is closed.
+// A failure to capture a profile does not fail the run (the operators may be restarted during the setup), it is only reported.
+func (c *Capturer) StartCapturing() chan struct{} {
+	if len(c.workloads) == 0 {
+		c.term.Infof("Profiles capturer has no workloads defined, skipping profiles capture...")
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		c.term.Fatalf(err, "cannot create the directory of the profiles '%s'", c.dir)
+	}
+	stop := make(chan struct{})
+	go func() {
+		k8sutil.Until(func() {
+			if err := c.CaptureAll(context.TODO()); err != nil {
+				c.term.Infof("⚠️  failed to capture some profiles: %s", err)
+			}
+		}, c.interval, stop)
+	}()
+	return stop
+}
+
+// CaptureAll captures all kinds of profiles of all the running pods of the workloads, and returns the first error which occurred (if any)
+func (c *Capturer) CaptureAll(ctx context.Context) error {
+	var firstErr error
+	for _, w := range c.workloads {
+		pods, err := c.runningPods(ctx, w)
+		if err != nil {
+			c.failed()
+			firstErr = firstError(firstErr, err)
+			continue
+		}
+		for _, pod := range pods {
+			for _, kind := range c.kinds {
+				if _, err := c.capture(ctx, w, pod, kind); err != nil {
+					c.failed()
+					firstErr = firstError(firstErr, err)
+				}
+			}
+		}
+	}
+	return firstErr
+}
+
+// runningPods returns the names of the running pods of the given workload
+func (c *Capturer) runningPods(ctx context.Context, w Workload) ([]string, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(w.Namespace).Get(ctx, w.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get the deployment '%s/%s'", w.Namespace, w.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector of the deployment '%s/%s'", w.Namespace, w.Name)
+	}
+	pods, err := c.clientset.CoreV1().Pods(w.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list the pods of the deployment '%s/%s'", w.Namespace, w.Name)
+	}
+	names := []string{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			names = append(names, pod.Name)
+		}
+	}
+	return names, nil
+}
+
+// capture captures the profile of the given kind of the given pod and stores it in the `<dir>/<workload>` directory, in a file named
+// after the pod, the kind of profile and the time of the capture. Returns the path of the file.
+func (c *Capturer) capture(ctx context.Context, w Workload, pod string, kind Kind) (string, error) {
+	params := map[string]string{}
+	if kind.sampled {
+		params["seconds"] = strconv.Itoa(int(c.cpuDuration.Seconds()))
+	}
+	start := c.now()
+	data, err := c.clientset.CoreV1().Pods(w.Namespace).ProxyGet("http", pod, strconv.Itoa(c.port), kind.Path, params).DoRaw(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot capture the %s profile of the pod '%s/%s'", kind.Name, w.Namespace, pod)
+	}
+	dir := filepath.Join(c.dir, w.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.pb.gz", pod, kind.Name, start.Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	c.term.Debugf("captured the %s profile of the pod '%s/%s' in %s", kind.Name, w.Namespace, pod, path)
+	c.lock.Lock()
+	c.files = append(c.files, path)
+	c.lock.Unlock()
+	return path, nil
+}
+
+func (c *Capturer) failed() {
+	c.lock.Lock()
+	c.failures++
+	c.lock.Unlock()
+}
+
+// Files returns the paths of the captured profiles
+func (c *Capturer) Files() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return append([]string{}, c.files...)
+}
+
+// PrintResults prints the number of captured profiles and the directory in which they are stored
+func (c *Capturer) PrintResults() {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	c.term.Infof("Profiles: %d captured in %s (%d failures), eg. analyze them with 'go tool pprof -top <file>'", len(c.files), c.dir, c.failures)
+}
+
+func firstError(first, err error) error {
+	if first != nil {
+		return first
+	}
+	return err
+}
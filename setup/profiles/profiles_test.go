@@ -0,0 +1,170 @@
+package profiles
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCaptureAll(t *testing.T) {
+	// given
+	now := time.Date(2023, 4, 6, 10, 30, 0, 0, time.UTC)
+	objs := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "toolchain-host-operator", Name: "host-operator-controller-manager"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"control-plane": "controller-manager"}},
+			},
+		},
+		newPod("host-operator-controller-manager-abc", corev1.PodRunning),
+		newPod("host-operator-controller-manager-def", corev1.PodPending),
+	}
+
+	t.Run("all profiles captured", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		clientset := fake.NewSimpleClientset(objs...)
+		clientset.AddProxyReactor("pods", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+			proxy := action.(k8stesting.ProxyGetAction)
+			return true, &fakeResponse{data: []byte(fmt.Sprintf("%s:%s:%s:%v", proxy.GetName(), proxy.GetPort(), proxy.GetPath(), proxy.GetParams()))}, nil
+		})
+		c := newCapturer(clientset, dir, now)
+
+		// when
+		err := c.CaptureAll(context.TODO())
+
+		// then
+		require.NoError(t, err)
+		cpuFile := filepath.Join(dir, "host-operator-controller-manager", "host-operator-controller-manager-abc-cpu-20230406-103000.pb.gz")
+		heapFile := filepath.Join(dir, "host-operator-controller-manager", "host-operator-controller-manager-abc-heap-20230406-103000.pb.gz")
+		assert.ElementsMatch(t, []string{cpuFile, heapFile}, c.Files()) // the pending pod is not profiled
+		assertFileContent(t, cpuFile, "host-operator-controller-manager-abc:6060:debug/pprof/profile:map[seconds:10]")
+		assertFileContent(t, heapFile, "host-operator-controller-manager-abc:6060:debug/pprof/heap:map[]")
+	})
+
+	t.Run("failures", func(t *testing.T) {
+
+		t.Run("profile not served", func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			clientset := fake.NewSimpleClientset(objs...)
+			clientset.AddProxyReactor("pods", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+				proxy := action.(k8stesting.ProxyGetAction)
+				if proxy.GetPath() == Heap.Path {
+					return true, &fakeResponse{err: fmt.Errorf("mock error")}, nil
+				}
+				return true, &fakeResponse{data: []byte("cpu")}, nil
+			})
+			c := newCapturer(clientset, dir, now)
+
+			// when
+			err := c.CaptureAll(context.TODO())
+
+			// then
+			require.EqualError(t, err, "cannot capture the heap profile of the pod 'toolchain-host-operator/host-operator-controller-manager-abc': mock error")
+			require.Len(t, c.Files(), 1) // the CPU profile was captured anyway
+			assert.Equal(t, 1, c.failures)
+		})
+
+		t.Run("unknown workload", func(t *testing.T) {
+			// given
+			c := newCapturer(fake.NewSimpleClientset(), t.TempDir(), now)
+
+			// when
+			err := c.CaptureAll(context.TODO())
+
+			// then
+			require.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), "cannot get the deployment 'toolchain-host-operator/host-operator-controller-manager'"))
+			assert.Empty(t, c.Files())
+			assert.Equal(t, 1, c.failures)
+		})
+	})
+}
+
+func TestNewCapturer(t *testing.T) {
+	t.Run("cpu duration is 10s by default", func(t *testing.T) {
+		// when
+		c := New(newTerminal(), fake.NewSimpleClientset(), t.TempDir(), 6060, 5*time.Minute)
+
+		// then
+		assert.Equal(t, 10*time.Second, c.cpuDuration)
+	})
+
+	t.Run("cpu duration is the interval if shorter", func(t *testing.T) {
+		// when
+		c := New(newTerminal(), fake.NewSimpleClientset(), t.TempDir(), 6060, 5*time.Second)
+
+		// then
+		assert.Equal(t, 5*time.Second, c.cpuDuration)
+	})
+}
+
+func newCapturer(clientset *fake.Clientset, dir string, now time.Time) *Capturer {
+	c := New(newTerminal(), clientset, dir, 6060, time.Minute)
+	c.AddWorkload("toolchain-host-operator", "host-operator-controller-manager")
+	c.now = func() time.Time {
+		return now
+	}
+	return c
+}
+
+func newTerminal() terminal.Terminal {
+	out := &bytes.Buffer{}
+	return terminal.New(func() io.Reader {
+		return &bytes.Buffer{}
+	}, func() io.Writer {
+		return out
+	}, false)
+}
+
+func newPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "toolchain-host-operator",
+			Name:      name,
+			Labels:    map[string]string{"control-plane": "controller-manager"},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+}
+
+// fakeResponse a response of the proxy to a pod
+type fakeResponse struct {
+	data []byte
+	err  error
+}
+
+func (r *fakeResponse) DoRaw(context.Context) ([]byte, error) {
+	return r.data, r.err
+}
+
+func (r *fakeResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r.data)), r.err
+}
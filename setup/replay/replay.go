@@ -0,0 +1,30 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewClients returns a client for each cluster of the given script, created with the given func from the kubeconfig set for the cluster
+// in the given map (or else from the default kubeconfig)
+func NewClients(script wait.ReplayScript, kubeconfigs map[string]string, defaultKubeconfig string, newClient func(kubeconfig string) (client.Client, error)) (map[string]client.Client, error) {
+	clients := map[string]client.Client{}
+	for _, step := range script.Steps {
+		if _, exists := clients[step.Cluster]; exists {
+			continue
+		}
+		kubeconfig := defaultKubeconfig
+		if k, ok := kubeconfigs[step.Cluster]; ok {
+			kubeconfig = k
+		}
+		cl, err := newClient(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create client for cluster '%s': %w", step.Cluster, err)
+		}
+		clients[step.Cluster] = cl
+	}
+	return clients, nil
+}
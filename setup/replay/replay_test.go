@@ -0,0 +1,101 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	// given
+	// the scenario is recorded with the clients of the awaitilities, as in `testsupport.RecordScenario`
+	recorder := wait.NewScenarioRecorder()
+	hostAwait := wait.NewHostAwaitility(nil, commontest.NewFakeClient(t), commontest.HostOperatorNs, "registration-service")
+	memberAwait := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member-cluster")
+	awaitilities := wait.NewAwaitilities(hostAwait, memberAwait).WithRecorder(recorder)
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "oddity", Namespace: commontest.HostOperatorNs},
+		Spec:       toolchainv1alpha1.SpaceSpec{TierName: "base"},
+	}
+	require.NoError(t, awaitilities.Host().Client.Create(context.TODO(), space))
+	space.Spec.TargetCluster = "member-cluster"
+	require.NoError(t, awaitilities.Host().Client.Update(context.TODO(), space))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "oddity-cm", Namespace: commontest.MemberOperatorNs},
+		Data:       map[string]string{"key": "value"},
+	}
+	require.NoError(t, awaitilities.Member1().Client.Create(context.TODO(), cm))
+	require.NoError(t, awaitilities.Member1().Client.Delete(context.TODO(), cm))
+	path := filepath.Join(t.TempDir(), "replay.yaml")
+	require.NoError(t, recorder.Script("TestOddity").Save(path))
+
+	// the clusters of the dev environment in which the script is replayed
+	devClients := map[string]*commontest.FakeClient{
+		"/path/to/host":   commontest.NewFakeClient(t),
+		"/path/to/member": commontest.NewFakeClient(t),
+	}
+	newClient := func(kubeconfig string) (client.Client, error) {
+		cl, ok := devClients[kubeconfig]
+		if !ok {
+			return nil, fmt.Errorf("unknown kubeconfig '%s'", kubeconfig)
+		}
+		return cl, nil
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		// given
+		script, err := wait.LoadReplayScript(path)
+		require.NoError(t, err)
+		assert.Equal(t, "TestOddity", script.Test)
+
+		// when
+		clients, err := NewClients(script, map[string]string{"member-cluster": "/path/to/member"}, "/path/to/host", newClient)
+		require.NoError(t, err)
+		var replayed []string
+		err = wait.Replay(context.TODO(), clients, script, func(_ int, step wait.ReplayStep) {
+			replayed = append(replayed, step.String())
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"create Space 'oddity' in namespace 'toolchain-host-operator' on cluster 'host'",
+			"update Space 'oddity' in namespace 'toolchain-host-operator' on cluster 'host'",
+			"create ConfigMap 'oddity-cm' in namespace 'toolchain-member-operator' on cluster 'member-cluster'",
+			"delete ConfigMap 'oddity-cm' in namespace 'toolchain-member-operator' on cluster 'member-cluster'",
+		}, replayed)
+		// the calls are replayed on the cluster of the kubeconfig set for each cluster
+		actual := &toolchainv1alpha1.Space{}
+		require.NoError(t, devClients["/path/to/host"].Get(context.TODO(), client.ObjectKeyFromObject(space), actual))
+		assert.Equal(t, "base", actual.Spec.TierName)
+		assert.Equal(t, "member-cluster", actual.Spec.TargetCluster)
+		err = devClients["/path/to/member"].Get(context.TODO(), client.ObjectKeyFromObject(space), &toolchainv1alpha1.Space{})
+		assert.True(t, errors.IsNotFound(err))
+		err = devClients["/path/to/member"].Get(context.TODO(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("no client for a cluster", func(t *testing.T) {
+		// given
+		script, err := wait.LoadReplayScript(path)
+		require.NoError(t, err)
+
+		// when
+		_, err = NewClients(script, map[string]string{"member-cluster": "/path/to/unknown"}, "/path/to/host", newClient)
+
+		// then
+		require.EqualError(t, err, "cannot create client for cluster 'member-cluster': unknown kubeconfig '/path/to/unknown'")
+	})
+}
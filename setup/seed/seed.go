@@ -0,0 +1,99 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/resources"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/users"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DemoUser a user provisioned by the `seed` command
+type DemoUser struct {
+	Name string
+	// Workloads whether the workloads of the default template are created in the namespace of the user
+	Workloads bool
+}
+
+// DemoUsers the users provisioned by the `seed` command (and deleted by the `unseed` command)
+var DemoUsers = []DemoUser{
+	{Name: "demo-developer", Workloads: true},
+	{Name: "demo-tester", Workloads: true},
+	{Name: "demo-newcomer", Workloads: false},
+}
+
+// Seed provisions the demo users which do not exist yet in the member cluster of the given namespace, and applies the workloads
+// of the given templates in the namespaces of the users which have workloads. It can be run several times: the existing users
+// are left unchanged and the workloads are applied again. The given func is called once each user is seeded.
+func Seed(cl client.Client, s *runtime.Scheme, hostOperatorNamespace, memberOperatorNamespace string, templatePaths []string, seeded func(user DemoUser, created bool)) error {
+	for _, user := range DemoUsers {
+		created, err := ensureUser(cl, user.Name, hostOperatorNamespace, memberOperatorNamespace)
+		if err != nil {
+			return errors.Wrapf(err, "failed to provision demo user '%s'", user.Name)
+		}
+		if user.Workloads && len(templatePaths) > 0 {
+			if err := resources.CreateUserResourcesFromTemplateFiles(cl, s, user.Name, templatePaths); err != nil {
+				return errors.Wrapf(err, "failed to create the workloads of demo user '%s'", user.Name)
+			}
+		}
+		seeded(user, created)
+	}
+	return nil
+}
+
+// ensureUser creates the UserSignup of the given user unless it already exists, and returns true if it was created
+func ensureUser(cl client.Client, username, hostOperatorNamespace, memberOperatorNamespace string) (bool, error) {
+	err := cl.Get(context.TODO(), types.NamespacedName{Namespace: hostOperatorNamespace, Name: username}, &toolchainv1alpha1.UserSignup{})
+	if err == nil {
+		return false, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	return true, users.Create(cl, username, hostOperatorNamespace, memberOperatorNamespace)
+}
+
+// Unseed deletes the UserSignups of the demo users and waits until their namespaces are deleted. It can be run several times,
+// or when the demo users were partially seeded. The given func is called once each user is deleted.
+func Unseed(cl client.Client, hostOperatorNamespace string, unseeded func(user DemoUser, deleted bool)) error {
+	for _, user := range DemoUsers {
+		userSignup := &toolchainv1alpha1.UserSignup{}
+		userSignup.Namespace = hostOperatorNamespace
+		userSignup.Name = user.Name
+		deleted := true
+		if err := cl.Delete(context.TODO(), userSignup); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to delete demo user '%s'", user.Name)
+			}
+			deleted = false
+		}
+		if err := waitForNamespaceDeleted(cl, fmt.Sprintf("%s-dev", user.Name)); err != nil {
+			return err
+		}
+		unseeded(user, deleted)
+	}
+	return nil
+}
+
+func waitForNamespaceDeleted(cl client.Client, namespace string) error {
+	if err := k8swait.Poll(configuration.DefaultRetryInterval, configuration.DefaultTimeout, func() (bool, error) {
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: namespace}, &corev1.Namespace{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}); err != nil {
+		return errors.Wrapf(err, "namespace '%s' was not deleted", namespace)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSeedAndUnseed(t *testing.T) {
+	// given
+	configuration.DefaultTimeout = time.Second * 5
+	hostOperatorNamespace := "toolchain-host-operator"
+	memberOperatorNamespace := "toolchain-member-operator"
+	memberCluster := &toolchainv1alpha1.ToolchainCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: hostOperatorNamespace,
+			Name:      "member-abcd",
+			Labels: map[string]string{
+				"namespace": memberOperatorNamespace,
+				"type":      "member",
+			},
+		},
+		Status: toolchainv1alpha1.ToolchainClusterStatus{
+			Conditions: []toolchainv1alpha1.ToolchainClusterCondition{
+				{
+					Type:   toolchainv1alpha1.ToolchainClusterReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	cl := commontest.NewFakeClient(t, memberCluster)
+
+	t.Run("seed", func(t *testing.T) {
+		// when
+		created := map[string]bool{}
+		err := Seed(cl, cl.Scheme(), hostOperatorNamespace, memberOperatorNamespace, nil, func(user DemoUser, c bool) {
+			created[user.Name] = c
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, created, len(DemoUsers))
+		for _, user := range DemoUsers {
+			assert.True(t, created[user.Name], "demo user '%s' was not created", user.Name)
+			userSignup := &toolchainv1alpha1.UserSignup{}
+			require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Namespace: hostOperatorNamespace, Name: user.Name}, userSignup))
+			assert.Equal(t, memberCluster.Name, userSignup.Spec.TargetCluster)
+		}
+
+		t.Run("seed again leaves the existing users unchanged", func(t *testing.T) {
+			// when
+			created := map[string]bool{}
+			err := Seed(cl, cl.Scheme(), hostOperatorNamespace, memberOperatorNamespace, nil, func(user DemoUser, c bool) {
+				created[user.Name] = c
+			})
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, created, len(DemoUsers))
+			for _, user := range DemoUsers {
+				assert.False(t, created[user.Name], "demo user '%s' was created again", user.Name)
+			}
+		})
+	})
+
+	t.Run("unseed", func(t *testing.T) {
+		// when
+		deleted := map[string]bool{}
+		err := Unseed(cl, hostOperatorNamespace, func(user DemoUser, d bool) {
+			deleted[user.Name] = d
+		})
+
+		// then
+		require.NoError(t, err)
+		userSignups := &toolchainv1alpha1.UserSignupList{}
+		require.NoError(t, cl.List(context.TODO(), userSignups, client.InNamespace(hostOperatorNamespace)))
+		assert.Empty(t, userSignups.Items)
+		for _, user := range DemoUsers {
+			assert.True(t, deleted[user.Name], "demo user '%s' was not deleted", user.Name)
+		}
+
+		t.Run("unseed again", func(t *testing.T) {
+			// when
+			deleted := map[string]bool{}
+			err := Unseed(cl, hostOperatorNamespace, func(user DemoUser, d bool) {
+				deleted[user.Name] = d
+			})
+
+			// then
+			require.NoError(t, err)
+			for _, user := range DemoUsers {
+				assert.False(t, deleted[user.Name], "demo user '%s' was deleted again", user.Name)
+			}
+		})
+	})
+}
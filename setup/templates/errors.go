@@ -0,0 +1,158 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ErrorClass the class of an error returned when applying an object, which determines how the apply is retried
+type ErrorClass string
+
+const (
+	// Throttled the API server rejected the request because of too many requests
+	Throttled ErrorClass = "throttled"
+	// Conflict the object was modified concurrently (eg, the cluster resource quota of the user)
+	Conflict ErrorClass = "conflict"
+	// WebhookTimeout an admission webhook did not respond in time
+	WebhookTimeout ErrorClass = "webhook timeout"
+	// Invalid the object was rejected by the API server (retrying would not help)
+	Invalid ErrorClass = "invalid"
+	// Other any other error
+	Other ErrorClass = "other"
+)
+
+// RetryPolicies the retry policy of each class of errors. The number of steps is the maximum number of attempts
+// for the errors of the class (ie, 1 means no retry).
+var RetryPolicies = map[ErrorClass]k8swait.Backoff{
+	Throttled: {
+		Duration: time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    6,
+	},
+	Conflict: {
+		Duration: 100 * time.Millisecond,
+		Factor:   1.5,
+		Jitter:   0.1,
+		Steps:    10,
+	},
+	WebhookTimeout: {
+		Duration: 5 * time.Second,
+		Factor:   1,
+		Steps:    3,
+	},
+	Invalid: {
+		Steps: 1,
+	},
+	Other: {
+		Duration: time.Second,
+		Factor:   1,
+		Steps:    3,
+	},
+}
+
+// ClassifyError returns the class of the given error returned when applying an object
+func ClassifyError(err error) ErrorClass {
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		return Throttled
+	case apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err):
+		return Conflict
+	case isWebhookTimeout(err):
+		return WebhookTimeout
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) || apierrors.IsForbidden(err):
+		return Invalid
+	default:
+		return Other
+	}
+}
+
+// isWebhookTimeout returns true if the error was caused by a call to an admission webhook which timed out, eg:
+// Internal error occurred: failed calling webhook "users.webhook": Post "https://...": context deadline exceeded
+func isWebhookTimeout(err error) bool {
+	msg := err.Error()
+	if !strings.Contains(msg, "failed calling webhook") {
+		return false
+	}
+	return strings.Contains(msg, "deadline exceeded") || strings.Contains(msg, "timeout") || strings.Contains(msg, "Timeout")
+}
+
+// ApplyError an error which occurred while applying an object, after all the retries allowed by the policy of its class
+type ApplyError struct {
+	Class    ErrorClass
+	Attempts int
+	Err      error
+}
+
+func (e ApplyError) Error() string {
+	return fmt.Sprintf("%s error after %d attempt(s): %s", e.Class, e.Attempts, e.Err)
+}
+
+func (e ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCounts the number of errors of a class
+type ErrorCounts struct {
+	// Retried the number of errors after which the apply was retried
+	Retried int
+	// Failed the number of objects which could not be applied
+	Failed int
+}
+
+// ErrorReport the breakdown by class of the errors which occurred while applying the objects
+type ErrorReport struct {
+	lock   sync.Mutex
+	counts map[ErrorClass]ErrorCounts
+}
+
+// Errors the breakdown of the errors which occurred while applying the objects since the start of the setup
+var Errors = &ErrorReport{}
+
+func (r *ErrorReport) record(class ErrorClass, failed bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.counts == nil {
+		r.counts = map[ErrorClass]ErrorCounts{}
+	}
+	c := r.counts[class]
+	if failed {
+		c.Failed++
+	} else {
+		c.Retried++
+	}
+	r.counts[class] = c
+}
+
+// Counts returns a copy of the number of errors by class
+func (r *ErrorReport) Counts() map[ErrorClass]ErrorCounts {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	result := make(map[ErrorClass]ErrorCounts, len(r.counts))
+	for class, c := range r.counts {
+		result[class] = c
+	}
+	return result
+}
+
+// Lines returns the breakdown of the errors, one line per class (sorted by class)
+func (r *ErrorReport) Lines() []string {
+	counts := r.Counts()
+	classes := make([]string, 0, len(counts))
+	for class := range counts {
+		classes = append(classes, string(class))
+	}
+	sort.Strings(classes)
+	lines := make([]string, 0, len(classes))
+	for _, class := range classes {
+		c := counts[ErrorClass(class)]
+		lines = append(lines, fmt.Sprintf("%s: %d retried, %d failed", class, c.Retried, c.Failed))
+	}
+	return lines
+}
@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "configmaps"}
+
+	for expected, err := range map[ErrorClass]error{
+		Throttled:      apierrors.NewTooManyRequests("too many requests", 1),
+		Conflict:       apierrors.NewConflict(gr, "cm", fmt.Errorf("the object has been modified")),
+		WebhookTimeout: apierrors.NewInternalError(fmt.Errorf(`failed calling webhook "users.webhook": Post "https://member-operator-webhook": context deadline exceeded`)),
+		Invalid:        apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "cm", field.ErrorList{}),
+		Other:          fmt.Errorf("connection refused"),
+	} {
+		t.Run(string(expected), func(t *testing.T) {
+			// when
+			class := ClassifyError(err)
+
+			// then
+			assert.Equal(t, expected, class)
+		})
+	}
+
+	t.Run("wrapped error", func(t *testing.T) {
+		// given
+		err := fmt.Errorf("unable to create resource: %w", apierrors.NewConflict(gr, "cm", fmt.Errorf("the object has been modified")))
+
+		// when
+		class := ClassifyError(err)
+
+		// then
+		assert.Equal(t, Conflict, class)
+	})
+}
+
+func TestApplyObjectsWithRetries(t *testing.T) {
+	// given
+	Errors = &ErrorReport{}
+	t.Cleanup(func() {
+		Errors = &ErrorReport{}
+	})
+	cm := func() runtimeclient.Object {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cm",
+				Namespace: "user0001-stage",
+			},
+		}
+	}
+
+	t.Run("retried after conflicts", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t)
+		attempts := 0
+		cl.MockCreate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			attempts++
+			if attempts <= 2 {
+				return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), fmt.Errorf("the object has been modified"))
+			}
+			return cl.Client.Create(ctx, obj, opts...)
+		}
+
+		// when
+		err := ApplyObjects(cl, []runtimeclient.Object{cm()})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, ErrorCounts{Retried: 2}, Errors.Counts()[Conflict])
+	})
+
+	t.Run("not retried when invalid", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t)
+		attempts := 0
+		cl.MockCreate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			attempts++
+			return apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, obj.GetName(), field.ErrorList{})
+		}
+
+		// when
+		err := ApplyObjects(cl, []runtimeclient.Object{cm()})
+
+		// then
+		require.Error(t, err)
+		applyErr := ApplyError{}
+		require.True(t, errors.As(err, &applyErr))
+		assert.Equal(t, Invalid, applyErr.Class)
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, ErrorCounts{Failed: 1}, Errors.Counts()[Invalid])
+		assert.Contains(t, Errors.Lines(), "invalid: 0 retried, 1 failed")
+	})
+}
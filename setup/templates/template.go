@@ -8,7 +8,6 @@ import (
 
 	applyclientlib "github.com/codeready-toolchain/toolchain-common/pkg/client"
 
-	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
 	multierror "github.com/hashicorp/go-multierror"
 	templatev1 "github.com/openshift/api/template/v1"
 	"github.com/pkg/errors"
@@ -51,11 +50,18 @@ func ApplyObjects(cl runtimeclient.Client, objsToApply []runtimeclient.Object, m
 	return nil
 }
 
+// Concurrency the maximum number of objects applied concurrently by ApplyObjectsConcurrently (0 means no limit)
+var Concurrency = 0
+
 // ApplyObjectsConcurrently applies multiple objects concurrently
 func ApplyObjectsConcurrently(cl runtimeclient.Client, combinedObjsToProcess []runtimeclient.Object, modifiers ...ClientObjectModifier) error {
 	var objProcessors []<-chan error
 	objChannel := distribute(combinedObjsToProcess)
-	for i := 0; i < len(combinedObjsToProcess); i++ {
+	processors := len(combinedObjsToProcess)
+	if Concurrency > 0 && Concurrency < processors {
+		processors = Concurrency
+	}
+	for i := 0; i < processors; i++ {
 		objProcessors = append(objProcessors, startObjectProcessor(cl, objChannel, modifiers...))
 		time.Sleep(100 * time.Millisecond) // wait for a short time before starting each object processor to avoid hitting rate limits
 	}
@@ -138,15 +144,27 @@ func applyObject(applycl *applyclientlib.ApplyClient, obj runtimeclient.Object,
 		}
 	}
 
-	// retry the apply in case it fails due to errors like the following:
+	// retry the apply according to the policy of the class of the error, eg:
 	// unable to create resource of kind: Deployment, version: v1: Operation cannot be fulfilled on clusterresourcequotas.quota.openshift.io "for-zippy-1882-deployments": the object has been modified; please apply your changes to the latest version and try again
-	if err := k8swait.Poll(cfg.DefaultRetryInterval, 30*time.Second, func() (bool, error) {
-		if _, applyErr := applycl.ApplyObject(obj); applyErr != nil {
-			return false, applyErr
+	backoffs := map[ErrorClass]*k8swait.Backoff{}
+	for attempts := 1; ; attempts++ {
+		_, err := applycl.ApplyObject(obj)
+		if err == nil {
+			return nil
+		}
+		class := ClassifyError(err)
+		backoff, found := backoffs[class]
+		if !found {
+			b := RetryPolicies[class]
+			backoff = &b
+			backoffs[class] = backoff
 		}
-		return true, nil
-	}); err != nil {
-		return errors.Wrapf(err, "could not apply resource '%s' in namespace '%s'", obj.GetName(), obj.GetNamespace())
+		// the number of steps left is decremented by each call to `Step()`
+		if backoff.Steps <= 1 {
+			Errors.record(class, true)
+			return errors.Wrapf(ApplyError{Class: class, Attempts: attempts, Err: err}, "could not apply resource '%s' in namespace '%s'", obj.GetName(), obj.GetNamespace())
+		}
+		Errors.record(class, false)
+		time.Sleep(backoff.Step())
 	}
-	return nil
 }
@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
 	"github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +22,11 @@ func Create(cl client.Client, username, hostOperatorNamespace, memberOperatorNam
 	if err != nil {
 		return fmt.Errorf("unable to lookup member cluster name, ensure the sandbox setup steps are followed")
 	}
+	return CreateInCluster(cl, username, hostOperatorNamespace, memberClusterName)
+}
+
+// CreateInCluster creates a manually approved UserSignup for the given username, targeting the given member cluster
+func CreateInCluster(cl client.Client, username, hostOperatorNamespace, targetCluster string) error {
 	usersignup := &toolchainv1alpha1.UserSignup{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: hostOperatorNamespace,
@@ -30,13 +35,13 @@ func Create(cl client.Client, username, hostOperatorNamespace, memberOperatorNam
 				toolchainv1alpha1.UserSignupUserEmailAnnotationKey: fmt.Sprintf("%s@test.com", username),
 			},
 			Labels: map[string]string{
-				toolchainv1alpha1.UserSignupUserEmailHashLabelKey: hash.EncodeString(fmt.Sprintf("%s@test.com", username)),
+				toolchainv1alpha1.UserSignupUserEmailHashLabelKey: hash.EmailHash(fmt.Sprintf("%s@test.com", username)),
 			},
 		},
 		Spec: toolchainv1alpha1.UserSignupSpec{
 			Username:      username,
 			Userid:        username,
-			TargetCluster: memberClusterName,
+			TargetCluster: targetCluster,
 		},
 	}
 	states.SetApprovedManually(usersignup, true)
@@ -0,0 +1,131 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Placement distributes the users across the member clusters according to their weights (eg, 70/30),
+// using a smooth weighted round-robin so that the distribution is balanced all along the provisioning
+type Placement struct {
+	lock     sync.Mutex
+	clusters []string
+	weights  map[string]int
+	current  map[string]int
+	total    int
+}
+
+// NewPlacement returns a new Placement for the given weights by member cluster name
+func NewPlacement(weights map[string]int) (*Placement, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("no member cluster weights provided")
+	}
+	p := &Placement{
+		weights: map[string]int{},
+		current: map[string]int{},
+	}
+	for cluster, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("invalid weight for member cluster '%s': %d", cluster, weight)
+		}
+		if weight == 0 {
+			continue
+		}
+		p.clusters = append(p.clusters, cluster)
+		p.weights[cluster] = weight
+		p.total += weight
+	}
+	if p.total == 0 {
+		return nil, fmt.Errorf("at least one member cluster must have a weight greater than 0")
+	}
+	// make the placement deterministic
+	sort.Strings(p.clusters)
+	return p, nil
+}
+
+// Clusters returns the names of the member clusters with a weight greater than 0
+func (p *Placement) Clusters() []string {
+	return p.clusters
+}
+
+// Next returns the member cluster in which the next user should be provisioned
+func (p *Placement) Next() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	selected := ""
+	for _, cluster := range p.clusters {
+		p.current[cluster] += p.weights[cluster]
+		if selected == "" || p.current[cluster] > p.current[selected] {
+			selected = cluster
+		}
+	}
+	p.current[selected] -= p.total
+	return selected
+}
+
+// VerifyDistribution verifies that the ratio of the Spaces (of the users with the given prefix) provisioned in each member cluster
+// matches the weight of the cluster, within the given tolerance (eg, 0.05 for 5 percentage points). It returns the number of Spaces by cluster.
+func (p *Placement) VerifyDistribution(cl client.Client, hostOperatorNamespace, usernamePrefix string, tolerance float64) (map[string]int, error) {
+	spaces := &toolchainv1alpha1.SpaceList{}
+	if err := cl.List(context.TODO(), spaces, client.InNamespace(hostOperatorNamespace)); err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	count := 0
+	for _, s := range spaces.Items {
+		if !strings.HasPrefix(s.Name, usernamePrefix+"-") {
+			continue
+		}
+		counts[s.Status.TargetCluster]++
+		count++
+	}
+	if count == 0 {
+		return counts, fmt.Errorf("no Space found for the users with prefix '%s'", usernamePrefix)
+	}
+	mismatches := []string{}
+	for cluster, c := range counts {
+		if _, found := p.weights[cluster]; !found {
+			mismatches = append(mismatches, fmt.Sprintf("%d Space(s) provisioned in unexpected cluster '%s'", c, cluster))
+		}
+	}
+	for _, cluster := range p.clusters {
+		expected := float64(p.weights[cluster]) / float64(p.total)
+		actual := float64(counts[cluster]) / float64(count)
+		if math.Abs(expected-actual) > tolerance {
+			mismatches = append(mismatches, fmt.Sprintf("%.2f%% of the Spaces provisioned in cluster '%s' instead of %.2f%%", actual*100, cluster, expected*100))
+		}
+	}
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		return counts, fmt.Errorf("the distribution of the Spaces does not match the weights of the member clusters: %s", strings.Join(mismatches, ", "))
+	}
+	return counts, nil
+}
+
+// VerifyMemberClusters verifies that there is a ready member ToolchainCluster for each of the given names
+func VerifyMemberClusters(cl client.Client, hostOperatorNamespace string, names []string) error {
+	clusters := &toolchainv1alpha1.ToolchainClusterList{}
+	if err := cl.List(context.TODO(), clusters, client.InNamespace(hostOperatorNamespace), client.MatchingLabels{
+		"type": "member",
+	}); err != nil {
+		return err
+	}
+	ready := map[string]bool{}
+	for _, c := range clusters.Items {
+		ready[c.Name] = containsClusterCondition(c.Status.Conditions, wait.ReadyToolchainCluster)
+	}
+	for _, name := range names {
+		if !ready[name] {
+			return fmt.Errorf("member cluster '%s' not found or not ready", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package users
+
+import (
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewPlacement(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		// when
+		p, err := NewPlacement(map[string]int{"member-b": 30, "member-a": 70, "member-c": 0})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"member-a", "member-b"}, p.Clusters())
+	})
+
+	t.Run("failures", func(t *testing.T) {
+
+		t.Run("no weights", func(t *testing.T) {
+			// when
+			_, err := NewPlacement(map[string]int{})
+
+			// then
+			require.EqualError(t, err, "no member cluster weights provided")
+		})
+
+		t.Run("negative weight", func(t *testing.T) {
+			// when
+			_, err := NewPlacement(map[string]int{"member-a": -1})
+
+			// then
+			require.EqualError(t, err, "invalid weight for member cluster 'member-a': -1")
+		})
+
+		t.Run("only zero weights", func(t *testing.T) {
+			// when
+			_, err := NewPlacement(map[string]int{"member-a": 0})
+
+			// then
+			require.EqualError(t, err, "at least one member cluster must have a weight greater than 0")
+		})
+	})
+}
+
+func TestPlacementNext(t *testing.T) {
+	// given
+	p, err := NewPlacement(map[string]int{"member-a": 70, "member-b": 30})
+	require.NoError(t, err)
+
+	// when
+	placed := []string{}
+	for i := 0; i < 10; i++ {
+		placed = append(placed, p.Next())
+	}
+
+	// then
+	counts := map[string]int{}
+	for _, c := range placed {
+		counts[c]++
+	}
+	assert.Equal(t, map[string]int{"member-a": 7, "member-b": 3}, counts)
+	// the users are interleaved instead of being provisioned in a cluster then in the other
+	assert.Equal(t, []string{"member-a", "member-b", "member-a", "member-a", "member-a", "member-b", "member-a", "member-a", "member-b", "member-a"}, placed)
+}
+
+func TestVerifyDistribution(t *testing.T) {
+	// given
+	hostOperatorNamespace := "toolchain-host-operator"
+	p, err := NewPlacement(map[string]int{"member-a": 70, "member-b": 30})
+	require.NoError(t, err)
+	newSpaces := func(prefix string, counts map[string]int) []client.Object {
+		spaces := []client.Object{}
+		for cluster, count := range counts {
+			for i := 0; i < count; i++ {
+				spaces = append(spaces, &toolchainv1alpha1.Space{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: hostOperatorNamespace,
+						Name:      fmt.Sprintf("%s-%s-%04d", prefix, cluster, i),
+					},
+					Status: toolchainv1alpha1.SpaceStatus{
+						TargetCluster: cluster,
+					},
+				})
+			}
+		}
+		return spaces
+	}
+
+	t.Run("within tolerance", func(t *testing.T) {
+		// given
+		spaces := newSpaces("zippy", map[string]int{"member-a": 68, "member-b": 32})
+		// spaces of other users are ignored
+		spaces = append(spaces, newSpaces("other", map[string]int{"member-b": 100})...)
+		cl := commontest.NewFakeClient(t, spaces...)
+
+		// when
+		counts, err := p.VerifyDistribution(cl, hostOperatorNamespace, "zippy", 0.05)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"member-a": 68, "member-b": 32}, counts)
+	})
+
+	t.Run("outside tolerance", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, newSpaces("zippy", map[string]int{"member-a": 50, "member-b": 50})...)
+
+		// when
+		_, err := p.VerifyDistribution(cl, hostOperatorNamespace, "zippy", 0.05)
+
+		// then
+		require.EqualError(t, err, "the distribution of the Spaces does not match the weights of the member clusters: "+
+			"50.00% of the Spaces provisioned in cluster 'member-a' instead of 70.00%, "+
+			"50.00% of the Spaces provisioned in cluster 'member-b' instead of 30.00%")
+	})
+
+	t.Run("unexpected cluster", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, newSpaces("zippy", map[string]int{"member-a": 70, "member-b": 29, "member-c": 1})...)
+
+		// when
+		_, err := p.VerifyDistribution(cl, hostOperatorNamespace, "zippy", 0.05)
+
+		// then
+		require.EqualError(t, err, "the distribution of the Spaces does not match the weights of the member clusters: "+
+			"1 Space(s) provisioned in unexpected cluster 'member-c'")
+	})
+
+	t.Run("no space", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t)
+
+		// when
+		_, err := p.VerifyDistribution(cl, hostOperatorNamespace, "zippy", 0.05)
+
+		// then
+		require.EqualError(t, err, "no Space found for the users with prefix 'zippy'")
+	})
+}
+
+func TestVerifyMemberClusters(t *testing.T) {
+	// given
+	hostOperatorNamespace := "toolchain-host-operator"
+	newCluster := func(name string, status corev1.ConditionStatus) *toolchainv1alpha1.ToolchainCluster {
+		return &toolchainv1alpha1.ToolchainCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hostOperatorNamespace,
+				Name:      name,
+				Labels: map[string]string{
+					"type": "member",
+				},
+			},
+			Status: toolchainv1alpha1.ToolchainClusterStatus{
+				Conditions: []toolchainv1alpha1.ToolchainClusterCondition{
+					{
+						Type:   toolchainv1alpha1.ToolchainClusterReady,
+						Status: status,
+					},
+				},
+			},
+		}
+	}
+	cl := commontest.NewFakeClient(t, newCluster("member-a", corev1.ConditionTrue), newCluster("member-b", corev1.ConditionFalse))
+
+	t.Run("ready", func(t *testing.T) {
+		// when
+		err := VerifyMemberClusters(cl, hostOperatorNamespace, []string{"member-a"})
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		// when
+		err := VerifyMemberClusters(cl, hostOperatorNamespace, []string{"member-a", "member-b"})
+
+		// then
+		require.EqualError(t, err, "member cluster 'member-b' not found or not ready")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// when
+		err := VerifyMemberClusters(cl, hostOperatorNamespace, []string{"member-c"})
+
+		// then
+		require.EqualError(t, err, "member cluster 'member-c' not found or not ready")
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +34,27 @@ func ForNamespace(cl client.Client, namespace string) error {
 	return nil
 }
 
+// ForDeploymentReady waits until all the replicas of the deployment with the given name are ready
+func ForDeploymentReady(cl client.Client, name, namespace string) error {
+	if err := k8swait.Poll(configuration.DefaultRetryInterval, configuration.DefaultTimeout, func() (bool, error) {
+		deployment := &appsv1.Deployment{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, deployment); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.ReadyReplicas >= replicas, nil
+	}); err != nil {
+		return errors.Wrapf(err, "deployment '%s' in namespace '%s' is not ready", name, namespace)
+	}
+	return nil
+}
+
 func HasSubscriptionWithCriteria(cl client.Client, name, namespace string, criteria ...subCriteria) (bool, error) {
 	sub := &v1alpha1.Subscription{}
 	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sub); err != nil {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -55,6 +56,63 @@ func TestForNamespace(t *testing.T) {
 	})
 }
 
+func TestForDeploymentReady(t *testing.T) {
+	configuration.DefaultTimeout = time.Millisecond * 1
+	newDeployment := func(readyReplicas int32) *appsv1.Deployment {
+		replicas := int32(2)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "host-operator-controller-manager",
+				Namespace: "toolchain-host-operator",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas: readyReplicas,
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// given
+		cl := test.NewFakeClient(t, newDeployment(2))
+
+		// when
+		err := wait.ForDeploymentReady(cl, "host-operator-controller-manager", "toolchain-host-operator")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+
+		t.Run("not all replicas ready", func(t *testing.T) {
+			// given
+			configuration.DefaultTimeout = time.Second * 1
+			cl := test.NewFakeClient(t, newDeployment(1))
+
+			// when
+			err := wait.ForDeploymentReady(cl, "host-operator-controller-manager", "toolchain-host-operator")
+
+			// then
+			require.EqualError(t, err, "deployment 'host-operator-controller-manager' in namespace 'toolchain-host-operator' is not ready: timed out waiting for the condition")
+		})
+
+		t.Run("missing deployment", func(t *testing.T) {
+			// given
+			configuration.DefaultTimeout = time.Second * 1
+			cl := test.NewFakeClient(t)
+
+			// when
+			err := wait.ForDeploymentReady(cl, "host-operator-controller-manager", "toolchain-host-operator")
+
+			// then
+			require.EqualError(t, err, "deployment 'host-operator-controller-manager' in namespace 'toolchain-host-operator' is not ready: timed out waiting for the condition")
+		})
+	})
+}
+
 func TestHasSubscriptionWithCondition(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		t.Run("without criteria", func(t *testing.T) {
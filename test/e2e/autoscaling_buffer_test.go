@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"testing"
+
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+func TestAutoscalingBufferMatchesMemberOperatorConfig(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	originalConfig := memberAwait.GetMemberOperatorConfig(t)
+	// once the ToolchainConfig is restored at the end of the test, the buffer should be back to its original state
+	t.Cleanup(func() {
+		memberAwait.WaitForAutoscalingBufferAppMatchingConfig(t, originalConfig.Spec.Autoscaler)
+	})
+
+	// when & then
+	memberAwait.WaitForAutoscalingBufferAppMatchingConfig(t, originalConfig.Spec.Autoscaler)
+
+	t.Run("buffer is resized", func(t *testing.T) {
+		// when
+		memberConfig := testconfig.ModifyMemberOperatorConfigObj(memberAwait.GetMemberOperatorConfig(t),
+			testconfig.Autoscaler().Deploy(true).BufferReplicas(1).BufferMemory("60Mi"))
+		revision := memberAwait.GetDeploymentRevision(t, wait.AutoscalingBufferName)
+		hostAwait.UpdateToolchainConfig(t, testconfig.Members().Default(memberConfig.Spec))
+
+		// then
+		VerifyMemberOperatorConfig(t, hostAwait, memberAwait, wait.UntilMemberConfigMatches(memberConfig.Spec))
+		// the new memory request changes the pod template, hence a new rollout of the buffer
+		memberAwait.WaitForDeploymentToBeRestarted(t, wait.AutoscalingBufferName, revision)
+		memberAwait.WaitForAutoscalingBufferAppMatchingConfig(t, memberConfig.Spec.Autoscaler)
+	})
+
+	t.Run("buffer is disabled", func(t *testing.T) {
+		// when
+		memberConfig := testconfig.ModifyMemberOperatorConfigObj(memberAwait.GetMemberOperatorConfig(t),
+			testconfig.Autoscaler().Deploy(false))
+		hostAwait.UpdateToolchainConfig(t, testconfig.Members().Default(memberConfig.Spec))
+
+		// then
+		VerifyMemberOperatorConfig(t, hostAwait, memberAwait, wait.UntilMemberConfigMatches(memberConfig.Spec))
+		memberAwait.WaitForAutoscalingBufferAppMatchingConfig(t, memberConfig.Spec.Autoscaler)
+	})
+}
+
+func TestAutoscalingBufferProvidesHeadroom(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	memberAwait := awaitilities.Member1()
+	memberAwait.WaitForAutoscalingBufferAppMatchingConfig(t, memberAwait.GetMemberOperatorConfig(t).Spec.Autoscaler)
+
+	// when & then
+	event := VerifyAutoscalingBufferProvidesHeadroom(t, memberAwait)
+	t.Logf("buffer Pod '%s' was preempted: %s", event.InvolvedObject.Name, event.Message)
+}
@@ -30,6 +30,7 @@ func TestE2EFlow(t *testing.T) {
 	awaitilities := WaitForDeployments(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
+	awaitilities.SkipUnlessMultipleMembers(t)
 	memberAwait2 := awaitilities.Member2()
 
 	consoleURL := memberAwait.GetConsoleURL(t)
@@ -39,6 +40,10 @@ func TestE2EFlow(t *testing.T) {
 			VerifyMemberStatus(t, memberAwait, consoleURL)
 		})
 
+		t.Run("verify che integration in member status", func(t *testing.T) {
+			VerifyCheIntegration(t, memberAwait)
+		})
+
 		t.Run("verify overall toolchain status", func(t *testing.T) {
 			VerifyToolchainStatus(t, hostAwait, memberAwait)
 		})
@@ -0,0 +1,27 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/gofrs/uuid"
+)
+
+func TestForgetUser(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	username := "forgetme-" + uuid.Must(uuid.NewV4()).String()[:8]
+	// the username is also the compliant username of the user, so the graph can be saved if the signup fails
+	defer SaveResourceGraphOnFailure(t, awaitilities, username)
+	userSignup, _ := NewSignupRequest(awaitilities).
+		Username(username).
+		Email(username + "@redhat.com").
+		ManuallyApprove().
+		TargetCluster(awaitilities.Member1()).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).Resources()
+
+	// when & then
+	ForgetUser(t, awaitilities, userSignup)
+}
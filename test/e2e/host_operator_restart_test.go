@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+func TestHostOperatorRestartDuringSignup(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	// when & then
+	// the user is expected to be fully provisioned even though the host operator restarts (ie, its pods are terminating
+	// and then replaced) while the signup is processed
+	RestartHostOperatorDuring(t, hostAwait, func(restart func()) {
+		restart()
+		NewSignupRequest(awaitilities).
+			Username("restartduringsignup").
+			Email("restartduringsignup@redhat.com").
+			ManuallyApprove().
+			TargetCluster(awaitilities.Member1()).
+			EnsureMUR().
+			RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+			Execute(t)
+	})
+}
+
+func TestHostOperatorRestartDuringSpaceCreation(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	// when & then
+	RestartHostOperatorDuring(t, hostAwait, func(restart func()) {
+		restart()
+		space, _, _ := CreateSpace(t, awaitilities, WithTierName("appstudio"), WithTargetCluster(awaitilities.Member1().ClusterName))
+		VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name)
+	})
+}
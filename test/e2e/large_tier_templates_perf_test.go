@@ -0,0 +1,50 @@
+package e2e
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// largeTierObjectsVar the env var to set to enable the probe, with the number of objects to add in each namespace template
+	largeTierObjectsVar = "E2E_LARGE_TIER_OBJECTS"
+	// maxLargeTemplatesApplyDuration the max duration of the apply of the templates of the NSTemplateSet
+	maxLargeTemplatesApplyDuration = 2 * time.Minute
+	// maxLargeTemplatesMemoryUsageKB the max memory usage of the member operator once the templates were applied
+	maxLargeTemplatesMemoryUsageKB = 512 * 1024
+)
+
+// TestLargeTierTemplatesApply measures the duration of the apply of the templates of a custom tier with many objects per namespace,
+// and the memory usage of the member operator, to catch the performance regressions of the apply of the templates.
+// It is opt-in: it only runs when the `E2E_LARGE_TIER_OBJECTS` env var is set.
+func TestLargeTierTemplatesApply(t *testing.T) {
+	// given
+	value, found := os.LookupEnv(largeTierObjectsVar)
+	if !found {
+		t.Skipf("large tier templates probe is disabled, set the '%s' env var to enable it", largeTierObjectsVar)
+	}
+	objectsPerNamespace, err := strconv.Atoi(value)
+	require.NoError(t, err, "invalid value of '%s'", largeTierObjectsVar)
+	require.Positive(t, objectsPerNamespace, "invalid value of '%s'", largeTierObjectsVar)
+
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	baseTier, err := hostAwait.WaitForNSTemplateTier(t, "base")
+	require.NoError(t, err)
+	tier, _ := tiers.CreateCustomTier(t, hostAwait, "base", tiers.WithLargeNamespaceTemplates(t, baseTier, objectsPerNamespace))
+
+	// when
+	measurement := MeasureTemplateApply(t, awaitilities, tier, awaitilities.Member1(), objectsPerNamespace, 10*time.Minute)
+
+	// then
+	AssertTemplateApplyWithinThresholds(t, measurement, TemplateApplyThresholds{
+		MaxApplyDuration: maxLargeTemplatesApplyDuration,
+		MaxMemoryUsageKB: maxLargeTemplatesMemoryUsageKB,
+	})
+}
@@ -0,0 +1,23 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+func TestHostOperatorLeaderElectionFailover(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+
+	// when & then
+	// the leader pod is deleted while the UserSignups are being provisioned, and the new leader is expected
+	// to complete the provisioning without producing duplicate or conflicting resources
+	signups := ProvisionUsersWithLeaderFailover(t, awaitilities, awaitilities.Member1(), 10)
+
+	// and no SpaceBinding was left behind
+	for _, signup := range signups {
+		DeactivateAndCheckUser(t, awaitilities, signup)
+	}
+	VerifyNoOrphanSpaceBindings(t, awaitilities.Host())
+}
@@ -0,0 +1,16 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+func TestSignupQueuedWhileMemberCapacityExhausted(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+
+	// when & then
+	// the user is kept on the waiting list while all the member clusters are full, and is provisioned once the capacity is restored
+	VerifySignupQueuedUntilCapacityRestored(t, awaitilities, "capacityexhausted")
+}
@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+// TestMemberClusterDeregistration scales the toolchain down to zero members by deleting the ToolchainClusters of all the member clusters
+// while they still host Spaces, verifies that the Spaces are reported as unready (but not lost), and then registers the member clusters again
+func TestMemberClusterDeregistration(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	spaceNames := map[string][]string{}
+	for _, memberAwait := range awaitilities.AllMembers() {
+		space, _, _ := CreateSpace(t, awaitilities, WithTierName("base"), WithTargetCluster(memberAwait.ClusterName))
+		VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name)
+		spaceNames[memberAwait.ClusterName] = append(spaceNames[memberAwait.ClusterName], space.Name)
+	}
+
+	// when
+	deregistrations := DeregisterAllMemberClusters(t, awaitilities)
+
+	// then
+	for _, d := range deregistrations {
+		VerifyMemberDeregistered(t, d, spaceNames[d.ClusterName()]...)
+	}
+
+	t.Run("re-register the member clusters", func(t *testing.T) {
+		// when
+		for _, d := range deregistrations {
+			d.ReRegister(t)
+			d.WaitUntilMemberReady(t)
+		}
+
+		// then
+		for _, names := range spaceNames {
+			VerifySpacesRecoveredAfterReRegistration(t, awaitilities, names...)
+		}
+	})
+}
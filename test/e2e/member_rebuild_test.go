@@ -0,0 +1,35 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemberClusterRebuild simulates the rebuild of a member cluster: all the resources provisioned by the toolchain are deleted
+// in the member cluster, which is then registered again, and the Spaces which targeted it are expected to be provisioned again.
+// It is destructive (for the resources of the other tests, too) and thus opt-in: it only runs when the `E2E_MEMBER_REBUILD` env var is set.
+func TestMemberClusterRebuild(t *testing.T) {
+	SkipUnlessMemberRebuildEnabled(t)
+
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	var spaceNames []string
+	for _, tier := range []string{"base", "appstudio"} {
+		space, _, _ := CreateSpace(t, awaitilities, WithTierName(tier), WithTargetCluster(memberAwait.ClusterName))
+		VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name)
+		spaceNames = append(spaceNames, space.Name)
+	}
+
+	// when
+	wipe := WipeMemberCluster(t, memberAwait)
+	require.NotEmpty(t, wipe.Namespaces)
+	ReRegisterMemberCluster(t, hostAwait, memberAwait)
+
+	// then
+	recoveries := VerifySpacesRecovered(t, awaitilities, wipe, spaceNames...)
+	AssertAllSpacesRecovered(t, recoveries)
+}
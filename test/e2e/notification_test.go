@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationCleanup(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	ttl := 10 * time.Second
+	hostAwait.UpdateToolchainConfig(t, testconfig.Notifications().DurationBeforeNotificationDeletion(ttl.String()))
+
+	t.Run("delivered notification is deleted once its TTL expired", func(t *testing.T) {
+		// given
+		createdAt := time.Now()
+
+		// when
+		notification := CreateNotification(t, hostAwait, WithRecipient("e2e-notification@redhat.com"))
+
+		// then
+		VerifyNotificationCleanedUp(t, hostAwait, notification.Name, createdAt, ttl, 10*time.Second)
+	})
+
+	t.Run("undelivered notification is not deleted", func(t *testing.T) {
+		// when
+		notification := CreateNotification(t, hostAwait, WithUserID(uuid.Must(uuid.NewV4()).String()))
+
+		// then
+		VerifyNotificationNotDelivered(t, hostAwait, notification.Name, toolchainv1alpha1.NotificationContextErrorReason, 2*ttl)
+	})
+}
+
+func TestUserNotificationContext(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	// when
+	userSignup, _ := NewSignupRequest(awaitilities).
+		Username("notificationcontext").
+		Email("notificationcontext@redhat.com").
+		ManuallyApprove().
+		EnsureMUR().
+		TargetCluster(awaitilities.Member1()).
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).Resources()
+
+	// then
+	VerifyUserNotificationContext(t, hostAwait, userSignup, toolchainv1alpha1.NotificationTypeProvisioned)
+
+	t.Run("deactivated notification", func(t *testing.T) {
+		// when
+		userSignup, err := hostAwait.UpdateUserSignup(t, userSignup.Name, func(us *toolchainv1alpha1.UserSignup) {
+			states.SetDeactivated(us, true)
+		})
+		require.NoError(t, err)
+
+		// then
+		VerifyUserNotificationContext(t, hostAwait, userSignup, toolchainv1alpha1.NotificationTypeDeactivated)
+	})
+}
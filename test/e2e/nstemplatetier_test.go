@@ -70,6 +70,9 @@ func TestNSTemplateTiers(t *testing.T) {
 
 			// then
 			VerifyResourcesProvisionedForSignup(t, awaitilities, testingtiers, "deactivate30", tierToCheck) // deactivate30 is the default UserTier
+			tiers.VerifyNamespacesObjectsBudget(t, hostAwait, awaitilities.Member1(), testingTiersName)
+			tiers.VerifyNamespaceObjectsAsTemplated(t, hostAwait, awaitilities.Member1(), testingTiersName)
+			tiers.VerifyNamespacesConventions(t, awaitilities.Member1(), testingTiersName)
 		})
 	}
 }
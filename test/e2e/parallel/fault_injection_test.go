@@ -0,0 +1,35 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+func TestSelfHealingOfUserSignupInEdgeState(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	for _, fault := range []Fault{MissingMasterUserRecord, MissingSpace, OrphanedSpace, LostCompliantUsername, LostSpaceTargetCluster} {
+		fault := fault
+		t.Run(string(fault), func(t *testing.T) {
+			// given
+			userSignup, _ := NewSignupRequest(awaitilities).
+				Username("faulty-" + string(fault)).
+				ManuallyApprove().
+				TargetCluster(awaitilities.Member1()).
+				EnsureMUR().
+				RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+				Execute(t).Resources()
+			VerifyResourcesProvisionedForSignup(t, awaitilities, userSignup, "deactivate30", "base")
+
+			// when
+			injected := InjectFault(t, hostAwait, userSignup, fault)
+
+			// then
+			injected.WaitUntilSelfHealed(t, awaitilities, "deactivate30", "base")
+		})
+	}
+}
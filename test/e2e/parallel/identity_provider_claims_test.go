@@ -0,0 +1,71 @@
+package parallel
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+func TestSignupWithClaimsFromIdentityProviders(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+
+	for _, idp := range authsupport.IdentityProviders {
+		idp := idp
+		t.Run(string(idp), func(t *testing.T) {
+			suffix := rand.String(5)
+			usernames := map[string]string{
+				// username -> expected compliant username
+				fmt.Sprintf("rh-user-%s", suffix):              "rh-user-" + suffix,
+				fmt.Sprintf("octo_cat.%s", suffix):             "octo-cat-" + suffix,
+				fmt.Sprintf("jane.doe.%s@example.com", suffix): "jane-doe-" + suffix,
+			}
+			for username, expectedCompliantUsername := range usernames {
+				username, expectedCompliantUsername := username, expectedCompliantUsername
+				t.Run(username, func(t *testing.T) {
+					// given
+					sub, claims := authsupport.ClaimsFrom(idp)
+
+					// when
+					userSignup, _ := NewSignupRequest(awaitilities).
+						Username(username).
+						Claims(claims...).
+						ManuallyApprove().
+						TargetCluster(awaitilities.Member1()).
+						EnsureMUR().
+						RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+						Execute(t).Resources()
+
+					// then
+					assert.Equal(t, sub, userSignup.Spec.Userid)
+					assert.Equal(t, username, userSignup.Spec.Username)
+					assert.Equal(t, expectedCompliantUsername, userSignup.Status.CompliantUsername)
+				})
+			}
+		})
+
+		t.Run(string(idp)+" without preferred_username", func(t *testing.T) {
+			// given
+			_, claims := authsupport.ClaimsFrom(idp)
+			_, token, err := authsupport.NewToken(append(claims,
+				authsupport.WithEmail(uuid.Must(uuid.NewV4()).String()+"@example.com"),
+				authsupport.WithoutPreferredUsername())...)
+			require.NoError(t, err)
+
+			// when
+			resp := NewRegistrationServiceClient(awaitilities.Host()).Invoke(t, "POST", "/api/v1/signup", token, "")
+
+			// then
+			respErr := resp.RequireAuthError(t, http.StatusUnauthorized)
+			assert.Contains(t, respErr.Error, "username missing")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package parallel
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/fixtures"
+)
+
+func TestMain(m *testing.M) {
+	// expose the state of the suite (pending waits and cleanups), to inspect it if it hangs
+	stopDebugServer, err := testsupport.StartDebugServer()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	// delete the users shared by the tests, now that all of them completed
+	if err := fixtures.Teardown(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	// print the time spent in cleaning the resources created by the tests, to help reducing the suite duration
+	cleanup.PrintTimingReport(os.Stdout)
+	// print the resources consumed by the namespaces of each test, to identify the most expensive tests on shared clusters
+	testsupport.PrintResourceUsageReport(os.Stdout, "e2e-parallel")
+	if err := testsupport.WriteSuiteReports("e2e-parallel"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	// fail the suite if too many transient errors were retried by the tests
+	if err := testsupport.CheckErrorBudget(os.Stdout, "e2e-parallel"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	stopDebugServer()
+	os.Exit(code)
+}
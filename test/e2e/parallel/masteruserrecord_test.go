@@ -0,0 +1,66 @@
+package parallel
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMasterUserRecordWithoutUserSignup verifies how the host operator reconciles the MasterUserRecords which have no UserSignup,
+// as it may be the case for the MasterUserRecords of legacy or migrated users
+func TestMasterUserRecordWithoutUserSignup(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+
+	t.Run("provisioned and kept", func(t *testing.T) {
+		// when
+		mur := NewMasterUserRecord(t, hostAwait).
+			UserAccounts(memberAwait).
+			TierName("deactivate30").
+			Email("orphan@redhat.com").
+			Owner("deleted-usersignup").
+			Create(t)
+
+		// then
+		mur = VerifyOrphanMasterUserRecordProvisioned(t, awaitilities, mur)
+		_, err := hostAwait.WaitForMasterUserRecord(t, mur.Name,
+			wait.UntilMasterUserRecordHasTierName("deactivate30"),
+			wait.UntilMasterUserRecordHasAnnotation(toolchainv1alpha1.MasterUserRecordEmailAnnotationKey, "orphan@redhat.com"))
+		require.NoError(t, err)
+		VerifyOrphanMasterUserRecordKept(t, hostAwait, mur, 10*time.Second)
+
+		t.Run("deleted along with its useraccount", func(t *testing.T) {
+			VerifyOrphanMasterUserRecordDeleted(t, awaitilities, mur)
+		})
+	})
+
+	t.Run("useraccount in unknown cluster", func(t *testing.T) {
+		// when
+		mur := NewMasterUserRecord(t, hostAwait).
+			UserAccountInCluster("unknown-member").
+			Create(t)
+
+		// then
+		VerifyOrphanMasterUserRecordNotReady(t, hostAwait, mur, toolchainv1alpha1.MasterUserRecordTargetClusterNotReadyReason)
+
+		t.Run("provisioned once moved to a known cluster", func(t *testing.T) {
+			// when
+			mur, err := hostAwait.UpdateMasterUserRecordSpec(t, mur.Name, func(mur *toolchainv1alpha1.MasterUserRecord) {
+				mur.Spec.UserAccounts = []toolchainv1alpha1.UserAccountEmbedded{
+					{TargetCluster: memberAwait.ClusterName},
+				}
+			})
+			require.NoError(t, err)
+
+			// then
+			VerifyOrphanMasterUserRecordProvisioned(t, awaitilities, mur)
+		})
+	})
+}
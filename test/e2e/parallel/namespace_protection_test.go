@@ -0,0 +1,49 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceDeletionProtection(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	memberAwait := awaitilities.Member1()
+	_, mur := NewSignupRequest(awaitilities).
+		Username("nsprotection").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).
+		Resources()
+	VerifyResourcesProvisionedForSpace(t, awaitilities, mur.Name)
+
+	// when
+	attempts := VerifyNamespaceDeletionProtection(t, awaitilities, mur.Name, mur.Name)
+
+	// then
+	expected := map[string]DeletionOutcome{
+		// the users are never allowed to delete their namespaces, nor the limits of their namespaces
+		"Namespace":  DeletionPrevented,
+		"LimitRange": DeletionPrevented,
+		// the `rbac-edit` role of the space admins allows them to delete the roles and role bindings, which are recreated
+		"Role":        DeletionRepaired,
+		"RoleBinding": DeletionRepaired,
+	}
+	attempted := map[string]bool{}
+	for _, attempt := range attempts {
+		t.Logf("deletion of %s '%s' in namespace '%s': %s", attempt.Kind, attempt.Name, attempt.Namespace, attempt.Outcome)
+		attempted[attempt.Kind] = true
+		expectedOutcome, found := expected[attempt.Kind]
+		if assert.True(t, found, "unexpected deletion of %s '%s'", attempt.Kind, attempt.Name) {
+			assert.Equal(t, expectedOutcome, attempt.Outcome, "unexpected outcome of the deletion of %s '%s' in namespace '%s'", attempt.Kind, attempt.Name, attempt.Namespace)
+		}
+	}
+	for kind := range expected {
+		assert.True(t, attempted[kind], "no %s was deleted", kind)
+	}
+}
@@ -0,0 +1,48 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/fixtures"
+)
+
+func TestNetworkPoliciesConnectivity(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	// both users of the pool are provisioned in the `base` tier, with a `dev` and a `stage` namespace, in the first member cluster.
+	// The leases are exclusive, so that the two users are distinct and no other test runs pods in their namespaces.
+	user := fixtures.LeaseUser(t, awaitilities, fixtures.ExclusiveLease)
+	otherUser := fixtures.LeaseUser(t, awaitilities, fixtures.ExclusiveLease)
+	memberAwait := user.Member
+	serverIP := DeployProbeServer(t, memberAwait, user.MUR.Name+"-dev")
+
+	t.Run("allowed from the same namespace", func(t *testing.T) {
+		VerifyTrafficAllowed(t, memberAwait, user.MUR.Name+"-dev", serverIP, NetworkProbePort)
+	})
+
+	t.Run("allowed from the other namespace of the same user", func(t *testing.T) {
+		VerifyTrafficAllowed(t, memberAwait, user.MUR.Name+"-stage", serverIP, NetworkProbePort)
+	})
+
+	t.Run("denied from the namespace of another user", func(t *testing.T) {
+		VerifyTrafficDenied(t, memberAwait, otherUser.MUR.Name+"-dev", serverIP, NetworkProbePort)
+	})
+
+	t.Run("allowed from the ingress namespaces", func(t *testing.T) {
+		ns := CreateProbeNamespace(t, memberAwait, "netprobe-ingress", map[string]string{
+			"network.openshift.io/policy-group": "ingress",
+		})
+		VerifyTrafficAllowed(t, memberAwait, ns, serverIP, NetworkProbePort)
+	})
+
+	t.Run("denied from the other namespaces", func(t *testing.T) {
+		ns := CreateProbeNamespace(t, memberAwait, "netprobe-other", nil)
+		VerifyTrafficDenied(t, memberAwait, ns, serverIP, NetworkProbePort)
+	})
+
+	t.Run("allowed to the API server", func(t *testing.T) {
+		VerifyTrafficAllowed(t, memberAwait, user.MUR.Name+"-dev", "kubernetes.default.svc", 443)
+	})
+}
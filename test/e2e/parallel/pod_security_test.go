@@ -0,0 +1,23 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/fixtures"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+)
+
+func TestPrivilegedPodRejected(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	// the test only verifies that a pod is rejected, so the user is shared with the other tests
+	user := fixtures.ProvisionedUser(t, awaitilities)
+	memberAwait, mur := user.Member, user.MUR
+	// the Pod Security labels of the namespaces are verified against the tier templates
+	VerifyResourcesProvisionedForSpace(t, awaitilities, mur.Name)
+
+	// when/then
+	tiers.VerifyPrivilegedPodRejected(t, memberAwait, mur.Name+"-dev", mur.Name)
+}
@@ -0,0 +1,130 @@
+package parallel
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/socialevent"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistrationServiceErrorContract verifies the status, headers and body schema of the error responses of the registration service
+func TestRegistrationServiceErrorContract(t *testing.T) {
+	// given
+	t.Parallel()
+	await := WaitForDeployments(t)
+	hostAwait := await.Host()
+	regService := NewRegistrationServiceClient(hostAwait)
+
+	t.Run("missing token", func(t *testing.T) {
+		for _, method := range []string{"GET", "POST"} {
+			t.Run(method, func(t *testing.T) {
+				// when
+				resp := regService.Invoke(t, method, "/api/v1/signup", "", "")
+
+				// then
+				respErr := resp.RequireAuthError(t, http.StatusUnauthorized)
+				assert.Equal(t, "no token found", respErr.Error)
+			})
+		}
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		// given
+		_, token, err := authsupport.NewToken(
+			authsupport.WithEmail(uuid.Must(uuid.NewV4()).String()+"@acme.com"),
+			authsupport.WithExp(time.Now().Add(-60*time.Second)))
+		require.NoError(t, err)
+
+		for _, method := range []string{"GET", "POST"} {
+			t.Run(method, func(t *testing.T) {
+				// when
+				resp := regService.Invoke(t, method, "/api/v1/signup", token, "")
+
+				// then
+				respErr := resp.RequireAuthError(t, http.StatusUnauthorized)
+				assert.Contains(t, respErr.Error, "token is expired by ")
+			})
+		}
+	})
+
+	t.Run("banned user", func(t *testing.T) {
+		// given
+		email := uuid.Must(uuid.NewV4()).String() + "@acme.com"
+		CreateBannedUser(t, hostAwait, email)
+		_, token, err := authsupport.NewToken(authsupport.WithEmail(email))
+		require.NoError(t, err)
+
+		// when
+		resp := regService.Invoke(t, "POST", "/api/v1/signup", token, "")
+
+		// then
+		respErr := resp.RequireError(t, http.StatusForbidden)
+		assert.Equal(t, "forbidden: user has been banned", respErr.Message)
+	})
+
+	t.Run("incomplete verification", func(t *testing.T) {
+		// given
+		identity, token, err := authsupport.NewToken(authsupport.WithEmail(uuid.Must(uuid.NewV4()).String() + "@some.domain"))
+		require.NoError(t, err)
+		regService.Invoke(t, "POST", "/api/v1/signup", token, "").RequireStatus(t, http.StatusAccepted)
+		userSignup, err := hostAwait.WaitForUserSignup(t, identity.Username,
+			wait.UntilUserSignupHasConditions(ConditionSet(Default(), VerificationRequired())...),
+			wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueNotReady))
+		require.NoError(t, err)
+		cleanup.AddCleanTasks(t, hostAwait.Client, userSignup)
+
+		// requireVerificationIncomplete verifies that the signup is still pending, waiting for the verification
+		requireVerificationIncomplete := func(t *testing.T) {
+			signup := regService.Invoke(t, "GET", "/api/v1/signup", token, "").RequireSignup(t)
+			assert.Equal(t, identity.Username, signup.Username)
+			assert.Empty(t, signup.CompliantUsername)
+			assert.False(t, signup.Status.Ready)
+			assert.Equal(t, "PendingApproval", signup.Status.Reason)
+			assert.True(t, signup.Status.VerificationRequired)
+		}
+
+		t.Run("get signup", func(t *testing.T) {
+			requireVerificationIncomplete(t)
+		})
+
+		t.Run("unknown activation code", func(t *testing.T) {
+			// when
+			resp := regService.Invoke(t, "POST", socialevent.ActivationCodePath, token, fmt.Sprintf(`{"code":"%s"}`, socialevent.UnknownCode))
+
+			// then
+			respErr := resp.RequireError(t, http.StatusForbidden)
+			assert.Equal(t, "invalid code", respErr.Message)
+			requireVerificationIncomplete(t)
+		})
+
+		t.Run("phone verification", func(t *testing.T) {
+			// when
+			resp := regService.Invoke(t, "PUT", "/api/v1/signup/verification", token, `{ "country_code":"+61", "phone_number":"408999998" }`)
+
+			// then
+			resp.RequireStatus(t, http.StatusNoContent)
+			assert.Empty(t, resp.Body)
+			requireVerificationIncomplete(t)
+
+			t.Run("invalid code", func(t *testing.T) {
+				// when
+				resp := regService.Invoke(t, "GET", "/api/v1/signup/verification/invalid", token, "")
+
+				// then
+				respErr := resp.RequireError(t, http.StatusForbidden)
+				assert.Equal(t, "invalid code", respErr.Message)
+				requireVerificationIncomplete(t)
+			})
+		})
+	})
+}
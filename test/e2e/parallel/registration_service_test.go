@@ -1,25 +1,22 @@
 package parallel
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
 	"testing"
 	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
-	commonsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/socialevent"
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
 	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
-	testsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/test/socialevent"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/socialevent"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/gofrs/uuid"
@@ -30,8 +27,6 @@ import (
 	k8swait "k8s.io/apimachinery/pkg/util/wait"
 )
 
-var httpClient = HTTPClient
-
 func TestLandingPageReachable(t *testing.T) {
 	// given
 	t.Parallel()
@@ -39,14 +34,12 @@ func TestLandingPageReachable(t *testing.T) {
 	route := await.Host().RegistrationServiceURL
 
 	// just make sure that the landing page is reachable
-	req, err := http.NewRequest("GET", route, nil)
-	require.NoError(t, err)
-
-	resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer Close(t, resp)`
-	require.NoError(t, err)
-	defer Close(t, resp)
+	resp := NewRESTClient().Do(t, RESTRequest{
+		Method: "GET",
+		URL:    route,
+	})
 
-	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected response status:\n%s", resp)
 }
 
 func TestHealth(t *testing.T) {
@@ -57,22 +50,7 @@ func TestHealth(t *testing.T) {
 
 	t.Run("get healthcheck 200 OK", func(t *testing.T) {
 		// Call health endpoint.
-		req, err := http.NewRequest("GET", route+"/api/v1/health", nil)
-		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
-		require.NoError(t, err)
+		mp := invokeEndpoint(t, "GET", route+"/api/v1/health", "", "", http.StatusOK)
 
 		// Verify JSON response.
 		alive := mp["alive"]
@@ -102,24 +80,14 @@ func TestWoopra(t *testing.T) {
 
 	assertNotSecuredGetResponseEquals := func(endPointPath, expectedResponseValue string) {
 		// Call woopra domain endpoint.
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/%s", route, endPointPath), nil)
-		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		value := string(body)
-		require.NoError(t, err)
+		resp := NewRESTClient().Do(t, RESTRequest{
+			Method: "GET",
+			URL:    fmt.Sprintf("%s/api/v1/%s", route, endPointPath),
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected response status:\n%s", resp)
 
 		// Verify JSON response.
-		require.Equal(t, expectedResponseValue, value)
+		require.Equal(t, expectedResponseValue, string(resp.Body))
 	}
 
 	t.Run("get woopra domain 200 OK", func(t *testing.T) {
@@ -141,18 +109,12 @@ func TestAuthConfig(t *testing.T) {
 
 	t.Run("get authconfig 200 OK", func(t *testing.T) {
 		// Call authconfig endpoint.
-		req, err := http.NewRequest("GET", route+"/api/v1/authconfig", nil)
-		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
+		resp := NewRESTClient().Do(t, RESTRequest{
+			Method: "GET",
+			URL:    route + "/api/v1/authconfig",
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "unexpected response status:\n%s", resp)
+		require.NotEmpty(t, resp.Body)
 	})
 }
 
@@ -166,24 +128,9 @@ func TestSignupFails(t *testing.T) {
 		// Call signup endpoint without a token.
 		requestBody, err := json.Marshal(map[string]string{})
 		require.NoError(t, err)
-		req, err := http.NewRequest("POST", route+"/api/v1/signup", bytes.NewBuffer(requestBody))
-		require.NoError(t, err)
-		req.Header.Set("content-type", "application/json")
-
-		resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer.Close(...)`
-		defer Close(t, resp)
-		require.NoError(t, err)
 
 		// Retrieve unauthorized http status code.
-		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
-		require.NoError(t, err)
+		mp := invokeEndpoint(t, "POST", route+"/api/v1/signup", "", string(requestBody), http.StatusUnauthorized)
 
 		// Check token error.
 		tokenErr := mp["error"]
@@ -212,24 +159,8 @@ func TestSignupFails(t *testing.T) {
 	})
 	t.Run("get signup error no token 401 Unauthorized", func(t *testing.T) {
 		// Call signup endpoint without a token.
-		req, err := http.NewRequest("GET", route+"/api/v1/signup", nil)
-		require.NoError(t, err)
-		req.Header.Set("content-type", "application/json")
-
-		resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer.Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
 		// Retrieve unauthorized http status code.
-		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
-		require.NoError(t, err)
+		mp := invokeEndpoint(t, "GET", route+"/api/v1/signup", "", "", http.StatusUnauthorized)
 
 		// Check token error.
 		tokenErr := mp["error"]
@@ -469,6 +400,9 @@ func TestPhoneVerification(t *testing.T) {
 	// Confirm the expiry time has been set
 	require.NotEmpty(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationExpiryAnnotationKey])
 
+	// Confirm the phone number hash label has been set
+	hash.RequireLabel(t, userSignup, toolchainv1alpha1.UserSignupUserPhoneHashLabelKey, hash.PhoneNumberHash("+61", "408999999"))
+
 	// Attempt to verify with an incorrect verification code
 	invokeEndpoint(t, "GET", route+"/api/v1/signup/verification/invalid", token0, "", http.StatusForbidden)
 
@@ -593,24 +527,21 @@ func TestActivationCodeVerification(t *testing.T) {
 	t.Parallel()
 	await := WaitForDeployments(t)
 	hostAwait := await.Host()
-	route := hostAwait.RegistrationServiceURL
 
 	t.Run("verification successful", func(t *testing.T) {
 		// given
-		event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
-			testsocialevent.WithUserTier("deactivate80"),
-			testsocialevent.WithSpaceTier("base1ns6didler"))
-		err := hostAwait.CreateWithCleanup(t, event)
-		require.NoError(t, err)
+		event := socialevent.Create(t, hostAwait,
+			socialevent.WithUserTier("deactivate80"),
+			socialevent.WithSpaceTier("base1ns6didler"))
 		userSignup, token := signup(t, hostAwait)
 
 		// when call verification endpoint with a valid activation code
-		invokeEndpoint(t, "POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusOK)
+		socialevent.Activate(t, hostAwait, token, event.Name).RequireStatus(t, http.StatusOK)
 
 		// then
 		// ensure the UserSignup is in "pending approval" condition,
 		// because in these series of parallel tests, automatic approval is disabled ¯\_(ツ)_/¯
-		_, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
+		_, err := hostAwait.WaitForUserSignup(t, userSignup.Name,
 			wait.UntilUserSignupHasLabel(toolchainv1alpha1.SocialEventUserSignupLabelKey, event.Name),
 			wait.UntilUserSignupHasConditions(ConditionSet(Default(), PendingApproval())...))
 		require.NoError(t, err)
@@ -639,8 +570,7 @@ func TestActivationCodeVerification(t *testing.T) {
 		require.NoError(t, err)
 
 		// also check that the SocialEvent status was updated accordingly
-		_, err = hostAwait.WaitForSocialEvent(t, event.Name, wait.UntilSocialEventHasActivationCount(1))
-		require.NoError(t, err)
+		socialevent.WaitForActivationCount(t, hostAwait, event.Name, 1)
 	})
 
 	t.Run("verification failed", func(t *testing.T) {
@@ -649,77 +579,37 @@ func TestActivationCodeVerification(t *testing.T) {
 			// given
 			userSignup, token := signup(t, hostAwait)
 
-			// when call verification endpoint with a valid activation code
-			invokeEndpoint(t, "POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, "unknown"), http.StatusForbidden)
-
-			// then
-			// ensure the UserSignup is not approved yet
-			userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name,
-				wait.UntilUserSignupHasConditions(ConditionSet(Default(), VerificationRequired())...))
-			require.NoError(t, err)
-			assert.Equal(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey], "1")
+			// when & then
+			socialevent.RequireActivationRejected(t, hostAwait, userSignup, token, socialevent.UnknownCode)
 		})
 
 		t.Run("over capacity", func(t *testing.T) {
 			// given
-			event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
-				testsocialevent.WithUserTier("deactivate80"),
-				testsocialevent.WithSpaceTier("base1ns6didler"))
-			err := hostAwait.CreateWithCleanup(t, event)
-			require.NoError(t, err)
-			event, err = hostAwait.WaitForSocialEvent(t, event.Name) // need to reload event
-			require.NoError(t, err)
-			event.Status.ActivationCount = event.Spec.MaxAttendees // activation count identical to `MaxAttendees`
-			err = hostAwait.Client.Status().Update(context.TODO(), event)
-			require.NoError(t, err)
-
+			event := socialevent.CreateFull(t, hostAwait,
+				socialevent.WithUserTier("deactivate80"),
+				socialevent.WithSpaceTier("base1ns6didler"))
 			userSignup, token := signup(t, hostAwait)
 
-			// when call verification endpoint with a valid activation code
-			invokeEndpoint(t, "POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
-
-			// then
-			// ensure the UserSignup is not approved yet
-			userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
-				wait.UntilUserSignupHasConditions(ConditionSet(Default(), VerificationRequired())...))
-			require.NoError(t, err)
-			assert.Equal(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey], "1")
+			// when & then
+			socialevent.RequireActivationRejected(t, hostAwait, userSignup, token, event.Name)
 		})
 
 		t.Run("not opened yet", func(t *testing.T) {
 			// given
-			event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(), testsocialevent.WithStartTime(time.Now().Add(time.Hour))) // not open yet
-			err := hostAwait.CreateWithCleanup(t, event)
-			require.NoError(t, err)
+			event := socialevent.Create(t, hostAwait, socialevent.NotOpenedYet())
 			userSignup, token := signup(t, hostAwait)
 
-			// when call verification endpoint with a valid activation code
-			invokeEndpoint(t, "POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
-
-			// then
-			// ensure the UserSignup is not approved yet
-			userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
-				wait.UntilUserSignupHasConditions(ConditionSet(Default(), VerificationRequired())...))
-			require.NoError(t, err)
-			assert.Equal(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey], "1")
+			// when & then
+			socialevent.RequireActivationRejected(t, hostAwait, userSignup, token, event.Name)
 		})
 
 		t.Run("already closed", func(t *testing.T) {
 			// given
-			event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(), testsocialevent.WithEndTime(time.Now().Add(-time.Hour))) // already closd
-			err := hostAwait.CreateWithCleanup(t, event)
-			require.NoError(t, err)
+			event := socialevent.Create(t, hostAwait, socialevent.AlreadyClosed())
 			userSignup, token := signup(t, hostAwait)
 
-			// when call verification endpoint with a valid activation code
-			invokeEndpoint(t, "POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
-
-			// then
-			// ensure the UserSignup is approved
-			userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
-				wait.UntilUserSignupHasConditions(ConditionSet(Default(), VerificationRequired())...))
-			require.NoError(t, err)
-			assert.Equal(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey], "1")
+			// when & then
+			socialevent.RequireActivationRejected(t, hostAwait, userSignup, token, event.Name)
 		})
 
 		t.Run("invalid code", func(t *testing.T) {
@@ -758,12 +648,16 @@ func assertGetSignupStatusProvisioned(t *testing.T, await wait.Awaitilities, use
 	mp := waitForUserSignupReadyInRegistrationService(t, hostAwait.RegistrationServiceURL, username, bearerToken)
 	assert.Equal(t, username, mp["compliantUsername"])
 	assert.Equal(t, username, mp["username"])
-	assert.Equal(t, memberAwait.GetConsoleURL(t), mp["consoleURL"])
+	if openShift, err := memberAwait.HasCapability(wait.CapabilityOpenShift); err == nil && openShift {
+		// there is no web console (route) in a kind cluster
+		assert.Equal(t, memberAwait.GetConsoleURL(t), mp["consoleURL"])
+	}
 	memberCluster, found, err := hostAwait.GetToolchainCluster(t, cluster.Member, memberAwait.Namespace, nil)
 	require.NoError(t, err)
 	require.True(t, found)
 	assert.Equal(t, memberCluster.Spec.APIEndpoint, mp["apiEndpoint"])
 	assert.Equal(t, hostAwait.APIProxyURL, mp["proxyURL"])
+	VerifyCheDashboardForUser(t, hostAwait, memberAwait, bearerToken)
 }
 
 func assertGetSignupStatusPendingApproval(t *testing.T, await wait.Awaitilities, username, bearerToken string) {
@@ -814,30 +708,18 @@ func waitForUserSignupReadyInRegistrationService(t *testing.T, registrationServi
 
 // invokeEndpoint invokes given http URL and returns the json body response
 func invokeEndpoint(t *testing.T, method, path, authToken, requestBody string, requiredStatus int) map[string]interface{} {
-	var reqBody io.Reader
-	t.Logf("invoking http request: %s %s", method, path)
-	if requestBody != "" {
-		t.Logf("request body: %s", requestBody)
-		reqBody = strings.NewReader(requestBody)
-	}
-	req, err := http.NewRequest(method, path, reqBody)
-	require.NoError(t, err)
-	req.Header.Set("Authorization", "Bearer "+authToken)
-	req.Header.Set("content-type", "application/json")
-	resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer.Close(...)`
-	t.Logf("response status code: %d", resp.StatusCode)
-	require.NoError(t, err)
-	defer Close(t, resp)
-
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
-	require.NotNil(t, body)
-	require.Equal(t, requiredStatus, resp.StatusCode, "unexpected response status with body: %s", body)
+	resp := NewRESTClient().Do(t, RESTRequest{
+		Method: method,
+		URL:    path,
+		Token:  authToken,
+		Body:   requestBody,
+	})
+	require.Equal(t, requiredStatus, resp.StatusCode, "unexpected response status:\n%s", resp)
 
 	mp := make(map[string]interface{})
-	if len(body) > 0 {
-		err = json.Unmarshal(body, &mp)
-		require.NoError(t, err)
+	if len(resp.Body) > 0 {
+		err := json.Unmarshal(resp.Body, &mp)
+		require.NoError(t, err, "unable to decode the response:\n%s", resp)
 	}
 	return mp
 }
@@ -8,7 +8,6 @@ import (
 
 	"github.com/codeready-toolchain/api/api/v1alpha1"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
-	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,8 +35,7 @@ func TestDoNotOverrideServiceAccount(t *testing.T) {
 		Resources()
 
 	// and move the user to appstudio tier
-	tiers.MoveSpaceToTier(t, awaitilities.Host(), mur.Name, "appstudio")
-	VerifyResourcesProvisionedForSpace(t, awaitilities, mur.Name)
+	PromoteUser(t, awaitilities, mur.Name, "appstudio")
 
 	// get the SA that is provisioned for the user in the ns
 	sa, err := member.WaitForServiceAccount(t, mur.Name, fmt.Sprintf("appstudio-%s", mur.Name))
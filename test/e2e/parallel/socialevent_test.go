@@ -5,15 +5,12 @@ import (
 	"testing"
 	"time"
 
-	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	commonsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/socialevent"
-	testsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/test/socialevent"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/socialevent"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	corev1 "k8s.io/api/core/v1"
 )
 
 func TestCreateSocialEvent(t *testing.T) {
@@ -28,12 +25,12 @@ func TestCreateSocialEvent(t *testing.T) {
 		// given
 		start := time.Now().Add(time.Hour).Round(time.Second)
 		end := time.Now().Add(24 * time.Hour).Round(time.Second)
-		event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
-			testsocialevent.WithUserTier("deactivate30"),
-			testsocialevent.WithSpaceTier("base1ns"),
-			testsocialevent.WithStartTime(start),
-			testsocialevent.WithEndTime(end),
-			testsocialevent.WithMaxAttendees(5),
+		event := socialevent.New(hostAwait,
+			socialevent.WithUserTier("deactivate30"),
+			socialevent.WithSpaceTier("base1ns"),
+			socialevent.WithStartTime(start),
+			socialevent.WithEndTime(end),
+			socialevent.WithMaxAttendees(5),
 		)
 
 		// when
@@ -41,10 +38,7 @@ func TestCreateSocialEvent(t *testing.T) {
 
 		// then
 		require.NoError(t, err)
-		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
-			Type:   toolchainv1alpha1.ConditionReady,
-			Status: corev1.ConditionTrue,
-		}))
+		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(socialevent.Ready()))
 		require.NoError(t, err)
 		assert.Equal(t, "deactivate30", event.Spec.UserTier)
 		assert.Equal(t, "base1ns", event.Spec.SpaceTier)
@@ -55,21 +49,16 @@ func TestCreateSocialEvent(t *testing.T) {
 
 	t.Run("create socialevent with invalid user tier name", func(t *testing.T) {
 		// given
-		event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
-			testsocialevent.WithUserTier("invalid"),
-			testsocialevent.WithSpaceTier("base1ns"))
+		event := socialevent.New(hostAwait,
+			socialevent.WithUserTier("invalid"),
+			socialevent.WithSpaceTier("base1ns"))
 
 		// when
 		err := hostAwait.CreateWithCleanup(t, event)
 
 		// then
 		require.NoError(t, err)
-		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
-			Type:    toolchainv1alpha1.ConditionReady,
-			Status:  corev1.ConditionFalse,
-			Reason:  toolchainv1alpha1.SocialEventInvalidUserTierReason,
-			Message: "UserTier 'invalid' not found",
-		}))
+		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(socialevent.InvalidUserTier("invalid")))
 		require.NoError(t, err)
 
 		t.Run("update with valid tier name", func(t *testing.T) {
@@ -81,31 +70,23 @@ func TestCreateSocialEvent(t *testing.T) {
 
 			// then
 			require.NoError(t, err)
-			_, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
-				Type:   toolchainv1alpha1.ConditionReady,
-				Status: corev1.ConditionTrue,
-			}))
+			_, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(socialevent.Ready()))
 			require.NoError(t, err)
 		})
 	})
 
 	t.Run("create socialevent with invalid space tier name", func(t *testing.T) {
 		// given
-		event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
-			testsocialevent.WithUserTier("deactivate30"),
-			testsocialevent.WithSpaceTier("invalid"))
+		event := socialevent.New(hostAwait,
+			socialevent.WithUserTier("deactivate30"),
+			socialevent.WithSpaceTier("invalid"))
 
 		// when
 		err := hostAwait.CreateWithCleanup(t, event)
 
 		// then
 		require.NoError(t, err)
-		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
-			Type:    toolchainv1alpha1.ConditionReady,
-			Status:  corev1.ConditionFalse,
-			Reason:  toolchainv1alpha1.SocialEventInvalidSpaceTierReason,
-			Message: "NSTemplateTier 'invalid' not found",
-		}))
+		event, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(socialevent.InvalidSpaceTier("invalid")))
 		require.NoError(t, err)
 
 		t.Run("update with valid tier name", func(t *testing.T) {
@@ -117,10 +98,7 @@ func TestCreateSocialEvent(t *testing.T) {
 
 			// then
 			require.NoError(t, err)
-			_, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
-				Type:   toolchainv1alpha1.ConditionReady,
-				Status: corev1.ConditionTrue,
-			}))
+			_, err = hostAwait.WaitForSocialEvent(t, event.Name, UntilSocialEventHasConditions(socialevent.Ready()))
 			require.NoError(t, err)
 		})
 	})
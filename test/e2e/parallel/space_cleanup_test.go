@@ -11,12 +11,14 @@ import (
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestSpaceAndSpaceBindingCleanup(t *testing.T) {
 	// given
 	t.Parallel()
-	awaitilities := WaitForDeployments(t)
+	// the deletions race with the controllers, hence the replay script saved if the test fails
+	awaitilities := RecordScenario(t, WaitForDeployments(t))
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
 	// let's create the spaces for the deletion here so they have older creation timestamp and we don't have to wait entire 30 seconds for the deletion
@@ -52,8 +54,29 @@ func TestSpaceAndSpaceBindingCleanup(t *testing.T) {
 			t.Logf("user signup '%s' set to deactivated", userSignup.Name)
 
 			// then
+			err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, userSignup.Status.CompliantUsername)
+			require.NoError(t, err)
 			err = hostAwait.WaitUntilSpaceBindingDeleted(spaceBinding.Name)
 			require.NoError(t, err)
+			// the MUR must not be re-created for the deactivated UserSignup
+			err = hostAwait.WaitUntilGoneAndStaysGone(t, &toolchainv1alpha1.MasterUserRecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: hostAwait.Namespace,
+					Name:      userSignup.Status.CompliantUsername,
+				},
+			}, 10*time.Second)
+			require.NoError(t, err)
+		})
+
+		t.Run("no orphan spacebinding remains", func(t *testing.T) {
+			// only the SpaceBindings of the users and spaces above are checked, since the tests running in parallel
+			// are deleting their own MURs and Spaces
+			for _, murName := range []string{"joe", "lara"} {
+				VerifyNoOrphanSpaceBindingsWithLabel(t, hostAwait, toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey, murName)
+			}
+			for _, spaceName := range []string{"for-redhat", "for-ibm"} {
+				VerifyNoOrphanSpaceBindingsWithLabel(t, hostAwait, toolchainv1alpha1.SpaceBindingSpaceLabelKey, spaceName)
+			}
 		})
 	})
 
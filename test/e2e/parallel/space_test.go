@@ -4,13 +4,14 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
-	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestCreateSpace(t *testing.T) {
@@ -26,6 +27,18 @@ func TestCreateSpace(t *testing.T) {
 		space, _, _ := CreateSpace(t, awaitilities, WithTierName("appstudio"), WithTargetCluster(memberAwait.ClusterName))
 		// then
 		VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name, UntilSpaceHasStatusTargetCluster(memberAwait.ClusterName))
+		// also, make sure that the Space and its NSTemplateSet do not keep on flipping between `Ready=True` and `Ready=False`
+		AssertConditionStable(t, hostAwait.Awaitility, space, Provisioned(), 10*time.Second)
+		nsTemplateSet := &toolchainv1alpha1.NSTemplateSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      space.Name,
+				Namespace: memberAwait.Namespace,
+			},
+		}
+		AssertConditionStable(t, memberAwait.Awaitility, nsTemplateSet, Provisioned(), 10*time.Second)
+		// and that they are not updated over and over again by the operators once provisioned
+		AssertNoReconcileChurn(t, hostAwait.Awaitility, space, 10*time.Second, 1)
+		AssertNoReconcileChurn(t, memberAwait.Awaitility, nsTemplateSet, 10*time.Second, 1)
 
 		t.Run("delete space", func(t *testing.T) {
 			// now, delete the Space and expect that the NSTemplateSet will be deleted as well,
@@ -228,7 +241,6 @@ func TestPromoteSpace(t *testing.T) {
 	// given
 	// make sure everything is ready before running the actual tests
 	awaitilities := WaitForDeployments(t)
-	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
 
 	// when
@@ -237,14 +249,25 @@ func TestPromoteSpace(t *testing.T) {
 	VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name, UntilSpaceHasStatusTargetCluster(memberAwait.ClusterName))
 
 	t.Run("to advanced tier", func(t *testing.T) {
-		// when
-		tiers.MoveSpaceToTier(t, hostAwait, space.Name, "advanced")
-
-		// then
-		VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name)
+		// when/then
+		PromoteUser(t, awaitilities, space.Name, "advanced")
 	})
 }
 
+func TestMemberlessSpace(t *testing.T) {
+	t.Parallel()
+	// given
+	// make sure everything is ready before running the actual tests
+	awaitilities := WaitForDeployments(t)
+	memberAwait := awaitilities.Member1()
+
+	// when
+	space, tier := CreateMemberlessSpace(t, awaitilities, "base", WithTargetCluster(memberAwait.ClusterName))
+
+	// then
+	VerifyMemberlessSpace(t, awaitilities, space.Name, tier)
+}
+
 func TestRetargetSpace(t *testing.T) {
 	// given
 	t.Parallel()
@@ -252,6 +275,7 @@ func TestRetargetSpace(t *testing.T) {
 	awaitilities := WaitForDeployments(t)
 	hostAwait := awaitilities.Host()
 	member1Await := awaitilities.Member1()
+	awaitilities.SkipUnlessMultipleMembers(t)
 	member2Await := awaitilities.Member2()
 
 	// when
@@ -451,3 +475,34 @@ func TerminatingFailed(msg string) toolchainv1alpha1.Condition {
 		Message: msg,
 	}
 }
+
+func TestSpaceBindingPrecedence(t *testing.T) {
+	// given
+	t.Parallel()
+	// make sure everything is ready before running the actual tests
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+
+	// when
+	// the user is maintainer in the parent Space, and admin in the sub-space created via a SpaceRequest
+	precedence := CreateOverlappingSpaceBindings(t, awaitilities, memberAwait.ClusterName, "maintainer", "admin")
+
+	// then
+	// the SpaceBinding of the sub-space takes precedence over the one inherited from the parent Space
+	VerifySpaceBindingPrecedence(t, awaitilities, precedence)
+
+	t.Run("inherited role applies again when SpaceBinding of sub-space is deleted", func(t *testing.T) {
+		// when
+		err := hostAwait.Client.Delete(context.TODO(), precedence.SubSpaceBinding)
+
+		// then
+		require.NoError(t, err)
+		VerifyEffectiveSpaceRoles(t, awaitilities, precedence.SubSpace.Name, EffectiveSpaceRoles{
+			precedence.MUR.Name: "maintainer",
+		})
+		VerifyEffectiveSpaceRoles(t, awaitilities, precedence.ParentSpace.Name, EffectiveSpaceRoles{
+			precedence.MUR.Name: "maintainer",
+		})
+	})
+}
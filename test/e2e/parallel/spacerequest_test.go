@@ -2,7 +2,9 @@ package parallel
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
@@ -145,6 +147,24 @@ func TestCreateSpaceRequest(t *testing.T) {
 			require.NoError(t, err)
 		})
 	})
+
+	t.Run("namespace-manager has access to the namespaces provisioned for an appstudio-env subSpace", func(t *testing.T) {
+		// given
+		// the `namespace-manager` ServiceAccount is only provisioned by the `appstudio-env` tier
+		spaceRequest, parentSpace := CreateSpaceRequest(t, awaitilities, memberAwait.ClusterName,
+			WithSpecTierName("appstudio-env"))
+		subSpace, err := awaitilities.Host().WaitForSubSpace(t, spaceRequest.Name, spaceRequest.Namespace, parentSpace.GetName(),
+			UntilSpaceHasTier("appstudio-env"),
+			UntilSpaceHasAnyProvisionedNamespaces(),
+		)
+		require.NoError(t, err)
+		subSpace, _ = VerifyResourcesProvisionedForSpace(t, awaitilities, subSpace.Name, UntilSpaceHasAnyTargetClusterSet())
+
+		// then
+		for _, ns := range subSpace.Status.ProvisionedNamespaces {
+			VerifyNamespaceManagerAccess(t, memberAwait, ns.Name)
+		}
+	})
 }
 
 func TestUpdateSpaceRequest(t *testing.T) {
@@ -225,3 +245,42 @@ func TestUpdateSpaceRequest(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestDeactivationRacingWithSpaceRequest(t *testing.T) {
+	// given
+	t.Parallel()
+	// make sure everything is ready before running the actual tests
+	awaitilities := WaitForDeployments(t)
+	memberAwait := awaitilities.Member1()
+
+	for i, race := range []struct {
+		name   string
+		offset time.Duration
+	}{
+		{name: "simultaneous", offset: 0},
+		{name: "space request after deactivation", offset: 500 * time.Millisecond},
+		{name: "space request before deactivation", offset: -500 * time.Millisecond},
+		{name: "space request while deprovisioning", offset: 3 * time.Second},
+	} {
+		i, race := i, race
+		t.Run(race.name, func(t *testing.T) {
+			// given
+			userSignup, mur := NewSignupRequest(awaitilities).
+				Username(fmt.Sprintf("deactrace-%d", i)).
+				ManuallyApprove().
+				TargetCluster(memberAwait).
+				EnsureMUR().
+				RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+				Execute(t).Resources()
+			PromoteUser(t, awaitilities, mur.Name, "appstudio")
+
+			// when
+			deactivation := RaceDeactivationWithSpaceRequest(t, awaitilities, userSignup, race.offset,
+				WithSpecTierName("appstudio"),
+				WithSpecTargetClusterRoles([]string{cluster.RoleLabel(cluster.Tenant)}))
+
+			// then
+			VerifyDeactivationRaceConverged(t, awaitilities, deactivation)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+func TestSignupWithThrottledClients(t *testing.T) {
+	// given
+	t.Parallel()
+	// the clients of the test are limited to a few requests per second, as if they were throttled by the API server,
+	// so the timeouts are scaled accordingly
+	awaitilities := WaitForDeployments(t).WithThrottling(2, 5, 3)
+	memberAwait := awaitilities.Member1()
+
+	// when
+	userSignup, _ := NewSignupRequest(awaitilities).
+		Username("throttledclients").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).
+		Resources()
+
+	// then
+	VerifyResourcesProvisionedForSignup(t, awaitilities, userSignup, "deactivate30", "base")
+}
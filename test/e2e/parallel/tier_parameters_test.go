@@ -0,0 +1,40 @@
+package parallel
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpaceTierWithParameters(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	baseTier, err := hostAwait.WaitForNSTemplateTier(t, "base")
+	require.NoError(t, err)
+
+	for name, sizing := range map[string]tiers.Sizing{
+		"small": {CPU: "1", Memory: "1Gi"},
+		"large": {CPU: "4500m", Memory: "8Gi"},
+	} {
+		sizing := sizing
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tier, _ := tiers.CreateCustomTier(t, hostAwait, "base", tiers.WithSizingParameters(t, baseTier, sizing))
+
+			// when
+			space, _, _ := CreateSpace(t, awaitilities, WithTierName(tier.Name), WithTargetCluster(memberAwait.ClusterName))
+
+			// then
+			_, err := memberAwait.WaitForNSTmplSet(t, space.Name, wait.UntilNSTemplateSetHasConditions(Provisioned()))
+			require.NoError(t, err)
+			tiers.VerifySizingParameters(t, memberAwait, space.Name, sizing)
+			tiers.VerifyNamespaceObjectsAsTemplated(t, hostAwait, memberAwait, space.Name)
+		})
+	}
+}
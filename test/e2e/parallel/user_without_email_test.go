@@ -0,0 +1,52 @@
+package parallel
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserWithoutEmail(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	t.Run("signup rejected", func(t *testing.T) {
+		for _, idp := range authsupport.IdentityProviders {
+			idp := idp
+			t.Run(string(idp), func(t *testing.T) {
+				// given
+				_, claims := authsupport.ClaimsFrom(idp)
+
+				// when & then
+				VerifySignupRejectedWithoutEmail(t, hostAwait, claims...)
+			})
+		}
+	})
+
+	t.Run("usersignup created without email", func(t *testing.T) {
+		// given
+		userSignup := NewUserSignupWithoutEmail(hostAwait.Namespace, "noemail-"+uuid.Must(uuid.NewV4()).String()[:8])
+
+		// when
+		err := hostAwait.CreateWithCleanup(t, userSignup)
+		require.NoError(t, err)
+
+		// then
+		userSignup = VerifyUserSignupWithoutEmailNotProvisioned(t, hostAwait, userSignup)
+
+		t.Run("notification to the user is not delivered", func(t *testing.T) {
+			// when
+			notification := CreateNotification(t, hostAwait, WithUserID(userSignup.Spec.Userid))
+
+			// then the delivery fails (without crashing the host operator), and the notification is kept
+			VerifyNotificationNotDelivered(t, hostAwait, notification.Name, "", 10*time.Second)
+			hostAwait.WaitForDeploymentToGetReady(t, "host-operator-controller-manager", 1)
+		})
+	})
+}
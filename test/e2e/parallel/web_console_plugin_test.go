@@ -1,9 +1,7 @@
 package parallel
 
 import (
-	"crypto/tls"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -22,6 +20,8 @@ import (
 func TestWebConsoleDeployedSuccessfully(t *testing.T) {
 	// given
 	await := WaitForDeployments(t)
+	// the console plugin is accessed via a route
+	await.Member1().SkipUnlessCapable(t, wait.CapabilityOpenShift)
 
 	for i, memberAwait := range await.AllMembers() {
 
@@ -91,47 +91,28 @@ func TestWebConsoleDeployedSuccessfully(t *testing.T) {
 		manifestURL := fmt.Sprintf("%s%s%s", "https://", routeURL, "plugin-manifest.json")
 		healthCheckURL := fmt.Sprintf("%s%s%s", "https://", routeURL, "status")
 
-		var tr *http.Transport
-		{ // #nosec G402
-			tr = &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				},
-			}
-		}
-
-		httpClient := &http.Client{Transport: tr}
-
-		var healthCheckResponse *http.Response
-
 		// at this point, since the test is not executed as the first one in the whole e2e test suite,
 		// we expect that the service should be already healthy, thus we don't need to pool because waiting
 		// another minute shouldn't have any impact on the outcome. At the same time the presence
 		// of all Web console plugins related resources are verified at the beginning of thi test including
 		// the availability of the deployment. In other words, if it fails, then there is definitely
 		// some problem with the service.
-		req, err := http.NewRequest("GET", healthCheckURL, nil)
-		require.NoError(t, err)
-		req.Header.Set("Authorization", signupRequest.GetToken())
-
-		healthCheckResponse, err = httpClient.Do(req) //nolint
-		require.NoError(t, err)
-		defer healthCheckResponse.Body.Close()
-		require.Equal(t, http.StatusOK, healthCheckResponse.StatusCode, "error calling health check endpoint", route)
-
-		req, err = http.NewRequest("GET", manifestURL, nil)
-		require.NoError(t, err)
-		req.Header.Set("Authorization", signupRequest.GetToken())
-
-		manifestResponse, err := httpClient.Do(req)
-		require.NoError(t, err)
-		defer manifestResponse.Body.Close()
-		require.Equal(t, http.StatusOK, healthCheckResponse.StatusCode, "error calling console plugin manifests", route)
-
-		body, err := io.ReadAll(manifestResponse.Body)
-		require.NoError(t, err)
-
-		require.True(t, strings.HasPrefix(string(body), "{\n  \"name\": \"toolchain-member-web-console-plugin\","))
+		restClient := NewRESTClient()
+		healthCheckResponse := restClient.Do(t, RESTRequest{
+			Method: "GET",
+			URL:    healthCheckURL,
+			Header: http.Header{"Authorization": []string{signupRequest.GetToken()}},
+		})
+		require.Equal(t, http.StatusOK, healthCheckResponse.StatusCode, "error calling health check endpoint:\n%s", healthCheckResponse)
+
+		manifestResponse := restClient.Do(t, RESTRequest{
+			Method: "GET",
+			URL:    manifestURL,
+			Header: http.Header{"Authorization": []string{signupRequest.GetToken()}},
+		})
+		require.Equal(t, http.StatusOK, manifestResponse.StatusCode, "error calling console plugin manifests:\n%s", manifestResponse)
+
+		require.True(t, strings.HasPrefix(string(manifestResponse.Body), "{\n  \"name\": \"toolchain-member-web-console-plugin\","))
 	}
 }
 
@@ -0,0 +1,65 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/workloads"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUserWorkloadsCountInQuotas(t *testing.T) {
+	// given
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	memberAwait := awaitilities.Member1()
+	// the user is provisioned in the `base` tier, with a `dev` and a `stage` namespace
+	NewSignupRequest(awaitilities).
+		Username("workloadquota").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t)
+
+	t.Run("pvc consumer counts in the storage quota", func(t *testing.T) {
+		// when
+		workloads.ApplyAndWaitUntilReady(t, memberAwait, "workloadquota-dev", "pvc-consumer", workloads.PVCConsumer())
+
+		// then
+		_, err := memberAwait.WaitForResourceQuota(t, "workloadquota-dev", "storage", resourceQuotaUsed("count/persistentvolumeclaims", "1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("deployment counts in the compute quota", func(t *testing.T) {
+		// when
+		workloads.ApplyAndWaitUntilReady(t, memberAwait, "workloadquota-stage", "sleep", workloads.Sleep(workloads.WithReplicas(2)))
+
+		// then
+		_, err := memberAwait.WaitForResourceQuota(t, "workloadquota-stage", "compute-deploy", resourceQuotaUsed(corev1.ResourceRequestsCPU, "2m")) // 1m per pod
+		require.NoError(t, err)
+	})
+
+	t.Run("route-exposed app is reachable", func(t *testing.T) {
+		memberAwait.SkipUnlessCapable(t, wait.CapabilityOpenShift)
+
+		// when & then
+		workloads.ApplyAndWaitUntilReady(t, memberAwait, "workloadquota-stage", "nginx", workloads.RouteExposedApp())
+	})
+}
+
+func resourceQuotaUsed(resource corev1.ResourceName, expected string) wait.ResourceQuotaWaitCriterion {
+	return wait.ResourceQuotaWaitCriterion{
+		Match: func(actual *corev1.ResourceQuota) bool {
+			used, found := actual.Status.Used[resource]
+			return found && used.String() == expected
+		},
+		Diff: func(actual *corev1.ResourceQuota) string {
+			used := actual.Status.Used[resource]
+			return fmt.Sprintf("expected '%s' used in ResourceQuota '%s' to be '%s'\nbut it was '%s'", resource, actual.Name, expected, used.String())
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// maxProxyCachePropagationDelay the max delay for a change of a SpaceBinding to be reflected by the proxy
+const maxProxyCachePropagationDelay = 30 * time.Second
+
+func TestProxyCacheInvalidation(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	setStoneSoupConfig(t, hostAwait, memberAwait)
+
+	owner := &proxyUser{
+		expectedMemberCluster: memberAwait,
+		username:              "cache-owner",
+		identityID:            uuid.Must(uuid.NewV4()),
+	}
+	guest := &proxyUser{
+		expectedMemberCluster: memberAwait,
+		username:              "cache-guest",
+		identityID:            uuid.Must(uuid.NewV4()),
+	}
+	createAppStudioUser(t, awaitilities, owner)
+	createAppStudioUser(t, awaitilities, guest)
+	owner.shareSpaceWith(t, hostAwait, guest)
+	spaceBinding, err := hostAwait.WaitForSpaceBinding(t, guest.compliantUsername, owner.compliantUsername)
+	require.NoError(t, err)
+	WaitForWorkspaceAccessDecision(t, hostAwait, guest.token, owner.compliantUsername, maxProxyCachePropagationDelay, WorkspaceHasRole("admin"))
+
+	t.Run("role change is reflected by the proxy", func(t *testing.T) {
+		// when
+		_, err := hostAwait.UpdateSpaceBinding(t, spaceBinding.Name, func(sb *toolchainv1alpha1.SpaceBinding) {
+			sb.Spec.SpaceRole = "contributor"
+		})
+		require.NoError(t, err)
+
+		// then
+		delay := WaitForWorkspaceAccessDecision(t, hostAwait, guest.token, owner.compliantUsername, maxProxyCachePropagationDelay, WorkspaceHasRole("contributor"))
+		t.Logf("role change propagated to the proxy in %s", delay)
+	})
+
+	t.Run("access revocation is reflected by the proxy", func(t *testing.T) {
+		// when
+		err := hostAwait.Client.Delete(context.TODO(), spaceBinding)
+		require.NoError(t, err)
+
+		// then
+		delay := WaitForWorkspaceAccessDecision(t, hostAwait, guest.token, owner.compliantUsername, maxProxyCachePropagationDelay, WorkspaceIsNotAccessible())
+		t.Logf("access revocation propagated to the proxy in %s", delay)
+		// the owner still has access to the workspace
+		WaitForWorkspaceAccessDecision(t, hostAwait, owner.token, owner.compliantUsername, maxProxyCachePropagationDelay, WorkspaceHasRole("admin"))
+	})
+}
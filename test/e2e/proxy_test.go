@@ -88,6 +88,7 @@ func TestProxyFlow(t *testing.T) {
 	awaitilities := WaitForDeployments(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
+	awaitilities.SkipUnlessMultipleMembers(t)
 	memberAwait2 := awaitilities.Member2()
 
 	setStoneSoupConfig(t, hostAwait, memberAwait)
@@ -121,6 +122,7 @@ func TestProxyFlow(t *testing.T) {
 
 	for index, user := range users {
 		t.Run(user.username, func(t *testing.T) {
+			start := time.Now()
 
 			t.Run("use proxy to create a HAS Application CR in the user appstudio namespace via proxy API and use websocket to watch it created", func(t *testing.T) {
 				// Start a new websocket watcher which watches for Application CRs in the user's namespace
@@ -160,6 +162,23 @@ func TestProxyFlow(t *testing.T) {
 				}
 			})
 
+			t.Run("proxied requests are audited with the identity of the user", func(t *testing.T) {
+				VerifyProxiedRequestAudited(t, user.expectedMemberCluster, user.compliantUsername, "create", "applications",
+					tenantNsName(user.compliantUsername), fmt.Sprintf("%s-test-app-%d", user.compliantUsername, 0), start)
+			})
+
+			t.Run("use the contexts of a kubeconfig of the user workspaces", func(t *testing.T) {
+				// given
+				workspaces := user.listWorkspaces(t, hostAwait)
+
+				// when
+				kubeconfig := NewWorkspacesKubeconfig(t, hostAwait, user.compliantUsername, user.token, workspaces...)
+
+				// then
+				require.Len(t, kubeconfig.Contexts, len(workspaces))
+				VerifyWorkspacesKubeconfig(t, awaitilities, kubeconfig)
+			})
+
 			t.Run("try to create a resource in an unauthorized namespace", func(t *testing.T) {
 				// given
 				appName := fmt.Sprintf("%s-proxy-test-app", user.username)
@@ -257,24 +276,12 @@ func TestProxyFlow(t *testing.T) {
 				CreateProxyPluginWithCleanup(t, hostAwait, "openshift-console", "openshift-console", "console")
 				VerifyProxyPlugin(t, hostAwait, "openshift-console")
 				proxyPluginWorkspaceURL := hostAwait.PluginProxyURLWithWorkspaceContext("openshift-console", user.compliantUsername)
-				client := http.Client{
-					Timeout: 30 * time.Second,
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
-					},
-				}
-				request, err := http.NewRequest("GET", proxyPluginWorkspaceURL, nil)
-				require.NoError(t, err)
-
-				request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", user.token))
-				var resp *http.Response
-				resp, err = client.Do(request)
-				require.NoError(t, err)
-				defer resp.Body.Close()
-				var body []byte
-				body, err = io.ReadAll(resp.Body)
-				require.NoError(t, err)
-				bodyStr := string(body)
+				resp := NewRESTClient(WithRequestTimeout(30*time.Second)).Do(t, RESTRequest{
+					Method: "GET",
+					URL:    proxyPluginWorkspaceURL,
+					Token:  user.token,
+				})
+				bodyStr := string(resp.Body)
 				if resp.StatusCode != http.StatusOK {
 					t.Errorf("unexpected http return code of %d with body text %s", resp.StatusCode, bodyStr)
 				}
@@ -404,6 +411,7 @@ func TestSpaceLister(t *testing.T) {
 	awaitilities := WaitForDeployments(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
+	awaitilities.SkipUnlessMultipleMembers(t)
 	memberAwait2 := awaitilities.Member2()
 
 	setStoneSoupConfig(t, hostAwait, memberAwait)
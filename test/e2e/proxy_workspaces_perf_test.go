@@ -0,0 +1,77 @@
+package e2e
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// proxyWorkspacesCountVar the env var to set to enable the probe, with the number of shared workspaces to create for the user
+	proxyWorkspacesCountVar = "E2E_PROXY_WORKSPACES_COUNT"
+	// maxWorkspacesListingDuration the max median duration of a call to list all the workspaces via the proxy
+	maxWorkspacesListingDuration = 5 * time.Second
+	// maxWorkspacesListingScaling the max ratio between the median durations of the listings when the number of workspaces doubles.
+	// A linear implementation should be around 2, a quadratic one around 4.
+	maxWorkspacesListingScaling = 3.0
+)
+
+// TestProxyWorkspacesListingPerformance measures the duration of the listing of the workspaces via the proxy,
+// for a user who has access to many (shared) workspaces.
+// It is opt-in: it only runs when the `E2E_PROXY_WORKSPACES_COUNT` env var is set.
+func TestProxyWorkspacesListingPerformance(t *testing.T) {
+	// given
+	value, found := os.LookupEnv(proxyWorkspacesCountVar)
+	if !found {
+		t.Skipf("proxy workspaces listing probe is disabled, set the '%s' env var to enable it", proxyWorkspacesCountVar)
+	}
+	count, err := strconv.Atoi(value)
+	require.NoError(t, err, "invalid value of '%s'", proxyWorkspacesCountVar)
+	require.GreaterOrEqual(t, count, 2, "invalid value of '%s'", proxyWorkspacesCountVar)
+
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	req := NewSignupRequest(awaitilities).
+		Username("workspaces-prober").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t)
+	_, mur := req.Resources()
+	token := req.GetToken()
+
+	createSharedSpaces := func(n int) {
+		for i := 0; i < n; i++ {
+			CreateSpaceWithBinding(t, awaitilities, mur, WithTierName("base1ns"), WithTargetCluster(memberAwait.ClusterName))
+		}
+	}
+
+	// when
+	// the user has its own workspace, plus the shared ones
+	createSharedSpaces(count/2 - 1)
+	WaitForWorkspaces(t, hostAwait, token, count/2)
+	half := MeasureWorkspacesListing(t, hostAwait, token, count/2, 10)
+
+	createSharedSpaces(count - count/2)
+	workspaces := WaitForWorkspaces(t, hostAwait, token, count)
+	full := MeasureWorkspacesListing(t, hostAwait, token, count, 10)
+
+	// then
+	names := map[string]bool{}
+	for _, ws := range workspaces {
+		assert.False(t, names[ws.Name], "workspace '%s' was listed more than once", ws.Name)
+		names[ws.Name] = true
+	}
+	assert.True(t, names[mur.Name], "the workspace of the user is missing")
+	assert.LessOrEqual(t, full.Median, maxWorkspacesListingDuration, "listing %d workspaces is too slow", count)
+	scaling := float64(full.Median) / float64(half.Median)
+	t.Logf("listing duration ratio when doubling the number of workspaces: %.2f", scaling)
+	assert.LessOrEqual(t, scaling, maxWorkspacesListingScaling, "listing the workspaces does not scale linearly")
+}
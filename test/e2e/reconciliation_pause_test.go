@@ -0,0 +1,46 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAccountProvisionedWhenMemberReconciliationResumed(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	resume := memberAwait.PauseReconciliation(t)
+
+	// when
+	userSignup := NewUserSignup(hostAwait.Namespace, "pausedreconcile", "pausedreconcile@test.com")
+	states.SetApprovedManually(userSignup, true)
+	userSignup.Spec.TargetCluster = memberAwait.ClusterName
+	require.NoError(t, hostAwait.CreateWithCleanup(t, userSignup))
+
+	// then
+	// the UserAccount is created by the host operator, but its status is not set while the member operator is paused
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name, wait.UntilUserSignupHasCompliantUsername())
+	require.NoError(t, err)
+	userAccount, err := memberAwait.WaitForUserAccount(t, userSignup.Status.CompliantUsername)
+	require.NoError(t, err)
+	assert.Empty(t, userAccount.Status.Conditions)
+
+	t.Run("user account provisioned when reconciliation resumed", func(t *testing.T) {
+		// when
+		resume()
+
+		// then
+		_, err := memberAwait.WaitForUserAccount(t, userSignup.Status.CompliantUsername,
+			wait.UntilUserAccountHasConditions(Provisioned()))
+		require.NoError(t, err)
+		_, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
+			wait.UntilUserSignupHasConditions(ConditionSet(Default(), ApprovedByAdmin())...))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignupWhileRegistrationServiceScales(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+
+	// when
+	result := ScaleRegistrationServiceDuringSignups(t, hostAwait, 4, 3)
+
+	// then
+	require.NotEmpty(t, result.Identities)
+	VerifyNoDuplicateUserSignups(t, result)
+	// a few requests may fail when they are handled by a terminating pod, but no more than 10% of the users
+	AssertNoServerErrorStorm(t, result, len(result.Identities)/10)
+}
@@ -19,6 +19,7 @@ func TestAutomaticClusterAssignment(t *testing.T) {
 	awaitilities := WaitForDeployments(t)
 	hostAwait := awaitilities.Host()
 	memberAwait1 := awaitilities.Member1()
+	awaitilities.SkipUnlessMultipleMembers(t)
 	memberAwait2 := awaitilities.Member2()
 	_, mur := NewSignupRequest(awaitilities).
 		Username("for-member1").
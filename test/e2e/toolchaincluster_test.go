@@ -9,6 +9,7 @@ import (
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/faultproxy"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,128 @@ func TestToolchainClusterE2E(t *testing.T) {
 	verifyToolchainCluster(t, memberAwait.Awaitility, hostAwait.Awaitility)
 }
 
+func TestToolchainClusterTokenRotation(t *testing.T) {
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+
+	t.Run("host to member", func(t *testing.T) {
+		verifyToolchainClusterTokenRotation(t, hostAwait.Awaitility, memberAwait.Awaitility)
+	})
+	t.Run("member to host", func(t *testing.T) {
+		verifyToolchainClusterTokenRotation(t, memberAwait.Awaitility, hostAwait.Awaitility)
+	})
+}
+
+func TestToolchainClusterTokenExpiry(t *testing.T) {
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+
+	t.Run("host to member", func(t *testing.T) {
+		verifyToolchainClusterTokenExpiry(t, hostAwait.Awaitility, memberAwait.Awaitility)
+	})
+	t.Run("member to host", func(t *testing.T) {
+		verifyToolchainClusterTokenExpiry(t, memberAwait.Awaitility, hostAwait.Awaitility)
+	})
+}
+
+func TestToolchainClusterNetworkFaults(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	proxy := faultproxy.Deploy(t, hostAwait, memberAwait)
+	proxy.Route(t)
+
+	t.Run("user provisioned despite latency", func(t *testing.T) {
+		// given
+		proxy.AddLatency(t, 2*time.Second, 500*time.Millisecond)
+		defer proxy.Heal(t)
+
+		// when
+		userSignup, _ := NewSignupRequest(awaitilities).
+			Username("faultproxy-latency").
+			ManuallyApprove().
+			TargetCluster(memberAwait).
+			Execute(t).Resources()
+
+		// then
+		VerifyResourcesProvisionedForSignup(t, awaitilities, userSignup, "deactivate30", "base")
+	})
+
+	for name, inject := range map[string]func(*testing.T){
+		"blackhole":    proxy.Blackhole,
+		"disconnected": proxy.Disconnect,
+	} {
+		name, inject := name, inject
+		t.Run("user provisioned after "+name+" partition", func(t *testing.T) {
+			// given
+			partitionedAt := time.Now()
+			inject(t)
+			defer proxy.Heal(t)
+			_, err := hostAwait.WithRetryOptions(wait.TimeoutOption(wait.ToolchainClusterConditionTimeout)).WaitForToolchainCluster(t,
+				wait.UntilToolchainClusterHasName(proxy.ToolchainCluster),
+				wait.UntilToolchainClusterIsNotReadyProbedAfter(partitionedAt))
+			require.NoError(t, err, "ToolchainCluster of member cluster '%s' was not degraded by the partition", memberAwait.ClusterName)
+			userSignup, _ := NewSignupRequest(awaitilities).
+				Username("faultproxy-" + name).
+				ManuallyApprove().
+				TargetCluster(memberAwait).
+				Execute(t).Resources()
+
+			// when
+			healedAt := time.Now()
+			proxy.Heal(t)
+
+			// then
+			_, err = hostAwait.WithRetryOptions(wait.TimeoutOption(wait.ToolchainClusterConditionTimeout)).WaitForToolchainCluster(t,
+				wait.UntilToolchainClusterHasName(proxy.ToolchainCluster),
+				wait.UntilToolchainClusterHasReadyConditionProbedAfter(healedAt))
+			require.NoError(t, err, "ToolchainCluster of member cluster '%s' did not recover from the partition", memberAwait.ClusterName)
+			VerifyResourcesProvisionedForSignup(t, awaitilities, userSignup, "deactivate30", "base")
+		})
+	}
+}
+
+// verifyToolchainClusterTokenExpiry verifies that the ToolchainCluster pointing to the other cluster is degraded when its token expires,
+// and that it recovers once its secret is recreated with a new token
+func verifyToolchainClusterTokenExpiry(t *testing.T, await *wait.Awaitility, otherAwait *wait.Awaitility) {
+	// given
+	await.VerifyToolchainClusterAccess(t, otherAwait)
+
+	// when
+	degraded := await.ExpireToolchainClusterToken(t, otherAwait)
+	t.Logf("ToolchainCluster '%s' degraded with conditions: %v", degraded.Name, degraded.Status.Conditions)
+	token := await.RecreateToolchainClusterSecret(t, otherAwait)
+
+	// then
+	config := await.GetToolchainClusterConfig(t, otherAwait)
+	require.Equal(t, token, config.RestConfig.BearerToken)
+	await.VerifyToolchainClusterAccess(t, otherAwait)
+}
+
+// verifyToolchainClusterTokenRotation verifies that the ToolchainCluster pointing to the other cluster is still ready
+// and grants access to the other cluster after its token was rotated
+func verifyToolchainClusterTokenRotation(t *testing.T, await *wait.Awaitility, otherAwait *wait.Awaitility) {
+	// given
+	await.VerifyToolchainClusterAccess(t, otherAwait)
+
+	// when
+	token := await.RotateToolchainClusterToken(t, otherAwait)
+
+	// then
+	config := await.GetToolchainClusterConfig(t, otherAwait)
+	require.Equal(t, token, config.RestConfig.BearerToken)
+	await.VerifyToolchainClusterAccess(t, otherAwait)
+	_, err := otherAwait.WaitForToolchainCluster(t,
+		wait.UntilToolchainClusterHasLabels(client.MatchingLabels{
+			"namespace": await.Namespace,
+			"type":      string(await.Type),
+		}), wait.UntilToolchainClusterHasCondition(*wait.ReadyToolchainCluster))
+	require.NoError(t, err)
+}
+
 // verifyToolchainCluster verifies existence and correct conditions of ToolchainCluster CRD
 // in the target cluster type operator
 func verifyToolchainCluster(t *testing.T, await *wait.Awaitility, otherAwait *wait.Awaitility) {
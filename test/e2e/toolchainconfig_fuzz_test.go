@@ -0,0 +1,42 @@
+package e2e
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+// TestToolchainConfigFuzzing applies random (but valid) ToolchainConfigs and verifies that the operators settle after each of them.
+// It is opt-in: it only runs when the `E2E_TOOLCHAINCONFIG_FUZZ_ITERATIONS` env var is set.
+func TestToolchainConfigFuzzing(t *testing.T) {
+	// given
+	iterations, seed := ToolchainConfigFuzzSettings(t)
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	r := rand.New(rand.NewSource(seed)) // nolint:gosec
+	restarts := GetOperatorPodRestartCounts(t, awaitilities)
+
+	for i := 0; i < iterations; i++ {
+		mutations := RandomToolchainConfigMutations(r, 3)
+		t.Run(fmt.Sprintf("iteration %d", i), func(t *testing.T) {
+			options := make([]testconfig.ToolchainConfigOption, 0, len(mutations))
+			for _, m := range mutations {
+				t.Logf("mutating %s", m.Description)
+				options = append(options, m.Option)
+			}
+
+			// when
+			hostAwait.UpdateToolchainConfig(t, options...)
+
+			// then
+			VerifyOperatorsSettled(t, awaitilities, restarts, time.Minute)
+		})
+	}
+
+	// the original config is restored by the cleanup of each iteration
+	VerifyOperatorsSettled(t, awaitilities, restarts, time.Minute)
+}
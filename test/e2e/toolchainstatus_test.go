@@ -89,3 +89,18 @@ func TestForceMetricsSynchronization(t *testing.T) {
 	})
 
 }
+
+func TestOperatorsCompatibility(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+
+	// when
+	versions := VerifyOperatorsCompatibility(t, awaitilities)
+
+	// then
+	require.NotEmpty(t, versions.Host.Revision)
+	require.Len(t, versions.Members, len(awaitilities.AllMembers()))
+	for clusterName, memberVersion := range versions.Members {
+		require.NotEmpty(t, memberVersion.Revision, "missing revision for member operator in cluster '%s'", clusterName)
+	}
+}
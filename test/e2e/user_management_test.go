@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -30,8 +29,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var httpClient = HTTPClient
-
 func TestUserManagement(t *testing.T) {
 	suite.Run(t, &userManagementTestSuite{})
 }
@@ -98,6 +95,7 @@ func (s *userManagementTestSuite) TestVerifyUserTiers() {
 }
 
 func (s *userManagementTestSuite) TestUserDeactivation() {
+	s.SkipUnlessMultipleMembers(s.T())
 	hostAwait := s.Host()
 	memberAwait := s.Member1()
 	memberAwait2 := s.Member2()
@@ -521,19 +519,25 @@ func (s *userManagementTestSuite) TestUserBanning() {
 		hostAwait.UpdateToolchainConfig(t, testconfig.AutomaticApproval().Enabled(false))
 
 		// Create a new UserSignup and approve it manually
-		userSignup, _ := NewSignupRequest(s.Awaitilities).
+		signupRequest := NewSignupRequest(s.Awaitilities).
 			Username("banprovisioned").
 			Email("banprovisioned@test.com").
 			ManuallyApprove().
 			TargetCluster(memberAwait).
+			EnsureMUR().
 			RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
-			Execute(t).Resources()
+			Execute(t)
+		userSignup, _ := signupRequest.Resources()
+		space, err := hostAwait.WaitForSpace(t, userSignup.Status.CompliantUsername,
+			wait.UntilSpaceHasStatusTargetCluster(memberAwait.ClusterName),
+			wait.UntilSpaceHasAnyProvisionedNamespaces())
+		require.NoError(t, err)
 
 		// Create the BannedUser
 		CreateBannedUser(t, s.Host(), userSignup.Annotations[toolchainv1alpha1.UserSignupUserEmailAnnotationKey])
 
 		// Confirm the user is banned
-		_, err := hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitForUserSignup(t, userSignup.Name,
+		_, err = hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitForUserSignup(t, userSignup.Name,
 			wait.UntilUserSignupHasConditions(ConditionSet(Default(), ApprovedByAdmin(), Banned())...))
 		require.NoError(t, err)
 
@@ -545,6 +549,13 @@ func (s *userManagementTestSuite) TestUserBanning() {
 			wait.UntilUserSignupHasConditions(ConditionSet(Default(), ApprovedByAdmin(), Banned())...),
 			wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueBanned))
 		require.NoError(t, err)
+
+		// Confirm that the user cannot access the platform anymore
+		VerifyAccessRevoked(t, s.Awaitilities, RevokedUser{
+			UserSignup: userSignup,
+			Space:      space,
+			Token:      signupRequest.GetToken(),
+		})
 	})
 
 	s.T().Run("manually created usersignup with preexisting banneduser", func(t *testing.T) {
@@ -595,24 +606,17 @@ func (s *userManagementTestSuite) TestUserBanning() {
 		route := hostAwait.RegistrationServiceURL
 
 		// Call signup endpoint with a valid token to initiate a signup process
-		req, err := http.NewRequest("POST", route+"/api/v1/signup", nil)
-		require.NoError(t, err)
-		req.Header.Set("Authorization", "Bearer "+token0)
-		req.Header.Set("content-type", "application/json")
-
-		resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer Close(t, resp)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		resp := NewRESTClient().Do(t, RESTRequest{
+			Method: "POST",
+			URL:    route + "/api/v1/signup",
+			Token:  token0,
+		})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "unexpected response status:\n%s", resp)
 
 		// Check the error.
 		statusErr := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &statusErr)
-		require.NoError(t, err)
+		err = json.Unmarshal(resp.Body, &statusErr)
+		require.NoError(t, err, "unable to decode the response:\n%s", resp)
 		require.Equal(t, "forbidden: user has been banned", statusErr["message"])
 	})
 
@@ -731,6 +735,7 @@ func (s *userManagementTestSuite) TestUserDisabled() {
 }
 
 func (s *userManagementTestSuite) TestReturningUsersProvisionedToLastCluster() {
+	s.SkipUnlessMultipleMembers(s.T())
 	hostAwait := s.Host()
 	memberAwait := s.Member1()
 	memberAwait2 := s.Member2()
@@ -31,16 +31,15 @@ func (s *userSignupIntegrationTest) SetupSuite() {
 }
 
 func (s *userSignupIntegrationTest) TearDownTest() {
-	hostAwait := s.Host()
-	memberAwait := s.Member1()
-	memberAwait2 := s.Member2()
-	hostAwait.Clean(s.T())
-	memberAwait.Clean(s.T())
-	memberAwait2.Clean(s.T())
+	s.Host().Clean(s.T())
+	for _, memberAwait := range s.AllMembers() {
+		memberAwait.Clean(s.T())
+	}
 }
 
 func (s *userSignupIntegrationTest) TestAutomaticApproval() {
 	// given
+	s.SkipUnlessMultipleMembers(s.T())
 	hostAwait := s.Host()
 	hostAwait.UpdateToolchainConfig(s.T(), testconfig.AutomaticApproval().Enabled(true))
 	memberAwait1 := s.Member1()
@@ -166,6 +165,7 @@ func (s *userSignupIntegrationTest) TestAutomaticApproval() {
 }
 
 func (s *userSignupIntegrationTest) TestProvisionToOtherClusterWhenOneIsFull() {
+	s.SkipUnlessMultipleMembers(s.T())
 	hostAwait := s.Host()
 	memberAwait1 := s.Member1()
 	memberAwait2 := s.Member2()
@@ -278,6 +278,7 @@ func (s *userSignupIntegrationTest) TestManualApproval() {
 }
 
 func (s *userSignupIntegrationTest) TestCapacityManagementWithManualApproval() {
+	s.SkipUnlessMultipleMembers(s.T())
 	hostAwait := s.Host()
 	memberAwait1 := s.Member1()
 	memberAwait2 := s.Member2()
@@ -0,0 +1,32 @@
+package e2e
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookCertsRotation(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	NewSignupRequest(awaitilities).
+		Username("certrotation").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t)
+	VerifyWebhooksEnforced(t, memberAwait, "certrotation-dev", "certrotation")
+	restarts := GetWebhookAndOperatorPodRestarts(t, memberAwait)
+
+	// when
+	RotateWebhookCerts(t, hostAwait, memberAwait)
+
+	// then
+	VerifyWebhooksEnforced(t, memberAwait, "certrotation-dev", "certrotation")
+	// neither the webhook nor the operator were restarted to serve the new certificate
+	assert.Equal(t, restarts, GetWebhookAndOperatorPodRestarts(t, memberAwait))
+}
@@ -7,10 +7,10 @@ import (
 	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
 	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	"github.com/gofrs/uuid"
@@ -24,6 +24,7 @@ import (
 func TestMetricsWhenUsersDeactivated(t *testing.T) {
 	// given
 	awaitilities := WaitForDeployments(t)
+	awaitilities.SkipUnlessMultipleMembers(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
 	memberAwait2 := awaitilities.Member2()
@@ -59,8 +60,9 @@ func TestMetricsWhenUsersDeactivated(t *testing.T) {
 	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "external") // never incremented
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsApprovedMetric, 2)                                                    // all activated
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsDeactivatedMetric, 0)                                                 // none deactivated
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait.ClusterName)
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 2, "cluster_name", memberAwait2.ClusterName) // 2 spaces created on member-2
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait.ClusterName)
+	metricsAssertion.WaitForSpacesMetricDelta(t, 2, "cluster_name", memberAwait2.ClusterName) // 2 spaces created on member-2
+	VerifyUserSignupStates(t, hostAwait)
 
 	// when deactivating the users
 	for username, usersignup := range usersignups {
@@ -83,15 +85,16 @@ func TestMetricsWhenUsersDeactivated(t *testing.T) {
 	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "external") // never incremented
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsApprovedMetric, 2)                                                    // all deactivated (but counters are never decremented)
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsDeactivatedMetric, 2)                                                 // all deactivated
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait.ClusterName)
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName) // 2 spaces deleted from member-2
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait.ClusterName)
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName) // 2 spaces deleted from member-2
+	VerifyUserSignupStates(t, hostAwait)
 
 }
 
 // TestMetricsWhenUsersReactivated activates and deactivates a few users, and check the metrics.
 // user-0001 will be activated 1 time
 // user-0002 will be activated 2 times
-// user-0003 will be activated 3 times
+// user-0003 will be activated 3 times, then a 4th time to verify that it moves to the next bucket of the metric
 func TestMetricsWhenUsersDeactivatedAndReactivated(t *testing.T) {
 	// given
 	awaitilities := WaitForDeployments(t)
@@ -122,39 +125,22 @@ func TestMetricsWhenUsersDeactivatedAndReactivated(t *testing.T) {
 			Resources()
 
 		for j := 1; j < i; j++ { // deactivate and reactivate as many times as necessary (based on its "number")
-			// deactivate the user
-			_, err := hostAwait.UpdateUserSignup(t, usersignups[username].Name,
-				func(usersignup *toolchainv1alpha1.UserSignup) {
-					states.SetDeactivated(usersignup, true)
-				})
-			require.NoError(t, err)
-
-			err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, username)
-			require.NoError(t, err)
-
-			err = hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(t, username)
-			require.NoError(t, err)
-
-			// reactivate the user
-			usersignups[username], _ = NewSignupRequest(awaitilities).
-				IdentityID(uuid.Must(uuid.FromString(usersignups[username].Spec.Userid))).
-				Username(username).
-				ManuallyApprove().
-				TargetCluster(memberAwait).
-				EnsureMUR().
-				RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
-				Execute(t).
-				Resources()
+			usersignups[username] = deactivateAndReactivate(t, awaitilities, usersignups[username])
 		}
 	}
 
 	// then verify the value of the `sandbox_users_per_activations` metric
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 1, "activations", "1", "domain", "external") // 1 activation
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "internal") // no activation
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 1, "activations", "2", "domain", "external") // 1 activation
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "2", "domain", "internal") // no activation
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 1, "activations", "3", "domain", "external") // 1 activation
-	metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "3", "domain", "internal") // no activation
+	metricsAssertion.WaitForUsersPerActivationsDelta(t, "external", 1, 1, 1) // user-0001, user-0002 and user-0003 were activated 1, 2 and 3 times
+	metricsAssertion.WaitForUsersPerActivationsDelta(t, "internal")          // no activation
+
+	t.Run("reactivate user and verify that it moved to the next activations bucket", func(t *testing.T) {
+		// when
+		usersignups["user-0003"] = deactivateAndReactivate(t, awaitilities, usersignups["user-0003"])
+
+		// then
+		metricsAssertion.WaitForUsersPerActivationsDelta(t, "external", 1, 1, 0, 1) // user-0003 moved from 3 to 4 activations
+		metricsAssertion.WaitForUsersPerActivationsDelta(t, "internal")             // no activation
+	})
 
 	t.Run("restart host-operator pod and verify that metrics are still available", func(t *testing.T) {
 		// given
@@ -169,15 +155,41 @@ func TestMetricsWhenUsersDeactivatedAndReactivated(t *testing.T) {
 		_, err = hostAwait.WaitForRouteToBeAvailable(t, hostAwait.Namespace, "host-operator-metrics-service", "/metrics")
 		require.NoError(t, err, "failed while setting up or waiting for the route to the 'host-operator-metrics-service' service to be available")
 		// also verify that the metric values "survived" the restart
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "external") // user-0001 was 1 time (unchanged after pod restarted)
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "internal") // no activation
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "2", "domain", "external") // user-0002 was 2 times (unchanged after pod restarted)
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "2", "domain", "internal") // no activation
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "3", "domain", "external") // user-0003 was 3 times (unchanged after pod restarted)
-		metricsAssertion.WaitForMetricDelta(t, UsersPerActivationsAndDomainMetric, 0, "activations", "3", "domain", "internal") // no activation
+		metricsAssertion.WaitForUsersPerActivationsDelta(t, "external") // user-0001, user-0002 and user-0003 were activated 1, 2 and 4 times (unchanged after pod restarted)
+		metricsAssertion.WaitForUsersPerActivationsDelta(t, "internal") // no activation
 	})
 }
 
+// deactivateAndReactivate deactivates the user of the given UserSignup, waits until its resources are deleted and then reactivates it
+func deactivateAndReactivate(t *testing.T, awaitilities wait.Awaitilities, usersignup *toolchainv1alpha1.UserSignup) *toolchainv1alpha1.UserSignup {
+	hostAwait := awaitilities.Host()
+	username := usersignup.Spec.Username
+	// deactivate the user
+	_, err := hostAwait.UpdateUserSignup(t, usersignup.Name,
+		func(usersignup *toolchainv1alpha1.UserSignup) {
+			states.SetDeactivated(usersignup, true)
+		})
+	require.NoError(t, err)
+
+	err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, username)
+	require.NoError(t, err)
+
+	err = hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(t, username)
+	require.NoError(t, err)
+
+	// reactivate the user
+	usersignup, _ = NewSignupRequest(awaitilities).
+		IdentityID(uuid.Must(uuid.FromString(usersignup.Spec.Userid))).
+		Username(username).
+		ManuallyApprove().
+		TargetCluster(awaitilities.Member1()).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).
+		Resources()
+	return usersignup
+}
+
 // TestMetricsWhenUsersDeleted verifies that the `UsersPerActivationsAndDomainMetric` metric is NOT decreased when users are deleted
 func TestMetricsWhenUsersDeleted(t *testing.T) {
 	// given
@@ -241,6 +253,7 @@ func TestMetricsWhenUsersBanned(t *testing.T) {
 
 	// given
 	awaitilities := WaitForDeployments(t)
+	awaitilities.SkipUnlessMultipleMembers(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
 	memberAwait2 := awaitilities.Member2()
@@ -281,8 +294,9 @@ func TestMetricsWhenUsersBanned(t *testing.T) {
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsBannedMetric, 1)
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "external")
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "internal")
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait.ClusterName)
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName)
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait.ClusterName)
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName)
+	VerifyUserSignupStates(t, hostAwait)
 
 	t.Run("unban the banned user", func(t *testing.T) {
 		// given
@@ -307,8 +321,9 @@ func TestMetricsWhenUsersBanned(t *testing.T) {
 		metricsAssertion.WaitForMetricDelta(t, UserSignupsBannedMetric, 0)   // unchanged: banneduser already existed
 		metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 1, "domain", "external")
 		metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "internal")
-		metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 1, "cluster_name", memberAwait.ClusterName)  // space provisioned on member1
-		metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName) // no spaces on member2
+		metricsAssertion.WaitForSpacesMetricDelta(t, 1, "cluster_name", memberAwait.ClusterName)  // space provisioned on member1
+		metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName) // no spaces on member2
+		VerifyUserSignupStates(t, hostAwait)
 	})
 }
 
@@ -316,6 +331,7 @@ func TestMetricsWhenUsersBanned(t *testing.T) {
 func TestMetricsWhenUserDisabled(t *testing.T) {
 	// given
 	awaitilities := WaitForDeployments(t)
+	awaitilities.SkipUnlessMultipleMembers(t)
 	hostAwait := awaitilities.Host()
 	memberAwait := awaitilities.Member1()
 	memberAwait2 := awaitilities.Member2()
@@ -345,8 +361,8 @@ func TestMetricsWhenUserDisabled(t *testing.T) {
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsBannedMetric, 0)
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "internal")
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 1, "domain", "external")
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 1, "cluster_name", memberAwait.ClusterName)  // space present on member1
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName) // no space on member2
+	metricsAssertion.WaitForSpacesMetricDelta(t, 1, "cluster_name", memberAwait.ClusterName)  // space present on member1
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName) // no space on member2
 
 	// when disabling MUR
 	_, err := hostAwait.UpdateMasterUserRecordSpec(t, mur.Name,
@@ -362,8 +378,8 @@ func TestMetricsWhenUserDisabled(t *testing.T) {
 	metricsAssertion.WaitForMetricDelta(t, UserSignupsBannedMetric, 0)
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "internal")
 	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 1, "domain", "external")
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 1, "cluster_name", memberAwait.ClusterName)  // space is on member1
-	metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName) // no space on member2
+	metricsAssertion.WaitForSpacesMetricDelta(t, 1, "cluster_name", memberAwait.ClusterName)  // space is on member1
+	metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName) // no space on member2
 
 	t.Run("re-enabled mur", func(t *testing.T) {
 		// given
@@ -383,10 +399,47 @@ func TestMetricsWhenUserDisabled(t *testing.T) {
 		metricsAssertion.WaitForMetricDelta(t, UserSignupsBannedMetric, 0)
 		metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "external") // unchanged, user was already provisioned
 		metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 0, "domain", "internal")
-		metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait.ClusterName)
-		metricsAssertion.WaitForMetricDelta(t, SpacesMetric, 0, "cluster_name", memberAwait2.ClusterName)
+		metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait.ClusterName)
+		metricsAssertion.WaitForSpacesMetricDelta(t, 0, "cluster_name", memberAwait2.ClusterName)
+
+	})
+}
 
+// TestMetricsWhenUserPromoted verifies that the `SpacesMetric` gauge of the previous tier is decreased and the one of the target tier
+// is increased when a user is promoted, while the other metrics remain unchanged
+func TestMetricsWhenUserPromoted(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+	hostAwait.UpdateToolchainConfig(t, testconfig.AutomaticApproval().Enabled(false))
+	// host metrics should be available at this point
+	VerifyHostMetricsService(t, hostAwait)
+	VerifyMemberMetricsService(t, memberAwait)
+	metricsAssertion := InitMetricsAssertion(t, awaitilities)
+	t.Cleanup(func() {
+		t.Log("waiting for metrics to get back to their baseline values...")
+		metricsAssertion.WaitForMetricBaseline(t, SpacesMetric, "cluster_name", memberAwait.ClusterName)
 	})
+
+	_, mur := NewSignupRequest(awaitilities).
+		Username("promoteduser").
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).
+		Resources()
+	metricsAssertion.WaitForSpacesMetricDelta(t, 1, "cluster_name", memberAwait.ClusterName)
+
+	// when
+	PromoteUser(t, awaitilities, mur.Name, "advanced", WithUserTier("deactivate90"), WithTierMetrics(metricsAssertion))
+
+	// then
+	metricsAssertion.WaitForMetricDelta(t, UserSignupsMetric, 1)
+	metricsAssertion.WaitForMetricDelta(t, UserSignupsApprovedMetric, 1) // unchanged by the promotion
+	metricsAssertion.WaitForMetricDelta(t, MasterUserRecordsPerDomainMetric, 1, "domain", "external")
+	metricsAssertion.WaitForSpacesMetricDelta(t, 1, "cluster_name", memberAwait.ClusterName) // still a single space on member1
 }
 
 func banUser(t *testing.T, hostAwait *wait.HostAwaitility, email string) *toolchainv1alpha1.BannedUser {
@@ -395,7 +448,7 @@ func banUser(t *testing.T, hostAwait *wait.HostAwaitility, email string) *toolch
 			Name:      uuid.Must(uuid.NewV4()).String(),
 			Namespace: hostAwait.Namespace,
 			Labels: map[string]string{
-				toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(email),
+				toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EmailHash(email),
 			},
 		},
 		Spec: toolchainv1alpha1.BannedUserSpec{
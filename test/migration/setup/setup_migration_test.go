@@ -10,6 +10,8 @@ import (
 func TestSetupMigration(t *testing.T) {
 	// given
 	awaitilities := WaitForDeployments(t)
+	// the migration setup provisions resources in the second member cluster
+	awaitilities.SkipUnlessMultipleMembers(t)
 
 	runner := migration.SetupMigrationRunner{
 		Awaitilities: awaitilities,
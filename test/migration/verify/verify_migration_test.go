@@ -8,11 +8,11 @@ import (
 	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
 	"github.com/codeready-toolchain/toolchain-e2e/test/migration"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	"github.com/stretchr/testify/require"
@@ -22,6 +22,8 @@ import (
 func TestAfterMigration(t *testing.T) {
 	// given
 	awaitilities := WaitForDeployments(t)
+	// the migration setup provisioned resources in the second member cluster
+	awaitilities.SkipUnlessMultipleMembers(t)
 	// increase timeout to be sure that the operators had enough time to properly initialize and reconcile all present resources
 	awaitilities = wait.NewAwaitilities(
 		awaitilities.Host().WithRetryOptions(wait.TimeoutOption(wait.DefaultTimeout*2)),
@@ -162,7 +164,7 @@ func verifyBannedSignup(t *testing.T, awaitilities wait.Awaitilities, signup *to
 
 	// get the BannedUser resource
 	matchEmailHash := client.MatchingLabels{
-		toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(signup.Annotations[toolchainv1alpha1.UserSignupUserEmailAnnotationKey]),
+		toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EmailHash(signup.Annotations[toolchainv1alpha1.UserSignupUserEmailAnnotationKey]),
 	}
 	bannedUsers := &toolchainv1alpha1.BannedUserList{}
 	err = hostAwait.Client.List(context.TODO(), bannedUsers, client.InNamespace(hostAwait.Namespace), matchEmailHash)
@@ -0,0 +1,13 @@
+package preflight
+
+import (
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+)
+
+// TestPreflight validates the environment before running the e2e tests, so that a misconfigured or unhealthy environment
+// fails fast with an actionable message instead of cascading timeouts in all the tests
+func TestPreflight(t *testing.T) {
+	RunPreflightChecks(t)
+}
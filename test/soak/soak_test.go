@@ -0,0 +1,79 @@
+package soak
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	soakDuration         = flag.Duration("soak-duration", 0, "duration of the soak test (the test is skipped if not set)")
+	soakSnapshotInterval = flag.Duration("soak-snapshot-interval", 10*time.Minute, "interval between two snapshots of the resources and metrics of the operators during the soak test")
+	soakGrowthTolerance  = flag.Float64("soak-growth-tolerance", 0.1, "ratio by which a value must grow between two periods of the soak test to be considered as leaking")
+)
+
+// TestSoak loops over the core scenarios (signup, idling, deactivation) for the given duration, while periodically taking snapshots
+// of the number of toolchain resources and of the runtime metrics of the operators, and verifies that none of them keeps growing.
+// It is opt-in: it only runs when the `-soak-duration` flag is set, eg. `go test ./test/soak -v -timeout=0 -soak-duration=4h`
+func TestSoak(t *testing.T) {
+	if *soakDuration == 0 {
+		t.Skip("soak test is disabled, set the '-soak-duration' flag to enable it")
+	}
+	awaitilities := WaitForDeployments(t)
+
+	snapshots := []SoakSnapshot{TakeSoakSnapshot(t, awaitilities)}
+	deadline := time.Now().Add(*soakDuration)
+	for i := 0; time.Now().Before(deadline); i++ {
+		t.Run(fmt.Sprintf("iteration %d", i), func(t *testing.T) {
+			runSoakScenario(t, awaitilities, fmt.Sprintf("soak-%d", i))
+		})
+		if t.Failed() {
+			break
+		}
+		if time.Since(snapshots[len(snapshots)-1].Time) >= *soakSnapshotInterval {
+			snapshots = append(snapshots, TakeSoakSnapshot(t, awaitilities))
+		}
+	}
+	snapshots = append(snapshots, TakeSoakSnapshot(t, awaitilities))
+
+	AssertNoUnboundedGrowth(t, snapshots, *soakGrowthTolerance)
+}
+
+// runSoakScenario signs up a user, waits until the Idlers of its namespaces are running, then deactivates the user
+// and deletes its UserSignup, so that all the resources of the user are expected to be deleted at the end of the scenario
+func runSoakScenario(t *testing.T, awaitilities wait.Awaitilities, username string) {
+	hostAwait := awaitilities.Host()
+	memberAwait := awaitilities.Member1()
+
+	// signup
+	userSignup, _ := NewSignupRequest(awaitilities).
+		Username(username).
+		ManuallyApprove().
+		TargetCluster(memberAwait).
+		EnsureMUR().
+		RequireConditions(ConditionSet(Default(), ApprovedByAdmin())...).
+		Execute(t).
+		Resources()
+
+	// idling
+	space, err := hostAwait.WaitForSpace(t, userSignup.Status.CompliantUsername, wait.UntilSpaceHasAnyProvisionedNamespaces())
+	require.NoError(t, err)
+	for _, ns := range space.Status.ProvisionedNamespaces {
+		_, err := memberAwait.WaitForIdler(t, ns.Name, wait.IdlerConditions(Running()))
+		require.NoError(t, err)
+	}
+
+	// deactivation
+	userSignup = DeactivateAndCheckUser(t, awaitilities, userSignup)
+	require.NoError(t, hostAwait.Client.Delete(context.TODO(), userSignup))
+	err = hostAwait.WaitUntilUserSignupDeleted(t, userSignup.Name)
+	require.NoError(t, err)
+	err = memberAwait.WaitUntilNSTemplateSetDeleted(t, space.Name)
+	require.NoError(t, err)
+}
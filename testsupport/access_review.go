@@ -0,0 +1,36 @@
+package testsupport
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AssertCan verifies that the user of the given client is allowed to perform the given verb on the given resource in the given
+// namespace (or at the cluster scope if the namespace is empty), using a SelfSubjectAccessReview. Since the roles are propagated
+// asynchronously, the review is retried by the given awaitility until the access is allowed.
+func AssertCan(t *testing.T, await *wait.Awaitility, userClient client.Client, verb string, gvr schema.GroupVersionResource, namespace string) {
+	require.NoError(t, await.WaitForAccessDecision(t, wait.SelfSubjectAccessReview(userClient, verb, gvr, namespace), true))
+}
+
+// AssertCannot verifies that the user of the given client is not allowed to perform the given verb on the given resource in the given
+// namespace (or at the cluster scope if the namespace is empty), using a SelfSubjectAccessReview. Since the roles are revoked
+// asynchronously, the review is retried by the given awaitility until the access is denied.
+func AssertCannot(t *testing.T, await *wait.Awaitility, userClient client.Client, verb string, gvr schema.GroupVersionResource, namespace string) {
+	require.NoError(t, await.WaitForAccessDecision(t, wait.SelfSubjectAccessReview(userClient, verb, gvr, namespace), false))
+}
+
+// AssertUserCan verifies that the given user is allowed to perform the given verb on the given resource in the given namespace,
+// using a SubjectAccessReview created with the client of the given awaitility
+func AssertUserCan(t *testing.T, await *wait.Awaitility, username string, verb string, gvr schema.GroupVersionResource, namespace string) {
+	require.NoError(t, await.WaitForAccessDecision(t, wait.SubjectAccessReview(await.Client, username, verb, gvr, namespace), true))
+}
+
+// AssertUserCannot verifies that the given user is not allowed to perform the given verb on the given resource in the given namespace,
+// using a SubjectAccessReview created with the client of the given awaitility
+func AssertUserCannot(t *testing.T, await *wait.Awaitility, username string, verb string, gvr schema.GroupVersionResource, namespace string) {
+	require.NoError(t, await.WaitForAccessDecision(t, wait.SubjectAccessReview(await.Client, username, verb, gvr, namespace), false))
+}
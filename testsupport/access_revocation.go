@@ -0,0 +1,64 @@
+package testsupport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RevokedUser the user whose access is expected to be revoked, as it was before its access was revoked
+// (ie, with the Space which was provisioned for it, and the token it used to sign up)
+type RevokedUser struct {
+	UserSignup *toolchainv1alpha1.UserSignup
+	Space      *toolchainv1alpha1.Space
+	Token      string
+}
+
+// VerifyAccessRevoked verifies that the given (banned) user cannot access the platform anymore, whatever the ingress path:
+// - via the proxy, its workspace is not accessible anymore,
+// - via the API server of the member cluster, it is not allowed to access the namespaces which were provisioned for it,
+// - via the registration service, it cannot sign up again.
+func VerifyAccessRevoked(t *testing.T, awaitilities wait.Awaitilities, user RevokedUser) {
+	hostAwait := awaitilities.Host()
+	t.Logf("verifying that the access of user '%s' is revoked", user.UserSignup.Spec.Username)
+
+	t.Run("via proxy", func(t *testing.T) {
+		WaitForWorkspaceAccessDecision(t, hostAwait, user.Token, user.Space.Name, hostAwait.Timeout, WorkspaceIsNotAccessible())
+	})
+
+	t.Run("via member API", func(t *testing.T) {
+		memberAwait, err := awaitilities.Member(user.Space.Spec.TargetCluster)
+		require.NoError(t, err)
+		userAwait, err := memberAwait.Impersonate(user.UserSignup.Status.CompliantUsername)
+		require.NoError(t, err)
+		for _, ns := range user.Space.Status.ProvisionedNamespaces {
+			waitUntilNamespaceIsNotAccessible(t, memberAwait, userAwait.Client, ns.Name)
+		}
+	})
+
+	t.Run("via registration service", func(t *testing.T) {
+		resp := NewRegistrationServiceClient(hostAwait).Invoke(t, "POST", "/api/v1/signup", user.Token, "")
+		respErr := resp.RequireError(t, http.StatusForbidden)
+		assert.Equal(t, "forbidden: user has been banned", respErr.Message)
+	})
+}
+
+// waitUntilNamespaceIsNotAccessible waits until listing the ConfigMaps of the given namespace with the given client
+// is rejected (or the namespace does not exist anymore)
+func waitUntilNamespaceIsNotAccessible(t *testing.T, memberAwait *wait.MemberAwaitility, cl client.Client, namespace string) {
+	t.Logf("waiting until namespace '%s' is not accessible", namespace)
+	var lastErr error
+	err := memberAwait.Poll(func() (done bool, err error) {
+		lastErr = cl.List(context.TODO(), &corev1.ConfigMapList{}, client.InNamespace(namespace))
+		return apierrors.IsForbidden(lastErr) || apierrors.IsNotFound(lastErr), nil
+	})
+	require.NoError(t, err, "namespace '%s' is still accessible (last error: %v)", namespace, lastErr)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/gofrs/uuid"
+)
+
+// IdentityProvider the identity provider which issued a token, and which determines the shape of its claims
+type IdentityProvider string
+
+const (
+	// RedHatSSO tokens have a UUID as `sub`, and the `user_id` and `account_id` claims
+	RedHatSSO IdentityProvider = "redhat-sso"
+	// GitHub tokens (brokered by the SSO) have a `sub` prefixed with `github|`, followed by the numeric ID of the GitHub user
+	GitHub IdentityProvider = "github"
+	// CustomOIDC tokens have an opaque `sub` prefixed with the name of the provider, and no `user_id` nor `account_id` claims
+	CustomOIDC IdentityProvider = "custom-oidc"
+)
+
+// IdentityProviders all the identity providers whose claims can be generated
+var IdentityProviders = []IdentityProvider{RedHatSSO, GitHub, CustomOIDC}
+
+// ClaimsFrom returns randomized claims, shaped like the ones in the tokens issued by the given identity provider,
+// along with the value of their `sub` claim
+func ClaimsFrom(idp IdentityProvider) (string, []Claim) {
+	switch idp {
+	case GitHub:
+		sub := fmt.Sprintf("github|%d", 1000000+rand.Intn(1000000)) // nolint:gosec
+		return sub, []Claim{WithSub(sub)}
+	case CustomOIDC:
+		sub := "oidc|" + strings.ReplaceAll(uuid.Must(uuid.NewV4()).String(), "-", "")
+		return sub, []Claim{WithSub(sub)}
+	default:
+		sub := uuid.Must(uuid.NewV4()).String()
+		return sub, []Claim{
+			WithSub(sub),
+			commonauth.WithUserIDClaim(strconv.Itoa(10000000 + rand.Intn(10000000))),    // nolint:gosec
+			commonauth.WithAccountIDClaim(strconv.Itoa(10000000 + rand.Intn(10000000))), // nolint:gosec
+		}
+	}
+}
+
+func WithSub(sub string) Claim {
+	return commonauth.WithSubClaim(sub)
+}
+
+// WithoutPreferredUsername removes the `preferred_username` claim, which is not issued by all the identity providers
+func WithoutPreferredUsername() Claim {
+	return commonauth.WithPreferredUsernameClaim("")
+}
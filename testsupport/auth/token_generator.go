@@ -8,13 +8,15 @@ import (
 
 func NewToken(claims ...Claim) (*commonauth.Identity, string, error) {
 	identity := commonauth.NewIdentity()
-	claims = append(claims, commonauth.WithSubClaim(identity.ID.String()))
+	// the `sub` claim is set first, so that it can be overridden by the given claims
+	claims = append([]Claim{commonauth.WithSubClaim(identity.ID.String())}, claims...)
 	token, err := commonauth.GenerateSignedE2ETestToken(*identity, claims...)
 	return identity, token, err
 }
 
 func NewTokenFromIdentity(identity *commonauth.Identity, claims ...Claim) (string, error) {
-	claims = append(claims, commonauth.WithSubClaim(identity.ID.String()))
+	// the `sub` claim is set first, so that it can be overridden by the given claims
+	claims = append([]Claim{commonauth.WithSubClaim(identity.ID.String())}, claims...)
 	token, err := commonauth.GenerateSignedE2ETestToken(*identity, claims...)
 	return token, err
 }
@@ -36,3 +38,8 @@ func WithIAT(iat time.Time) Claim {
 func WithPreferredUsername(username string) Claim {
 	return commonauth.WithPreferredUsernameClaim(username)
 }
+
+// WithoutEmail removes the `email` claim, which is not issued for service accounts nor by all the identity providers
+func WithoutEmail() Claim {
+	return commonauth.WithEmailClaim("")
+}
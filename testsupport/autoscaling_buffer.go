@@ -0,0 +1,86 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/workloads"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// usersPodsPriorityClassName the PriorityClass set by the member webhook on the pods of the users
+	usersPodsPriorityClassName = "sandbox-users-pods"
+)
+
+// VerifyAutoscalingBufferProvidesHeadroom verifies that the autoscaling buffer actually provides headroom for the user workloads:
+// a heavy workload (with the priority of the user pods) which only fits on the node of a buffer pod once this buffer pod is evicted
+// is scheduled on this node, and the buffer pod is preempted, as reported by the event of the scheduler. Once the workload is deleted,
+// the buffer is back to its configured number of replicas. Returns the `Preempted` event of the buffer pod.
+func VerifyAutoscalingBufferProvidesHeadroom(t *testing.T, memberAwait *wait.MemberAwaitility) *corev1.Event {
+	config := memberAwait.GetMemberOperatorConfig(t)
+	require.True(t, config.Spec.Autoscaler.Deploy != nil && *config.Spec.Autoscaler.Deploy, "the autoscaling buffer is not deployed in member cluster '%s'", memberAwait.ClusterName)
+	replicas := wait.AutoscalingBufferReplicas(config.Spec.Autoscaler)
+	bufferPods, err := memberAwait.WaitForAutoscalingBufferPods(t, replicas)
+	require.NoError(t, err)
+	bufferPod := bufferPods[0]
+
+	// given a workload which does not fit on the node of the buffer pod, unless this buffer pod is evicted
+	namespace := fmt.Sprintf("autoscaling-headroom-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	memberAwait.CreateNamespace(t, namespace)
+	node, memory := requiredMemoryToPreempt(t, memberAwait, bufferPod)
+	name := fmt.Sprintf("heavy-workload-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	t.Logf("creating Pod '%s' requesting %s of memory on node '%s' of buffer Pod '%s'", name, memory.String(), bufferPod.Spec.NodeName, bufferPod.Name)
+
+	// when
+	workload := workloads.Apply(t, memberAwait, namespace, name, workloads.Pause(memory,
+		// with the priority of the user pods, on the node of the buffer pod (the node name is not set, so that the pod goes
+		// through the scheduler, which performs the preemption)
+		workloads.WithPriorityClassName(usersPodsPriorityClassName),
+		workloads.WithNodeSelector(map[string]string{corev1.LabelHostname: node.Labels[corev1.LabelHostname]})))
+
+	// then
+	event, err := memberAwait.WaitForPodPreemptedEvent(t, bufferPod)
+	require.NoError(t, err, "buffer Pod '%s' was not preempted by Pod '%s'", bufferPod.Name, workload.Name)
+	_, err = memberAwait.WaitForPod(t, namespace, workload.Name, wait.PodRunning(), wait.PodScheduledOnNode(bufferPod.Spec.NodeName))
+	require.NoError(t, err)
+
+	// and the buffer is restored once the workload is gone
+	require.NoError(t, memberAwait.Client.Delete(context.TODO(), workload.Objects[0]))
+	require.NoError(t, memberAwait.WaitUntilPodDeleted(t, namespace, workload.Name))
+	_, err = memberAwait.WaitForAutoscalingBufferPods(t, replicas)
+	require.NoError(t, err, "the autoscaling buffer was not restored after the deletion of Pod '%s'", workload.Name)
+	return event
+}
+
+// requiredMemoryToPreempt returns the memory which a pod must request to be scheduled on the node of the given buffer pod only once
+// this buffer pod is evicted, ie, the allocatable memory of the node which is not requested by its pods yet, plus the memory
+// requested by the buffer pod. Also returns the node.
+func requiredMemoryToPreempt(t *testing.T, memberAwait *wait.MemberAwaitility, bufferPod corev1.Pod) (*corev1.Node, resource.Quantity) {
+	node := &corev1.Node{}
+	require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: bufferPod.Spec.NodeName}, node))
+	pods := &corev1.PodList{}
+	require.NoError(t, memberAwait.Client.List(context.TODO(), pods, client.MatchingFields{"spec.nodeName": node.Name}))
+
+	required := node.Status.Allocatable.Memory().DeepCopy()
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			required.Sub(*c.Resources.Requests.Memory())
+		}
+	}
+	require.True(t, required.Sign() >= 0, "the memory requested by the pods of node '%s' exceeds its allocatable memory", node.Name)
+	for _, c := range bufferPod.Spec.Containers {
+		required.Add(*c.Resources.Requests.Memory())
+	}
+	return node, required
+}
@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
@@ -29,7 +29,7 @@ func NewBannedUser(host *wait.HostAwaitility, email string) *toolchainv1alpha1.B
 			Name:      uuid.Must(uuid.NewV4()).String(),
 			Namespace: host.Namespace,
 			Labels: map[string]string{
-				toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(email),
+				toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EmailHash(email),
 			},
 		},
 		Spec: toolchainv1alpha1.BannedUserSpec{
@@ -0,0 +1,62 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// VerifyCheIntegration verifies that the Che status and the Che dashboard URL in the MemberStatus are consistent with
+// the Che installation of the member cluster (ie, with its route). The test is skipped if Che is not installed in the cluster.
+// Returns the URL of the Che dashboard.
+func VerifyCheIntegration(t *testing.T, memberAwait *wait.MemberAwaitility) string {
+	memberAwait.SkipUnlessCapable(t, wait.CapabilityChe)
+	expectedURL, err := memberAwait.GetCheDashboardURL(t)
+	require.NoError(t, err, "Che is installed in the member cluster, but its route was not found")
+	err = memberAwait.WaitForMemberStatus(t, wait.MemberStatusWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.MemberStatus) bool {
+			return actual.Status.Routes != nil && actual.Status.Routes.CheDashboardURL == expectedURL &&
+				actual.Status.Che != nil && condition.IsTrue(actual.Status.Che.Conditions, toolchainv1alpha1.ConditionReady)
+		},
+		Diff: func(actual *toolchainv1alpha1.MemberStatus) string {
+			return fmt.Sprintf("expected MemberStatus to have the Che dashboard URL '%s' and a ready Che status\nactual routes: %+v\nactual che status: %+v",
+				expectedURL, actual.Status.Routes, actual.Status.Che)
+		},
+	})
+	require.NoError(t, err)
+	return expectedURL
+}
+
+// VerifyCheDashboardForUser verifies, if Che is installed in the member cluster, that the Che dashboard URL returned by
+// the registration service for the user with the given token is the one of the member cluster, and that the dashboard
+// responds to the user (the response may be a redirect to the login page). Nothing is verified if Che is not installed.
+func VerifyCheDashboardForUser(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility, token string) {
+	if capable, err := memberAwait.HasCapability(wait.CapabilityChe); err != nil || !capable {
+		t.Log("skipping the verification of the Che dashboard because Che is not installed in the member cluster")
+		return
+	}
+	expectedURL, err := memberAwait.GetCheDashboardURL(t)
+	require.NoError(t, err, "Che is installed in the member cluster, but its route was not found")
+
+	status := struct {
+		CheDashboardURL string `json:"cheDashboardURL"`
+	}{}
+	resp := NewRegistrationServiceClient(hostAwait).Invoke(t, http.MethodGet, "/api/v1/signup", token, "").RequireStatus(t, http.StatusOK)
+	require.NoError(t, json.Unmarshal(resp.Body, &status), "unable to decode the signup status:\n%s", resp)
+	assert.Equal(t, expectedURL, status.CheDashboardURL)
+
+	// smoke test: the dashboard is served (ie, no 5xx response, the requests are retried otherwise)
+	resp = NewRESTClient().Do(t, RESTRequest{
+		Method: http.MethodGet,
+		URL:    expectedURL,
+		Token:  token,
+	})
+	assert.Less(t, resp.StatusCode, http.StatusInternalServerError, "unexpected response from the Che dashboard:\n%s", resp)
+}
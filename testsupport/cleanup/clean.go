@@ -10,6 +10,7 @@ import (
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -30,49 +31,110 @@ var (
 	})
 )
 
-type cleanManager struct {
+// Manager manages the cleaning tasks added by the tests, and records their durations.
+// The package-level functions use a default Manager shared by all the tests of the process, but other test suites
+// (eg, in the operator repositories) may use their own Manager, created with `NewManager`.
+type Manager struct {
 	sync.RWMutex
 	cleanTasks map[*testing.T][]*cleanTask
+	timings    *timings
+	pending    *pendingTasks
 }
 
-var cleaning = &cleanManager{
-	cleanTasks: map[*testing.T][]*cleanTask{},
+// NewManager returns a new Manager, with no cleaning task
+func NewManager() *Manager {
+	return &Manager{
+		cleanTasks: map[*testing.T][]*cleanTask{},
+		timings: &timings{
+			durations: map[string][]time.Duration{},
+		},
+		pending: &pendingTasks{
+			tasks: map[*cleanTask]*PendingCleanTask{},
+		},
+	}
 }
 
+var defaultManager = NewManager()
+
 type AwaitilityInt interface {
 	GetClient() client.Client
 }
 
+// CleanTaskOption an option to configure the cleaning task of an object
+type CleanTaskOption func(*cleanTask)
+
+// WithPropagation sets the propagation policy used when deleting the object (foreground by default).
+// Background propagation is recommended for objects owning many dependents (eg. namespaces), as the cleaning task
+// then only waits for the deletion of the object itself.
+func WithPropagation(policy metav1.DeletionPropagation) CleanTaskOption {
+	return func(task *cleanTask) {
+		task.deleteOpts = &client.DeleteOptions{
+			PropagationPolicy: &policy,
+		}
+	}
+}
+
 // AddCleanTasks adds cleaning tasks for the given objects that will be automatically performed at the end of the test execution
 func AddCleanTasks(t *testing.T, cl client.Client, objects ...client.Object) {
-	cleaning.addCleanTasks(t, cl, objects...)
+	defaultManager.AddCleanTasks(t, cl, objects...)
 }
 
-func (c *cleanManager) addCleanTasks(t *testing.T, cl client.Client, objects ...client.Object) {
-	c.Lock()
-	defer c.Unlock()
-	for _, obj := range objects {
-		if len(c.cleanTasks[t]) == 0 {
-			t.Cleanup(c.clean(t))
-		}
-		c.cleanTasks[t] = append(c.cleanTasks[t], newCleanTask(t, cl, obj))
-	}
+// AddCleanTaskWithOptions adds a cleaning task configured with the given options for the given object,
+// that will be automatically performed at the end of the test execution
+func AddCleanTaskWithOptions(t *testing.T, cl client.Client, obj client.Object, opts ...CleanTaskOption) {
+	defaultManager.AddCleanTaskWithOptions(t, cl, obj, opts...)
 }
 
 // ExecuteAllCleanTasks triggers cleanup of all resources that were marked to be cleaned before that
 func ExecuteAllCleanTasks(t *testing.T) {
-	cleaning.clean(t)()
+	defaultManager.ExecuteAllCleanTasks(t)
 }
 
-func (c *cleanManager) clean(t *testing.T) func() {
+// AddCleanTasks adds cleaning tasks for the given objects that will be automatically performed at the end of the test execution
+func (c *Manager) AddCleanTasks(t *testing.T, cl client.Client, objects ...client.Object) {
+	for _, obj := range objects {
+		c.addCleanTask(t, cl, obj)
+	}
+}
+
+// AddCleanTaskWithOptions adds a cleaning task configured with the given options for the given object,
+// that will be automatically performed at the end of the test execution
+func (c *Manager) AddCleanTaskWithOptions(t *testing.T, cl client.Client, obj client.Object, opts ...CleanTaskOption) {
+	c.addCleanTask(t, cl, obj, opts...)
+}
+
+// ExecuteAllCleanTasks triggers cleanup of all resources of the given test that were marked to be cleaned before that
+func (c *Manager) ExecuteAllCleanTasks(t *testing.T) {
+	c.clean(t)()
+}
+
+func (c *Manager) addCleanTask(t *testing.T, cl client.Client, obj client.Object, opts ...CleanTaskOption) {
+	c.Lock()
+	defer c.Unlock()
+	if len(c.cleanTasks[t]) == 0 {
+		t.Cleanup(c.clean(t))
+	}
+	task := newCleanTask(t, cl, obj, c.timings, opts...)
+	c.cleanTasks[t] = append(c.cleanTasks[t], task)
+	c.pending.add(task)
+}
+
+func (c *Manager) clean(t *testing.T) func() {
 	return func() {
 		c.Lock()
 		defer c.Unlock()
+		start := time.Now()
+		defer func() {
+			c.timings.recordTeardown(time.Since(start))
+			report.RecordPhase(t, report.PhaseCleanup, time.Since(start))
+		}()
 		var wg sync.WaitGroup
 		for _, task := range c.cleanTasks[t] {
 			wg.Add(1)
 			go func(cleanTask *cleanTask) {
 				defer wg.Done()
+				c.pending.start(cleanTask)
+				defer c.pending.remove(cleanTask)
 				cleanTask.clean()
 			}(task)
 		}
@@ -85,18 +147,26 @@ type cleanTask struct {
 	sync.Once
 	objToClean client.Object
 	client     client.Client
+	deleteOpts client.DeleteOption
 	t          *testing.T
+	timings    *timings
 }
 
 func (c *cleanTask) clean() {
 	c.Do(c.cleanObject)
 }
-func newCleanTask(t *testing.T, cl client.Client, obj client.Object) *cleanTask {
-	return &cleanTask{
+func newCleanTask(t *testing.T, cl client.Client, obj client.Object, timings *timings, opts ...CleanTaskOption) *cleanTask {
+	task := &cleanTask{
 		t:          t,
+		timings:    timings,
 		client:     cl,
 		objToClean: obj,
+		deleteOpts: propagationPolicyOpts,
+	}
+	for _, apply := range opts {
+		apply(task)
 	}
+	return task
 }
 
 func (c *cleanTask) cleanObject() {
@@ -110,8 +180,13 @@ func (c *cleanTask) cleanObject() {
 	if kind == "" {
 		kind = reflect.TypeOf(c.objToClean).Elem().Name()
 	}
+	start := time.Now()
+	defer func() {
+		c.timings.recordTask(kind, time.Since(start))
+	}()
+	report.RecordResource(c.t, kind, objToClean.GetName())
 	c.t.Logf("deleting %s: %s ...", kind, objToClean.GetName())
-	if err := c.client.Delete(context.TODO(), objToClean, propagationPolicyOpts); err != nil {
+	if err := c.client.Delete(context.TODO(), objToClean, c.deleteOpts); err != nil {
 		if errors.IsNotFound(err) {
 			// if the object was UserSignup, then let's check that the MUR was deleted as well
 			murDeleted, err := c.verifyMurDeleted(isUserSignup, userSignup, true)
@@ -189,7 +264,7 @@ func (c *cleanTask) verifyMurDeleted(isUserSignup bool, userSignup *toolchainv1a
 			}
 			if delete {
 				c.t.Logf("deleting also the related MasterUserRecord: %s", userSignup.Status.CompliantUsername)
-				if err := c.client.Delete(context.TODO(), mur, propagationPolicyOpts); err != nil {
+				if err := c.client.Delete(context.TODO(), mur, c.deleteOpts); err != nil {
 					if errors.IsNotFound(err) {
 						c.t.Logf("the related MasterUserRecord: %s is deleted as well", userSignup.Status.CompliantUsername)
 						return true, nil
@@ -223,7 +298,7 @@ func (c *cleanTask) verifySpaceDeleted(isUserSignup bool, userSignup *toolchainv
 			}
 			if delete {
 				c.t.Logf("deleting also the related Space: %s", userSignup.Status.CompliantUsername)
-				if err := c.client.Delete(context.TODO(), space, propagationPolicyOpts); err != nil {
+				if err := c.client.Delete(context.TODO(), space, c.deleteOpts); err != nil {
 					if errors.IsNotFound(err) {
 						c.t.Logf("the related Space: %s is deleted as well", userSignup.Status.CompliantUsername)
 						return true, nil
@@ -0,0 +1,70 @@
+package cleanup_test
+
+import (
+	"context"
+	"testing"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCleanTaskPropagation(t *testing.T) {
+	// newClient returns a client which contains a ConfigMap and records the options of the deletions
+	newClient := func(t *testing.T) (*commontest.FakeClient, *corev1.ConfigMap, *[]client.DeleteOptions) {
+		var deletions []client.DeleteOptions
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: commontest.MemberOperatorNs},
+		}
+		cl := commontest.NewFakeClient(t, cm)
+		cl.MockDelete = func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+			deleteOpts := client.DeleteOptions{}
+			deleteOpts.ApplyOptions(opts)
+			deletions = append(deletions, deleteOpts)
+			return cl.Client.Delete(ctx, obj, opts...)
+		}
+		return cl, cm, &deletions
+	}
+
+	t.Run("foreground propagation by default", func(t *testing.T) {
+		// given
+		cl, cm, deletions := newClient(t)
+		manager := cleanup.NewManager()
+		manager.AddCleanTasks(t, cl, cm)
+
+		// when
+		manager.ExecuteAllCleanTasks(t)
+
+		// then
+		require.Len(t, *deletions, 1)
+		require.NotNil(t, (*deletions)[0].PropagationPolicy)
+		assert.Equal(t, metav1.DeletePropagationForeground, *(*deletions)[0].PropagationPolicy)
+		assertDeleted(t, cl, cm)
+	})
+
+	t.Run("with background propagation", func(t *testing.T) {
+		// given
+		cl, cm, deletions := newClient(t)
+		manager := cleanup.NewManager()
+		manager.AddCleanTaskWithOptions(t, cl, cm, cleanup.WithPropagation(metav1.DeletePropagationBackground))
+
+		// when
+		manager.ExecuteAllCleanTasks(t)
+
+		// then
+		require.Len(t, *deletions, 1)
+		require.NotNil(t, (*deletions)[0].PropagationPolicy)
+		assert.Equal(t, metav1.DeletePropagationBackground, *(*deletions)[0].PropagationPolicy)
+		assertDeleted(t, cl, cm)
+	})
+}
+
+func assertDeleted(t *testing.T, cl client.Client, obj client.Object) {
+	err := cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+	assert.True(t, errors.IsNotFound(err), "object '%s' was not deleted", obj.GetName())
+}
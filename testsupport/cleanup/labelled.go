@@ -0,0 +1,111 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LabelledKinds the kinds of resources deleted by DeleteLabelled, in the order of their deletion: the UserSignups and
+// the Spaces come first so that the operators delete the resources they own (eg, the MasterUserRecords and the NSTemplateSets),
+// and the namespaces come last
+var LabelledKinds = []client.ObjectList{
+	&toolchainv1alpha1.UserSignupList{},
+	&toolchainv1alpha1.BannedUserList{},
+	&toolchainv1alpha1.SocialEventList{},
+	&toolchainv1alpha1.SpaceRequestList{},
+	&toolchainv1alpha1.SpaceBindingList{},
+	&toolchainv1alpha1.SpaceList{},
+	&toolchainv1alpha1.ProxyPluginList{},
+	&toolchainv1alpha1.NotificationList{},
+	&toolchainv1alpha1.NSTemplateTierList{},
+	&toolchainv1alpha1.TierTemplateList{},
+	&corev1.NamespaceList{},
+}
+
+// DeleteLabelled deletes all the resources of the LabelledKinds which have the given labels (eg, the run ID label of
+// a previous e2e suite run which crashed before its cleaning tasks were performed), and waits until they are all gone.
+// The kinds which are not registered in the scheme of the client or not installed in the cluster (eg, the UserSignups
+// in a member cluster) are skipped. The onDelete func (if not nil) is called for each deleted resource.
+// Returns the number of deleted resources.
+func DeleteLabelled(ctx context.Context, cl client.Client, labels map[string]string, timeout time.Duration, onDelete func(kind, namespace, name string)) (int, error) {
+	deleted := 0
+	var remaining []client.ObjectList
+	for _, kind := range LabelledKinds {
+		list, ok := kind.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return deleted, fmt.Errorf("unexpected list type: %T", kind)
+		}
+		items, err := listLabelled(ctx, cl, list, labels)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return deleted, err
+		}
+		for _, item := range items {
+			if err := cl.Delete(ctx, item, propagationPolicyOpts); err != nil && !errors.IsNotFound(err) {
+				return deleted, err
+			}
+			deleted++
+			if onDelete != nil {
+				onDelete(kindOf(list), item.GetNamespace(), item.GetName())
+			}
+		}
+		if len(items) > 0 {
+			remaining = append(remaining, list)
+		}
+	}
+
+	// wait until all the resources are completely deleted
+	var left []string
+	err := wait.Poll(defaultRetryInterval, timeout, func() (done bool, err error) {
+		left = nil
+		for _, list := range remaining {
+			items, err := listLabelled(ctx, cl, list, labels)
+			if err != nil {
+				return false, err
+			}
+			for _, item := range items {
+				left = append(left, fmt.Sprintf("%s '%s'", kindOf(list), client.ObjectKeyFromObject(item)))
+			}
+		}
+		return len(left) == 0, nil
+	})
+	if err != nil {
+		return deleted, fmt.Errorf("%w: resources still present: %s", err, strings.Join(left, ", "))
+	}
+	return deleted, nil
+}
+
+func listLabelled(ctx context.Context, cl client.Client, list client.ObjectList, labels map[string]string) ([]client.Object, error) {
+	if err := cl.List(ctx, list, client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]client.Object, 0, len(objs))
+	for _, o := range objs {
+		if item, ok := o.(client.Object); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// kindOf returns the kind of the items of the given list (eg, `UserSignup` for a `UserSignupList`)
+func kindOf(list client.ObjectList) string {
+	return strings.TrimSuffix(reflect.TypeOf(list).Elem().Name(), "List")
+}
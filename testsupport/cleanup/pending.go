@@ -0,0 +1,81 @@
+package cleanup
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PendingCleanTask a cleaning task which was added by a test and is not completed yet
+type PendingCleanTask struct {
+	Test      string `json:"test"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Added the time at which the task was added
+	Added time.Time `json:"added"`
+	// Running whether the task is being performed (ie, the test completed and the object is being deleted)
+	Running bool `json:"running"`
+}
+
+// pendingTasks the cleaning tasks which are not completed yet. They are guarded by their own lock, so that they can be
+// inspected while the cleaning tasks are performed (which holds the lock of the manager).
+type pendingTasks struct {
+	sync.Mutex
+	tasks map[*cleanTask]*PendingCleanTask
+}
+
+// PendingCleanTasks returns the cleaning tasks of the default manager which are not completed yet
+func PendingCleanTasks() []PendingCleanTask {
+	return defaultManager.PendingCleanTasks()
+}
+
+// PendingCleanTasks returns the cleaning tasks of the manager which are not completed yet, sorted by the time at which they were added
+func (c *Manager) PendingCleanTasks() []PendingCleanTask {
+	c.pending.Lock()
+	defer c.pending.Unlock()
+	result := make([]PendingCleanTask, 0, len(c.pending.tasks))
+	for _, p := range c.pending.tasks {
+		result = append(result, *p)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Added.Equal(result[j].Added) {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Added.Before(result[j].Added)
+	})
+	return result
+}
+
+func (p *pendingTasks) add(task *cleanTask) {
+	pending := &PendingCleanTask{
+		Test:  task.t.Name(),
+		Added: time.Now(),
+	}
+	if task.objToClean != nil {
+		pending.Kind = task.objToClean.GetObjectKind().GroupVersionKind().Kind
+		if pending.Kind == "" {
+			pending.Kind = reflect.TypeOf(task.objToClean).Elem().Name()
+		}
+		pending.Namespace = task.objToClean.GetNamespace()
+		pending.Name = task.objToClean.GetName()
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.tasks[task] = pending
+}
+
+func (p *pendingTasks) start(task *cleanTask) {
+	p.Lock()
+	defer p.Unlock()
+	if pending, found := p.tasks[task]; found {
+		pending.Running = true
+	}
+}
+
+func (p *pendingTasks) remove(task *cleanTask) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.tasks, task)
+}
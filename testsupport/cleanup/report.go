@@ -0,0 +1,103 @@
+package cleanup
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// timings records the duration of the cleaning tasks, so that a summary can be printed at the end of the test suite
+type timings struct {
+	sync.Mutex
+	// durations the durations of the cleaning tasks, per kind of resource
+	durations map[string][]time.Duration
+	// teardown the total time spent in cleaning the resources at the end of the tests
+	teardown time.Duration
+}
+
+func (r *timings) recordTask(kind string, duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.durations[kind] = append(r.durations[kind], duration)
+}
+
+func (r *timings) recordTeardown(duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.teardown += duration
+}
+
+// KindTimings the durations of the cleaning tasks for a given kind of resource
+type KindTimings struct {
+	Kind  string
+	Count int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Average returns the average duration of the cleaning tasks
+func (k KindTimings) Average() time.Duration {
+	if k.Count == 0 {
+		return 0
+	}
+	return k.Total / time.Duration(k.Count)
+}
+
+// Timings returns the durations of the cleaning tasks performed so far, per kind of resource and sorted by total duration
+// (slowest first), along with the total time spent in the teardown of the tests
+func Timings() ([]KindTimings, time.Duration) {
+	return defaultManager.Timings()
+}
+
+// Timings returns the durations of the cleaning tasks performed so far by the manager, per kind of resource and sorted
+// by total duration (slowest first), along with the total time spent in the teardown of the tests
+func (c *Manager) Timings() ([]KindTimings, time.Duration) {
+	recorded := c.timings
+	recorded.Lock()
+	defer recorded.Unlock()
+	result := make([]KindTimings, 0, len(recorded.durations))
+	for kind, durations := range recorded.durations {
+		k := KindTimings{
+			Kind:  kind,
+			Count: len(durations),
+		}
+		for _, d := range durations {
+			k.Total += d
+			if d > k.Max {
+				k.Max = d
+			}
+		}
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total == result[j].Total {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Total > result[j].Total
+	})
+	return result, recorded.teardown
+}
+
+// PrintTimingReport prints a summary of the cleaning tasks performed so far, with the slowest kinds of resources first
+// and the total teardown time. It is meant to be called at the end of the test suite (eg. in `TestMain`)
+func PrintTimingReport(out io.Writer) {
+	defaultManager.PrintTimingReport(out)
+}
+
+// PrintTimingReport prints a summary of the cleaning tasks performed so far by the manager (see the package-level `PrintTimingReport`)
+func (c *Manager) PrintTimingReport(out io.Writer) {
+	kinds, teardown := c.Timings()
+	if len(kinds) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "cleanup report: total teardown time %s\n", teardown.Round(time.Millisecond))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tCOUNT\tTOTAL\tAVERAGE\tMAX")
+	for _, k := range kinds {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", k.Kind, k.Count, k.Total.Round(time.Millisecond), k.Average().Round(time.Millisecond), k.Max.Round(time.Millisecond))
+	}
+	w.Flush()
+}
@@ -0,0 +1,92 @@
+package cleanup
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimings(t *testing.T) {
+
+	t.Run("no cleaning task", func(t *testing.T) {
+		// given
+		m := NewManager()
+
+		// when
+		kinds, teardown := m.Timings()
+
+		// then
+		assert.Empty(t, kinds)
+		assert.Zero(t, teardown)
+	})
+
+	t.Run("durations aggregated per kind and sorted by total duration", func(t *testing.T) {
+		// given
+		m := NewManager()
+		m.timings.recordTask("Namespace", 3*time.Second)
+		m.timings.recordTask("UserSignup", time.Second)
+		m.timings.recordTask("Namespace", 5*time.Second)
+		m.timings.recordTask("Namespace", time.Second)
+		m.timings.recordTask("Space", 2*time.Second)
+		m.timings.recordTask("UserSignup", 4*time.Second)
+		// same total as the `UserSignup` kind, hence sorted by kind
+		m.timings.recordTask("MasterUserRecord", 5*time.Second)
+		m.timings.recordTeardown(6 * time.Second)
+		m.timings.recordTeardown(4 * time.Second)
+
+		// when
+		kinds, teardown := m.Timings()
+
+		// then
+		assert.Equal(t, 10*time.Second, teardown)
+		assert.Equal(t, []KindTimings{
+			{Kind: "Namespace", Count: 3, Total: 9 * time.Second, Max: 5 * time.Second},
+			{Kind: "MasterUserRecord", Count: 1, Total: 5 * time.Second, Max: 5 * time.Second},
+			{Kind: "UserSignup", Count: 2, Total: 5 * time.Second, Max: 4 * time.Second},
+			{Kind: "Space", Count: 1, Total: 2 * time.Second, Max: 2 * time.Second},
+		}, kinds)
+		assert.Equal(t, 3*time.Second, kinds[0].Average())
+		assert.Equal(t, 2500*time.Millisecond, kinds[2].Average())
+	})
+
+	t.Run("average without cleaning task", func(t *testing.T) {
+		assert.Zero(t, KindTimings{Kind: "Namespace"}.Average())
+	})
+}
+
+func TestPrintTimingReport(t *testing.T) {
+
+	t.Run("nothing printed without cleaning task", func(t *testing.T) {
+		// given
+		m := NewManager()
+		out := &bytes.Buffer{}
+
+		// when
+		m.PrintTimingReport(out)
+
+		// then
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("slowest kinds first", func(t *testing.T) {
+		// given
+		m := NewManager()
+		m.timings.recordTask("Space", 1500*time.Millisecond)
+		m.timings.recordTask("Namespace", 3*time.Second)
+		m.timings.recordTask("Namespace", time.Second)
+		m.timings.recordTeardown(4 * time.Second)
+		out := &bytes.Buffer{}
+
+		// when
+		m.PrintTimingReport(out)
+
+		// then
+		require.Equal(t, "cleanup report: total teardown time 4s\n"+
+			"KIND       COUNT  TOTAL  AVERAGE  MAX\n"+
+			"Namespace  2      4s     2s       3s\n"+
+			"Space      1      1.5s   1.5s     1.5s\n", out.String())
+	})
+}
@@ -0,0 +1,140 @@
+package testsupport
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// CompatibilityModeVar the name of the env var to set to `enforce` in order to fail the tests when the versions of the operators
+// are not compatible with each other. By default, the versions are only recorded in the test logs.
+const CompatibilityModeVar = "E2E_COMPATIBILITY_MODE"
+
+// OperatorVersion the version of an operator, as reported in the ToolchainStatus
+type OperatorVersion struct {
+	Version        string
+	Revision       string
+	BuildTimestamp string
+}
+
+func (v OperatorVersion) String() string {
+	return fmt.Sprintf("version='%s' revision='%s' buildTimestamp='%s'", v.Version, v.Revision, v.BuildTimestamp)
+}
+
+// semver returns the major, minor and patch numbers of the version, or false if the version is not a semantic version
+// (eg, when the operator was built from a development revision)
+func (v OperatorVersion) semver() ([3]int, bool) {
+	result := [3]int{}
+	version := strings.SplitN(strings.TrimPrefix(v.Version, "v"), "-", 2)[0]
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return result, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return result, false
+		}
+		result[i] = n
+	}
+	return result, true
+}
+
+// AtLeast returns true if the version is greater than or equal to the given minimum semantic version (eg, `0.4.0`).
+// An operator built from a development revision is assumed to be at the latest version.
+func (v OperatorVersion) AtLeast(minVersion string) bool {
+	actual, ok := v.semver()
+	if !ok {
+		return true
+	}
+	expected, ok := OperatorVersion{Version: minVersion}.semver()
+	if !ok {
+		panic(fmt.Sprintf("invalid minimum version '%s'", minVersion))
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return actual[i] > expected[i]
+		}
+	}
+	return true
+}
+
+// OperatorVersions the versions of the host operator and of the member operators (by cluster name)
+type OperatorVersions struct {
+	Host    OperatorVersion
+	Members map[string]OperatorVersion
+}
+
+// GetOperatorVersions returns the versions of the operators, once they are reported in the ToolchainStatus
+func GetOperatorVersions(t *testing.T, awaitilities wait.Awaitilities) OperatorVersions {
+	toolchainStatus, err := awaitilities.Host().WaitForToolchainStatus(t, wait.UntilToolchainStatusHasConditions(ToolchainStatusReadyAndUnreadyNotificationNotCreated()...))
+	require.NoError(t, err)
+	require.NotNil(t, toolchainStatus.Status.HostOperator, "host operator status is missing in the ToolchainStatus")
+	versions := OperatorVersions{
+		Host: OperatorVersion{
+			Version:        toolchainStatus.Status.HostOperator.Version,
+			Revision:       toolchainStatus.Status.HostOperator.Revision,
+			BuildTimestamp: toolchainStatus.Status.HostOperator.BuildTimestamp,
+		},
+		Members: map[string]OperatorVersion{},
+	}
+	for _, m := range toolchainStatus.Status.Members {
+		versions.Members[m.ClusterName] = memberOperatorVersion(m)
+	}
+	return versions
+}
+
+func memberOperatorVersion(member toolchainv1alpha1.Member) OperatorVersion {
+	if member.MemberStatus.MemberOperator == nil {
+		return OperatorVersion{}
+	}
+	return OperatorVersion{
+		Version:        member.MemberStatus.MemberOperator.Version,
+		Revision:       member.MemberStatus.MemberOperator.Revision,
+		BuildTimestamp: member.MemberStatus.MemberOperator.BuildTimestamp,
+	}
+}
+
+// VerifyOperatorsCompatibility records the versions of the operators in the test logs and verifies that the member operators
+// have the same major and minor versions as the host operator. A mismatch fails the test only if the `E2E_COMPATIBILITY_MODE` env var
+// is set to `enforce`, otherwise it is only recorded. Operators built from a development revision are compatible with any other version.
+func VerifyOperatorsCompatibility(t *testing.T, awaitilities wait.Awaitilities) OperatorVersions {
+	versions := GetOperatorVersions(t, awaitilities)
+	t.Logf("host operator: %s", versions.Host)
+	enforce := os.Getenv(CompatibilityModeVar) == "enforce"
+	hostSemver, hostOK := versions.Host.semver()
+	for clusterName, memberVersion := range versions.Members {
+		t.Logf("member operator in cluster '%s': %s", clusterName, memberVersion)
+		memberSemver, memberOK := memberVersion.semver()
+		if !hostOK || !memberOK || (hostSemver[0] == memberSemver[0] && hostSemver[1] == memberSemver[1]) {
+			continue
+		}
+		msg := fmt.Sprintf("member operator in cluster '%s' at version '%s' is not compatible with host operator at version '%s'",
+			clusterName, memberVersion.Version, versions.Host.Version)
+		if enforce {
+			require.Fail(t, msg)
+		}
+		t.Logf("WARNING: %s", msg)
+	}
+	return versions
+}
+
+// SkipUnlessOperatorsAtLeast skips the test if the host operator or any of the member operators is older than the given minimum version
+// (eg, when a test covers a feature which is not available in older operators)
+func SkipUnlessOperatorsAtLeast(t *testing.T, awaitilities wait.Awaitilities, minVersion string) {
+	versions := GetOperatorVersions(t, awaitilities)
+	if !versions.Host.AtLeast(minVersion) {
+		t.Skipf("skipping test because host operator is older than version '%s': %s", minVersion, versions.Host)
+	}
+	for clusterName, memberVersion := range versions.Members {
+		if !memberVersion.AtLeast(minVersion) {
+			t.Skipf("skipping test because member operator in cluster '%s' is older than version '%s': %s", clusterName, minVersion, memberVersion)
+		}
+	}
+}
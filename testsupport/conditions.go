@@ -368,3 +368,12 @@ func TerminatingSpace() toolchainv1alpha1.Condition {
 		Reason: toolchainv1alpha1.SpaceTerminatingReason,
 	}
 }
+
+func SpaceProvisioningFailed(msg string) toolchainv1alpha1.Condition {
+	return toolchainv1alpha1.Condition{
+		Type:    toolchainv1alpha1.ConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  toolchainv1alpha1.SpaceProvisioningFailedReason,
+		Message: msg,
+	}
+}
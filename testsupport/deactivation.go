@@ -27,7 +27,8 @@ func DeactivateAndCheckUser(t *testing.T, awaitilities wait.Awaitilities, userSi
 	require.NoError(t, err)
 
 	// "deactivated"
-	notifications, err := hostAwait.WaitForNotifications(t, userSignup.Status.CompliantUsername, toolchainv1alpha1.NotificationTypeDeactivated, 1, wait.UntilNotificationHasConditions(Sent()))
+	notifications, err := hostAwait.WaitForNotifications(t, userSignup.Status.CompliantUsername, toolchainv1alpha1.NotificationTypeDeactivated, 1,
+		append(NotificationLinksCriteria(t, hostAwait), wait.UntilNotificationHasConditions(Sent()))...)
 	require.NoError(t, err)
 	require.NotEmpty(t, notifications)
 	require.Len(t, notifications, 1)
@@ -0,0 +1,158 @@
+package testsupport
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeactivationRace the deactivation of a user which raced with the creation of a SpaceRequest (and thus of a subSpace) in the Space of the user
+type DeactivationRace struct {
+	UserSignup *toolchainv1alpha1.UserSignup
+	// Space the Space of the user, ie, the parent Space of the subSpace
+	Space *toolchainv1alpha1.Space
+	// SpaceRequest the SpaceRequest, or nil if its creation was rejected (eg, because its namespace was already being terminated)
+	SpaceRequest *toolchainv1alpha1.SpaceRequest
+	memberAwait  *wait.MemberAwaitility
+}
+
+// RaceDeactivationWithSpaceRequest deactivates the given (provisioned) UserSignup and concurrently creates a SpaceRequest with the given options
+// in the default namespace of the Space of the user. A positive offset delays the creation of the SpaceRequest after the deactivation, and a
+// negative offset delays the deactivation after the creation of the SpaceRequest, so that the different interleavings can be covered.
+// The Space of the user must have a default namespace (eg, in the `appstudio` tier).
+func RaceDeactivationWithSpaceRequest(t *testing.T, awaitilities wait.Awaitilities, userSignup *toolchainv1alpha1.UserSignup, offset time.Duration, opts ...SpaceRequestOption) *DeactivationRace {
+	hostAwait := awaitilities.Host()
+	space, err := hostAwait.WaitForSpace(t, userSignup.Status.CompliantUsername, wait.UntilSpaceHasAnyProvisionedNamespaces())
+	require.NoError(t, err)
+	memberAwait := getSpaceTargetMember(t, awaitilities, space)
+	namespace := GetDefaultNamespace(space.Status.ProvisionedNamespaces)
+	require.NotEmpty(t, namespace, "Space '%s' has no default namespace in which the SpaceRequest can be created", space.Name)
+	spaceRequest := NewSpaceRequest(t, append(opts, InNamespace(namespace))...)
+
+	t.Logf("deactivating UserSignup '%s' while creating a SpaceRequest in namespace '%s' (offset: %s)", userSignup.Name, namespace, offset)
+	start := make(chan struct{})
+	var deactivationErr, creationErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		<-start
+		if offset < 0 {
+			time.Sleep(-offset)
+		}
+		_, deactivationErr = hostAwait.UpdateUserSignup(t, userSignup.Name, func(us *toolchainv1alpha1.UserSignup) {
+			states.SetDeactivated(us, true)
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		<-start
+		if offset > 0 {
+			time.Sleep(offset)
+		}
+		creationErr = memberAwait.CreateWithCleanup(t, spaceRequest)
+	}()
+	close(start)
+	wg.Wait()
+	require.NoError(t, deactivationErr)
+
+	race := &DeactivationRace{
+		UserSignup:  userSignup,
+		Space:       space,
+		memberAwait: memberAwait,
+	}
+	if creationErr != nil {
+		// the namespace of the SpaceRequest may already be terminating (or gone) when the deactivation wins the race
+		require.True(t, apierrors.IsForbidden(creationErr) || apierrors.IsNotFound(creationErr), "unexpected error while creating the SpaceRequest: %v", creationErr)
+		t.Logf("the creation of the SpaceRequest was rejected: %s", creationErr.Error())
+		return race
+	}
+	race.SpaceRequest = spaceRequest
+	t.Logf("SpaceRequest '%s' created in namespace '%s'", spaceRequest.Name, namespace)
+	return race
+}
+
+// VerifyDeactivationRaceConverged verifies that the race between the deactivation and the creation of the SpaceRequest ended up
+// with all the resources of the user being cleaned up: the MasterUserRecord, the Space and the SpaceBindings in the host cluster,
+// and no orphaned subSpace (nor its NSTemplateSet and namespaces) nor SpaceRequest left behind in any cluster
+func VerifyDeactivationRaceConverged(t *testing.T, awaitilities wait.Awaitilities, race *DeactivationRace) {
+	hostAwait := awaitilities.Host().WithRetryOptions(wait.TimeoutOption(2 * awaitilities.Host().Timeout))
+	memberAwait := race.memberAwait
+	username := race.UserSignup.Status.CompliantUsername
+
+	_, err := hostAwait.WaitForUserSignup(t, race.UserSignup.Name,
+		wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueDeactivated))
+	require.NoError(t, err)
+	require.NoError(t, hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, username))
+
+	// the subSpaces may be created (and deleted) until the namespace of the SpaceRequest is gone, so the names of all
+	// the subSpaces which were seen while waiting are recorded to verify their resources in the member cluster afterwards
+	subSpaces := map[string]bool{}
+	t.Logf("waiting until the subSpaces of Space '%s' are deleted", race.Space.Name)
+	err = hostAwait.Poll(func() (done bool, err error) {
+		spaces := &toolchainv1alpha1.SpaceList{}
+		if err := hostAwait.Client.List(context.TODO(), spaces, client.InNamespace(hostAwait.Namespace),
+			client.MatchingLabels{toolchainv1alpha1.ParentSpaceLabelKey: race.Space.Name}); err != nil {
+			return false, err
+		}
+		for _, s := range spaces.Items {
+			subSpaces[s.Name] = true
+		}
+		return len(spaces.Items) == 0, nil
+	})
+	require.NoError(t, err, "orphaned subSpaces of Space '%s': %v", race.Space.Name, sortedKeys(subSpaces))
+	require.NoError(t, hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(t, race.Space.Name))
+
+	for _, name := range append([]string{race.Space.Name}, sortedKeys(subSpaces)...) {
+		require.NoError(t, memberAwait.WaitUntilNSTemplateSetDeleted(t, name), "orphaned NSTemplateSet '%s'", name)
+		waitUntilNamespacesOfSpaceDeleted(t, memberAwait, name)
+	}
+	if race.SpaceRequest != nil {
+		err = memberAwait.PollWithTimeout(2*memberAwait.Timeout, func() (done bool, err error) {
+			err = memberAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: race.SpaceRequest.Namespace, Name: race.SpaceRequest.Name}, &toolchainv1alpha1.SpaceRequest{})
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+		require.NoError(t, err, "orphaned SpaceRequest '%s' in namespace '%s'", race.SpaceRequest.Name, race.SpaceRequest.Namespace)
+	}
+}
+
+// waitUntilNamespacesOfSpaceDeleted waits until there is no namespace owned by the Space with the given name in the member cluster
+func waitUntilNamespacesOfSpaceDeleted(t *testing.T, memberAwait *wait.MemberAwaitility, spaceName string) {
+	t.Logf("waiting until the namespaces of Space '%s' are deleted", spaceName)
+	var remaining []string
+	err := memberAwait.PollWithTimeout(2*memberAwait.Timeout, func() (done bool, err error) {
+		namespaces := &corev1.NamespaceList{}
+		if err := memberAwait.Client.List(context.TODO(), namespaces, client.MatchingLabels{toolchainv1alpha1.OwnerLabelKey: spaceName}); err != nil {
+			return false, err
+		}
+		remaining = make([]string, 0, len(namespaces.Items))
+		for _, ns := range namespaces.Items {
+			remaining = append(remaining, ns.Name)
+		}
+		return len(remaining) == 0, nil
+	})
+	require.NoError(t, err, "orphaned namespaces of Space '%s': %v", spaceName, remaining)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
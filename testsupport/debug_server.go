@@ -0,0 +1,126 @@
+package testsupport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+// DebugAddrVar the env var with the address on which the debug endpoint listens while the suite is running (eg, `localhost:8899`).
+// The endpoint is not started if the env var is not set.
+const DebugAddrVar = "E2E_DEBUG_ADDR"
+
+// DebugState the state of the suite exposed by the debug endpoint
+type DebugState struct {
+	// Time the time at which the state was captured
+	Time time.Time `json:"time"`
+	// Waiters the waits in progress
+	Waiters []wait.PendingWaiter `json:"waiters"`
+	// Cleanups the cleaning tasks registered by the tests, which are not completed yet (ie, the resources created by the tests
+	// which are not deleted yet)
+	Cleanups []cleanup.PendingCleanTask `json:"cleanups"`
+	// Tests the tests in progress, with the resources which they waited for or cleaned up so far
+	Tests []report.TestResult `json:"tests"`
+}
+
+// debugSources the registries of the waits and the cleanup managers given to the awaitilities which do not use the default ones,
+// so that their waits in progress and pending cleaning tasks are also exposed by the debug endpoint (see RegisterDebugSources)
+var debugSources = struct {
+	sync.Mutex
+	waiterRegistries map[*wait.WaiterRegistry]struct{}
+	cleanupManagers  map[*cleanup.Manager]struct{}
+}{
+	waiterRegistries: map[*wait.WaiterRegistry]struct{}{},
+	cleanupManagers:  map[*cleanup.Manager]struct{}{},
+}
+
+// RegisterDebugSources registers the waiter registry and the cleanup manager of the given awaitility (if it was given its own ones),
+// so that its waits in progress and its pending cleaning tasks are exposed by the debug endpoint along with the default ones
+func RegisterDebugSources(a *wait.Awaitility) {
+	debugSources.Lock()
+	defer debugSources.Unlock()
+	if a.WaiterRegistry != nil {
+		debugSources.waiterRegistries[a.WaiterRegistry] = struct{}{}
+	}
+	if a.CleanupManager != nil {
+		debugSources.cleanupManagers[a.CleanupManager] = struct{}{}
+	}
+}
+
+// CurrentDebugState returns the current state of the suite: the pending waits and the pending cleaning tasks (of the default registry
+// and manager, and of the ones registered with RegisterDebugSources) and the tests in progress
+func CurrentDebugState() DebugState {
+	waiters := wait.PendingWaiters()
+	cleanups := cleanup.PendingCleanTasks()
+	debugSources.Lock()
+	for r := range debugSources.waiterRegistries {
+		waiters = append(waiters, r.PendingWaiters()...)
+	}
+	for m := range debugSources.cleanupManagers {
+		cleanups = append(cleanups, m.PendingCleanTasks()...)
+	}
+	debugSources.Unlock()
+	sort.SliceStable(waiters, func(i, j int) bool {
+		return waiters[i].Since.Before(waiters[j].Since)
+	})
+	sort.SliceStable(cleanups, func(i, j int) bool {
+		return cleanups[i].Added.Before(cleanups[j].Added)
+	})
+	return DebugState{
+		Time:     time.Now(),
+		Waiters:  waiters,
+		Cleanups: cleanups,
+		Tests:    report.InProgress(),
+	}
+}
+
+// StartDebugServer starts the debug endpoint on the address set in the `E2E_DEBUG_ADDR` env var, which returns the current state of
+// the suite as JSON on `GET /debug/state` (see CurrentDebugState), so that a hung suite can be inspected without killing it.
+// It is meant to be called at the beginning of the test suite (eg. in `TestMain`), and returns a function to stop the endpoint
+// at the end of the suite. Does nothing if the env var is not set.
+func StartDebugServer() (func(), error) {
+	addr := os.Getenv(DebugAddrVar)
+	if addr == "" {
+		return func() {}, nil
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start the debug endpoint on '%s': %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/state", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(CurrentDebugState())
+	})
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "debug endpoint stopped: %v\n", err)
+		}
+	}()
+	fmt.Fprintf(os.Stdout, "debug endpoint listening on http://%s/debug/state\n", listener.Addr())
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}, nil
+}
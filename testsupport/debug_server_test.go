@@ -0,0 +1,55 @@
+package testsupport_test
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCurrentDebugState(t *testing.T) {
+	// given
+	hostAwait := wait.NewHostAwaitility(nil, commontest.NewFakeClient(t), commontest.HostOperatorNs, "registration-service")
+	hostAwait.WaiterRegistry = wait.NewWaiterRegistry()
+	hostAwait.CleanupManager = cleanup.NewManager()
+	hostAwait.RetryInterval = time.Millisecond
+	hostAwait.Timeout = time.Second
+	testsupport.RegisterDebugSources(hostAwait.Awaitility)
+	defer hostAwait.CleanupManager.ExecuteAllCleanTasks(t)
+
+	// when
+	err := hostAwait.CreateWithCleanup(t, &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "johnsmith", Namespace: commontest.HostOperatorNs},
+	})
+	require.NoError(t, err)
+	// the MasterUserRecord never exists, so the wait is in progress until it times out
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = hostAwait.WaitForMasterUserRecord(t, "johnsmith")
+	}()
+
+	// then
+	assert.Eventually(t, func() bool {
+		for _, w := range testsupport.CurrentDebugState().Waiters {
+			if w.Kind == "MasterUserRecord" && w.Name == "johnsmith" {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond, "the wait of the awaitility with its own registry is not in the debug state")
+	cleanups := testsupport.CurrentDebugState().Cleanups
+	found := false
+	for _, c := range cleanups {
+		found = found || (c.Kind == "Space" && c.Name == "johnsmith")
+	}
+	assert.True(t, found, "the cleaning task of the awaitility with its own manager is not in the debug state: %v", cleanups)
+	<-done
+}
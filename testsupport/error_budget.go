@@ -0,0 +1,28 @@
+package testsupport
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+)
+
+// ErrorBudgetVar the env var with the max number of transient errors (conflicts, 429 and 5xx responses, transport errors) which can be
+// retried during the run of a suite before it is failed (eg, `50`). There is no limit if the env var is not set.
+const ErrorBudgetVar = "E2E_ERROR_BUDGET"
+
+// CheckErrorBudget prints the number of transient errors which were observed and retried by the tests of the suite (by kind and source,
+// and by test), and returns an error if their total exceeds the budget set with the `E2E_ERROR_BUDGET` env var. It is meant to be called
+// at the end of the test suite (eg. in `TestMain`), so that the silent retries become a signal of the health of the clusters.
+func CheckErrorBudget(out io.Writer, suiteName string) error {
+	budget := -1
+	if value, found := os.LookupEnv(ErrorBudgetVar); found {
+		var err error
+		if budget, err = strconv.Atoi(value); err != nil || budget < 0 {
+			return fmt.Errorf("invalid value of '%s': '%s'", ErrorBudgetVar, value)
+		}
+	}
+	return report.Results(suiteName).CheckErrorBudget(out, budget)
+}
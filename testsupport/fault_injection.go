@@ -0,0 +1,118 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Fault an edge state which a provisioned UserSignup can be forced into, by removing one of its resources or by resetting
+// a field of the status read by the host operator, behind the back of the host operator
+type Fault string
+
+const (
+	// MissingMasterUserRecord the MasterUserRecord of the UserSignup is missing
+	MissingMasterUserRecord Fault = "missing-masteruserrecord"
+	// MissingSpace the Space of the UserSignup is missing
+	MissingSpace Fault = "missing-space"
+	// OrphanedSpace the SpaceBinding between the MasterUserRecord and the Space of the UserSignup is missing
+	OrphanedSpace Fault = "orphaned-space"
+	// LostCompliantUsername the UserSignup has no compliant username (nor conditions) in its status, ie, the UserSignup controller
+	// does not know which MasterUserRecord it provisioned
+	LostCompliantUsername Fault = "lost-compliant-username"
+	// LostSpaceTargetCluster the Space has no target cluster in its status, ie, the Space controller does not know on which
+	// cluster the NSTemplateSet was provisioned
+	LostSpaceTargetCluster Fault = "lost-space-target-cluster"
+)
+
+// InjectedFault a fault which was injected for a given UserSignup
+type InjectedFault struct {
+	Fault      Fault
+	UserSignup *toolchainv1alpha1.UserSignup
+	// the UID of the resource which was removed, to verify that it is recreated
+	removedUID types.UID
+	// the value of the status field which was reset, to verify that it is set again
+	resetValue string
+}
+
+// InjectFault forces the given (provisioned) UserSignup into the edge state corresponding to the given fault, and verifies
+// that the edge state was reached (ie, that the resource was deleted, or that the status field was reset)
+func InjectFault(t *testing.T, hostAwait *wait.HostAwaitility, userSignup *toolchainv1alpha1.UserSignup, fault Fault) *InjectedFault {
+	t.Logf("injecting fault '%s' for UserSignup '%s'", fault, userSignup.Name)
+	mur, err := hostAwait.WaitForMasterUserRecord(t, userSignup.Status.CompliantUsername)
+	require.NoError(t, err)
+	injected := &InjectedFault{
+		Fault:      fault,
+		UserSignup: userSignup,
+	}
+	switch fault {
+	case MissingMasterUserRecord:
+		injected.removedUID = mur.UID
+		err = hostAwait.Client.Delete(context.TODO(), mur)
+		require.NoError(t, err)
+	case MissingSpace:
+		space, err := hostAwait.WaitForSpace(t, mur.Name)
+		require.NoError(t, err)
+		injected.removedUID = space.UID
+		err = hostAwait.Client.Delete(context.TODO(), space)
+		require.NoError(t, err)
+	case OrphanedSpace:
+		spaceBinding, err := hostAwait.WaitForSpaceBinding(t, mur.Name, mur.Name)
+		require.NoError(t, err)
+		injected.removedUID = spaceBinding.UID
+		err = hostAwait.Client.Delete(context.TODO(), spaceBinding)
+		require.NoError(t, err)
+	case LostCompliantUsername:
+		injected.resetValue = userSignup.Status.CompliantUsername
+		reset, err := wait.UpdateStatus(t, hostAwait.Awaitility, userSignup.Name, func(us *toolchainv1alpha1.UserSignup) {
+			us.Status.CompliantUsername = ""
+			us.Status.Conditions = nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, reset.Status.CompliantUsername)
+	case LostSpaceTargetCluster:
+		space, err := hostAwait.WaitForSpace(t, mur.Name, wait.UntilSpaceHasAnyTargetClusterSet())
+		require.NoError(t, err)
+		injected.resetValue = space.Spec.TargetCluster
+		reset, err := wait.UpdateStatus(t, hostAwait.Awaitility, space.Name, func(s *toolchainv1alpha1.Space) {
+			s.Status.TargetCluster = ""
+		})
+		require.NoError(t, err)
+		assert.Empty(t, reset.Status.TargetCluster)
+	default:
+		require.FailNowf(t, "unknown fault", "cannot inject fault '%s'", fault)
+	}
+	return injected
+}
+
+// WaitUntilSelfHealed waits until the host operator recreated the resource that was removed (or set again the status field that was reset)
+// when the fault was injected, and then verifies that all the resources of the UserSignup are provisioned again
+func (f *InjectedFault) WaitUntilSelfHealed(t *testing.T, awaitilities wait.Awaitilities, userTierName, spaceTierName string) {
+	hostAwait := awaitilities.Host()
+	murName := f.UserSignup.Status.CompliantUsername
+	var err error
+	switch f.Fault {
+	case MissingMasterUserRecord:
+		_, err = hostAwait.WaitForMasterUserRecord(t, murName, wait.UntilMasterUserRecordIsRecreated(f.removedUID))
+	case MissingSpace:
+		_, err = hostAwait.WaitForSpace(t, murName, wait.UntilSpaceIsRecreated(f.removedUID))
+	case OrphanedSpace:
+		_, err = hostAwait.WaitForSpaceBinding(t, murName, murName, wait.UntilSpaceBindingIsRecreated(f.removedUID))
+	case LostCompliantUsername:
+		var userSignup *toolchainv1alpha1.UserSignup
+		userSignup, err = hostAwait.WaitForUserSignup(t, f.UserSignup.Name, wait.UntilUserSignupHasCompliantUsername())
+		if err == nil {
+			// the UserSignup must be reconnected to its MasterUserRecord, not provisioned with a new one
+			assert.Equal(t, f.resetValue, userSignup.Status.CompliantUsername)
+		}
+	case LostSpaceTargetCluster:
+		_, err = hostAwait.WaitForSpace(t, murName, wait.UntilSpaceHasStatusTargetCluster(f.resetValue))
+	}
+	require.NoError(t, err, "the host operator did not recover from the fault '%s'", f.Fault)
+	VerifyResourcesProvisionedForSignup(t, awaitilities, f.UserSignup, userTierName, spaceTierName)
+}
@@ -0,0 +1,85 @@
+package faultproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/rest"
+)
+
+// toxic a fault of the proxy (see https://github.com/Shopify/toxiproxy#toxics)
+type toxic struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Stream the direction of the data affected by the toxic: `upstream` (to the API server) or `downstream` (to the client)
+	Stream     string           `json:"stream"`
+	Toxicity   float64          `json:"toxicity"`
+	Attributes map[string]int64 `json:"attributes"`
+}
+
+// apiClient a client of the HTTP API of the proxy, which is accessed via the proxy of the API server to its service,
+// so that the API does not need to be exposed outside of the cluster
+type apiClient struct {
+	restClient rest.Interface
+	namespace  string
+	service    string
+}
+
+func newAPIClient(restClient rest.Interface, namespace, service string) *apiClient {
+	return &apiClient{
+		restClient: restClient,
+		namespace:  namespace,
+		service:    service,
+	}
+}
+
+// createProxy creates a proxy with the given name, which listens on the given address and forwards the connections to the upstream address
+func (c *apiClient) createProxy(name, listen, upstream string) error {
+	return c.post("/proxies", map[string]interface{}{
+		"name":     name,
+		"listen":   listen,
+		"upstream": upstream,
+		"enabled":  true,
+	})
+}
+
+// addToxic adds the given toxic to the proxy with the given name
+func (c *apiClient) addToxic(proxy string, t toxic) error {
+	return c.post(fmt.Sprintf("/proxies/%s/toxics", proxy), t)
+}
+
+// setEnabled enables or disables the proxy with the given name. A disabled proxy closes all its connections and does not listen anymore.
+func (c *apiClient) setEnabled(proxy string, enabled bool) error {
+	return c.post(fmt.Sprintf("/proxies/%s", proxy), map[string]interface{}{
+		"enabled": enabled,
+	})
+}
+
+// reset enables all the proxies and removes all their toxics
+func (c *apiClient) reset() error {
+	return c.post("/reset", nil)
+}
+
+func (c *apiClient) post(path string, body interface{}) error {
+	data := []byte{}
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	result := c.restClient.Post().
+		Namespace(c.namespace).
+		Resource("services").
+		Name(fmt.Sprintf("http:%s:%d", c.service, APIPort)).
+		SubResource("proxy").
+		Suffix(path).
+		Body(data).
+		Do(context.TODO())
+	if err := result.Error(); err != nil {
+		raw, _ := result.Raw()
+		return fmt.Errorf("request 'POST %s' to fault proxy '%s' failed: %w (%s)", path, c.service, err, string(raw))
+	}
+	return nil
+}
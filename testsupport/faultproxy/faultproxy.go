@@ -0,0 +1,263 @@
+package faultproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// APIPort the port on which the API of the proxy (used to inject the faults) listens
+	APIPort = 8474
+	// ListenPort the port on which the proxy listens for the connections to the member cluster
+	ListenPort = 8666
+
+	image     = "ghcr.io/shopify/toxiproxy:2.5.0"
+	proxyName = "member-api"
+)
+
+// FaultProxy a TCP proxy deployed in the host cluster, through which the ToolchainCluster of a member cluster can be routed
+// (see Route) so that faults can be injected in the connections of the host operator to the API server of the member cluster
+type FaultProxy struct {
+	Name string
+	// ToolchainCluster the name of the ToolchainCluster (in the host cluster) of the member cluster
+	ToolchainCluster string
+	// Upstream the address (host:port) of the API server of the member cluster
+	Upstream  string
+	hostAwait *wait.HostAwaitility
+	api       *apiClient
+}
+
+// Deploy deploys a fault proxy in the namespace of the host operator, which forwards the connections to the API endpoint of the
+// ToolchainCluster of the given member cluster. Initially, no fault is injected and the ToolchainCluster is not routed through
+// the proxy. The proxy is deleted at the end of the test.
+func Deploy(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility) *FaultProxy {
+	toolchainCluster, found, err := hostAwait.GetToolchainCluster(t, cluster.Member, memberAwait.Namespace, nil)
+	require.NoError(t, err)
+	require.True(t, found, "no ToolchainCluster for member cluster '%s'", memberAwait.ClusterName)
+	upstream, err := UpstreamAddress(toolchainCluster.Spec.APIEndpoint)
+	require.NoError(t, err)
+
+	name := fmt.Sprintf("fault-proxy-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	t.Logf("deploying fault proxy '%s' to '%s' in namespace '%s'", name, upstream, hostAwait.Namespace)
+	require.NoError(t, hostAwait.CreateWithCleanup(t, newDeployment(hostAwait.Namespace, name)))
+	require.NoError(t, hostAwait.CreateWithCleanup(t, newService(hostAwait.Namespace, name)))
+	hostAwait.WaitForDeploymentToGetReady(t, name, 1)
+
+	clientset, err := kubernetes.NewForConfig(hostAwait.RestConfig)
+	require.NoError(t, err)
+	p := &FaultProxy{
+		Name:             name,
+		ToolchainCluster: toolchainCluster.Name,
+		Upstream:         upstream,
+		hostAwait:        hostAwait,
+		api:              newAPIClient(clientset.CoreV1().RESTClient(), hostAwait.Namespace, name),
+	}
+	// the API of the proxy may not be reachable via the service right after the pod is ready
+	err = hostAwait.Poll(func() (done bool, err error) {
+		if err := p.api.createProxy(proxyName, fmt.Sprintf("0.0.0.0:%d", ListenPort), upstream); err != nil {
+			t.Logf("cannot create the proxy to '%s' yet: %s", upstream, err.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+	require.NoError(t, err, "cannot configure fault proxy '%s'", name)
+	return p
+}
+
+// Endpoint returns the API endpoint of the member cluster via the proxy, ie, the address of the service of the proxy
+func (p *FaultProxy) Endpoint() string {
+	return fmt.Sprintf("https://%s.%s.svc:%d", p.Name, p.hostAwait.Namespace, ListenPort)
+}
+
+// Route routes the ToolchainCluster of the member cluster through the proxy and waits until it is ready again. Since the certificate
+// of the API server of the member cluster is not issued for the hostname of the proxy, the CA bundle of the ToolchainCluster is
+// removed and all the TLS validations are disabled. The original endpoint, CA bundle and TLS validations are restored at the end of the test.
+func (p *FaultProxy) Route(t *testing.T) {
+	toolchainCluster := &toolchainv1alpha1.ToolchainCluster{}
+	err := p.hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: p.hostAwait.Namespace, Name: p.ToolchainCluster}, toolchainCluster)
+	require.NoError(t, err)
+	originalEndpoint := toolchainCluster.Spec.APIEndpoint
+	originalCABundle := toolchainCluster.Spec.CABundle
+	originalDisabledTLSValidations := toolchainCluster.Spec.DisabledTLSValidations
+
+	t.Logf("routing ToolchainCluster '%s' through fault proxy '%s'", toolchainCluster.Name, p.Name)
+	p.updateToolchainCluster(t, toolchainCluster.Name, p.Endpoint(), "", []toolchainv1alpha1.TLSValidation{toolchainv1alpha1.TLSAll})
+	t.Cleanup(func() {
+		t.Logf("restoring the API endpoint of ToolchainCluster '%s'", toolchainCluster.Name)
+		p.updateToolchainCluster(t, toolchainCluster.Name, originalEndpoint, originalCABundle, originalDisabledTLSValidations)
+	})
+}
+
+// updateToolchainCluster sets the given API endpoint, CA bundle and disabled TLS validations in the ToolchainCluster,
+// and waits until it is ready again
+func (p *FaultProxy) updateToolchainCluster(t *testing.T, name, endpoint, caBundle string, disabledTLSValidations []toolchainv1alpha1.TLSValidation) {
+	updatedAt := time.Now()
+	_, err := p.hostAwait.UpdateToolchainCluster(t, name, func(tc *toolchainv1alpha1.ToolchainCluster) {
+		tc.Spec.APIEndpoint = endpoint
+		tc.Spec.CABundle = caBundle
+		tc.Spec.DisabledTLSValidations = disabledTLSValidations
+	})
+	require.NoError(t, err)
+	_, err = p.hostAwait.WithRetryOptions(wait.TimeoutOption(wait.ToolchainClusterConditionTimeout)).WaitForToolchainCluster(t,
+		wait.UntilToolchainClusterHasName(name),
+		wait.UntilToolchainClusterHasReadyConditionProbedAfter(updatedAt))
+	require.NoError(t, err, "ToolchainCluster '%s' is not ready with API endpoint '%s'", name, endpoint)
+}
+
+// AddLatency delays the data sent by the API server of the member cluster by the given latency (with the given jitter)
+func (p *FaultProxy) AddLatency(t *testing.T, latency, jitter time.Duration) {
+	t.Logf("adding a latency of %s (+/- %s) to fault proxy '%s'", latency, jitter, p.Name)
+	err := p.api.addToxic(proxyName, toxic{
+		Name:     "latency",
+		Type:     "latency",
+		Stream:   "downstream",
+		Toxicity: 1,
+		Attributes: map[string]int64{
+			"latency": latency.Milliseconds(),
+			"jitter":  jitter.Milliseconds(),
+		},
+	})
+	require.NoError(t, err)
+}
+
+// Blackhole stops forwarding the data between the host operator and the API server of the member cluster, without closing
+// the connections, ie, the requests of the host operator hang until they time out (as when the packets are dropped)
+func (p *FaultProxy) Blackhole(t *testing.T) {
+	t.Logf("dropping all the data in fault proxy '%s'", p.Name)
+	err := p.api.addToxic(proxyName, toxic{
+		Name:     "blackhole",
+		Type:     "timeout",
+		Stream:   "upstream",
+		Toxicity: 1,
+		Attributes: map[string]int64{
+			"timeout": 0, // the data is dropped until the toxic is removed
+		},
+	})
+	require.NoError(t, err)
+}
+
+// Disconnect closes all the connections and refuses the new ones (as when the member cluster is down)
+func (p *FaultProxy) Disconnect(t *testing.T) {
+	t.Logf("disconnecting fault proxy '%s'", p.Name)
+	require.NoError(t, p.api.setEnabled(proxyName, false))
+}
+
+// Heal removes all the faults, ie, the connections are forwarded to the API server of the member cluster without any delay
+func (p *FaultProxy) Heal(t *testing.T) {
+	t.Logf("removing all the faults of fault proxy '%s'", p.Name)
+	require.NoError(t, p.api.reset())
+}
+
+// UpstreamAddress returns the address (host:port) of the given API endpoint of a ToolchainCluster, which can be a URL,
+// a hostname, hostname:port, IP or IP:port (the default port is 443)
+func UpstreamAddress(apiEndpoint string) (string, error) {
+	hostPort := apiEndpoint
+	if u, err := url.Parse(apiEndpoint); err == nil && u.Host != "" {
+		hostPort = u.Host
+	}
+	if hostPort == "" {
+		return "", fmt.Errorf("invalid API endpoint '%s'", apiEndpoint)
+	}
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		return net.JoinHostPort(hostPort, "443"), nil
+	}
+	return hostPort, nil
+}
+
+func newDeployment(namespace, name string) *appsv1.Deployment {
+	replicas := int32(1)
+	zero := int64(0)
+	runAsNonRoot := true
+	return &appsv1.Deployment{
+		ObjectMeta: newObjectMeta(namespace, name),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": name},
+				},
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: &zero,
+					Containers: []corev1.Container{{
+						Name:  "toxiproxy",
+						Image: image,
+						Args:  []string{"-host=0.0.0.0", "-port=" + strconv.Itoa(APIPort)},
+						Ports: []corev1.ContainerPort{
+							{Name: "api", ContainerPort: APIPort, Protocol: corev1.ProtocolTCP},
+							{Name: "proxy", ContainerPort: ListenPort, Protocol: corev1.ProtocolTCP},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/version", Port: intstr.FromInt(APIPort)},
+							},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("10m"),
+								corev1.ResourceMemory: resource.MustParse("32Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("200m"),
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: new(bool),
+							RunAsNonRoot:             &runAsNonRoot,
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+							SeccompProfile: &corev1.SeccompProfile{
+								Type: corev1.SeccompProfileTypeRuntimeDefault,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func newService(namespace, name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: newObjectMeta(namespace, name),
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports: []corev1.ServicePort{
+				{Name: "api", Port: APIPort, TargetPort: intstr.FromInt(APIPort), Protocol: corev1.ProtocolTCP},
+				{Name: "proxy", Port: ListenPort, TargetPort: intstr.FromInt(ListenPort), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+func newObjectMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		Labels: map[string]string{
+			"app": name,
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package faultproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestUpstreamAddress(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"https://api.member.example.com:6443": "api.member.example.com:6443",
+		"https://api.member.example.com":      "api.member.example.com:443",
+		"api.member.example.com:6443":         "api.member.example.com:6443",
+		"api.member.example.com":              "api.member.example.com:443",
+		"https://10.0.0.1:6443":               "10.0.0.1:6443",
+		"10.0.0.1":                            "10.0.0.1:443",
+	} {
+		t.Run(endpoint, func(t *testing.T) {
+			// when
+			actual, err := UpstreamAddress(endpoint)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("empty endpoint", func(t *testing.T) {
+		// when
+		_, err := UpstreamAddress("")
+
+		// then
+		require.EqualError(t, err, "invalid API endpoint ''")
+	})
+}
+
+func TestAPIClient(t *testing.T) {
+	// given
+	requests := map[string]string{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests[r.Method+" "+r.URL.Path] = string(body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	c := newAPIClient(clientset.CoreV1().RESTClient(), "toolchain-host-operator", "fault-proxy-abcd")
+	prefix := "POST /api/v1/namespaces/toolchain-host-operator/services/http:fault-proxy-abcd:8474/proxy"
+
+	t.Run("create proxy", func(t *testing.T) {
+		// when
+		err := c.createProxy("member-api", "0.0.0.0:8666", "api.member.example.com:6443")
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"member-api","listen":"0.0.0.0:8666","upstream":"api.member.example.com:6443","enabled":true}`, requests[prefix+"/proxies"])
+	})
+
+	t.Run("add toxic", func(t *testing.T) {
+		// when
+		err := c.addToxic("member-api", toxic{Name: "latency", Type: "latency", Stream: "downstream", Toxicity: 1, Attributes: map[string]int64{"latency": 1000}})
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"latency","type":"latency","stream":"downstream","toxicity":1,"attributes":{"latency":1000}}`, requests[prefix+"/proxies/member-api/toxics"])
+	})
+
+	t.Run("disable proxy", func(t *testing.T) {
+		// when
+		err := c.setEnabled("member-api", false)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"enabled":false}`, requests[prefix+"/proxies/member-api"])
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		// when
+		err := c.reset()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, requests, prefix+"/reset")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		// given
+		status = http.StatusConflict
+		defer func() {
+			status = http.StatusOK
+		}()
+
+		// when
+		err := c.createProxy("member-api", "0.0.0.0:8666", "api.member.example.com:6443")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request 'POST /proxies' to fault proxy 'fault-proxy-abcd' failed")
+	})
+}
@@ -0,0 +1,149 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// User a provisioned user (UserSignup, MasterUserRecord and Space) shared by all the tests asking for the same fixture.
+// The tests must not modify the resources of this user, and should provision their own user otherwise.
+type User struct {
+	UserSignup *toolchainv1alpha1.UserSignup
+	MUR        *toolchainv1alpha1.MasterUserRecord
+	// Space is nil if the user was provisioned without space
+	Space *toolchainv1alpha1.Space
+	// Token the token to use to call the registration service and the proxy on behalf of the user
+	Token string
+	// Member the awaitility of the member cluster where the user is provisioned
+	Member *wait.MemberAwaitility
+}
+
+// UserOption an option to configure the user fixture
+type UserOption func(*userOptions)
+
+type userOptions struct {
+	name    string
+	member  *wait.MemberAwaitility
+	noSpace bool
+}
+
+// Named sets the name of the fixture, for the tests which need more than one shared user
+func Named(name string) UserOption {
+	return func(o *userOptions) {
+		o.name = name
+	}
+}
+
+// OnMember sets the member cluster where the user is provisioned (the first member cluster by default)
+func OnMember(member *wait.MemberAwaitility) UserOption {
+	return func(o *userOptions) {
+		o.member = member
+	}
+}
+
+// WithoutSpace provisions the user without Space
+func WithoutSpace() UserOption {
+	return func(o *userOptions) {
+		o.noSpace = true
+	}
+}
+
+type userEntry struct {
+	once   sync.Once
+	user   *User
+	client client.Client
+}
+
+var users = struct {
+	sync.Mutex
+	entries map[string]*userEntry
+}{
+	entries: map[string]*userEntry{},
+}
+
+// ProvisionedUser returns the user matching the given options. The user is provisioned by the first test asking for it,
+// and then it is reused by all the other tests (including the ones running in parallel) asking for the same options.
+// Since the user outlives the test which provisioned it, it is not deleted by the cleanup of this test but by Teardown.
+func ProvisionedUser(t *testing.T, awaitilities wait.Awaitilities, opts ...UserOption) *User {
+	options := &userOptions{
+		name:   "default",
+		member: awaitilities.Member1(),
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+	key := fmt.Sprintf("%s/%s/%t", options.name, options.member.ClusterName, options.noSpace)
+
+	users.Lock()
+	entry, found := users.entries[key]
+	if !found {
+		entry = &userEntry{
+			client: awaitilities.Host().Client,
+		}
+		users.entries[key] = entry
+	}
+	users.Unlock()
+
+	entry.once.Do(func() {
+		entry.user = provisionUser(t, awaitilities, options)
+	})
+	require.NotNil(t, entry.user, "failed to provision the user fixture '%s', see the logs of the test which provisioned it", key)
+	return entry.user
+}
+
+func provisionUser(t *testing.T, awaitilities wait.Awaitilities, options *userOptions) *User {
+	t.Logf("provisioning user fixture '%s' on member cluster '%s'", options.name, options.member.ClusterName)
+	request := testsupport.NewSignupRequest(awaitilities).
+		Username(fmt.Sprintf("fixture-%s-%s", options.name, rand.String(5))).
+		ManuallyApprove().
+		TargetCluster(options.member).
+		EnsureMUR().
+		RequireConditions(testsupport.ConditionSet(testsupport.Default(), testsupport.ApprovedByAdmin())...).
+		DisableCleanup()
+	if options.noSpace {
+		request = request.NoSpace()
+	}
+	request.Execute(t)
+	userSignup, mur := request.Resources()
+
+	user := &User{
+		UserSignup: userSignup,
+		MUR:        mur,
+		Token:      request.GetToken(),
+		Member:     options.member,
+	}
+	if !options.noSpace {
+		space, err := awaitilities.Host().WaitForSpace(t, mur.Name, wait.UntilSpaceHasAnyProvisionedNamespaces())
+		require.NoError(t, err)
+		user.Space = space
+	}
+	return user
+}
+
+// Teardown deletes all the users provisioned as fixtures. It is meant to be called once all the tests completed,
+// eg. from the `TestMain` function of the test package.
+func Teardown() error {
+	users.Lock()
+	defer users.Unlock()
+	for key, entry := range users.entries {
+		if entry.user == nil {
+			continue
+		}
+		if err := entry.client.Delete(context.TODO(), entry.user.UserSignup, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete the UserSignup of user fixture '%s': %w", key, err)
+		}
+		delete(users.entries, key)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package fixtures
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// UserPoolSizeVar the env var to set to change the number of users in the pool
+	UserPoolSizeVar = "E2E_USER_POOL_SIZE"
+	// DefaultUserPoolSize the number of users in the pool, unless specified otherwise with the `E2E_USER_POOL_SIZE` env var
+	DefaultUserPoolSize = 5
+)
+
+// LeaseMode the mode of a lease on a pooled user
+type LeaseMode string
+
+const (
+	// SharedLease a lease for a test which only reads the resources of the user. The user can be leased by other tests
+	// with a shared lease at the same time.
+	SharedLease LeaseMode = "shared"
+	// ExclusiveLease a lease for a test which needs to be the only one using the user (eg. because it verifies
+	// the events or the metrics related to the user). The resources of the user must still not be modified.
+	ExclusiveLease LeaseMode = "exclusive"
+)
+
+type pooledUser struct {
+	user         *User
+	sharedLeases int
+	exclusive    bool
+}
+
+type userPool struct {
+	sync.Mutex
+	available *sync.Cond
+	users     []*pooledUser
+}
+
+var (
+	pool     *userPool
+	poolOnce sync.Once
+)
+
+// LeaseUser leases a user from the pool of pre-provisioned users, waiting until one is available for the given mode.
+// The pool is provisioned by the first test leasing a user, and the lease is returned at the end of the test.
+// The users of the pool are deleted by Teardown.
+func LeaseUser(t *testing.T, awaitilities wait.Awaitilities, mode LeaseMode) *User {
+	poolOnce.Do(func() {
+		pool = provisionUserPool(t, awaitilities, userPoolSize(t))
+	})
+	require.NotNil(t, pool, "failed to provision the pool of users, see the logs of the test which provisioned it")
+
+	leased := pool.lease(mode)
+	t.Logf("leased user '%s' from the pool (%s)", leased.user.MUR.Name, mode)
+	t.Cleanup(func() {
+		pool.release(leased, mode)
+	})
+	return leased.user
+}
+
+func userPoolSize(t *testing.T) int {
+	value, found := os.LookupEnv(UserPoolSizeVar)
+	if !found {
+		return DefaultUserPoolSize
+	}
+	size, err := strconv.Atoi(value)
+	require.NoError(t, err, "invalid value of '%s'", UserPoolSizeVar)
+	require.Positive(t, size, "invalid value of '%s'", UserPoolSizeVar)
+	return size
+}
+
+func provisionUserPool(t *testing.T, awaitilities wait.Awaitilities, size int) *userPool {
+	t.Logf("provisioning a pool of %d users", size)
+	p := &userPool{}
+	p.available = sync.NewCond(p)
+	for i := 0; i < size; i++ {
+		p.users = append(p.users, &pooledUser{
+			user: ProvisionedUser(t, awaitilities, Named(fmt.Sprintf("pool-%d", i))),
+		})
+	}
+	return p
+}
+
+// lease blocks until a user can be leased in the given mode: an exclusive lease requires a user without any lease,
+// a shared lease requires a user without exclusive lease (the one with the fewest shared leases is picked).
+func (p *userPool) lease(mode LeaseMode) *pooledUser {
+	p.Lock()
+	defer p.Unlock()
+	for {
+		var candidate *pooledUser
+		for _, u := range p.users {
+			if u.exclusive {
+				continue
+			}
+			if mode == ExclusiveLease {
+				if u.sharedLeases == 0 {
+					candidate = u
+					break
+				}
+				continue
+			}
+			if candidate == nil || u.sharedLeases < candidate.sharedLeases {
+				candidate = u
+			}
+		}
+		if candidate != nil {
+			if mode == ExclusiveLease {
+				candidate.exclusive = true
+			} else {
+				candidate.sharedLeases++
+			}
+			return candidate
+		}
+		p.available.Wait()
+	}
+}
+
+func (p *userPool) release(u *pooledUser, mode LeaseMode) {
+	p.Lock()
+	defer p.Unlock()
+	if mode == ExclusiveLease {
+		u.exclusive = false
+	} else {
+		u.sharedLeases--
+	}
+	p.available.Broadcast()
+}
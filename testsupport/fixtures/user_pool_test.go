@@ -0,0 +1,164 @@
+package fixtures
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUserPoolLease(t *testing.T) {
+
+	t.Run("shared leases are spread across the users", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0", "user-1")
+
+		// when
+		first := p.lease(SharedLease)
+		second := p.lease(SharedLease)
+		third := p.lease(SharedLease)
+
+		// then
+		assert.NotSame(t, first, second)
+		assert.Same(t, first, third)
+		assert.Equal(t, 2, first.sharedLeases)
+		assert.Equal(t, 1, second.sharedLeases)
+	})
+
+	t.Run("exclusive lease on a user without lease", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0", "user-1")
+		shared := p.lease(SharedLease)
+
+		// when
+		exclusive := p.lease(ExclusiveLease)
+
+		// then
+		assert.NotSame(t, shared, exclusive)
+		assert.True(t, exclusive.exclusive)
+		assert.Equal(t, 0, exclusive.sharedLeases)
+	})
+
+	t.Run("shared lease skips the users with an exclusive lease", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0", "user-1")
+		exclusive := p.lease(ExclusiveLease)
+
+		// when
+		shared := p.lease(SharedLease)
+		other := p.lease(SharedLease)
+
+		// then
+		assert.NotSame(t, exclusive, shared)
+		assert.Same(t, shared, other)
+		assert.Equal(t, 2, shared.sharedLeases)
+	})
+
+	t.Run("exclusive lease waits until the shared leases are released", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0")
+		shared := p.lease(SharedLease)
+		leased := make(chan *pooledUser)
+
+		// when
+		go func() {
+			leased <- p.lease(ExclusiveLease)
+		}()
+
+		// then
+		assertNotLeased(t, leased)
+		p.release(shared, SharedLease)
+		exclusive := assertLeased(t, leased)
+		assert.Same(t, shared, exclusive)
+		assert.True(t, exclusive.exclusive)
+	})
+
+	t.Run("shared lease waits until the exclusive lease is released", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0")
+		exclusive := p.lease(ExclusiveLease)
+		leased := make(chan *pooledUser)
+
+		// when
+		go func() {
+			leased <- p.lease(SharedLease)
+		}()
+
+		// then
+		assertNotLeased(t, leased)
+		p.release(exclusive, ExclusiveLease)
+		shared := assertLeased(t, leased)
+		assert.False(t, shared.exclusive)
+		assert.Equal(t, 1, shared.sharedLeases)
+	})
+
+	t.Run("concurrent exclusive leases never share a user", func(t *testing.T) {
+		// given
+		p := newTestUserPool("user-0", "user-1")
+		var wg sync.WaitGroup
+		var lock sync.Mutex
+		inUse := map[*pooledUser]bool{}
+
+		// when
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				u := p.lease(ExclusiveLease)
+				lock.Lock()
+				assert.False(t, inUse[u], "user '%s' leased twice", u.user.MUR.Name)
+				inUse[u] = true
+				lock.Unlock()
+				time.Sleep(time.Millisecond)
+				lock.Lock()
+				inUse[u] = false
+				lock.Unlock()
+				p.release(u, ExclusiveLease)
+			}()
+		}
+
+		// then
+		wg.Wait()
+		for _, u := range p.users {
+			assert.False(t, u.exclusive)
+			assert.Equal(t, 0, u.sharedLeases)
+		}
+	})
+}
+
+func newTestUserPool(names ...string) *userPool {
+	p := &userPool{}
+	p.available = sync.NewCond(p)
+	for _, name := range names {
+		p.users = append(p.users, &pooledUser{
+			user: &User{
+				MUR: &toolchainv1alpha1.MasterUserRecord{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+				},
+			},
+		})
+	}
+	return p
+}
+
+func assertNotLeased(t *testing.T, leased <-chan *pooledUser) {
+	select {
+	case u := <-leased:
+		require.Failf(t, "unexpected lease", "user '%s' was leased", u.user.MUR.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func assertLeased(t *testing.T, leased <-chan *pooledUser) *pooledUser {
+	select {
+	case u := <-leased:
+		return u
+	case <-time.After(time.Second):
+		require.FailNow(t, "no user was leased")
+		return nil
+	}
+}
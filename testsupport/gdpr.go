@@ -0,0 +1,190 @@
+package testsupport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PIILeftover a resource which still contains some personal information of a user who asked to be forgotten
+type PIILeftover struct {
+	Cluster   string
+	Kind      string
+	Namespace string
+	Name      string
+	// Fields the kind of personal information found in the resource (eg: `email`)
+	Fields []string
+}
+
+func (l PIILeftover) String() string {
+	name := l.Name
+	if l.Namespace != "" {
+		name = l.Namespace + "/" + l.Name
+	}
+	return fmt.Sprintf("%s %s '%s': %s", l.Cluster, l.Kind, name, strings.Join(l.Fields, ", "))
+}
+
+// PIILeftoverReport the resources which still contain some personal information of a user who asked to be forgotten
+type PIILeftoverReport []PIILeftover
+
+func (r PIILeftoverReport) String() string {
+	if len(r) == 0 {
+		return "no PII leftover"
+	}
+	lines := make([]string, 0, len(r))
+	for _, l := range r {
+		lines = append(lines, "- "+l.String())
+	}
+	return fmt.Sprintf("%d resource(s) with PII leftover:\n%s", len(r), strings.Join(lines, "\n"))
+}
+
+// ForgetUser runs the whole "forget me" flow for the given (provisioned) user: the UserSignup is deactivated,
+// then deleted, and all the resources which may contain personal information about the user (UserSignups, MasterUserRecords,
+// Spaces, SpaceBindings and Notifications on the host cluster, UserAccounts, NSTemplateSets, Users, Identities
+// and Namespaces on the member clusters) are verified until none of them refers to the user anymore.
+// Fails the test with a report of the PII leftovers otherwise.
+func ForgetUser(t *testing.T, awaitilities wait.Awaitilities, userSignup *toolchainv1alpha1.UserSignup) {
+	hostAwait := awaitilities.Host()
+	pii := personalInformation(userSignup)
+
+	// deactivate
+	userSignup = DeactivateAndCheckUser(t, awaitilities, userSignup)
+
+	// delete
+	err := hostAwait.Client.Delete(context.TODO(), userSignup, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	require.NoError(t, err)
+	err = hostAwait.WaitUntilUserSignupDeleted(t, userSignup.Name)
+	require.NoError(t, err)
+
+	// verify
+	t.Logf("waiting until there is no PII leftover for user '%s'", userSignup.Spec.Username)
+	var report PIILeftoverReport
+	err = hostAwait.Poll(func() (done bool, err error) {
+		report, err = FindPIILeftovers(awaitilities, pii)
+		if err != nil {
+			return false, err
+		}
+		return len(report) == 0, nil
+	})
+	require.NoError(t, err, "user '%s' was not forgotten: %s", userSignup.Spec.Username, report)
+}
+
+// personalInformation returns the personal information of the given user, indexed by kind of information
+func personalInformation(userSignup *toolchainv1alpha1.UserSignup) map[string]string {
+	pii := map[string]string{
+		"username":           userSignup.Spec.Username,
+		"user ID":            userSignup.Spec.Userid,
+		"compliant username": userSignup.Status.CompliantUsername,
+	}
+	if email := userSignup.Annotations[toolchainv1alpha1.UserSignupUserEmailAnnotationKey]; email != "" {
+		pii["email"] = email
+		pii["email hash"] = hash.EmailHash(email)
+	}
+	if phoneHash := userSignup.Labels[toolchainv1alpha1.UserSignupUserPhoneHashLabelKey]; phoneHash != "" {
+		pii["phone number hash"] = phoneHash
+	}
+	for kind, value := range pii {
+		if value == "" {
+			delete(pii, kind)
+		}
+	}
+	return pii
+}
+
+// FindPIILeftovers returns the resources on the host and member clusters which still contain any of the given personal information
+// (indexed by kind of information, eg: `email`)
+func FindPIILeftovers(awaitilities wait.Awaitilities, pii map[string]string) (PIILeftoverReport, error) {
+	hostAwait := awaitilities.Host()
+	report, err := findPIILeftovers(hostAwait.Awaitility, pii, []client.ObjectList{
+		&toolchainv1alpha1.UserSignupList{},
+		&toolchainv1alpha1.MasterUserRecordList{},
+		&toolchainv1alpha1.SpaceList{},
+		&toolchainv1alpha1.SpaceBindingList{},
+		&toolchainv1alpha1.NotificationList{},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberAwait := range awaitilities.AllMembers() {
+		leftovers, err := findPIILeftovers(memberAwait.Awaitility, pii, []client.ObjectList{
+			&toolchainv1alpha1.UserAccountList{},
+			&toolchainv1alpha1.NSTemplateSetList{},
+		}, []client.ObjectList{
+			&userv1.UserList{},
+			&userv1.IdentityList{},
+			&corev1.NamespaceList{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, leftovers...)
+	}
+	return report, nil
+}
+
+// findPIILeftovers lists the resources of the given (namespaced and cluster-scoped) kinds and returns those whose content
+// contains any of the given personal information
+func findPIILeftovers(a *wait.Awaitility, pii map[string]string, namespacedLists, clusterLists []client.ObjectList) (PIILeftoverReport, error) {
+	report := PIILeftoverReport{}
+	lists := make([]client.ObjectList, 0, len(namespacedLists)+len(clusterLists))
+	for _, list := range namespacedLists {
+		if err := a.Client.List(context.TODO(), list, client.InNamespace(a.Namespace)); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	for _, list := range clusterLists {
+		if err := a.Client.List(context.TODO(), list); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	for _, list := range lists {
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			content, err := json.Marshal(obj)
+			if err != nil {
+				return nil, err
+			}
+			fields := []string{}
+			for kind, value := range pii {
+				if strings.Contains(string(content), value) {
+					fields = append(fields, kind)
+				}
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			sort.Strings(fields)
+			report = append(report, PIILeftover{
+				Cluster:   a.ClusterName,
+				Kind:      strings.TrimSuffix(reflect.TypeOf(list).Elem().Name(), "List"),
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+				Fields:    fields,
+			})
+		}
+	}
+	return report, nil
+}
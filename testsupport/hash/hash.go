@@ -0,0 +1,50 @@
+package hash
+
+import (
+	"regexp"
+	"testing"
+
+	commonhash "github.com/codeready-toolchain/toolchain-common/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	validHash    = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	nonDigitChar = regexp.MustCompile(`[^0-9]`)
+)
+
+// EmailHash returns the hash of the given email address, as set by the host operator and the registration service
+// in the `toolchain.dev.openshift.com/email-hash` label of the UserSignups and BannedUsers
+func EmailHash(email string) string {
+	return commonhash.EncodeString(email)
+}
+
+// PhoneNumberHash returns the hash of the given phone number, as set by the registration service
+// in the `toolchain.dev.openshift.com/phone-hash` label of the UserSignups and BannedUsers.
+// The country code and the phone number are normalized in the E.164 format (eg: `+61408999999`) before being hashed,
+// so that `+61` and `408 999 999` give the same hash as `61` and `408-999-999`.
+func PhoneNumberHash(countryCode, phoneNumber string) string {
+	return commonhash.EncodeString(E164PhoneNumber(countryCode, phoneNumber))
+}
+
+// E164PhoneNumber returns the given country code and phone number in the E.164 format (eg: `+61408999999`)
+func E164PhoneNumber(countryCode, phoneNumber string) string {
+	return "+" + nonDigitChar.ReplaceAllString(countryCode, "") + nonDigitChar.ReplaceAllString(phoneNumber, "")
+}
+
+// IsValid returns true if the given value is a well-formed hash (ie, 32 lowercase hexadecimal characters)
+func IsValid(value string) bool {
+	return validHash.MatchString(value)
+}
+
+// RequireLabel verifies that the given object has the given hash label with a well-formed value
+// which matches the expected one, and returns the value of the label
+func RequireLabel(t *testing.T, obj client.Object, labelKey, expected string) string {
+	value, found := obj.GetLabels()[labelKey]
+	require.True(t, found, "missing label '%s' on %s '%s'", labelKey, obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+	require.True(t, IsValid(value), "invalid hash '%s' in label '%s' on '%s'", value, labelKey, obj.GetName())
+	assert.Equal(t, expected, value, "unexpected hash in label '%s' on '%s'", labelKey, obj.GetName())
+	return value
+}
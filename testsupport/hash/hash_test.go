@@ -0,0 +1,56 @@
+package hash
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEmailHash(t *testing.T) {
+	// when
+	h := EmailHash("johnsmith@redhat.com")
+
+	// then
+	assert.Equal(t, "57e46a4968bab87c552924607e46be82", h)
+	assert.True(t, IsValid(h))
+}
+
+func TestPhoneNumberHash(t *testing.T) {
+	// given
+	expected := PhoneNumberHash("+61", "408999999")
+
+	// then
+	assert.True(t, IsValid(expected))
+	assert.Equal(t, "+61408999999", E164PhoneNumber("+61", "408999999"))
+	assert.Equal(t, expected, PhoneNumberHash("61", "408 999 999"))
+	assert.Equal(t, expected, PhoneNumberHash("(+61)", "408-999-999"))
+	assert.NotEqual(t, expected, PhoneNumberHash("+1", "408999999"))
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, IsValid("57e46a4968bab87c552924607e46be82"))
+	assert.False(t, IsValid(""))
+	assert.False(t, IsValid("57E46A4968BAB87C552924607E46BE82"))
+	assert.False(t, IsValid("57e46a4968bab87c552924607e46be8"))
+	assert.False(t, IsValid("johnsmith@redhat.com"))
+}
+
+func TestRequireLabel(t *testing.T) {
+	// given
+	bannedUser := &toolchainv1alpha1.BannedUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "banned",
+			Labels: map[string]string{
+				toolchainv1alpha1.BannedUserEmailHashLabelKey: EmailHash("johnsmith@redhat.com"),
+			},
+		},
+	}
+
+	// when
+	value := RequireLabel(t, bannedUser, toolchainv1alpha1.BannedUserEmailHashLabelKey, EmailHash("johnsmith@redhat.com"))
+
+	// then
+	assert.Equal(t, "57e46a4968bab87c552924607e46be82", value)
+}
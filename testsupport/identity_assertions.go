@@ -0,0 +1,71 @@
+package testsupport
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	identitypkg "github.com/codeready-toolchain/toolchain-common/pkg/identity"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+)
+
+// DefaultIdentityProvider the identity provider of the Identities, when it is not set in the MemberOperatorConfig
+const DefaultIdentityProvider = "rhd"
+
+// IdentityProviderOf returns the identity provider set in the given MemberOperatorConfig, or the default one
+func IdentityProviderOf(config *toolchainv1alpha1.MemberOperatorConfig) string {
+	if config != nil && config.Spec.Auth.Idp != nil && *config.Spec.Auth.Idp != "" {
+		return *config.Spec.Auth.Idp
+	}
+	return DefaultIdentityProvider
+}
+
+// ExpectedIdentity an Identity which is expected to be provisioned for a UserAccount
+type ExpectedIdentity struct {
+	Provider string
+	UserID   string
+}
+
+// Name returns the name of the Identity, in which the user ID is encoded if it contains special characters
+func (i ExpectedIdentity) Name() string {
+	return identitypkg.NewIdentityNamingStandard(i.UserID, i.Provider).IdentityName()
+}
+
+// Criteria returns the criteria of the Identity provisioned for the given UserAccount
+func (i ExpectedIdentity) Criteria(userAccount *toolchainv1alpha1.UserAccount) []wait.IdentityWaitCriterion {
+	return []wait.IdentityWaitCriterion{
+		wait.UntilIdentityHasLabel(toolchainv1alpha1.ProviderLabelKey, toolchainv1alpha1.ProviderLabelValue),
+		wait.UntilIdentityHasLabel(toolchainv1alpha1.OwnerLabelKey, userAccount.Name),
+		wait.UntilIdentityHasProvider(i.Provider, i.UserID),
+		wait.UntilIdentityIsMappedToUser(userAccount.Name),
+	}
+}
+
+// ExpectedIdentities returns the Identities expected for the given UserAccount for each of the given identity providers:
+// one for the user ID (ie, the `sub` claim) and one for the original `sub` claim, if any
+func ExpectedIdentities(userAccount *toolchainv1alpha1.UserAccount, providers ...string) []ExpectedIdentity {
+	var identities []ExpectedIdentity
+	for _, provider := range providers {
+		identities = append(identities, ExpectedIdentity{Provider: provider, UserID: userAccount.Spec.UserID})
+		if userAccount.Spec.OriginalSub != "" {
+			identities = append(identities, ExpectedIdentity{Provider: provider, UserID: userAccount.Spec.OriginalSub})
+		}
+	}
+	return identities
+}
+
+// VerifyIdentities verifies that the Identities of the given UserAccount exist for each of the given identity providers
+func VerifyIdentities(t *testing.T, memberAwait *wait.MemberAwaitility, userAccount *toolchainv1alpha1.UserAccount, providers ...string) {
+	for _, identity := range ExpectedIdentities(userAccount, providers...) {
+		_, err := memberAwait.WaitForIdentity(t, identity.Name(), identity.Criteria(userAccount)...)
+		assert.NoError(t, err, "no identity with name '%s' found", identity.Name())
+	}
+}
+
+// VerifyIdentitiesDeleted verifies that none of the Identities of the given UserAccount exist for the given identity providers
+func VerifyIdentitiesDeleted(t *testing.T, memberAwait *wait.MemberAwaitility, userAccount *toolchainv1alpha1.UserAccount, providers ...string) {
+	for _, identity := range ExpectedIdentities(userAccount, providers...) {
+		err := memberAwait.WaitUntilIdentityDeleted(t, identity.Name())
+		assert.NoError(t, err, "identity with name '%s' was not deleted", identity.Name())
+	}
+}
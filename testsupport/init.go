@@ -11,6 +11,7 @@ import (
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
 	appstudiov1 "github.com/codeready-toolchain/toolchain-e2e/testsupport/appstudio/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/kubectl/pkg/scheme"
@@ -21,6 +22,7 @@ import (
 	templatev1 "github.com/openshift/api/template/v1"
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	metrics "k8s.io/metrics/pkg/apis/metrics/v1beta1"
@@ -39,7 +41,13 @@ var (
 // autoscaling buffer app. Based on the given cluster type that represents the current operator that is the target of
 // the e2e test it retrieves namespace names. Also waits for the registration service to be deployed (with 3 replica)
 // Returns the test context and an instance of Awaitility that contains all necessary information
+// In single-cluster mode (see wait.SingleClusterMode), there is no second member operator and the registration service,
+// the API proxy and the metrics services are accessed via the URLs set in the env vars instead of via routes.
 func WaitForDeployments(t *testing.T) wait.Awaitilities {
+	start := time.Now()
+	defer func() {
+		report.RecordPhase(t, report.PhaseSetup, time.Since(start))
+	}()
 	initOnce.Do(func() {
 		memberNs := os.Getenv(wait.MemberNsVar)
 		memberNs2 := os.Getenv(wait.MemberNsVar2)
@@ -49,6 +57,13 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		t.Logf("Member1 Operator namespace: %s", memberNs)
 		t.Logf("Member2 Operator namespace: %s", memberNs2)
 		t.Logf("Registration Service namespace: %s", registrationServiceNs)
+		// needed to delete the resources of the run with `setup cleanup` if the test binary crashed before the cleaning tasks were performed
+		t.Logf("Run ID: %s", wait.RunID())
+		watchFilterKey, watchFilterValue, err := wait.WatchFilterLabel()
+		require.NoError(t, err)
+		if watchFilterKey != "" {
+			t.Logf("Watch filter label: %s=%s", watchFilterKey, watchFilterValue)
+		}
 
 		apiConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
 		require.NoError(t, err)
@@ -68,25 +83,35 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		// wait for registration service to be ready
 		initHostAwait.WaitForDeploymentToGetReady(t, "registration-service", 2)
 
-		// set registration service values
-		registrationServiceRoute, err := initHostAwait.WaitForRouteToBeAvailable(t, registrationServiceNs, "registration-service", "/")
-		require.NoError(t, err, "failed while waiting for registration service route")
-
-		registrationServiceURL := "http://" + registrationServiceRoute.Spec.Host
-		if registrationServiceRoute.Spec.TLS != nil {
-			registrationServiceURL = "https://" + registrationServiceRoute.Spec.Host
+		if wait.SingleClusterMode() {
+			// there are no routes in the cluster, so the registration service and the API proxy are accessed via the given URLs
+			t.Log("running in single-cluster mode")
+			initHostAwait.RegistrationServiceURL = wait.SingleClusterURL(wait.RegistrationServiceURLVar, "http://localhost:8080")
+			initHostAwait.APIProxyURL = wait.SingleClusterURL(wait.APIProxyURLVar, "https://localhost:8081")
+		} else {
+			// set registration service values
+			registrationServiceRoute, err := initHostAwait.WaitForRouteToBeReachable(t, registrationServiceNs, "registration-service", "/api/v1/health")
+			require.NoError(t, err, "failed while waiting for registration service route")
+
+			registrationServiceURL := "http://" + registrationServiceRoute.Spec.Host
+			if registrationServiceRoute.Spec.TLS != nil {
+				registrationServiceURL = "https://" + registrationServiceRoute.Spec.Host
+			}
+			initHostAwait.RegistrationServiceURL = registrationServiceURL
+
+			// set api proxy values
+			apiRoute, err := initHostAwait.WaitForRouteToBeReachable(t, registrationServiceNs, "api", "/proxyhealth")
+			require.NoError(t, err)
+			initHostAwait.APIProxyURL = strings.TrimSuffix(fmt.Sprintf("https://%s/%s", apiRoute.Spec.Host, apiRoute.Spec.Path), "/")
 		}
-		initHostAwait.RegistrationServiceURL = registrationServiceURL
-
-		// set api proxy values
-		apiRoute, err := initHostAwait.WaitForRouteToBeAvailable(t, registrationServiceNs, "api", "/proxyhealth")
-		require.NoError(t, err)
-		initHostAwait.APIProxyURL = strings.TrimSuffix(fmt.Sprintf("https://%s/%s", apiRoute.Spec.Host, apiRoute.Spec.Path), "/")
 
 		// wait for member operators to be ready
 		initMemberAwait = getMemberAwaitility(t, cl, initHostAwait, memberNs)
 
-		initMember2Await = getMemberAwaitility(t, cl, initHostAwait, memberNs2)
+		// there is no second member operator in single-cluster mode
+		if !wait.SingleClusterMode() {
+			initMember2Await = getMemberAwaitility(t, cl, initHostAwait, memberNs2)
+		}
 
 		hostToolchainCluster, err := initMemberAwait.WaitForToolchainClusterWithCondition(t, "e2e", hostNs, wait.ReadyToolchainCluster)
 		require.NoError(t, err)
@@ -94,21 +119,28 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		require.NoError(t, err)
 		initHostAwait.RestConfig = hostConfig.RestConfig
 
-		// setup host metrics route for metrics verification in tests
-		hostMetricsRoute, err := initHostAwait.SetupRouteForService(t, "host-operator-metrics-service", "/metrics")
-		require.NoError(t, err)
-		initHostAwait.MetricsURL = hostMetricsRoute.Status.Ingress[0].Host
-
-		// setup member metrics route for metrics verification in tests
-		memberMetricsRoute, err := initMemberAwait.SetupRouteForService(t, "member-operator-metrics-service", "/metrics")
-		require.NoError(t, err, "failed while setting up or waiting for the route to the 'member-operator-metrics' service to be available")
-		initMemberAwait.MetricsURL = memberMetricsRoute.Status.Ingress[0].Host
+		if wait.SingleClusterMode() {
+			initHostAwait.MetricsURL = wait.SingleClusterURL(wait.HostMetricsURLVar, "localhost:8443")
+			initMemberAwait.MetricsURL = wait.SingleClusterURL(wait.MemberMetricsURLVar, "localhost:8444")
+		} else {
+			// setup host metrics route for metrics verification in tests
+			hostMetricsRoute, err := initHostAwait.SetupRouteForService(t, "host-operator-metrics-service", "/metrics")
+			require.NoError(t, err)
+			initHostAwait.MetricsURL = hostMetricsRoute.Status.Ingress[0].Host
+
+			// setup member metrics route for metrics verification in tests
+			memberMetricsRoute, err := initMemberAwait.SetupRouteForService(t, "member-operator-metrics-service", "/metrics")
+			require.NoError(t, err, "failed while setting up or waiting for the route to the 'member-operator-metrics' service to be available")
+			initMemberAwait.MetricsURL = memberMetricsRoute.Status.Ingress[0].Host
+		}
 
 		_, err = initMemberAwait.WaitForToolchainClusterWithCondition(t, initHostAwait.Type, initHostAwait.Namespace, wait.ReadyToolchainCluster)
 		require.NoError(t, err)
 
-		_, err = initMember2Await.WaitForToolchainClusterWithCondition(t, initHostAwait.Type, initHostAwait.Namespace, wait.ReadyToolchainCluster)
-		require.NoError(t, err)
+		if initMember2Await != nil {
+			_, err = initMember2Await.WaitForToolchainClusterWithCondition(t, initHostAwait.Type, initHostAwait.Namespace, wait.ReadyToolchainCluster)
+			require.NoError(t, err)
+		}
 
 		// Wait for the webhooks in Member 1 only because we do not deploy webhooks for Member 2
 		// (we can't deploy the same webhook multiple times on the same cluster)
@@ -118,7 +150,9 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		require.NotEmpty(t, webhookImage, "The value of the env var MEMBER_OPERATOR_WEBHOOK_IMAGE wasn't found in the deployment of the member operator.")
 		initMemberAwait.WaitForMemberWebhooks(t, webhookImage)
 		initMemberAwait.WaitForAutoscalingBufferApp(t)
-		initMember2Await.WaitForAutoscalingBufferApp(t)
+		if initMember2Await != nil {
+			initMember2Await.WaitForAutoscalingBufferApp(t)
+		}
 
 		// check that the tier exists, and all its namespace other cluster-scoped resource revisions
 		// are different from `000000a` which is the value specified in the initial manifest (used for base tier)
@@ -133,6 +167,9 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		t.Log("all operators are ready and in running state")
 	})
 
+	if initMember2Await == nil {
+		return wait.NewAwaitilities(initHostAwait, initMemberAwait)
+	}
 	return wait.NewAwaitilities(initHostAwait, initMemberAwait, initMember2Await)
 }
 
@@ -166,6 +203,7 @@ func schemeWithAllAPIs(t *testing.T) *runtime.Scheme {
 		quotav1.Install,
 		openshiftappsv1.Install,
 		corev1.AddToScheme,
+		coordinationv1.AddToScheme,
 		metrics.AddToScheme,
 		appstudiov1.AddToScheme,
 	)
@@ -0,0 +1,98 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	appstudiov1 "github.com/codeready-toolchain/toolchain-e2e/testsupport/appstudio/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewWorkspacesKubeconfig returns a kubeconfig with a context for each of the given workspaces, which connects to the proxy
+// in the context of the workspace, with the given token of the user and the default namespace of the workspace.
+// The kubeconfig is built on the client side, since the proxy does not serve the kubeconfigs of the workspaces.
+func NewWorkspacesKubeconfig(t *testing.T, hostAwait *wait.HostAwaitility, username, token string, workspaces ...toolchainv1alpha1.Workspace) *clientcmdapi.Config {
+	require.NotEmpty(t, workspaces, "no workspace to add in the kubeconfig of user '%s'", username)
+	tlsConfig := hostAwait.RestConfig.TLSClientConfig
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.AuthInfos[username] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	for _, workspace := range workspaces {
+		kubeconfig.Clusters[workspace.Name] = &clientcmdapi.Cluster{
+			Server:                   hostAwait.ProxyURLWithWorkspaceContext(workspace.Name),
+			CertificateAuthority:     tlsConfig.CAFile,
+			CertificateAuthorityData: tlsConfig.CAData,
+			InsecureSkipTLSVerify:    tlsConfig.Insecure,
+		}
+		kubeconfig.Contexts[workspace.Name] = &clientcmdapi.Context{
+			Cluster:   workspace.Name,
+			AuthInfo:  username,
+			Namespace: GetDefaultNamespace(workspace.Status.Namespaces),
+		}
+	}
+	kubeconfig.CurrentContext = workspaces[0].Name
+
+	// make sure that the kubeconfig can be written and loaded back, as it would be by the users
+	data, err := clientcmd.Write(*kubeconfig)
+	require.NoError(t, err)
+	kubeconfig, err = clientcmd.Load(data)
+	require.NoError(t, err)
+	return kubeconfig
+}
+
+// VerifyWorkspacesKubeconfig verifies that each context of the given kubeconfig works against the member cluster of its workspace
+// and in the default namespace of the workspace, by creating an Application with the client of the context and by checking
+// that it exists in the expected namespace of the expected member cluster
+func VerifyWorkspacesKubeconfig(t *testing.T, awaitilities wait.Awaitilities, kubeconfig *clientcmdapi.Config) {
+	hostAwait := awaitilities.Host()
+	for contextName, kubeContext := range kubeconfig.Contexts {
+		t.Logf("verifying context '%s' of the kubeconfig", contextName)
+		clientConfig := clientcmd.NewNonInteractiveClientConfig(*kubeconfig, contextName, &clientcmd.ConfigOverrides{}, nil)
+		config, err := clientConfig.ClientConfig()
+		require.NoError(t, err)
+		namespace, _, err := clientConfig.Namespace()
+		require.NoError(t, err)
+		require.NotEmpty(t, kubeContext.Namespace, "context '%s' has no namespace", contextName)
+		require.Equal(t, kubeContext.Namespace, namespace)
+
+		space, err := hostAwait.WaitForSpace(t, kubeContext.Cluster, wait.UntilSpaceHasAnyTargetClusterSet())
+		require.NoError(t, err)
+		memberAwait, err := awaitilities.Member(space.Spec.TargetCluster)
+		require.NoError(t, err)
+
+		// creating the client can fail until the proxy cache is up-to-date, hence the retries (as in `CreateAPIProxyClient`)
+		var cl client.Client
+		var clientErr error
+		err = hostAwait.Poll(func() (done bool, err error) {
+			cl, clientErr = client.New(config, client.Options{Scheme: hostAwait.Client.Scheme()})
+			return clientErr == nil, nil
+		})
+		require.NoError(t, err, "unable to create a client for context '%s': %v", contextName, clientErr)
+		app := &appstudiov1.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("kubeconfig-%s", uuid.Must(uuid.NewV4()).String()[:8]),
+				Namespace: namespace,
+			},
+			Spec: appstudiov1.ApplicationSpec{
+				DisplayName: fmt.Sprintf("Kubeconfig test for context %s", contextName),
+			},
+		}
+		require.NoError(t, cl.Create(context.TODO(), app), "unable to create an Application with context '%s'", contextName)
+
+		actual := &appstudiov1.Application{}
+		err = memberAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(app), actual)
+		require.NoError(t, err, "Application created with context '%s' not found in namespace '%s' of cluster '%s'", contextName, namespace, memberAwait.ClusterName)
+		assert.Equal(t, app.Spec.DisplayName, actual.Spec.DisplayName)
+		require.NoError(t, cl.Delete(context.TODO(), app))
+	}
+}
@@ -0,0 +1,70 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProvisionUsersWithLeaderFailover creates the given number of approved UserSignups targeting the given member cluster,
+// deletes the host operator pod holding the leader election lease while the UserSignups are being provisioned,
+// and then verifies that the new leader completed the provisioning without creating any duplicate or conflicting resources
+// (ie, exactly one Space and one SpaceBinding per MasterUserRecord, and a single UserAccount in the target cluster).
+func ProvisionUsersWithLeaderFailover(t *testing.T, awaitilities wait.Awaitilities, targetCluster *wait.MemberAwaitility, count int) []*toolchainv1alpha1.UserSignup {
+	hostAwait := awaitilities.Host()
+	prefix := NewObjectNamePrefix(t)
+
+	// create all the UserSignups at once, so that the leader is still reconciling them when it is deleted
+	signups := make([]*toolchainv1alpha1.UserSignup, count)
+	for i := 0; i < count; i++ {
+		username := fmt.Sprintf("%s-%d", prefix, i)
+		signups[i] = NewUserSignup(hostAwait.Namespace, username, fmt.Sprintf("%s@test.com", username))
+		states.SetApprovedManually(signups[i], true)
+		signups[i].Spec.TargetCluster = targetCluster.ClusterName
+		require.NoError(t, hostAwait.CreateWithCleanup(t, signups[i]))
+	}
+
+	// when
+	previousLeader := hostAwait.DeleteLeaderPod(t)
+	hostAwait.WaitForNewLeaderPod(t, previousLeader)
+	hostAwait.WaitForDeploymentToGetReady(t, "host-operator-controller-manager", 1)
+
+	// then
+	for i, signup := range signups {
+		userSignup, err := hostAwait.WaitForUserSignup(t, signup.Name,
+			wait.UntilUserSignupHasConditions(ConditionSet(Default(), ApprovedByAdmin())...),
+			wait.UntilUserSignupHasCompliantUsername())
+		require.NoError(t, err)
+		signups[i] = userSignup
+		VerifyNoDuplicateResourcesForUser(t, hostAwait, targetCluster, userSignup)
+	}
+	return signups
+}
+
+// VerifyNoDuplicateResourcesForUser verifies that the given UserSignup was provisioned with exactly one Space and one SpaceBinding
+// for its MasterUserRecord, and with a single UserAccount in the target cluster
+func VerifyNoDuplicateResourcesForUser(t *testing.T, hostAwait *wait.HostAwaitility, targetCluster *wait.MemberAwaitility, userSignup *toolchainv1alpha1.UserSignup) {
+	murName := userSignup.Status.CompliantUsername
+	_, err := hostAwait.WaitForMasterUserRecord(t, murName,
+		wait.UntilMasterUserRecordHasConditions(Provisioned(), ProvisionedNotificationCRCreated()))
+	require.NoError(t, err)
+	_, err = targetCluster.WaitForUserAccount(t, murName)
+	require.NoError(t, err)
+
+	spaces := &toolchainv1alpha1.SpaceList{}
+	require.NoError(t, hostAwait.Client.List(context.TODO(), spaces, client.InNamespace(hostAwait.Namespace),
+		client.MatchingLabels{toolchainv1alpha1.SpaceCreatorLabelKey: userSignup.Name}))
+	assert.Len(t, spaces.Items, 1, "unexpected number of Spaces created for the UserSignup '%s'", userSignup.Name)
+
+	spaceBindings := &toolchainv1alpha1.SpaceBindingList{}
+	require.NoError(t, hostAwait.Client.List(context.TODO(), spaceBindings, client.InNamespace(hostAwait.Namespace),
+		client.MatchingLabels{toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: murName}))
+	assert.Len(t, spaceBindings.Items, 1, "unexpected number of SpaceBindings created for the MasterUserRecord '%s'", murName)
+}
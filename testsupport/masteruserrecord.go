@@ -0,0 +1,210 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewMasterUserRecord creates a builder of a MasterUserRecord which is created directly in the host cluster, ie, without any
+// UserSignup, to simulate the MasterUserRecords of legacy or migrated users. By default, the MasterUserRecord has a random name
+// (which is also its user ID), no UserAccount, no owner label and no annotation. Function chaining may be used, for example:
+//
+// mur := NewMasterUserRecord(t, hostAwait).
+// UserAccounts(memberAwait).
+// TierName("deactivate30").
+// Email("orphan@redhat.com").
+// Create(t)
+func NewMasterUserRecord(t *testing.T, hostAwait *wait.HostAwaitility) *MasterUserRecordBuilder {
+	name := fmt.Sprintf("orphan-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	return &MasterUserRecordBuilder{
+		hostAwait: hostAwait,
+		mur: &toolchainv1alpha1.MasterUserRecord{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hostAwait.Namespace,
+				Name:      name,
+			},
+			Spec: toolchainv1alpha1.MasterUserRecordSpec{
+				UserID: name,
+			},
+		},
+	}
+}
+
+// MasterUserRecordBuilder provides an API for creating a MasterUserRecord without any UserSignup
+type MasterUserRecordBuilder struct {
+	hostAwait *wait.HostAwaitility
+	mur       *toolchainv1alpha1.MasterUserRecord
+}
+
+// Name specifies the name of the MasterUserRecord (which is also the name of its UserAccounts)
+func (b *MasterUserRecordBuilder) Name(name string) *MasterUserRecordBuilder {
+	b.mur.Name = name
+	return b
+}
+
+// UserID specifies the user ID of the MasterUserRecord (by default, its name)
+func (b *MasterUserRecordBuilder) UserID(userID string) *MasterUserRecordBuilder {
+	b.mur.Spec.UserID = userID
+	return b
+}
+
+// UserAccounts adds a UserAccount in each one of the given member clusters
+func (b *MasterUserRecordBuilder) UserAccounts(targetClusters ...*wait.MemberAwaitility) *MasterUserRecordBuilder {
+	for _, memberAwait := range targetClusters {
+		b.mur.Spec.UserAccounts = append(b.mur.Spec.UserAccounts, toolchainv1alpha1.UserAccountEmbedded{
+			TargetCluster: memberAwait.ClusterName,
+		})
+	}
+	return b
+}
+
+// UserAccountInCluster adds a UserAccount in the member cluster with the given name, which may not exist (eg, a cluster
+// which was removed from the toolchain since the MasterUserRecord was provisioned)
+func (b *MasterUserRecordBuilder) UserAccountInCluster(clusterName string) *MasterUserRecordBuilder {
+	b.mur.Spec.UserAccounts = append(b.mur.Spec.UserAccounts, toolchainv1alpha1.UserAccountEmbedded{
+		TargetCluster: clusterName,
+	})
+	return b
+}
+
+// TierName specifies the name of the UserTier of the MasterUserRecord
+func (b *MasterUserRecordBuilder) TierName(tierName string) *MasterUserRecordBuilder {
+	b.mur.Spec.TierName = tierName
+	return b
+}
+
+// Email specifies the email address of the user, set in the email annotation
+func (b *MasterUserRecordBuilder) Email(email string) *MasterUserRecordBuilder {
+	return b.Annotation(toolchainv1alpha1.MasterUserRecordEmailAnnotationKey, email)
+}
+
+// Annotation adds an annotation with the given key and value
+func (b *MasterUserRecordBuilder) Annotation(key, value string) *MasterUserRecordBuilder {
+	if b.mur.Annotations == nil {
+		b.mur.Annotations = map[string]string{}
+	}
+	b.mur.Annotations[key] = value
+	return b
+}
+
+// Owner specifies the name of the UserSignup in the owner label, which does not need to exist (eg, a UserSignup
+// which was deleted while its MasterUserRecord was kept)
+func (b *MasterUserRecordBuilder) Owner(userSignupName string) *MasterUserRecordBuilder {
+	if b.mur.Labels == nil {
+		b.mur.Labels = map[string]string{}
+	}
+	b.mur.Labels[toolchainv1alpha1.MasterUserRecordOwnerLabelKey] = userSignupName
+	return b
+}
+
+// Disabled marks the MasterUserRecord as disabled
+func (b *MasterUserRecordBuilder) Disabled() *MasterUserRecordBuilder {
+	b.mur.Spec.Disabled = true
+	return b
+}
+
+// Build returns the MasterUserRecord without creating it
+func (b *MasterUserRecordBuilder) Build() *toolchainv1alpha1.MasterUserRecord {
+	return b.mur.DeepCopy()
+}
+
+// Create creates the MasterUserRecord in the host cluster (it is deleted at the end of the test)
+func (b *MasterUserRecordBuilder) Create(t *testing.T) *toolchainv1alpha1.MasterUserRecord {
+	mur := b.Build()
+	err := b.hostAwait.CreateWithCleanup(t, mur)
+	require.NoError(t, err)
+	t.Logf("MasterUserRecord '%s' created without UserSignup", mur.Name)
+	return mur
+}
+
+// VerifyOrphanMasterUserRecordProvisioned verifies that the given MasterUserRecord (created without UserSignup) is provisioned:
+// it is ready and its UserAccounts are provisioned in their member clusters, and no UserSignup was created for it
+func VerifyOrphanMasterUserRecordProvisioned(t *testing.T, awaitilities wait.Awaitilities, mur *toolchainv1alpha1.MasterUserRecord) *toolchainv1alpha1.MasterUserRecord {
+	hostAwait := awaitilities.Host()
+	mur, err := hostAwait.WaitForMasterUserRecord(t, mur.Name,
+		wait.UntilMasterUserRecordHasCondition(Provisioned()))
+	require.NoError(t, err)
+	expectedStatuses := make([]toolchainv1alpha1.UserAccountStatusEmbedded, 0, len(mur.Spec.UserAccounts))
+	for _, ua := range mur.Spec.UserAccounts {
+		memberAwait, err := awaitilities.Member(ua.TargetCluster)
+		require.NoError(t, err)
+		userAccount, err := memberAwait.WaitForUserAccount(t, mur.Name,
+			wait.UntilUserAccountHasConditions(Provisioned()),
+			wait.UntilUserAccountMatchesMur(hostAwait))
+		require.NoError(t, err)
+		memberCluster, ok, err := hostAwait.GetToolchainCluster(t, cluster.Member, memberAwait.Namespace, nil)
+		require.NoError(t, err)
+		require.True(t, ok)
+		expectedStatuses = append(expectedStatuses, toolchainv1alpha1.UserAccountStatusEmbedded{
+			Cluster: toolchainv1alpha1.Cluster{
+				Name:        ua.TargetCluster,
+				APIEndpoint: memberCluster.Spec.APIEndpoint,
+				ConsoleURL:  memberAwait.GetConsoleURL(t),
+			},
+			UserAccountStatus: userAccount.Status,
+		})
+	}
+	mur, err = hostAwait.WaitForMasterUserRecord(t, mur.Name,
+		wait.UntilMasterUserRecordHasUserAccountStatuses(expectedStatuses...))
+	require.NoError(t, err)
+	assert.NotNil(t, mur.Status.ProvisionedTime, "the provisioned time of MasterUserRecord '%s' should be set", mur.Name)
+	requireNoUserSignupForMasterUserRecord(t, hostAwait, mur)
+	return mur
+}
+
+// VerifyOrphanMasterUserRecordNotReady verifies that the given MasterUserRecord (created without UserSignup) is not ready for the given reason
+// (eg, `TargetClusterNotReady` for a UserAccount in an unknown member cluster) and that no UserSignup was created for it
+func VerifyOrphanMasterUserRecordNotReady(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolchainv1alpha1.MasterUserRecord, reason string) *toolchainv1alpha1.MasterUserRecord {
+	mur, err := hostAwait.WaitForMasterUserRecord(t, mur.Name,
+		wait.UntilMasterUserRecordIsNotReady(reason))
+	require.NoError(t, err)
+	requireNoUserSignupForMasterUserRecord(t, hostAwait, mur)
+	return mur
+}
+
+// VerifyOrphanMasterUserRecordKept verifies that the given MasterUserRecord (created without UserSignup) stays provisioned during
+// the given window, ie, it is neither deleted nor re-provisioned by the host operator because it has no UserSignup
+func VerifyOrphanMasterUserRecordKept(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolchainv1alpha1.MasterUserRecord, window time.Duration) {
+	AssertConditionStable(t, hostAwait.Awaitility, mur, Provisioned(), window)
+	requireNoUserSignupForMasterUserRecord(t, hostAwait, mur)
+}
+
+// VerifyOrphanMasterUserRecordDeleted deletes the given MasterUserRecord (created without UserSignup) and verifies that
+// it is deleted along with its UserAccounts, since there is no UserSignup to deal with the deletion
+func VerifyOrphanMasterUserRecordDeleted(t *testing.T, awaitilities wait.Awaitilities, mur *toolchainv1alpha1.MasterUserRecord) {
+	hostAwait := awaitilities.Host()
+	err := hostAwait.Client.Delete(context.TODO(), mur)
+	require.NoError(t, err)
+	err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, mur.Name)
+	require.NoError(t, err)
+	for _, ua := range mur.Spec.UserAccounts {
+		memberAwait, err := awaitilities.Member(ua.TargetCluster)
+		if err != nil {
+			// UserAccount in an unknown member cluster
+			continue
+		}
+		err = memberAwait.WaitUntilUserAccountDeleted(t, mur.Name)
+		require.NoError(t, err)
+	}
+}
+
+// requireNoUserSignupForMasterUserRecord verifies that there is no UserSignup owning the given MasterUserRecord
+func requireNoUserSignupForMasterUserRecord(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolchainv1alpha1.MasterUserRecord) {
+	userSignups := &toolchainv1alpha1.UserSignupList{}
+	err := hostAwait.Client.List(context.TODO(), userSignups, client.InNamespace(hostAwait.Namespace))
+	require.NoError(t, err)
+	for _, userSignup := range userSignups.Items {
+		assert.NotEqual(t, mur.Name, userSignup.Status.CompliantUsername, "no UserSignup should be created for MasterUserRecord '%s'", mur.Name)
+	}
+}
@@ -0,0 +1,82 @@
+package testsupport
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MemberCapacitySwitch a switch which makes all the member clusters look out of capacity to the host operator,
+// so that the new users are kept on the waiting list until the capacity is restored.
+// Note: there is no SpaceProvisionerConfig in the version of the API used by the tests, hence the capacity is exhausted
+// via the capacity thresholds of the ToolchainConfig.
+type MemberCapacitySwitch struct {
+	hostAwait *wait.HostAwaitility
+	// original the capacity thresholds of the ToolchainConfig before the capacity was exhausted
+	original toolchainv1alpha1.CapacityThresholds
+}
+
+// ExhaustMemberCapacity sets a resource capacity threshold of 1% for all the member clusters (which are always above it),
+// so that no member cluster is available for the new users. The original capacity thresholds are restored with `Restore`
+// (or at the end of the test).
+func ExhaustMemberCapacity(t *testing.T, hostAwait *wait.HostAwaitility) *MemberCapacitySwitch {
+	s := &MemberCapacitySwitch{
+		hostAwait: hostAwait,
+	}
+	if config := hostAwait.GetToolchainConfig(t); config != nil {
+		s.original = *config.Spec.Host.CapacityThresholds.DeepCopy()
+	}
+	t.Log("exhausting the capacity of all the member clusters")
+	hostAwait.UpdateToolchainConfig(t, testconfig.CapacityThresholds().ResourceCapacityThreshold(1))
+	return s
+}
+
+// Restore restores the original capacity thresholds of the ToolchainConfig
+func (s *MemberCapacitySwitch) Restore(t *testing.T) {
+	t.Log("restoring the capacity of all the member clusters")
+	s.hostAwait.UpdateToolchainConfig(t, capacityThresholdsOption(s.original))
+}
+
+// capacityThresholdsOption a ToolchainConfigOption which sets the given capacity thresholds
+type capacityThresholdsOption toolchainv1alpha1.CapacityThresholds
+
+func (o capacityThresholdsOption) Apply(config *toolchainv1alpha1.ToolchainConfig) {
+	thresholds := toolchainv1alpha1.CapacityThresholds(o)
+	config.Spec.Host.CapacityThresholds = *thresholds.DeepCopy()
+}
+
+// VerifySignupQueuedUntilCapacityRestored exhausts the capacity of all the member clusters, signs up a user with the given name
+// (with the automatic approval enabled) and verifies that the user is kept on the waiting list, with no cluster available
+// and without incrementing the approved signups metric. Then it restores the capacity and verifies that the queued user
+// is approved and provisioned. Returns the UserSignup of the user.
+func VerifySignupQueuedUntilCapacityRestored(t *testing.T, awaitilities wait.Awaitilities, username string) *toolchainv1alpha1.UserSignup {
+	hostAwait := awaitilities.Host()
+	hostAwait.UpdateToolchainConfig(t, testconfig.AutomaticApproval().Enabled(true))
+	metricsAssertion := InitMetricsAssertion(t, awaitilities)
+	capacity := ExhaustMemberCapacity(t, hostAwait)
+
+	userSignup, _ := NewSignupRequest(awaitilities).
+		Username(username).
+		Email(username + "@redhat.com").
+		RequireConditions(ConditionSet(Default(), PendingApproval(), PendingApprovalNoCluster())...).
+		Execute(t).
+		Resources()
+	hostAwait.CheckMasterUserRecordIsDeleted(t, userSignup.Spec.Username)
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name)
+	require.NoError(t, err)
+	assert.Equal(t, toolchainv1alpha1.UserSignupStateLabelValuePending, userSignup.Labels[toolchainv1alpha1.UserSignupStateLabelKey])
+	metricsAssertion.WaitForMetricDelta(t, UserSignupsApprovedMetric, 0)
+
+	capacity.Restore(t)
+	userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
+		wait.UntilUserSignupHasConditions(ConditionSet(Default(), ApprovedAutomatically())...),
+		wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueApproved))
+	require.NoError(t, err)
+	VerifyResourcesProvisionedForSignup(t, awaitilities, userSignup, "deactivate30", "base")
+	metricsAssertion.WaitForMetricDelta(t, UserSignupsApprovedMetric, 1)
+	return userSignup
+}
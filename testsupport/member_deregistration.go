@@ -0,0 +1,178 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileTriggerAnnotationKey the annotation which is set on a resource to trigger its reconcile (or the reconcile of its owner) by the operators
+const reconcileTriggerAnnotationKey = "toolchain.dev.openshift.com/e2e-reconcile-trigger"
+
+// MemberDeregistration the deregistration of a member cluster from the host cluster, ie, the deletion of its ToolchainCluster
+type MemberDeregistration struct {
+	// ToolchainCluster the ToolchainCluster of the member cluster, as it was before its deletion
+	ToolchainCluster toolchainv1alpha1.ToolchainCluster
+	hostAwait        *wait.HostAwaitility
+	memberAwait      *wait.MemberAwaitility
+	reRegistered     bool
+}
+
+// DeregisterMemberCluster deletes the ToolchainCluster of the given member cluster in the host cluster (regardless of the Spaces which
+// it still hosts) and waits until it is gone. Unless it was already done by the test (see ReRegister), the member cluster is registered
+// again at the end of the test, and reported as ready in the ToolchainStatus before the next tests run.
+func DeregisterMemberCluster(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility) *MemberDeregistration {
+	toolchainCluster, found, err := hostAwait.GetToolchainCluster(t, cluster.Member, memberAwait.Namespace, nil)
+	require.NoError(t, err)
+	require.True(t, found, "no ToolchainCluster for member cluster '%s'", memberAwait.ClusterName)
+	t.Logf("deregistering member cluster '%s' by deleting ToolchainCluster '%s'", memberAwait.ClusterName, toolchainCluster.Name)
+
+	require.NoError(t, hostAwait.Client.Delete(context.TODO(), &toolchainCluster))
+	err = hostAwait.Poll(func() (done bool, err error) {
+		if err := hostAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(&toolchainCluster), &toolchainv1alpha1.ToolchainCluster{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+	require.NoError(t, err, "ToolchainCluster '%s' was not deleted", toolchainCluster.Name)
+
+	d := &MemberDeregistration{
+		ToolchainCluster: toolchainCluster,
+		hostAwait:        hostAwait,
+		memberAwait:      memberAwait,
+	}
+	t.Cleanup(func() {
+		if !d.reRegistered {
+			d.ReRegister(t)
+			d.WaitUntilMemberReady(t)
+		}
+	})
+	return d
+}
+
+// DeregisterAllMemberClusters deregisters all the member clusters (see DeregisterMemberCluster), ie, scales the toolchain down to zero members
+func DeregisterAllMemberClusters(t *testing.T, awaitilities wait.Awaitilities) []*MemberDeregistration {
+	deregistrations := make([]*MemberDeregistration, 0, len(awaitilities.AllMembers()))
+	for _, memberAwait := range awaitilities.AllMembers() {
+		deregistrations = append(deregistrations, DeregisterMemberCluster(t, awaitilities.Host(), memberAwait))
+	}
+	return deregistrations
+}
+
+// ClusterName returns the name of the deregistered member cluster
+func (d *MemberDeregistration) ClusterName() string {
+	return d.memberAwait.ClusterName
+}
+
+// ReRegister recreates the ToolchainCluster of the member cluster with the same labels and spec (as it would be when the member
+// cluster is registered again), and waits until it is ready. See WaitUntilMemberReady to also wait for the member cluster to be
+// reported as ready in the ToolchainStatus.
+func (d *MemberDeregistration) ReRegister(t *testing.T) {
+	t.Logf("re-registering member cluster '%s' with ToolchainCluster '%s'", d.memberAwait.ClusterName, d.ToolchainCluster.Name)
+	registered := &toolchainv1alpha1.ToolchainCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   d.ToolchainCluster.Namespace,
+			Name:        d.ToolchainCluster.Name,
+			Labels:      d.ToolchainCluster.Labels,
+			Annotations: d.ToolchainCluster.Annotations,
+		},
+		Spec: d.ToolchainCluster.Spec,
+	}
+	require.NoError(t, d.hostAwait.Client.Create(context.TODO(), registered))
+	d.reRegistered = true
+	_, err := d.hostAwait.WaitForNamedToolchainClusterWithCondition(t, registered.Name, wait.ReadyToolchainCluster)
+	require.NoError(t, err)
+}
+
+// WaitUntilMemberReady waits until the re-registered member cluster is reported as ready (with all its components) in the ToolchainStatus
+func (d *MemberDeregistration) WaitUntilMemberReady(t *testing.T) {
+	_, err := d.hostAwait.WaitForToolchainStatus(t,
+		wait.UntilToolchainStatusMemberHasReadyCondition(d.memberAwait.ClusterName, corev1.ConditionTrue, toolchainv1alpha1.ToolchainStatusAllComponentsReadyReason))
+	require.NoError(t, err, "member cluster '%s' is not ready in the ToolchainStatus after its re-registration", d.memberAwait.ClusterName)
+}
+
+// VerifyMemberDeregistered verifies the documented behavior of the host operator once the member cluster is deregistered:
+// - the member cluster is reported as not ready in the ToolchainStatus (since its ToolchainCluster is missing), and so is the ToolchainStatus,
+// - the Spaces with the given names, which still target the member cluster, are reported as unready once they are reconciled,
+// - the `sandbox_spaces_current` metric of the member cluster still counts all the Spaces which target it (ie, none was lost).
+func VerifyMemberDeregistered(t *testing.T, d *MemberDeregistration, spaceNames ...string) {
+	hostAwait := d.hostAwait
+	clusterName := d.memberAwait.ClusterName
+	_, err := hostAwait.WaitForToolchainStatus(t,
+		wait.UntilToolchainStatusMemberHasReadyCondition(clusterName, corev1.ConditionFalse, toolchainv1alpha1.ToolchainStatusMemberToolchainClusterMissingReason),
+		wait.ToolchainStatusWaitCriterion{
+			Match: func(actual *toolchainv1alpha1.ToolchainStatus) bool {
+				return condition.IsFalse(actual.Status.Conditions, toolchainv1alpha1.ConditionReady)
+			},
+			Diff: func(actual *toolchainv1alpha1.ToolchainStatus) string {
+				return fmt.Sprintf("expected ToolchainStatus to be not ready, but had conditions: %v", actual.Status.Conditions)
+			},
+		})
+	require.NoError(t, err, "member cluster '%s' is not reported as missing in the ToolchainStatus", clusterName)
+
+	for _, name := range spaceNames {
+		triggerSpaceReconcile(t, hostAwait, name)
+		_, err := hostAwait.WaitForSpace(t, name,
+			wait.UntilSpaceHasConditions(SpaceProvisioningFailed(fmt.Sprintf("unknown target member cluster '%s'", clusterName))))
+		require.NoError(t, err, "Space '%s' is not reported as unready after the deregistration of member cluster '%s'", name, clusterName)
+	}
+
+	VerifySpacesMetricMatchesSpaces(t, hostAwait, clusterName)
+}
+
+// VerifySpacesRecoveredAfterReRegistration verifies that the Spaces with the given names are provisioned (and thus ready) again once
+// reconciled after the re-registration of the member cluster which they target
+func VerifySpacesRecoveredAfterReRegistration(t *testing.T, awaitilities wait.Awaitilities, spaceNames ...string) {
+	for _, name := range spaceNames {
+		triggerSpaceReconcile(t, awaitilities.Host(), name)
+		VerifyResourcesProvisionedForSpace(t, awaitilities, name)
+	}
+}
+
+// VerifySpacesMetricMatchesSpaces waits until the `sandbox_spaces_current` metric of the member cluster with the given name
+// is equal to the number of Spaces which target it
+func VerifySpacesMetricMatchesSpaces(t *testing.T, hostAwait *wait.HostAwaitility, clusterName string) {
+	var spaces int
+	var value float64
+	err := hostAwait.Poll(func() (done bool, err error) {
+		list := &toolchainv1alpha1.SpaceList{}
+		if err := hostAwait.Client.List(context.TODO(), list, client.InNamespace(hostAwait.Namespace)); err != nil {
+			return false, err
+		}
+		spaces = 0
+		for _, s := range list.Items {
+			if s.Spec.TargetCluster == clusterName {
+				spaces++
+			}
+		}
+		value = hostAwait.GetMetricValueOrZero(t, SpacesMetric, "cluster_name", clusterName)
+		return int(value) == spaces, nil
+	})
+	require.NoError(t, err, "metric '%s' of member cluster '%s' is %v but %d Spaces target it", SpacesMetric, clusterName, value, spaces)
+}
+
+// triggerSpaceReconcile sets an annotation with the current time on the Space with the given name, so that it is reconciled
+// by the host operator (which does not watch the ToolchainClusters)
+func triggerSpaceReconcile(t *testing.T, hostAwait *wait.HostAwaitility, name string) {
+	_, err := hostAwait.UpdateSpace(t, name, func(s *toolchainv1alpha1.Space) {
+		if s.Annotations == nil {
+			s.Annotations = map[string]string{}
+		}
+		s.Annotations[reconcileTriggerAnnotationKey] = time.Now().Format(time.RFC3339Nano)
+	})
+	require.NoError(t, err)
+}
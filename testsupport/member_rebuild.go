@@ -0,0 +1,207 @@
+package testsupport
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MemberRebuildVar the env var to set to enable the (destructive) member cluster rebuild scenario
+const MemberRebuildVar = "E2E_MEMBER_REBUILD"
+
+// toolchainNamespacesLabels the labels of the namespaces provisioned by the toolchain
+var toolchainNamespacesLabels = client.MatchingLabels{toolchainv1alpha1.ProviderLabelKey: "codeready-toolchain"}
+
+// unrecoverableSpaceReasons the reasons of the `Ready=False` condition of a Space which is reported as unrecoverable
+var unrecoverableSpaceReasons = map[string]bool{
+	toolchainv1alpha1.SpaceUnableToCreateNSTemplateSetReason: true,
+	toolchainv1alpha1.SpaceUnableToUpdateNSTemplateSetReason: true,
+	toolchainv1alpha1.SpaceProvisioningFailedReason:          true,
+}
+
+// SkipUnlessMemberRebuildEnabled skips the test unless the member cluster rebuild scenario is enabled, since it wipes
+// all the resources provisioned by the toolchain in a member cluster (including the ones of the other tests)
+func SkipUnlessMemberRebuildEnabled(t *testing.T) {
+	if _, found := os.LookupEnv(MemberRebuildVar); !found {
+		t.Skipf("member cluster rebuild scenario is disabled, set the '%s' env var to enable it", MemberRebuildVar)
+	}
+}
+
+// MemberWipe the resources provisioned by the toolchain which were deleted in a member cluster to simulate its rebuild
+type MemberWipe struct {
+	// WipedAt the time at which the resources started to be deleted
+	WipedAt time.Time
+	// Namespaces the names of the deleted namespaces
+	Namespaces []string
+	// NSTemplateSetUIDs the UIDs of the deleted NSTemplateSets, by name
+	NSTemplateSetUIDs map[string]types.UID
+	memberAwait       *wait.MemberAwaitility
+}
+
+// WipeMemberCluster simulates the rebuild of the given member cluster by deleting all the resources provisioned by the toolchain:
+// the NSTemplateSets and UserAccounts in the namespace of the member operator (without letting the member operator clean up after them)
+// and all the namespaces with the provider label. Returns the deleted resources once the namespaces are gone.
+func WipeMemberCluster(t *testing.T, memberAwait *wait.MemberAwaitility) MemberWipe {
+	t.Logf("wiping the resources provisioned by the toolchain in member cluster '%s'", memberAwait.ClusterName)
+	wipe := MemberWipe{
+		WipedAt:           time.Now(),
+		NSTemplateSetUIDs: map[string]types.UID{},
+		memberAwait:       memberAwait,
+	}
+	nsTmplSets := &toolchainv1alpha1.NSTemplateSetList{}
+	require.NoError(t, memberAwait.Client.List(context.TODO(), nsTmplSets, client.InNamespace(memberAwait.Namespace)))
+	for i := range nsTmplSets.Items {
+		wipe.NSTemplateSetUIDs[nsTmplSets.Items[i].Name] = nsTmplSets.Items[i].UID
+		deleteWithoutFinalizers(t, memberAwait, &nsTmplSets.Items[i])
+	}
+	userAccounts := &toolchainv1alpha1.UserAccountList{}
+	require.NoError(t, memberAwait.Client.List(context.TODO(), userAccounts, client.InNamespace(memberAwait.Namespace)))
+	for i := range userAccounts.Items {
+		deleteWithoutFinalizers(t, memberAwait, &userAccounts.Items[i])
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	require.NoError(t, memberAwait.Client.List(context.TODO(), namespaces, toolchainNamespacesLabels))
+	names := make([]string, 0, len(namespaces.Items))
+	for i := range namespaces.Items {
+		if err := memberAwait.Client.Delete(context.TODO(), &namespaces.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			require.NoError(t, err)
+		}
+		names = append(names, namespaces.Items[i].Name)
+	}
+	err := memberAwait.PollWithTimeout(2*memberAwait.Timeout, func() (done bool, err error) {
+		remaining := &corev1.NamespaceList{}
+		if err := memberAwait.Client.List(context.TODO(), remaining, toolchainNamespacesLabels); err != nil {
+			return false, err
+		}
+		for _, ns := range remaining.Items {
+			for _, name := range names {
+				if ns.Name == name {
+					return false, nil
+				}
+			}
+		}
+		return true, nil
+	})
+	require.NoError(t, err, "toolchain namespaces still exist in member cluster '%s'", memberAwait.ClusterName)
+	t.Logf("deleted %d toolchain namespaces in member cluster '%s'", len(names), memberAwait.ClusterName)
+	wipe.Namespaces = names
+	return wipe
+}
+
+// deleteWithoutFinalizers deletes the given object and removes its finalizers, so that it is gone as if the cluster was rebuilt
+func deleteWithoutFinalizers(t *testing.T, memberAwait *wait.MemberAwaitility, obj client.Object) {
+	if err := memberAwait.Client.Delete(context.TODO(), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return
+		}
+		require.NoError(t, err)
+	}
+	if err := memberAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return
+		}
+		require.NoError(t, err)
+	}
+	obj.SetFinalizers(nil)
+	if err := memberAwait.Client.Update(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+		require.NoError(t, err)
+	}
+}
+
+// ReRegisterMemberCluster deletes the ToolchainCluster of the given member cluster in the host cluster, recreates it with the same
+// labels and spec (as it would be when the rebuilt member cluster is registered again), and waits until it is ready
+func ReRegisterMemberCluster(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility) {
+	DeregisterMemberCluster(t, hostAwait, memberAwait).ReRegister(t)
+}
+
+// SpaceRecovery the outcome of the rebuild of a member cluster for a Space which targeted it
+type SpaceRecovery struct {
+	Space *toolchainv1alpha1.Space
+	// Recovered whether the Space was provisioned again in the rebuilt member cluster
+	Recovered bool
+	// Reason the reason of the `Ready=False` condition of the Space when it was reported as unrecoverable
+	Reason string
+}
+
+// VerifySpacesRecovered waits until each Space with the given name is either provisioned again in the rebuilt member cluster
+// (in which case its NSTemplateSet and namespaces are verified), or correctly reported as unrecoverable, ie, with a `Ready=False`
+// condition whose reason is a failure (and not an endless provisioning), and returns the outcome for each Space.
+// Since the Spaces were ready before the wipe, a Space is only considered as provisioned again once its NSTemplateSet was recreated
+// (ie, with a new UID), and as unrecoverable if its `Ready` condition changed after the wipe.
+func VerifySpacesRecovered(t *testing.T, awaitilities wait.Awaitilities, wipe MemberWipe, spaceNames ...string) []SpaceRecovery {
+	hostAwait := awaitilities.Host()
+	memberAwait := wipe.memberAwait
+	recoveries := make([]SpaceRecovery, 0, len(spaceNames))
+	for _, name := range spaceNames {
+		space := &toolchainv1alpha1.Space{}
+		var reason string
+		err := hostAwait.PollWithTimeout(2*hostAwait.Timeout, func() (done bool, err error) {
+			space = &toolchainv1alpha1.Space{}
+			if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: name}, space); err != nil {
+				return false, err
+			}
+			if reason = unrecoverableReason(space, wipe.WipedAt); reason != "" {
+				return true, nil
+			}
+			if !condition.IsTrue(space.Status.Conditions, toolchainv1alpha1.ConditionReady) {
+				return false, nil
+			}
+			nsTmplSet := &toolchainv1alpha1.NSTemplateSet{}
+			if err := memberAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: memberAwait.Namespace, Name: name}, nsTmplSet); err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				return false, err
+			}
+			return nsTmplSet.UID != wipe.NSTemplateSetUIDs[name], nil
+		})
+		require.NoError(t, err, "Space '%s' was neither re-provisioned nor reported as unrecoverable (conditions: %v)", name, space.Status.Conditions)
+
+		recovery := SpaceRecovery{
+			Space:  space,
+			Reason: reason,
+		}
+		if recovery.Reason == "" {
+			recovery.Space, _ = VerifyResourcesProvisionedForSpace(t, awaitilities, name)
+			recovery.Recovered = true
+			t.Logf("Space '%s' was re-provisioned in member cluster '%s'", name, space.Status.TargetCluster)
+		} else {
+			t.Logf("Space '%s' was reported as unrecoverable with reason '%s'", name, recovery.Reason)
+		}
+		recoveries = append(recoveries, recovery)
+	}
+	return recoveries
+}
+
+// AssertAllSpacesRecovered verifies that all the Spaces were provisioned again in the rebuilt member cluster
+func AssertAllSpacesRecovered(t *testing.T, recoveries []SpaceRecovery) {
+	for _, recovery := range recoveries {
+		assert.True(t, recovery.Recovered, "Space '%s' was reported as unrecoverable with reason '%s'", recovery.Space.Name, recovery.Reason)
+	}
+}
+
+// unrecoverableReason returns the reason of the `Ready=False` condition of the given Space if it is a failure which occurred
+// after the given time, or an empty string
+func unrecoverableReason(space *toolchainv1alpha1.Space, after time.Time) string {
+	ready, found := condition.FindConditionByType(space.Status.Conditions, toolchainv1alpha1.ConditionReady)
+	if !found || ready.Status != corev1.ConditionFalse || !unrecoverableSpaceReasons[ready.Reason] {
+		return ""
+	}
+	// the transition times are truncated to the second
+	if ready.LastTransitionTime.Time.Before(after.Truncate(time.Second)) {
+		return ""
+	}
+	return ready.Reason
+}
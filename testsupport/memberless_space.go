@@ -0,0 +1,41 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateMemberlessSpace creates a Space in a custom tier based on the tier with the given name, but without any namespace template,
+// so that only the cluster resources of the tier are provisioned on the member cluster. Returns the Space along with its custom tier.
+func CreateMemberlessSpace(t *testing.T, awaitilities wait.Awaitilities, baseTier string, opts ...SpaceOption) (*toolchainv1alpha1.Space, *tiers.CustomNSTemplateTier) {
+	tier, _ := tiers.CreateCustomTier(t, awaitilities.Host(), baseTier, tiers.WithoutNamespaces())
+	space, _, _ := CreateSpace(t, awaitilities, append(opts, WithTierName(tier.Name))...)
+	return space, tier
+}
+
+// VerifyMemberlessSpace verifies the resources provisioned for a Space of a tier without any namespace template:
+// the NSTemplateSet and the cluster resources exist on the member cluster, but no namespace is provisioned for the Space
+func VerifyMemberlessSpace(t *testing.T, awaitilities wait.Awaitilities, spaceName string, tier *tiers.CustomNSTemplateTier) (*toolchainv1alpha1.Space, *toolchainv1alpha1.NSTemplateSet) {
+	space, err := awaitilities.Host().WaitForSpace(t, spaceName, wait.UntilSpaceHasAnyTargetClusterSet())
+	require.NoError(t, err)
+	memberAwait := getSpaceTargetMember(t, awaitilities, space)
+
+	space, nsTmplSet := VerifyResourcesProvisionedForSpaceWithCustomTier(t, awaitilities.Host(), memberAwait, spaceName, tier)
+	assert.Empty(t, nsTmplSet.Spec.Namespaces)
+	assert.Empty(t, nsTmplSet.Status.ProvisionedNamespaces)
+	assert.Empty(t, space.Status.ProvisionedNamespaces)
+
+	namespaces := &corev1.NamespaceList{}
+	err = memberAwait.Client.List(context.TODO(), namespaces, client.MatchingLabels{toolchainv1alpha1.OwnerLabelKey: spaceName})
+	require.NoError(t, err)
+	assert.Empty(t, namespaces.Items, "no namespace should be provisioned for member-less Space '%s'", spaceName)
+	return space, nsTmplSet
+}
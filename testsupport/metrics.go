@@ -6,20 +6,39 @@ import (
 	"testing"
 	"time"
 
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // MetricsAssertionHelper stores baseline metric values when initialized and has convenient functions for metrics assertions
 type MetricsAssertionHelper struct {
 	await          metricsProvider
 	baselineValues map[string]float64
+	// hostAwait used to count the Space resources, to take into account the spaces created or deleted by other tests
+	hostAwait *wait.HostAwaitility
+	// testName the name of the test which initialized the helper, to identify the resources created by the test and its subtests
+	testName string
+	// baselineOthers the contributions of the other tests to the metrics when the baseline values were captured
+	// (eg, the number of Spaces created by other tests), by baseline key
+	baselineOthers map[string]int
+	// baselineHistograms the baseline buckets of the histogram metrics, by baseline key
+	baselineHistograms map[string]*metrics.Histogram
 }
 
+// OtherContributions returns the contributions of the tests running in parallel to a metric, eg. the number of resources
+// which were not created by the test that initialized the MetricsAssertionHelper (or one of its subtests)
+type OtherContributions func() (int, error)
+
 type metricsProvider interface {
 	GetMetricValue(t *testing.T, family string, labels ...string) float64
 	GetMetricValueOrZero(t *testing.T, family string, labels ...string) float64
+	GetHistogram(t *testing.T, family string, labels ...string) *metrics.Histogram
+	GetLabelBuckets(t *testing.T, family, bucketLabel string, labels ...string) *metrics.Histogram
 	WaitForTestResourcesCleanup(t *testing.T, initialDelay time.Duration) error
 	WaitUntiltMetricHasValue(t *testing.T, family string, expectedValue float64, labels ...string)
 }
@@ -52,10 +71,18 @@ func InitMetricsAssertion(t *testing.T, awaitilities wait.Awaitilities) *Metrics
 
 	// Capture baseline values
 	m := &MetricsAssertionHelper{
-		await:          awaitilities.Host(),
-		baselineValues: make(map[string]float64),
+		await:              awaitilities.Host(),
+		baselineValues:     make(map[string]float64),
+		hostAwait:          awaitilities.Host(),
+		testName:           t.Name(),
+		baselineOthers:     make(map[string]int),
+		baselineHistograms: make(map[string]*metrics.Histogram),
+	}
+	memberClusterNames := make([]string, 0, len(awaitilities.AllMembers()))
+	for _, member := range awaitilities.AllMembers() {
+		memberClusterNames = append(memberClusterNames, member.ClusterName)
 	}
-	m.captureBaselineValues(t, awaitilities.Member1().ClusterName, awaitilities.Member2().ClusterName)
+	m.captureBaselineValues(t, memberClusterNames...)
 	t.Logf("captured baselines:\n%s", spew.Sdump(m.baselineValues))
 	return m
 }
@@ -69,6 +96,11 @@ func (m *MetricsAssertionHelper) captureBaselineValues(t *testing.T, memberClust
 	for _, name := range memberClusterNames { // sum of gauge value of all member clusters
 		spacesKey := m.baselineKey(t, SpacesMetric, "cluster_name", name)
 		m.baselineValues[spacesKey] += m.await.GetMetricValue(t, SpacesMetric, "cluster_name", name)
+		if m.hostAwait != nil {
+			others, err := m.countSpacesFromOtherTests("cluster_name", name)
+			require.NoError(t, err)
+			m.baselineOthers[spacesKey] = others
+		}
 	}
 	// capture `sandbox_users_per_activations_and_domain` with "activations" from `1` to `10` and `internal`/`external` domains
 	for i := 1; i <= 10; i++ {
@@ -77,6 +109,11 @@ func (m *MetricsAssertionHelper) captureBaselineValues(t *testing.T, memberClust
 			m.baselineValues[key] = m.await.GetMetricValueOrZero(t, UsersPerActivationsAndDomainMetric, "activations", strconv.Itoa(i), "domain", domain)
 		}
 	}
+	// also capture them as the buckets of a histogram per domain, to assert the moves of the users between the buckets
+	for _, domain := range []string{"internal", "external"} {
+		key := m.baselineKey(t, UsersPerActivationsAndDomainMetric, "domain", domain)
+		m.baselineHistograms[key] = m.await.GetLabelBuckets(t, UsersPerActivationsAndDomainMetric, "activations", "domain", domain)
+	}
 	for _, domain := range []string{"internal", "external"} {
 		key := m.baselineKey(t, MasterUserRecordsPerDomainMetric, "domain", domain)
 		m.baselineValues[key] = m.await.GetMetricValueOrZero(t, MasterUserRecordsPerDomainMetric, "domain", domain)
@@ -98,6 +135,53 @@ func (m *MetricsAssertionHelper) WaitForMetricBaseline(t *testing.T, family stri
 	m.await.WaitUntiltMetricHasValue(t, family, m.baselineValues[key], labels...)
 }
 
+// WaitForUsersPerActivationsDelta waits for the `sandbox_users_per_activations_and_domain` gauges of the given domain to reach
+// their baseline values adjusted with the given deltas, where `deltas[i]` is the delta of the users activated `i+1` times.
+// The gauges are decoded as the buckets of a histogram of the number of activations per user: when a user is reactivated, it moves
+// from the bucket of its previous number of activations to the next one, hence a reactivation is a `-1`/`+1` pair of deltas.
+// The buckets after the given deltas are expected to be unchanged.
+func (m *MetricsAssertionHelper) WaitForUsersPerActivationsDelta(t *testing.T, domain string, deltas ...int) {
+	bucketDeltas := make(map[float64]int, len(deltas))
+	for i, delta := range deltas {
+		bucketDeltas[float64(i+1)] = delta
+	}
+	m.waitForBucketsDelta(t, UsersPerActivationsAndDomainMetric, bucketDeltas, func() *metrics.Histogram {
+		return m.await.GetLabelBuckets(t, UsersPerActivationsAndDomainMetric, "activations", "domain", domain)
+	}, "domain", domain)
+}
+
+// CaptureHistogramBaseline captures the baseline buckets of the histogram metric with the given labels, which is needed
+// before calling WaitForHistogramBucketsDelta (the histograms are not captured when the helper is initialized)
+func (m *MetricsAssertionHelper) CaptureHistogramBaseline(t *testing.T, family string, labels ...string) {
+	key := m.baselineKey(t, family, labels...)
+	m.baselineHistograms[key] = m.await.GetHistogram(t, family, labels...)
+	t.Logf("captured baseline of histogram '%s{%v}': %v", family, labels, m.baselineHistograms[key].Buckets)
+}
+
+// WaitForHistogramBucketsDelta waits for the buckets of the histogram metric with the given labels to reach their baseline
+// values adjusted with the given deltas, by upper bound of the buckets. Contrary to the values exposed by the metrics endpoint,
+// the deltas are not cumulative: a delta only applies to the observations in its bucket (eg, an observation moved from the
+// `1` bucket to the `2` bucket is a `-1`/`+1` pair of deltas). The buckets without delta are expected to be unchanged.
+func (m *MetricsAssertionHelper) WaitForHistogramBucketsDelta(t *testing.T, family string, deltas map[float64]int, labels ...string) {
+	m.waitForBucketsDelta(t, family, deltas, func() *metrics.Histogram {
+		return m.await.GetHistogram(t, family, labels...)
+	}, labels...)
+}
+
+// waitForBucketsDelta waits for the buckets of the histogram returned by the given func to reach the baseline buckets of the given
+// family and labels, adjusted with the given deltas
+func (m *MetricsAssertionHelper) waitForBucketsDelta(t *testing.T, family string, deltas map[float64]int, histogram func() *metrics.Histogram, labels ...string) {
+	key := m.baselineKey(t, family, labels...)
+	baseline, found := m.baselineHistograms[key]
+	require.True(t, found, "no baseline captured for histogram '%s{%v}'", family, labels)
+	var mismatches []string
+	err := m.hostAwait.Poll(func() (done bool, err error) {
+		mismatches = metrics.BucketsMismatches(baseline, histogram(), deltas)
+		return len(mismatches) == 0, nil
+	})
+	require.NoError(t, err, "buckets of histogram '%s{%v}' did not reach the expected values:\n%s", family, labels, strings.Join(mismatches, "\n"))
+}
+
 // generates a key to retain the baseline metric value, by joining the metric name and its labels.
 // Note: there are probably more sophisticated ways to combine the name and the labels, but for now
 // this simple concatenation should be enough to make the keys unique
@@ -107,3 +191,143 @@ func (m *MetricsAssertionHelper) baselineKey(t *testing.T, name string, labelAnd
 	}
 	return strings.Join(append([]string{name}, labelAndValues...), ",")
 }
+
+// WaitForMetricDeltaAtLeast waits for the metric value to reach the baseline value increased by the given delta, or more.
+// Contrary to WaitForMetricDelta, it tolerates the increments caused by the tests running in parallel, hence it should be used
+// in the parallel tests, for the counters (which are never decremented) with the number of increments caused by the current test
+// as the delta. The serial tests own the cluster and use WaitForMetricDelta, which also catches the extra increments (eg, a double count).
+func (m *MetricsAssertionHelper) WaitForMetricDeltaAtLeast(t *testing.T, family string, delta float64, labels ...string) {
+	key := m.baselineKey(t, family, labels...)
+	expected := m.baselineValues[key] + delta
+	var actual float64
+	err := m.hostAwait.Poll(func() (done bool, err error) {
+		actual = m.await.GetMetricValueOrZero(t, family, labels...)
+		return actual >= expected, nil
+	})
+	require.NoError(t, err, "metric '%s' with labels %v: expected at least %v (baseline %v, delta %v) but was %v",
+		family, labels, expected, m.baselineValues[key], delta, actual)
+}
+
+// WaitForMetricDeltaExcludingOthers waits for the metric value to reach the baseline value adjusted with the given delta and with
+// the changes of the contributions of the other tests since the baseline was captured (eg, for a gauge of resources, the resources
+// created or deleted in the meantime by the tests running in parallel), so that the delta is only attributable to the current test.
+func (m *MetricsAssertionHelper) WaitForMetricDeltaExcludingOthers(t *testing.T, family string, delta float64, others OtherContributions, labels ...string) {
+	key := m.baselineKey(t, family, labels...)
+	baselineOthers, found := m.baselineOthers[key]
+	if !found {
+		// capture the contributions of the other tests for this combination of labels
+		m.captureBaselineExcludingOthers(t, family, others, labels...)
+		baselineOthers = m.baselineOthers[key]
+	}
+	var expected, actual float64
+	err := m.hostAwait.Poll(func() (done bool, err error) {
+		count, err := others()
+		if err != nil {
+			return false, err
+		}
+		expected = m.baselineValues[key] + delta + float64(count-baselineOthers)
+		actual = m.await.GetMetricValueOrZero(t, family, labels...)
+		return actual == expected, nil
+	})
+	require.NoError(t, err, "metric '%s' with labels %v: expected %v (baseline %v, delta %v, other tests %+d) but was %v",
+		family, labels, expected, m.baselineValues[key], delta, int(expected-m.baselineValues[key]-delta), actual)
+}
+
+// captureBaselineExcludingOthers captures the baseline value of the metric with the given labels along with the contributions
+// of the other tests, for the combinations of labels which are not captured when the helper is initialized
+func (m *MetricsAssertionHelper) captureBaselineExcludingOthers(t *testing.T, family string, others OtherContributions, labels ...string) {
+	key := m.baselineKey(t, family, labels...)
+	count, err := others()
+	require.NoError(t, err)
+	m.baselineOthers[key] = count
+	m.baselineValues[key] = m.await.GetMetricValueOrZero(t, family, labels...)
+}
+
+// ResourcesFromOtherTests returns an OtherContributions which counts the resources of the list returned by `newList`
+// which match the given filter (if any) and which were not created by the test that initialized the helper (or one of its subtests)
+func (m *MetricsAssertionHelper) ResourcesFromOtherTests(newList func() client.ObjectList, filter func(client.Object) bool) OtherContributions {
+	return func() (int, error) {
+		list := newList()
+		if err := m.hostAwait.ListPaginated(list); err != nil {
+			return 0, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return 0, err
+		}
+		count := 0
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || m.isCreatedByTest(obj) || (filter != nil && !filter(obj)) {
+				continue
+			}
+			count++
+		}
+		return count, nil
+	}
+}
+
+// WaitForSpacesMetricDelta waits for the `sandbox_spaces_current` metric with the given labels (`cluster_name` and/or `tier`) to reach
+// the baseline value adjusted with the given delta, while taking into account the Spaces created or deleted in the meantime by other tests
+// (ie, the Spaces which were not created - directly or via a UserSignup - by the test which initialized the helper or its subtests)
+func (m *MetricsAssertionHelper) WaitForSpacesMetricDelta(t *testing.T, delta float64, labels ...string) {
+	m.WaitForMetricDeltaExcludingOthers(t, SpacesMetric, delta, func() (int, error) {
+		return m.countSpacesFromOtherTests(labels...)
+	}, labels...)
+}
+
+// CaptureSpacesMetricBaseline captures the baseline value of the `sandbox_spaces_current` metric with the given labels, which is
+// needed before the Spaces are changed when the labels are not only the `cluster_name` (eg, before moving a Space to another tier)
+func (m *MetricsAssertionHelper) CaptureSpacesMetricBaseline(t *testing.T, labels ...string) {
+	m.captureBaselineExcludingOthers(t, SpacesMetric, func() (int, error) {
+		return m.countSpacesFromOtherTests(labels...)
+	}, labels...)
+}
+
+// countSpacesFromOtherTests returns the number of Spaces matching the given metric labels which were not created
+// by the test that initialized the helper (or one of its subtests), either directly or via a UserSignup
+func (m *MetricsAssertionHelper) countSpacesFromOtherTests(labels ...string) (int, error) {
+	signups := &toolchainv1alpha1.UserSignupList{}
+	if err := m.hostAwait.ListPaginated(signups, client.MatchingLabels{wait.RunIDLabelKey: wait.RunID()}); err != nil {
+		return 0, err
+	}
+	ownSignups := map[string]bool{}
+	for i := range signups.Items {
+		if m.isCreatedByTest(&signups.Items[i]) {
+			ownSignups[signups.Items[i].Name] = true
+		}
+	}
+	spaces := &toolchainv1alpha1.SpaceList{}
+	if err := m.hostAwait.ListPaginated(spaces); err != nil {
+		return 0, err
+	}
+	count := 0
+	for i, space := range spaces.Items {
+		if !spaceMatchesMetricLabels(space, labels...) || m.isCreatedByTest(&spaces.Items[i]) || ownSignups[space.Labels[toolchainv1alpha1.SpaceCreatorLabelKey]] {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (m *MetricsAssertionHelper) isCreatedByTest(obj client.Object) bool {
+	return wait.CreatedByTest(obj, m.testName)
+}
+
+// spaceMatchesMetricLabels returns true if the given Space matches the `cluster_name` and `tier` labels (other labels are ignored)
+func spaceMatchesMetricLabels(space toolchainv1alpha1.Space, labels ...string) bool {
+	for i := 0; i+1 < len(labels); i += 2 {
+		switch labels[i] {
+		case "cluster_name":
+			if space.Status.TargetCluster != labels[i+1] {
+				return false
+			}
+		case "tier":
+			if space.Spec.TierName != labels[i+1] {
+				return false
+			}
+		}
+	}
+	return true
+}
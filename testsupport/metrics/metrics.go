@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
 	"k8s.io/client-go/rest"
@@ -18,6 +20,169 @@ func GetMetricValue(restConfig *rest.Config, url string, family string, expected
 	if len(expectedLabels)%2 != 0 {
 		return -1, fmt.Errorf("received odd number of label arguments, labels must be key-value pairs")
 	}
+	families, err := getMetricFamilies(restConfig, url)
+	if err != nil {
+		return -1, err
+	}
+	if f, m := findMetric(families, family, expectedLabels); m != nil {
+		return getValue(f.GetType(), m)
+	}
+	// here we can return `0` is the metric does not exist, which may be valid if the expected value is `0`, too.
+	return 0, fmt.Errorf("metric '%s{%v}' not found", family, expectedLabels)
+}
+
+// Histogram the content of a histogram metric, decoded from the metrics endpoint
+type Histogram struct {
+	// Count the total number of observations
+	Count uint64
+	// Sum the sum of all the observed values
+	Sum float64
+	// Buckets the cumulative number of observations, by upper bound of the buckets (the `le` label),
+	// ie, the number of observations which are lower or equal to the upper bound
+	Buckets map[float64]uint64
+}
+
+// CountInBucket returns the number of observations in the bucket with the given upper bound only (ie, not cumulated
+// with the buckets with a lower upper bound), or `0` if there is no such bucket
+func (h *Histogram) CountInBucket(upperBound float64) uint64 {
+	count, found := h.Buckets[upperBound]
+	if !found {
+		return 0
+	}
+	var previous uint64
+	for bound, c := range h.Buckets {
+		if bound < upperBound && c > previous {
+			previous = c
+		}
+	}
+	return count - previous
+}
+
+// GetHistogram gets the buckets, count and sum of the histogram metric with the given family and labels (without the `le` label of the buckets)
+func GetHistogram(restConfig *rest.Config, url string, family string, expectedLabels []string) (*Histogram, error) {
+	if len(expectedLabels)%2 != 0 {
+		return nil, fmt.Errorf("received odd number of label arguments, labels must be key-value pairs")
+	}
+	families, err := getMetricFamilies(restConfig, url)
+	if err != nil {
+		return nil, err
+	}
+	f, m := findMetric(families, family, expectedLabels)
+	if m == nil {
+		return nil, fmt.Errorf("metric '%s{%v}' not found", family, expectedLabels)
+	}
+	if f.GetType() != dto.MetricType_HISTOGRAM {
+		return nil, fmt.Errorf("metric '%s' is not a histogram but a %s", family, f.GetType().String())
+	}
+	h := &Histogram{
+		Count:   m.GetHistogram().GetSampleCount(),
+		Sum:     m.GetHistogram().GetSampleSum(),
+		Buckets: map[float64]uint64{},
+	}
+	for _, b := range m.GetHistogram().GetBucket() {
+		h.Buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	return h, nil
+}
+
+// GetLabelBuckets gets the gauges of the given family whose other labels match the given labels, as the buckets of a histogram whose
+// upper bounds are the (numeric) values of the given bucket label (eg, the `activations` label of the `sandbox_users_per_activations_and_domain`
+// gauges, which count the users by number of activations). Since the gauges are not cumulative, they are cumulated in the buckets of the
+// returned histogram, as for a histogram metric. The histogram has no bucket if there is no matching gauge.
+func GetLabelBuckets(restConfig *rest.Config, url string, family, bucketLabel string, expectedLabels []string) (*Histogram, error) {
+	if len(expectedLabels)%2 != 0 {
+		return nil, fmt.Errorf("received odd number of label arguments, labels must be key-value pairs")
+	}
+	families, err := getMetricFamilies(restConfig, url)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[float64]uint64{}
+	if f, found := families[family]; found {
+	gauges:
+		for _, m := range f.GetMetric() {
+			if len(m.GetLabel()) != len(expectedLabels)/2+1 {
+				continue
+			}
+			var bound *float64
+			for _, l := range m.GetLabel() {
+				if l.GetName() == bucketLabel {
+					b, err := strconv.ParseFloat(l.GetValue(), 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid value of label '%s' of metric '%s': %w", bucketLabel, family, err)
+					}
+					bound = &b
+				} else if !hasLabel(expectedLabels, l.GetName(), l.GetValue()) {
+					continue gauges
+				}
+			}
+			if bound == nil {
+				continue
+			}
+			value, err := getValue(f.GetType(), m)
+			if err != nil {
+				return nil, err
+			}
+			counts[*bound] += uint64(value)
+		}
+	}
+	bounds := make([]float64, 0, len(counts))
+	for bound := range counts {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+	h := &Histogram{
+		Buckets: map[float64]uint64{},
+	}
+	for _, bound := range bounds {
+		h.Count += counts[bound]
+		h.Sum += bound * float64(counts[bound])
+		h.Buckets[bound] = h.Count
+	}
+	return h, nil
+}
+
+// BucketsMismatches returns the buckets of the actual histogram whose number of observations (not cumulated) do not match the number
+// in the same bucket of the baseline histogram adjusted with the given deltas, by upper bound of the buckets. The buckets without delta
+// are expected to be unchanged.
+func BucketsMismatches(baseline, actual *Histogram, deltas map[float64]int) []string {
+	bounds := map[float64]bool{}
+	for bound := range baseline.Buckets {
+		bounds[bound] = true
+	}
+	for bound := range actual.Buckets {
+		bounds[bound] = true
+	}
+	for bound := range deltas {
+		bounds[bound] = true
+	}
+	sorted := make([]float64, 0, len(bounds))
+	for bound := range bounds {
+		sorted = append(sorted, bound)
+	}
+	sort.Float64s(sorted)
+	var mismatches []string
+	for _, bound := range sorted {
+		expected := int(baseline.CountInBucket(bound)) + deltas[bound]
+		if count := int(actual.CountInBucket(bound)); count != expected {
+			mismatches = append(mismatches, fmt.Sprintf("bucket 'le=%v': expected %d (baseline %d, delta %+d) but was %d", bound, expected, baseline.CountInBucket(bound), deltas[bound], count))
+		}
+	}
+	return mismatches
+}
+
+// hasLabel returns `true` if the given label key-value pairs contain the given label with the given value
+func hasLabel(labels []string, name, value string) bool {
+	for i := 0; i+1 < len(labels); i += 2 {
+		if labels[i] == name && labels[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getMetricFamilies fetches and parses all the metrics exposed by the endpoint at the given URL
+func getMetricFamilies(restConfig *rest.Config, url string) (map[string]*dto.MetricFamily, error) {
 	uri := fmt.Sprintf("https://%s/metrics", url)
 	var metrics []byte
 
@@ -29,33 +194,33 @@ func GetMetricValue(restConfig *rest.Config, url string, family string, expected
 	}
 	request, err := http.NewRequest("Get", uri, nil)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", restConfig.BearerToken))
 	resp, err := client.Do(request)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 	metrics, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	// parse the metrics
 	parser := expfmt.TextParser{}
-	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
-	if err != nil {
-		return -1, err
-	}
+	return parser.TextToMetricFamilies(bytes.NewReader(metrics))
+}
+
+// findMetric returns the family and the metric with the given family name and labels, or `nil` if there is no such metric
+func findMetric(families map[string]*dto.MetricFamily, family string, expectedLabels []string) (*dto.MetricFamily, *dto.Metric) {
 	for _, f := range families {
 		if f.GetName() == family {
-			metricType := f.GetType()
 			// metric without labels
 			if len(f.GetMetric()) == 1 && len(expectedLabels) == 0 {
-				return getValue(metricType, f.GetMetric()[0])
+				return f, f.GetMetric()[0]
 			}
 
 		metricSearch:
@@ -76,12 +241,11 @@ func GetMetricValue(restConfig *rest.Config, url string, family string, expected
 					}
 					i += 2
 				}
-				return getValue(metricType, m)
+				return f, m
 			}
 		}
 	}
-	// here we can return `0` is the metric does not exist, which may be valid if the expected value is `0`, too.
-	return 0, fmt.Errorf("metric '%s{%v}' not found", family, expectedLabels)
+	return nil, nil
 }
 
 func getValue(t dto.MetricType, m *dto.Metric) (float64, error) {
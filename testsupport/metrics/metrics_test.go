@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -45,6 +46,26 @@ sandbox_user_signups_approved_total 7
 # HELP sandbox_user_signups_total Total number of unique User Signups
 # TYPE sandbox_user_signups_total counter
 sandbox_user_signups_total 7
+# HELP sandbox_user_activations Number of activations per user
+# TYPE sandbox_user_activations histogram
+sandbox_user_activations_bucket{domain="external",le="1"} 3
+sandbox_user_activations_bucket{domain="external",le="2"} 5
+sandbox_user_activations_bucket{domain="external",le="3"} 6
+sandbox_user_activations_bucket{domain="external",le="+Inf"} 6
+sandbox_user_activations_sum{domain="external"} 10
+sandbox_user_activations_count{domain="external"} 6
+sandbox_user_activations_bucket{domain="internal",le="1"} 1
+sandbox_user_activations_bucket{domain="internal",le="2"} 1
+sandbox_user_activations_bucket{domain="internal",le="3"} 1
+sandbox_user_activations_bucket{domain="internal",le="+Inf"} 1
+sandbox_user_activations_sum{domain="internal"} 1
+sandbox_user_activations_count{domain="internal"} 1
+# HELP sandbox_users_per_activations_and_domain Number of UserSignups per activations and domain
+# TYPE sandbox_users_per_activations_and_domain gauge
+sandbox_users_per_activations_and_domain{activations="1",domain="external"} 4
+sandbox_users_per_activations_and_domain{activations="2",domain="external"} 2
+sandbox_users_per_activations_and_domain{activations="4",domain="external"} 1
+sandbox_users_per_activations_and_domain{activations="1",domain="internal"} 3
 `
 
 func TestGetMetricValue(t *testing.T) {
@@ -127,3 +148,159 @@ func TestGetMetricValue(t *testing.T) {
 		})
 	})
 }
+
+func TestGetHistogram(t *testing.T) {
+	// given
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, response)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	config := &rest.Config{
+		BearerToken: "1a2b3bc",
+	}
+
+	url := strings.TrimPrefix(ts.URL, "https://")
+
+	t.Run("valid histogram", func(t *testing.T) {
+		// when
+		result, err := GetHistogram(config, url, "sandbox_user_activations", []string{"domain", "external"})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), result.Count)
+		assert.Equal(t, float64(10), result.Sum)
+		assert.Equal(t, map[float64]uint64{1: 3, 2: 5, 3: 6, math.Inf(1): 6}, result.Buckets)
+		assert.Equal(t, uint64(3), result.CountInBucket(1))
+		assert.Equal(t, uint64(2), result.CountInBucket(2))
+		assert.Equal(t, uint64(1), result.CountInBucket(3))
+		assert.Equal(t, uint64(0), result.CountInBucket(math.Inf(1)))
+		assert.Equal(t, uint64(0), result.CountInBucket(4)) // unknown bucket
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Run("metric does not exist", func(t *testing.T) {
+			// when
+			result, err := GetHistogram(config, url, "non_existent_histogram", []string{})
+			// then
+			require.EqualError(t, err, "metric 'non_existent_histogram{[]}' not found")
+			assert.Nil(t, result)
+		})
+
+		t.Run("labels do not match", func(t *testing.T) {
+			// when
+			result, err := GetHistogram(config, url, "sandbox_user_activations", []string{"domain", "unknown"})
+			// then
+			require.EqualError(t, err, "metric 'sandbox_user_activations{[domain unknown]}' not found")
+			assert.Nil(t, result)
+		})
+
+		t.Run("metric is not a histogram", func(t *testing.T) {
+			// when
+			result, err := GetHistogram(config, url, "sandbox_user_signups_total", []string{})
+			// then
+			require.EqualError(t, err, "metric 'sandbox_user_signups_total' is not a histogram but a COUNTER")
+			assert.Nil(t, result)
+		})
+
+		t.Run("odd number of label parameters", func(t *testing.T) {
+			// when
+			result, err := GetHistogram(config, url, "sandbox_user_activations", []string{"domain"})
+			// then
+			require.EqualError(t, err, "received odd number of label arguments, labels must be key-value pairs")
+			assert.Nil(t, result)
+		})
+	})
+}
+
+func TestGetLabelBuckets(t *testing.T) {
+	// given
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, response)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	config := &rest.Config{
+		BearerToken: "1a2b3bc",
+	}
+
+	url := strings.TrimPrefix(ts.URL, "https://")
+
+	t.Run("gauges as buckets", func(t *testing.T) {
+		// when
+		result, err := GetLabelBuckets(config, url, "sandbox_users_per_activations_and_domain", "activations", []string{"domain", "external"})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), result.Count)
+		assert.Equal(t, float64(12), result.Sum)
+		assert.Equal(t, map[float64]uint64{1: 4, 2: 6, 4: 7}, result.Buckets)
+		assert.Equal(t, uint64(4), result.CountInBucket(1))
+		assert.Equal(t, uint64(2), result.CountInBucket(2))
+		assert.Equal(t, uint64(0), result.CountInBucket(3)) // no gauge
+		assert.Equal(t, uint64(1), result.CountInBucket(4))
+	})
+
+	t.Run("no matching gauge", func(t *testing.T) {
+		// when
+		result, err := GetLabelBuckets(config, url, "sandbox_users_per_activations_and_domain", "activations", []string{"domain", "unknown"})
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), result.Count)
+		assert.Empty(t, result.Buckets)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Run("invalid bucket label", func(t *testing.T) {
+			// when
+			result, err := GetLabelBuckets(config, url, "sandbox_users_per_activations_and_domain", "domain", []string{"activations", "1"})
+			// then
+			require.EqualError(t, err, `invalid value of label 'domain' of metric 'sandbox_users_per_activations_and_domain': strconv.ParseFloat: parsing "external": invalid syntax`)
+			assert.Nil(t, result)
+		})
+
+		t.Run("odd number of label parameters", func(t *testing.T) {
+			// when
+			result, err := GetLabelBuckets(config, url, "sandbox_users_per_activations_and_domain", "activations", []string{"domain"})
+			// then
+			require.EqualError(t, err, "received odd number of label arguments, labels must be key-value pairs")
+			assert.Nil(t, result)
+		})
+	})
+}
+
+func TestBucketsMismatches(t *testing.T) {
+	// given
+	baseline := &Histogram{Buckets: map[float64]uint64{1: 4, 2: 6, 3: 7}} // 4, 2 and 1 observations
+
+	t.Run("no mismatch", func(t *testing.T) {
+		// given an observation moved from the `3` bucket to the `4` bucket, and a new observation in the `1` bucket
+		actual := &Histogram{Buckets: map[float64]uint64{1: 5, 2: 7, 3: 7, 4: 8}}
+
+		// when
+		mismatches := BucketsMismatches(baseline, actual, map[float64]int{1: 1, 3: -1, 4: 1})
+
+		// then
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("mismatches", func(t *testing.T) {
+		// given an observation moved from the `3` bucket to the `4` bucket, but none in the `1` bucket
+		actual := &Histogram{Buckets: map[float64]uint64{1: 4, 2: 6, 3: 6, 4: 7}}
+
+		// when
+		mismatches := BucketsMismatches(baseline, actual, map[float64]int{1: 1, 2: 0, 4: 1})
+
+		// then
+		assert.Equal(t, []string{
+			"bucket 'le=1': expected 5 (baseline 4, delta +1) but was 4",
+			"bucket 'le=3': expected 1 (baseline 1, delta +0) but was 0",
+		}, mismatches)
+	})
+}
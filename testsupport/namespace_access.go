@@ -0,0 +1,53 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VerifyNamespaceManagerAccess requests a token for the `namespace-manager` ServiceAccount of the given namespace
+// and verifies that it grants access to this namespace (and only to this namespace)
+func VerifyNamespaceManagerAccess(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) {
+	token, err := memberAwait.WaitForServiceAccountToken(t, namespace, wait.NamespaceManagerServiceAccountName)
+	require.NoError(t, err)
+	VerifyNamespaceAccess(t, memberAwait, memberAwait.NewRestConfigWithToken(token), namespace)
+
+	// also verify the effective permissions of the ServiceAccount (including the ones of the aggregated roles)
+	username := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, wait.NamespaceManagerServiceAccountName)
+	configMaps := corev1.SchemeGroupVersion.WithResource("configmaps")
+	AssertUserCan(t, memberAwait.Awaitility, username, "create", configMaps, namespace)
+	AssertUserCannot(t, memberAwait.Awaitility, username, "list", configMaps, memberAwait.Namespace)
+}
+
+// VerifyNamespaceAccess verifies that the given config grants access to the given namespace, by creating, reading and deleting a ConfigMap,
+// and that it does not grant access to the namespace of the member operator
+func VerifyNamespaceAccess(t *testing.T, memberAwait *wait.MemberAwaitility, config *rest.Config, namespace string) {
+	cl, err := memberAwait.NewClientWithConfig(config)
+	require.NoError(t, err)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "namespace-access-",
+			Namespace:    namespace,
+		},
+		Data: map[string]string{
+			"verified": "true",
+		},
+	}
+	require.NoError(t, cl.Create(context.TODO(), cm), "unable to create a ConfigMap in namespace '%s'", namespace)
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{}), "unable to read a ConfigMap in namespace '%s'", namespace)
+	require.NoError(t, cl.Delete(context.TODO(), cm), "unable to delete a ConfigMap in namespace '%s'", namespace)
+
+	err = cl.List(context.TODO(), &corev1.ConfigMapList{}, client.InNamespace(memberAwait.Namespace))
+	assert.True(t, apierrors.IsForbidden(err), "expected access to namespace '%s' to be forbidden, but got: %v", memberAwait.Namespace, err)
+}
@@ -0,0 +1,160 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeletionOutcome the outcome of the attempt of a user to delete a resource managed by the toolchain
+type DeletionOutcome string
+
+const (
+	// DeletionPrevented the deletion was rejected (by RBAC or by a webhook) and the resource was left untouched
+	DeletionPrevented DeletionOutcome = "prevented"
+	// DeletionRepaired the deletion was accepted, and the resource was then recreated by the member operator
+	DeletionRepaired DeletionOutcome = "repaired"
+)
+
+// DeletionAttempt the attempt of a user to delete a resource managed by the toolchain
+type DeletionAttempt struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Outcome   DeletionOutcome
+}
+
+// managedKinds the kinds of the namespaced resources which are provisioned by the tier templates, and which a user could try to delete
+var managedKinds = []struct {
+	kind    string
+	newList func() client.ObjectList
+}{
+	{kind: "Role", newList: func() client.ObjectList { return &rbacv1.RoleList{} }},
+	{kind: "RoleBinding", newList: func() client.ObjectList { return &rbacv1.RoleBindingList{} }},
+	{kind: "ResourceQuota", newList: func() client.ObjectList { return &corev1.ResourceQuotaList{} }},
+	{kind: "LimitRange", newList: func() client.ObjectList { return &corev1.LimitRangeList{} }},
+}
+
+// VerifyNamespaceDeletionProtection makes the given user (via an impersonated client) try to delete the namespaces of the given Space and
+// the resources provisioned in these namespaces by the tier templates, and verifies that each deletion is either prevented (by RBAC
+// or by a webhook), or repaired by the member operator, ie, that the Space is eventually provisioned as it was. Returns the attempts.
+func VerifyNamespaceDeletionProtection(t *testing.T, awaitilities wait.Awaitilities, spaceName, username string) []DeletionAttempt {
+	hostAwait := awaitilities.Host()
+	space, err := hostAwait.WaitForSpace(t, spaceName, wait.UntilSpaceHasAnyProvisionedNamespaces())
+	require.NoError(t, err)
+	memberAwait := getSpaceTargetMember(t, awaitilities, space)
+	userAwait, err := memberAwait.Impersonate(username)
+	require.NoError(t, err)
+
+	var attempts []DeletionAttempt
+	var deleted []client.Object
+	for _, ns := range space.Status.ProvisionedNamespaces {
+		objs := []client.Object{}
+		for _, managed := range managedKinds {
+			list := managed.newList()
+			require.NoError(t, memberAwait.Client.List(context.TODO(), list, client.InNamespace(ns.Name), toolchainNamespacesLabels))
+			items, err := meta.ExtractList(list)
+			require.NoError(t, err)
+			for _, item := range items {
+				obj := item.(client.Object)
+				objs = append(objs, obj)
+				attempts = append(attempts, DeletionAttempt{Kind: managed.kind, Namespace: ns.Name, Name: obj.GetName()})
+			}
+		}
+		namespace := &corev1.Namespace{}
+		require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: ns.Name}, namespace))
+		// the namespace is deleted last, so that the deletion of its content is not rejected because it is terminating
+		objs = append(objs, namespace)
+		attempts = append(attempts, DeletionAttempt{Kind: "Namespace", Name: ns.Name})
+
+		for i, obj := range objs {
+			attempt := &attempts[len(attempts)-len(objs)+i]
+			if deleteAsUser(t, userAwait.Client, obj) {
+				t.Logf("user '%s' deleted %s '%s' in namespace '%s'", username, attempt.Kind, attempt.Name, attempt.Namespace)
+				deleted = append(deleted, obj)
+				attempt.Outcome = DeletionRepaired
+				continue
+			}
+			verifyNotDeleted(t, memberAwait, obj)
+			attempt.Outcome = DeletionPrevented
+		}
+	}
+
+	if len(deleted) > 0 {
+		// the member operator does not watch all the kinds of resources which it provisions, hence the reconcile of the NSTemplateSet
+		triggerNSTemplateSetReconcile(t, memberAwait, space.Status.ProvisionedNamespaces)
+		for _, obj := range deleted {
+			waitUntilRecreated(t, memberAwait, obj)
+		}
+	}
+	VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name)
+	return attempts
+}
+
+// triggerNSTemplateSetReconcile sets an annotation with the current time on one of the given namespaces which is not being deleted, so
+// that the NSTemplateSet which owns the namespace is reconciled by the member operator, which watches the namespaces that it provisions.
+// Contrary to a change in the annotations of the NSTemplateSet, which does not change its generation, the update of the namespace is
+// never filtered out. If all the namespaces are being deleted, their deletion already triggered the reconcile.
+func triggerNSTemplateSetReconcile(t *testing.T, memberAwait *wait.MemberAwaitility, namespaces []toolchainv1alpha1.SpaceNamespace) {
+	for _, ns := range namespaces {
+		namespace := &corev1.Namespace{}
+		require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: ns.Name}, namespace))
+		if namespace.DeletionTimestamp != nil {
+			continue
+		}
+		_, err := wait.UpdateInNamespace(t, memberAwait.Awaitility, "", ns.Name, func(namespace *corev1.Namespace) {
+			if namespace.Annotations == nil {
+				namespace.Annotations = map[string]string{}
+			}
+			namespace.Annotations[reconcileTriggerAnnotationKey] = time.Now().Format(time.RFC3339Nano)
+		})
+		require.NoError(t, err)
+		return
+	}
+}
+
+// deleteAsUser deletes// deleteAsUser deletes the given object with the given client of the user, and returns `true` if the deletion was accepted,
+// or `false` if it was rejected (in which case the error must be `Forbidden`)
+func deleteAsUser(t *testing.T, cl client.Client, obj client.Object) bool {
+	err := cl.Delete(context.TODO(), obj.DeepCopyObject().(client.Object))
+	if err == nil {
+		return true
+	}
+	require.True(t, apierrors.IsForbidden(err), "unexpected error while deleting %T '%s': %v", obj, obj.GetName(), err)
+	return false
+}
+
+// verifyNotDeleted verifies that the given object still exists and is not being deleted
+func verifyNotDeleted(t *testing.T, memberAwait *wait.MemberAwaitility, obj client.Object) {
+	actual := obj.DeepCopyObject().(client.Object)
+	require.NoError(t, memberAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), actual))
+	require.Equal(t, obj.GetUID(), actual.GetUID(), "%T '%s' was recreated although its deletion was rejected", obj, obj.GetName())
+	require.Nil(t, actual.GetDeletionTimestamp(), "%T '%s' is being deleted although its deletion was rejected", obj, obj.GetName())
+}
+
+// waitUntilRecreated waits until the given (deleted) object is recreated, ie, until an object with the same name but another UID exists
+func waitUntilRecreated(t *testing.T, memberAwait *wait.MemberAwaitility, obj client.Object) {
+	t.Logf("waiting until %T '%s' is recreated", obj, obj.GetName())
+	// a namespace may take some time to terminate before it can be recreated
+	err := memberAwait.PollWithTimeout(2*memberAwait.Timeout, func() (done bool, err error) {
+		actual := obj.DeepCopyObject().(client.Object)
+		if err := memberAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), actual); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return actual.GetUID() != obj.GetUID() && actual.GetDeletionTimestamp() == nil, nil
+	})
+	require.NoError(t, err, "%T '%s' was not recreated by the member operator", obj, obj.GetName())
+}
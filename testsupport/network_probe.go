@@ -0,0 +1,86 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/workloads"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NetworkProbePort the port on which the probe server listens
+	NetworkProbePort = workloads.NginxPort
+	// networkProbeDeniedAttempts the number of consecutive failed probes after which the traffic is considered as denied
+	networkProbeDeniedAttempts = 3
+)
+
+// DeployProbeServer deploys the Nginx workload (listening on the NetworkProbePort) in the given namespace, and returns the IP
+// of its pod once it is running. The workload is deleted at the end of the test.
+func DeployProbeServer(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) string {
+	name := fmt.Sprintf("netprobe-server-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	pods := workloads.ApplyAndWaitUntilReady(t, memberAwait, namespace, name, workloads.Nginx())
+	require.NotEmpty(t, pods[0].Status.PodIP, "probe server '%s' in namespace '%s' has no IP", pods[0].Name, namespace)
+	return pods[0].Status.PodIP
+}
+
+// ProbeConnectivity runs a pod in the given namespace which opens a TCP connection to the given host and port,
+// and returns `true` if the connection succeeded
+func ProbeConnectivity(t *testing.T, memberAwait *wait.MemberAwaitility, fromNamespace, host string, port int) bool {
+	name := fmt.Sprintf("netprobe-client-%s", uuid.Must(uuid.NewV4()).String()[:8])
+	w := workloads.Apply(t, memberAwait, fromNamespace, name, workloads.Probe("nc", "-z", "-w", "3", host, strconv.Itoa(port)))
+	defer func() {
+		for _, obj := range w.Objects {
+			_ = memberAwait.Client.Delete(context.TODO(), obj)
+		}
+	}()
+	pod, err := memberAwait.WaitForPod(t, fromNamespace, name, wait.PodCompleted())
+	require.NoError(t, err)
+	return pod.Status.Phase == corev1.PodSucceeded
+}
+
+// VerifyTrafficAllowed verifies that a pod in the given namespace can connect to the given host and port.
+// The probe is retried until it succeeds, since the NetworkPolicies are applied asynchronously by the network plugin.
+func VerifyTrafficAllowed(t *testing.T, memberAwait *wait.MemberAwaitility, fromNamespace, host string, port int) {
+	t.Logf("verifying that the traffic from namespace '%s' to '%s:%d' is allowed", fromNamespace, host, port)
+	err := memberAwait.Poll(func() (done bool, err error) {
+		return ProbeConnectivity(t, memberAwait, fromNamespace, host, port), nil
+	})
+	require.NoError(t, err, "traffic from namespace '%s' to '%s:%d' is denied", fromNamespace, host, port)
+}
+
+// VerifyTrafficDenied verifies that a pod in the given namespace cannot connect to the given host and port.
+// Since a failed probe could also be caused by a server which is not ready yet, the traffic should first be verified
+// as allowed from another namespace (see VerifyTrafficAllowed).
+func VerifyTrafficDenied(t *testing.T, memberAwait *wait.MemberAwaitility, fromNamespace, host string, port int) {
+	t.Logf("verifying that the traffic from namespace '%s' to '%s:%d' is denied", fromNamespace, host, port)
+	for i := 0; i < networkProbeDeniedAttempts; i++ {
+		require.False(t, ProbeConnectivity(t, memberAwait, fromNamespace, host, port), "traffic from namespace '%s' to '%s:%d' is allowed", fromNamespace, host, port)
+	}
+}
+
+// CreateProbeNamespace creates a namespace with the given labels (eg, to simulate a namespace of the platform which
+// belongs to a network policy group) and returns its name. The namespace is deleted at the end of the test.
+func CreateProbeNamespace(t *testing.T, memberAwait *wait.MemberAwaitility, prefix string, labels map[string]string) string {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-%s", prefix, uuid.Must(uuid.NewV4()).String()[:8]),
+			Labels: labels,
+		},
+	}
+	wait.AddRunLabels(t, ns)
+	err := memberAwait.Client.Create(context.TODO(), ns)
+	require.NoError(t, err)
+	// the namespace owns the probe pods, hence the background propagation (see `cleanup.WithPropagation`)
+	cleanup.AddCleanTaskWithOptions(t, memberAwait.Client, ns, cleanup.WithPropagation(metav1.DeletePropagationBackground))
+	_, err = memberAwait.WaitForNamespaceWithName(t, ns.Name)
+	require.NoError(t, err)
+	return ns.Name
+}
@@ -0,0 +1,145 @@
+package testsupport
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationTypeE2E the type of the Notifications created directly by the tests
+const NotificationTypeE2E = "e2e"
+
+// NotificationOption an option to configure the Notification created by CreateNotification
+type NotificationOption func(*toolchainv1alpha1.Notification)
+
+// WithRecipient sets the recipient of the Notification, so that it can be sent without looking up a UserSignup
+func WithRecipient(recipient string) NotificationOption {
+	return func(n *toolchainv1alpha1.Notification) {
+		n.Spec.Recipient = recipient
+	}
+}
+
+// WithUserID sets the ID of the user to whom the Notification must be sent (ie, the recipient is looked up from the UserSignup)
+func WithUserID(userID string) NotificationOption {
+	return func(n *toolchainv1alpha1.Notification) {
+		n.Spec.Context = map[string]string{"UserID": userID}
+	}
+}
+
+// CreateNotification creates a Notification with a subject and a content (ie, without template), directly in the host namespace
+// (ie, not as a consequence of an event in the lifecycle of a user), so that its delivery and cleanup by the host operator can be verified
+func CreateNotification(t *testing.T, hostAwait *wait.HostAwaitility, opts ...NotificationOption) *toolchainv1alpha1.Notification {
+	notification := &toolchainv1alpha1.Notification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "e2e-" + uuid.Must(uuid.NewV4()).String()[:8],
+			Namespace: hostAwait.Namespace,
+			Labels: map[string]string{
+				toolchainv1alpha1.NotificationTypeLabelKey: NotificationTypeE2E,
+			},
+		},
+		Spec: toolchainv1alpha1.NotificationSpec{
+			Subject: "e2e notification",
+			Content: "notification created by the e2e tests",
+		},
+	}
+	for _, apply := range opts {
+		apply(notification)
+	}
+	err := hostAwait.CreateWithCleanup(t, notification)
+	require.NoError(t, err)
+	t.Logf("Notification '%s' created", notification.Name)
+	return notification
+}
+
+// VerifyNotificationCleanedUp waits until the Notification with the given name is sent, then deleted by the host operator,
+// and verifies that it was kept until the given TTL (ie, the `durationBeforeNotificationDeletion` config) expired, and deleted
+// within the given tolerance after that. The given creation time is the time (on the side of the test) at which the Notification
+// was created, before which it cannot have been sent: the Notification must thus still exist until this time plus the TTL
+// (this way, the verification does not depend on the clock of the cluster).
+func VerifyNotificationCleanedUp(t *testing.T, hostAwait *wait.HostAwaitility, name string, createdAt time.Time, ttl, tolerance time.Duration) {
+	_, err := hostAwait.WaitForNotificationWithName(t, name, NotificationTypeE2E, wait.UntilNotificationHasConditions(Sent()))
+	require.NoError(t, err)
+
+	if kept := time.Until(createdAt.Add(ttl)); kept > 0 {
+		err = hostAwait.WithRetryOptions(wait.TimeoutOption(kept)).WaitUntilNotificationWithNameDeleted(t, name)
+		require.Error(t, err, "Notification '%s' was deleted before its TTL expired", name)
+	}
+	err = hostAwait.WithRetryOptions(wait.TimeoutOption(tolerance)).WaitUntilNotificationWithNameDeleted(t, name)
+	require.NoError(t, err, "Notification '%s' was not deleted within %s after its TTL expired", name, tolerance)
+	t.Logf("Notification '%s' deleted %s after it was created", name, time.Since(createdAt))
+}
+
+// VerifyNotificationNotDelivered waits until the delivery of the Notification with the given name failed,
+// (with the given reason, or with any reason if empty), and verifies that it is kept (ie, not cleaned up) during the given duration,
+// so that the failure can be investigated
+func VerifyNotificationNotDelivered(t *testing.T, hostAwait *wait.HostAwaitility, name, reason string, during time.Duration) {
+	_, err := hostAwait.WaitForNotificationWithName(t, name, NotificationTypeE2E, wait.NotificationWaitCriterion{
+		// the message of the condition contains the details of the error, which are not verified here
+		Match: func(actual toolchainv1alpha1.Notification) bool {
+			c, found := condition.FindConditionByType(actual.Status.Conditions, toolchainv1alpha1.NotificationSent)
+			return found && c.Status == corev1.ConditionFalse && (reason == "" || c.Reason == reason)
+		},
+		Diff: func(actual toolchainv1alpha1.Notification) string {
+			return fmt.Sprintf("expected Notification to have a '%s' condition with status 'False' and reason '%s', but got: %+v",
+				toolchainv1alpha1.NotificationSent, reason, actual.Status.Conditions)
+		},
+	})
+	require.NoError(t, err)
+
+	err = hostAwait.WithRetryOptions(wait.TimeoutOption(during)).WaitUntilNotificationWithNameDeleted(t, name)
+	require.Error(t, err, "Notification '%s' was deleted although it was not delivered", name)
+}
+
+// NotificationRegistrationURLKey the key of the URL of the registration service (ie, of the links to the registration service)
+// in the context of the Notifications created by the host operator for a user
+const NotificationRegistrationURLKey = "RegistrationURL"
+
+// NotificationLinksCriteria returns the criteria to verify that the links in the Notifications created by the host operator for a user
+// point to the registration service URL set in the ToolchainConfig (which may have a custom domain), or no criteria if it is not set
+func NotificationLinksCriteria(t *testing.T, hostAwait *wait.HostAwaitility) []wait.NotificationWaitCriterion {
+	url := hostAwait.GetConfiguredRegistrationServiceURL(t)
+	if url == "" {
+		return nil
+	}
+	return []wait.NotificationWaitCriterion{wait.UntilNotificationHasContextValue(NotificationRegistrationURLKey, url)}
+}
+
+// userNotificationTemplates the names of the templates used by the host operator to render the Notifications of a user, by type
+// (only the types of the Notifications verified by the tests)
+var userNotificationTemplates = map[string]string{
+	toolchainv1alpha1.NotificationTypeProvisioned: "userprovisioned",
+	toolchainv1alpha1.NotificationTypeDeactivated: "userdeactivated",
+}
+
+// VerifyUserNotificationContext waits until the Notification of the given type is sent to the user of the given UserSignup,
+// and verifies that it refers to the template of its type, with the identity of the user (as in the UserSignup) and the link to
+// the registration service (as in the ToolchainConfig) in its context. The content rendered by the host operator from the template
+// and the context is not verified, since it is only passed to the delivery service.
+func VerifyUserNotificationContext(t *testing.T, hostAwait *wait.HostAwaitility, userSignup *toolchainv1alpha1.UserSignup, notificationType string) {
+	notifications, err := hostAwait.WaitForNotifications(t, userSignup.Status.CompliantUsername, notificationType, 1,
+		wait.UntilNotificationHasConditions(Sent()))
+	require.NoError(t, err)
+	notification := notifications[0]
+	require.Contains(t, userNotificationTemplates, notificationType, "no template known for Notifications of type '%s'", notificationType)
+	assert.Equal(t, userNotificationTemplates[notificationType], notification.Spec.Template)
+
+	assert.Equal(t, userSignup.Spec.Userid, notification.Spec.Context["UserID"])
+	assert.Equal(t, userSignup.Status.CompliantUsername, notification.Spec.Context["UserName"])
+	assert.Equal(t, userSignup.Spec.GivenName, notification.Spec.Context["FirstName"])
+	assert.Equal(t, userSignup.Spec.FamilyName, notification.Spec.Context["LastName"])
+	assert.Equal(t, userSignup.Spec.Company, notification.Spec.Context["CompanyName"])
+	assert.Equal(t, userSignup.Annotations[toolchainv1alpha1.UserSignupUserEmailAnnotationKey], notification.Spec.Context["UserEmail"])
+	assert.NotEmpty(t, notification.Spec.Context[NotificationRegistrationURLKey])
+	if url := hostAwait.GetConfiguredRegistrationServiceURL(t); url != "" {
+		assert.Equal(t, url, notification.Spec.Context[NotificationRegistrationURLKey])
+	}
+}
@@ -0,0 +1,57 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RestartHostOperatorDuring runs the given action, which must call the `restart` func it receives at the point where the host operator
+// should be restarted (eg, before creating a resource, so that it is provisioned by the new pods). The restart deletes all the pods
+// of the host operator (and returns once they are deleted), so that the rest of the action runs while the pods are replaced.
+// Once the action is done, it waits until the pods were replaced by new ready pods (ie, with new UIDs), then verifies that
+// the system converged, ie, that the ToolchainConfig is synced to the members and that the ToolchainStatus is ready.
+// The action is expected to verify its own outcome, which must not depend on the host operator being up during the whole action.
+func RestartHostOperatorDuring(t *testing.T, hostAwait *wait.HostAwaitility, action func(restart func())) {
+	name := hostAwait.OperatorDeploymentName()
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, hostAwait.Client.Get(context.TODO(), client.ObjectKey{Namespace: hostAwait.Namespace, Name: name}, deployment))
+	replicas := 1
+	if deployment.Spec.Replicas != nil {
+		replicas = int(*deployment.Spec.Replicas)
+	}
+	criteria := []client.ListOption{client.InNamespace(hostAwait.Namespace), client.MatchingLabels(deployment.Spec.Selector.MatchLabels)}
+
+	var deletedUIDs []types.UID
+	restarted := false
+	restart := func() {
+		require.False(t, restarted, "the host operator was already restarted during the action")
+		pods := &corev1.PodList{}
+		require.NoError(t, hostAwait.Client.List(context.TODO(), pods, criteria...))
+		require.NotEmpty(t, pods.Items, "no pod of deployment '%s' in namespace '%s'", name, hostAwait.Namespace)
+		t.Logf("restarting the host operator during the action")
+		for i := range pods.Items {
+			require.NoError(t, client.IgnoreNotFound(hostAwait.Client.Delete(context.TODO(), &pods.Items[i])),
+				"unable to delete pod '%s' of deployment '%s' in namespace '%s'", pods.Items[i].Name, name, hostAwait.Namespace)
+			deletedUIDs = append(deletedUIDs, pods.Items[i].UID)
+		}
+		restarted = true
+	}
+	action(restart)
+	require.True(t, restarted, "the host operator was not restarted during the action")
+
+	// the pods are recreated by the deployment, and the new leader resumes the reconciliation
+	_, err := hostAwait.WaitUntilPodsReplaced(t, deletedUIDs, replicas, criteria...)
+	require.NoError(t, err, "the pods of deployment '%s' in namespace '%s' were not replaced", name, hostAwait.Namespace)
+	hostAwait.WaitForDeploymentToGetReady(t, name, replicas)
+	_, err = hostAwait.WaitForToolchainConfig(t, wait.UntilToolchainConfigHasSyncedStatus(ToolchainConfigSyncComplete()))
+	require.NoError(t, err, "ToolchainConfig was not synced after the restart of the host operator")
+	_, err = hostAwait.WaitForToolchainStatus(t, wait.UntilToolchainStatusHasConditions(ToolchainStatusReadyAndUnreadyNotificationNotCreated()...))
+	require.NoError(t, err, "ToolchainStatus was not ready after the restart of the host operator")
+}
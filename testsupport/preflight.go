@@ -0,0 +1,223 @@
+package testsupport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreflightCheck a check of the environment which must pass before running the e2e tests
+type PreflightCheck struct {
+	Name string
+	// Hint the action to take when the check fails
+	Hint  string
+	Check func(t *testing.T, env PreflightEnv) error
+}
+
+// PreflightEnv the environment in which the preflight checks are run
+type PreflightEnv struct {
+	Config                *rest.Config
+	Client                client.Client
+	HostNs                string
+	MemberNs              string
+	MemberNs2             string
+	RegistrationServiceNs string
+}
+
+// requiredToolchainKinds the kinds of the toolchain API which must be served by the cluster
+var requiredToolchainKinds = []string{
+	"BannedUser", "MasterUserRecord", "MemberOperatorConfig", "NSTemplateSet", "NSTemplateTier", "Notification",
+	"Space", "SpaceBinding", "SpaceRequest", "TierTemplate", "ToolchainCluster", "ToolchainConfig", "ToolchainStatus",
+	"UserAccount", "UserSignup", "UserTier",
+}
+
+// PreflightChecks the checks to run before the e2e tests (in this order)
+var PreflightChecks = []PreflightCheck{
+	{
+		Name: "namespaces",
+		Hint: fmt.Sprintf("set the '%s', '%s', '%s' and '%s' env vars (eg, by running the tests with `make e2e-run`)",
+			wait.HostNsVar, wait.MemberNsVar, wait.MemberNsVar2, wait.RegistrationServiceVar),
+		Check: func(_ *testing.T, env PreflightEnv) error {
+			for name, value := range map[string]string{
+				wait.HostNsVar:              env.HostNs,
+				wait.MemberNsVar:            env.MemberNs,
+				wait.MemberNsVar2:           env.MemberNs2,
+				wait.RegistrationServiceVar: env.RegistrationServiceNs,
+			} {
+				if value == "" {
+					return fmt.Errorf("env var '%s' is not set", name)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name: "cluster connectivity",
+		Hint: "check that you are logged in the cluster (`oc whoami`) and that the API server is reachable",
+		Check: func(t *testing.T, env PreflightEnv) error {
+			discoveryClient, err := discovery.NewDiscoveryClientForConfig(env.Config)
+			if err != nil {
+				return err
+			}
+			version, err := discoveryClient.ServerVersion()
+			if err != nil {
+				return err
+			}
+			t.Logf("connected to cluster '%s' running Kubernetes %s", env.Config.Host, version.GitVersion)
+			return nil
+		},
+	},
+	{
+		Name: "required CRDs",
+		Hint: "deploy the operators with `make deploy-e2e` (or `make deploy-e2e-local`) so that their CRDs are installed",
+		Check: func(_ *testing.T, env PreflightEnv) error {
+			discoveryClient, err := discovery.NewDiscoveryClientForConfig(env.Config)
+			if err != nil {
+				return err
+			}
+			resources, err := discoveryClient.ServerResourcesForGroupVersion(toolchainv1alpha1.GroupVersion.String())
+			if err != nil {
+				return err
+			}
+			served := map[string]bool{}
+			for _, r := range resources.APIResources {
+				served[r.Kind] = true
+			}
+			for _, kind := range requiredToolchainKinds {
+				if !served[kind] {
+					return fmt.Errorf("kind '%s' is not served by the cluster", kind)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name: "operators",
+		Hint: "check the status of the deployments and the logs of the operators (`make print-logs`)",
+		Check: func(t *testing.T, env PreflightEnv) error {
+			for _, d := range []types.NamespacedName{
+				{Namespace: env.HostNs, Name: "host-operator-controller-manager"},
+				{Namespace: env.RegistrationServiceNs, Name: "registration-service"},
+				{Namespace: env.MemberNs, Name: "member-operator-controller-manager"},
+				{Namespace: env.MemberNs2, Name: "member-operator-controller-manager"},
+			} {
+				if err := checkDeploymentAvailable(t, env.Client, d); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name: "webhooks",
+		Hint: "check the status of the 'member-operator-webhook' deployment and its MutatingWebhookConfiguration in the first member cluster",
+		Check: func(t *testing.T, env PreflightEnv) error {
+			if err := checkDeploymentAvailable(t, env.Client, types.NamespacedName{Namespace: env.MemberNs, Name: "member-operator-webhook"}); err != nil {
+				return err
+			}
+			return env.Client.Get(context.TODO(), types.NamespacedName{Name: "member-operator-webhook"}, &admissionv1.MutatingWebhookConfiguration{})
+		},
+	},
+	{
+		Name: "registration service health",
+		Hint: "check the route, the pods and the logs of the registration service",
+		Check: func(t *testing.T, env PreflightEnv) error {
+			route := &routev1.Route{}
+			if err := env.Client.Get(context.TODO(), types.NamespacedName{Namespace: env.RegistrationServiceNs, Name: "registration-service"}, route); err != nil {
+				return err
+			}
+			url := "http://" + route.Spec.Host
+			if route.Spec.TLS != nil {
+				url = "https://" + route.Spec.Host
+			}
+			resp, err := HTTPClient.Get(url + "/api/v1/health") // nolint:noctx,bodyclose // see `defer Close(...)`
+			if err != nil {
+				return err
+			}
+			defer Close(t, resp)
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d with body: %s", resp.StatusCode, body)
+			}
+			health := struct {
+				Alive    bool   `json:"alive"`
+				Revision string `json:"revision"`
+			}{}
+			if err := json.Unmarshal(body, &health); err != nil {
+				return err
+			}
+			if !health.Alive {
+				return fmt.Errorf("registration service is not alive: %s", body)
+			}
+			t.Logf("registration service at revision '%s' is alive", health.Revision)
+			return nil
+		},
+	},
+}
+
+// RunPreflightChecks runs all the preflight checks against the cluster of the current kubeconfig, in a subtest each,
+// without waiting (unlike WaitForDeployments): the first failing check stops the run with a message about the action to take.
+func RunPreflightChecks(t *testing.T) {
+	apiConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	require.NoError(t, err, "unable to load the kubeconfig: check the KUBECONFIG env var")
+	kubeconfig, err := clientcmd.NewDefaultClientConfig(*apiConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	require.NoError(t, err, "unable to load the kubeconfig: check the KUBECONFIG env var")
+	cl, err := client.New(kubeconfig, client.Options{
+		Scheme: schemeWithAllAPIs(t),
+	})
+	require.NoError(t, err)
+	env := PreflightEnv{
+		Config:                kubeconfig,
+		Client:                cl,
+		HostNs:                os.Getenv(wait.HostNsVar),
+		MemberNs:              os.Getenv(wait.MemberNsVar),
+		MemberNs2:             os.Getenv(wait.MemberNsVar2),
+		RegistrationServiceNs: os.Getenv(wait.RegistrationServiceVar),
+	}
+
+	for _, check := range PreflightChecks {
+		ok := t.Run(check.Name, func(t *testing.T) {
+			err := check.Check(t, env)
+			require.NoError(t, err, "preflight check '%s' failed: %s", check.Name, check.Hint)
+		})
+		if !ok {
+			t.Fatalf("preflight check '%s' failed, skipping the remaining checks", check.Name)
+		}
+	}
+}
+
+// checkDeploymentAvailable checks that the given deployment exists and all its replicas are available,
+// and prints the images of its containers (ie, the version of the operator)
+func checkDeploymentAvailable(t *testing.T, cl client.Client, name types.NamespacedName) error {
+	deployment := &appsv1.Deployment{}
+	if err := cl.Get(context.TODO(), name, deployment); err != nil {
+		return fmt.Errorf("unable to get deployment '%s' in namespace '%s': %w", name.Name, name.Namespace, err)
+	}
+	if deployment.Spec.Replicas != nil && deployment.Status.AvailableReplicas < *deployment.Spec.Replicas {
+		return fmt.Errorf("deployment '%s' in namespace '%s' has %d available replica(s) out of %d", name.Name, name.Namespace,
+			deployment.Status.AvailableReplicas, *deployment.Spec.Replicas)
+	}
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		t.Logf("deployment '%s' in namespace '%s' runs image '%s'", name.Name, name.Namespace, c.Image)
+	}
+	return nil
+}
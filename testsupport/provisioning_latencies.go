@@ -0,0 +1,129 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RecordProvisioningLatencies records (see report.RecordProvisioning) and logs the latencies of the stages of the provisioning of the user
+// of the UserSignup with the given name. The latencies are computed from the creation timestamps and the transition times of the conditions
+// of the resources, ie, regardless of when the test started to wait for them, so that ordinary e2e runs can be used to measure the provisioning.
+// The timestamps have a granularity of one second, and the ones of the resources of the member cluster are subject to the clock skew between
+// the clusters. The stages which cannot be measured (eg, the Space of a user without space) are not recorded, and a resource which cannot
+// be retrieved does not fail the test.
+// Since the latencies are measured from the creation of the UserSignup, they are only recorded for the first provisioning of the user,
+// ie, not after a reactivation of the user or after an update of its Space (eg, a promotion or a change of tier).
+func RecordProvisioningLatencies(t *testing.T, awaitilities wait.Awaitilities, userSignupName string) map[report.Stage]time.Duration {
+	hostAwait := awaitilities.Host()
+	stages := map[report.Stage]time.Duration{}
+	userSignup := &toolchainv1alpha1.UserSignup{}
+	if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: userSignupName}, userSignup); err != nil {
+		t.Logf("cannot measure the provisioning latencies of UserSignup '%s': %s", userSignupName, err.Error())
+		return stages
+	}
+	if wasDeactivated(userSignup) {
+		t.Logf("not recording the provisioning latencies of UserSignup '%s' since it was deactivated before", userSignupName)
+		return stages
+	}
+	created := userSignup.CreationTimestamp.Time
+	since := func(stage report.Stage, timestamp metav1.Time) {
+		if !timestamp.IsZero() {
+			stages[stage] = timestamp.Sub(created)
+		}
+	}
+	since(report.StageApproved, conditionTrueSince(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupApproved))
+	since(report.StageComplete, conditionTrueSince(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupComplete))
+
+	username := userSignup.Status.CompliantUsername
+	mur := &toolchainv1alpha1.MasterUserRecord{}
+	if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: username}, mur); err == nil {
+		since(report.StageMasterUserRecord, mur.CreationTimestamp)
+		for _, ua := range mur.Spec.UserAccounts {
+			memberAwait, err := awaitilities.Member(ua.TargetCluster)
+			if err != nil {
+				continue
+			}
+			userAccount := &toolchainv1alpha1.UserAccount{}
+			if err := memberAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: memberAwait.Namespace, Name: username}, userAccount); err == nil {
+				since(report.StageUserAccount, conditionTrueSince(userAccount.Status.Conditions, toolchainv1alpha1.ConditionReady))
+			}
+		}
+	}
+
+	space := &toolchainv1alpha1.Space{}
+	if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: username}, space); err == nil {
+		// the Space is created with its spec, which is only updated afterwards (eg, to promote the user)
+		if space.Generation > 1 {
+			t.Logf("not recording the provisioning latencies of UserSignup '%s' since its Space was updated after its provisioning", userSignupName)
+			return map[report.Stage]time.Duration{}
+		}
+		since(report.StageSpace, conditionTrueSince(space.Status.Conditions, toolchainv1alpha1.ConditionReady))
+		if memberAwait, err := awaitilities.Member(space.Status.TargetCluster); err == nil && len(space.Status.ProvisionedNamespaces) > 0 {
+			since(report.StageNamespaces, lastNamespaceCreation(memberAwait, space.Status.ProvisionedNamespaces))
+		}
+	}
+
+	report.RecordProvisioning(t, username, stages)
+	t.Logf("provisioning latencies of user '%s': %s", username, formatLatencies(stages))
+	return stages
+}
+
+// wasDeactivated returns `true` if the given UserSignup was deactivated at some point, ie, if the conditions of the deactivation
+// notifications were set
+func wasDeactivated(userSignup *toolchainv1alpha1.UserSignup) bool {
+	for _, conditionType := range []toolchainv1alpha1.ConditionType{
+		toolchainv1alpha1.UserSignupUserDeactivatingNotificationCreated,
+		toolchainv1alpha1.UserSignupUserDeactivatedNotificationCreated,
+	} {
+		if _, found := condition.FindConditionByType(userSignup.Status.Conditions, conditionType); found {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionTrueSince returns the last transition time of the condition of the given type if its status is `True`, or a zero time otherwise
+func conditionTrueSince(conditions []toolchainv1alpha1.Condition, conditionType toolchainv1alpha1.ConditionType) metav1.Time {
+	c, found := condition.FindConditionByType(conditions, conditionType)
+	if !found || c.Status != corev1.ConditionTrue {
+		return metav1.Time{}
+	}
+	return c.LastTransitionTime
+}
+
+// lastNamespaceCreation returns the creation timestamp of the last created namespace among the given ones, or a zero time
+// if one of them cannot be retrieved
+func lastNamespaceCreation(memberAwait *wait.MemberAwaitility, namespaces []toolchainv1alpha1.SpaceNamespace) metav1.Time {
+	last := metav1.Time{}
+	for _, n := range namespaces {
+		ns := &corev1.Namespace{}
+		if err := memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: n.Name}, ns); err != nil {
+			return metav1.Time{}
+		}
+		if last.Before(&ns.CreationTimestamp) {
+			last = ns.CreationTimestamp
+		}
+	}
+	return last
+}
+
+// formatLatencies returns the given latencies as `<stage>=<duration>` pairs, sorted by latency
+func formatLatencies(stages map[report.Stage]time.Duration) string {
+	p := report.Provisioning{Stages: stages}
+	pairs := make([]string, 0, len(stages))
+	for _, stage := range p.SortedStages() {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", stage, stages[stage]))
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,42 @@
+package testsupport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// VerifyProxiedRequestAudited verifies that the request with the given verb on the given object, sent via the API proxy after the given time
+// on behalf of the user with the given compliant username, is in the audit log of the API server of the member cluster with the identity
+// of the user, ie, that the proxy impersonates the user instead of sending the request with its own identity only. The current test
+// is skipped if the audit log is not available (see wait.AuditLogVar), hence this func should be called in a dedicated subtest.
+func VerifyProxiedRequestAudited(t *testing.T, memberAwait *wait.MemberAwaitility, compliantUsername, verb, resource, namespace, name string, since time.Time) {
+	if !memberAwait.AuditLogAvailable(t) {
+		t.Skip("the audit log of the API server of the member cluster is not available")
+	}
+	criteria := []wait.AuditEventWaitCriterion{
+		wait.AuditEventHasVerb(verb),
+		wait.AuditEventForObject(resource, namespace, name),
+		wait.AuditEventReceivedAfter(since.Add(-time.Second)), // the timestamps of the events are truncated to the second by some audit backends
+		wait.AuditEventCompleted(),
+	}
+	_, err := memberAwait.WaitForAuditEvent(t, append(criteria, func(e wait.AuditEvent) bool {
+		return e.ImpersonatedUser != nil && e.ImpersonatedUser.Username == compliantUsername
+	})...)
+	require.NoError(t, err, "no event with the identity of user '%s' in the audit log for the request '%s' on %s '%s/%s'", compliantUsername, verb, resource, namespace, name)
+
+	// also verify that the proxy never sent the request with its own identity only
+	events, err := memberAwait.FindAuditEvents(t, criteria...)
+	require.NoError(t, err)
+	for _, e := range events {
+		if e.ImpersonatedUser == nil {
+			assert.NotContains(t, e.User.Groups, "system:serviceaccounts:"+memberAwait.Namespace,
+				"the request '%s' on %s '%s/%s' was sent by the proxy without impersonating the user: %s", verb, resource, namespace, name, e)
+			continue
+		}
+		assert.Equal(t, compliantUsername, e.ImpersonatedUser.Username, "the request '%s' on %s '%s/%s' was sent with the identity of another user: %s", verb, resource, namespace, name, e)
+	}
+}
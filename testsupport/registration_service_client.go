@@ -0,0 +1,118 @@
+package testsupport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RegistrationServiceError the schema of the body of the error responses returned by the registration service handlers
+type RegistrationServiceError struct {
+	Status  string `json:"status"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+}
+
+// RegistrationServiceAuthError the schema of the body of the error responses returned by the authentication middleware
+// of the registration service (eg. when the token is missing or invalid)
+type RegistrationServiceAuthError struct {
+	Error string `json:"error"`
+}
+
+// RegistrationServiceSignup the schema of the body of the response of `GET /api/v1/signup` (only the fields which do not depend
+// on the provisioning of the user)
+type RegistrationServiceSignup struct {
+	Username          string                          `json:"username"`
+	CompliantUsername string                          `json:"compliantUsername"`
+	Status            RegistrationServiceSignupStatus `json:"status"`
+}
+
+// RegistrationServiceSignupStatus the status of a signup, as returned by `GET /api/v1/signup`
+type RegistrationServiceSignupStatus struct {
+	Ready                bool   `json:"ready"`
+	Reason               string `json:"reason"`
+	VerificationRequired bool   `json:"verificationRequired"`
+}
+
+// RegistrationServiceClient a client to invoke the registration service endpoints, which keeps the whole response
+// (status, headers and body) so that tests can verify the contract of the API
+type RegistrationServiceClient struct {
+	baseURL    string
+	restClient *RESTClient
+}
+
+// NewRegistrationServiceClient returns a new client for the registration service of the given host
+func NewRegistrationServiceClient(hostAwait *wait.HostAwaitility) *RegistrationServiceClient {
+	return &RegistrationServiceClient{
+		baseURL:    hostAwait.RegistrationServiceURL,
+		restClient: NewRESTClient(),
+	}
+}
+
+// RegistrationServiceResponse the response of a call to the registration service
+type RegistrationServiceResponse = RESTResponse
+
+// Invoke calls the given endpoint (eg. `/api/v1/signup`) with the given method and body.
+// The token is set in the `Authorization` header, unless it is empty.
+func (c *RegistrationServiceClient) Invoke(t *testing.T, method, path, token, body string) *RegistrationServiceResponse {
+	return c.restClient.Do(t, RESTRequest{
+		Method: method,
+		URL:    c.baseURL + path,
+		Token:  token,
+		Body:   body,
+	})
+}
+
+// RequireStatus verifies that the response has the given status code and a JSON content type (if the response has a body)
+func (r *RegistrationServiceResponse) RequireStatus(t *testing.T, expectedStatus int) *RegistrationServiceResponse {
+	require.Equal(t, expectedStatus, r.StatusCode, "unexpected response status:\n%s", r)
+	if len(r.Body) == 0 {
+		return r
+	}
+	assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "application/json"), "unexpected content type: '%s'", r.Header.Get("Content-Type"))
+	return r
+}
+
+// RequireError verifies that the response has the given status code and a body matching the schema of the registration service errors
+// (with the same code and corresponding status text), and returns the decoded error
+func (r *RegistrationServiceResponse) RequireError(t *testing.T, expectedStatus int) RegistrationServiceError {
+	r.RequireStatus(t, expectedStatus)
+	respErr := RegistrationServiceError{}
+	decodeStrict(t, r.Body, &respErr)
+	assert.Equal(t, expectedStatus, respErr.Code)
+	assert.Equal(t, http.StatusText(expectedStatus), respErr.Status)
+	assert.NotEmpty(t, respErr.Message)
+	return respErr
+}
+
+// RequireAuthError verifies that the response has the given status code and a body matching the schema of the authentication errors,
+// and returns the decoded error
+func (r *RegistrationServiceResponse) RequireAuthError(t *testing.T, expectedStatus int) RegistrationServiceAuthError {
+	r.RequireStatus(t, expectedStatus)
+	respErr := RegistrationServiceAuthError{}
+	decodeStrict(t, r.Body, &respErr)
+	assert.NotEmpty(t, respErr.Error)
+	return respErr
+}
+
+// RequireSignup verifies that the response is a successful response of `GET /api/v1/signup`, and returns the decoded signup
+func (r *RegistrationServiceResponse) RequireSignup(t *testing.T) RegistrationServiceSignup {
+	r.RequireStatus(t, http.StatusOK)
+	signup := RegistrationServiceSignup{}
+	require.NoError(t, json.Unmarshal(r.Body, &signup), "unable to decode the signup: %s", r.Body)
+	return signup
+}
+
+// decodeStrict decodes the given JSON body, failing if it contains unknown fields
+func decodeStrict(t *testing.T, body []byte, obj interface{}) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	require.NoError(t, decoder.Decode(obj), "response body does not match the expected schema: %s", body)
+}
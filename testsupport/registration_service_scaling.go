@@ -0,0 +1,204 @@
+package testsupport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// registrationServiceDeploymentName the name of the deployment of the registration service
+const registrationServiceDeploymentName = "registration-service"
+
+// SignupsDuringScaling the outcome of the signups which were sent while the registration service was scaled up and back down
+type SignupsDuringScaling struct {
+	lock sync.Mutex
+	// Identities the identities of the users who signed up
+	Identities []*commonauth.Identity
+	// StatusCodes the number of responses by status code
+	StatusCodes map[int]int
+	// Errors the errors of the requests which got no response (eg, when the connection was closed by a terminating pod)
+	Errors []error
+	// UserSignups the UserSignups which were created for the users, by user ID
+	UserSignups map[string][]toolchainv1alpha1.UserSignup
+}
+
+// ServerErrors returns the number of requests which failed with a 5xx response or without any response
+func (s *SignupsDuringScaling) ServerErrors() int {
+	count := len(s.Errors)
+	for code, c := range s.StatusCodes {
+		if code >= http.StatusInternalServerError {
+			count += c
+		}
+	}
+	return count
+}
+
+// ScaleRegistrationServiceDuringSignups scales the registration service to the given number of replicas (via the ToolchainConfig)
+// and then back to its original number of replicas, while the given number of workers continuously sign up new users.
+// Each user sends two signup requests concurrently, so that they are likely to be handled by different replicas.
+// Once the registration service is back to its original number of replicas, the UserSignups of the users are collected
+// (and deleted at the end of the test) so that the result can be verified with `VerifyNoDuplicateUserSignups`
+// and `AssertNoServerErrorStorm`.
+func ScaleRegistrationServiceDuringSignups(t *testing.T, hostAwait *wait.HostAwaitility, replicas int32, workers int) *SignupsDuringScaling {
+	require.NoError(t, hostAwait.WaitUntilBaseNSTemplateTierIsUpdated(t))
+	original := registrationServiceReplicas(t, hostAwait)
+	result := &SignupsDuringScaling{
+		StatusCodes: map[int]int{},
+		UserSignups: map[string][]toolchainv1alpha1.UserSignup{},
+	}
+
+	// the workers are also stopped if the scaling fails, so that they do not outlive the test
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var stopOnce sync.Once
+	stopWorkers := func() {
+		stopOnce.Do(func() {
+			close(stop)
+			wg.Wait()
+		})
+	}
+	defer stopWorkers()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					result.signup(hostAwait)
+				}
+			}
+		}()
+	}
+
+	t.Logf("scaling the registration service from %d to %d replicas during the signups", original, replicas)
+	hostAwait.UpdateToolchainConfig(t, testconfig.RegistrationService().Replicas(replicas))
+	hostAwait.WaitForDeploymentToGetReady(t, registrationServiceDeploymentName, int(replicas))
+	t.Logf("scaling the registration service back to %d replicas during the signups", original)
+	hostAwait.UpdateToolchainConfig(t, testconfig.RegistrationService().Replicas(original))
+	hostAwait.WaitForDeploymentToGetReady(t, registrationServiceDeploymentName, int(original))
+	stopWorkers()
+
+	t.Logf("%d users signed up during the scaling of the registration service (responses: %v, errors: %d)",
+		len(result.Identities), result.StatusCodes, len(result.Errors))
+	result.collectUserSignups(t, hostAwait)
+	return result
+}
+
+// VerifyNoDuplicateUserSignups verifies that a single UserSignup was created for each user who signed up
+// while the registration service was scaled
+func VerifyNoDuplicateUserSignups(t *testing.T, result *SignupsDuringScaling) {
+	for _, identity := range result.Identities {
+		userSignups := result.UserSignups[identity.ID.String()]
+		names := make([]string, len(userSignups))
+		for i := range userSignups {
+			names[i] = userSignups[i].Name
+		}
+		assert.LessOrEqual(t, len(userSignups), 1, "duplicate UserSignups for user '%s': %v", identity.Username, names)
+	}
+}
+
+// AssertNoServerErrorStorm verifies that no more than the given number of signup requests failed with a 5xx response
+// (or without any response) while the registration service was scaled
+func AssertNoServerErrorStorm(t *testing.T, result *SignupsDuringScaling, maxServerErrors int) {
+	assert.LessOrEqual(t, result.ServerErrors(), maxServerErrors, "too many server errors during the scaling of the registration service "+
+		"(responses: %v, errors: %v)", result.StatusCodes, result.Errors)
+}
+
+// signup signs up a new user with two concurrent requests, and records the outcome of both requests
+func (s *SignupsDuringScaling) signup(hostAwait *wait.HostAwaitility) {
+	username := "rs-scaling-" + uuid.Must(uuid.NewV4()).String()[:8]
+	identity := &commonauth.Identity{
+		ID:       uuid.Must(uuid.NewV4()),
+		Username: username,
+	}
+	token, err := authsupport.NewTokenFromIdentity(identity, commonauth.WithEmailClaim(username+"@acme.com"))
+	if err != nil {
+		s.record(nil, err)
+		return
+	}
+	s.lock.Lock()
+	s.Identities = append(s.Identities, identity)
+	s.lock.Unlock()
+
+	// no retry, so that all the server errors are observed
+	restClient := NewRESTClient(WithRetries(0, 0))
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.record(restClient.send(RESTRequest{
+				Method: "POST",
+				URL:    hostAwait.RegistrationServiceURL + "/api/v1/signup",
+				Token:  token,
+			}))
+		}()
+	}
+	wg.Wait()
+}
+
+// record records the outcome of a signup request
+func (s *SignupsDuringScaling) record(resp *RESTResponse, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err != nil {
+		s.Errors = append(s.Errors, err)
+		return
+	}
+	s.StatusCodes[resp.StatusCode]++
+}
+
+// collectUserSignups waits until the UserSignup of each user who signed up exists, then lists all the UserSignups of these users
+// (to detect the duplicates) and ensures that they are deleted at the end of the test
+func (s *SignupsDuringScaling) collectUserSignups(t *testing.T, hostAwait *wait.HostAwaitility) {
+	for _, identity := range s.Identities {
+		// the users whose requests all failed may have no UserSignup, hence the short timeout
+		if _, err := hostAwait.WithRetryOptions(wait.TimeoutOption(10*time.Second)).
+			WaitForUserSignupByUserIDAndUsername(t, identity.ID.String(), identity.Username); err != nil {
+			t.Logf("no UserSignup for user '%s': %s", identity.Username, err)
+		}
+	}
+	userIDs := make(map[string]bool, len(s.Identities))
+	for _, identity := range s.Identities {
+		userIDs[identity.ID.String()] = true
+	}
+	userSignups := &toolchainv1alpha1.UserSignupList{}
+	require.NoError(t, hostAwait.Client.List(context.TODO(), userSignups, client.InNamespace(hostAwait.Namespace)))
+	for i := range userSignups.Items {
+		userSignup := userSignups.Items[i]
+		if !userIDs[userSignup.Spec.Userid] {
+			continue
+		}
+		s.UserSignups[userSignup.Spec.Userid] = append(s.UserSignups[userSignup.Spec.Userid], userSignup)
+		cleanup.AddCleanTasks(t, hostAwait.Client, &userSignup)
+	}
+}
+
+// registrationServiceReplicas returns the number of replicas of the registration service, as configured in the ToolchainConfig
+// or else as set in the spec of its deployment
+func registrationServiceReplicas(t *testing.T, hostAwait *wait.HostAwaitility) int32 {
+	if config := hostAwait.GetToolchainConfig(t); config != nil && config.Spec.Host.RegistrationService.Replicas != nil {
+		return *config.Spec.Host.RegistrationService.Replicas
+	}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, hostAwait.Client.Get(context.TODO(), client.ObjectKey{Namespace: hostAwait.Namespace, Name: registrationServiceDeploymentName}, deployment))
+	require.NotNil(t, deployment.Spec.Replicas, "no replicas in the spec of deployment '%s'", registrationServiceDeploymentName)
+	return *deployment.Spec.Replicas
+}
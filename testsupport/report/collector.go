@@ -0,0 +1,226 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Phase a phase of a test whose duration is recorded
+type Phase string
+
+const (
+	// PhaseSetup the initialization of the awaitilities (ie, waiting until the operators are ready)
+	PhaseSetup Phase = "setup"
+	// PhaseWait the waits for the resources to match the expected criteria (including the waits of the setup)
+	PhaseWait Phase = "wait"
+	// PhaseCleanup the deletion of the resources created by the test, at the end of the test
+	PhaseCleanup Phase = "cleanup"
+)
+
+// Outcome the outcome of a test
+type Outcome string
+
+const (
+	Passed  Outcome = "passed"
+	Failed  Outcome = "failed"
+	Skipped Outcome = "skipped"
+)
+
+// TestResult the result of a test, with the durations of its phases and the resources it touched
+type TestResult struct {
+	Name     string        `json:"name"`
+	Outcome  Outcome       `json:"outcome"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	// Phases the total duration of each phase of the test
+	Phases map[Phase]time.Duration `json:"phases"`
+	// Resources the resources which were waited for or cleaned up by the test, as `<kind>/<name>`
+	Resources []string `json:"resources,omitempty"`
+	// Provisioning the latencies of the stages of the provisioning of the users verified by the test
+	Provisioning []Provisioning `json:"provisioning,omitempty"`
+	// TransientErrors the number of transient errors which were retried during the test, by `<kind>/<source>`
+	TransientErrors map[string]int `json:"transientErrors,omitempty"`
+	// ResourceUsage the consumption of the resources of the namespaces created for the test
+	ResourceUsage []NamespaceUsage `json:"resourceUsage,omitempty"`
+	// done whether the test completed (the outcome and duration are only set once it completed)
+	done bool
+}
+
+// Suite the results of the tests of a suite, in the order in which they started
+type Suite struct {
+	Name  string       `json:"name"`
+	Tests []TestResult `json:"tests"`
+}
+
+// Collector collects the results of the tests, as reported by the testsupport packages.
+// The package-level functions use a default Collector shared by all the tests of the process.
+type Collector struct {
+	sync.Mutex
+	results map[*testing.T]*TestResult
+}
+
+// NewCollector returns a new Collector, with no result
+func NewCollector() *Collector {
+	return &Collector{
+		results: map[*testing.T]*TestResult{},
+	}
+}
+
+var defaultCollector = NewCollector()
+
+// RecordPhase adds the given duration to the given phase of the given test
+func RecordPhase(t *testing.T, phase Phase, duration time.Duration) {
+	defaultCollector.RecordPhase(t, phase, duration)
+}
+
+// RecordResource records that the given resource was touched by the given test
+func RecordResource(t *testing.T, kind, name string) {
+	defaultCollector.RecordResource(t, kind, name)
+}
+
+// Results returns the results of all the tests recorded so far by the default Collector
+func Results(suiteName string) Suite {
+	return defaultCollector.Results(suiteName)
+}
+
+// RecordPhase adds the given duration to the given phase of the given test
+func (c *Collector) RecordPhase(t *testing.T, phase Phase, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.result(t).Phases[phase] += duration
+}
+
+// RecordResource records that the given resource was touched by the given test
+func (c *Collector) RecordResource(t *testing.T, kind, name string) {
+	if t == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	result := c.result(t)
+	resource := fmt.Sprintf("%s/%s", kind, name)
+	for _, r := range result.Resources {
+		if r == resource {
+			return
+		}
+	}
+	result.Resources = append(result.Resources, resource)
+}
+
+// result returns the result of the given test, which is created on the first call for the test along with a cleanup function
+// which sets the outcome and the duration of the test once it completed
+func (c *Collector) result(t *testing.T) *TestResult {
+	if result, found := c.results[t]; found {
+		return result
+	}
+	result := &TestResult{
+		Name:   t.Name(),
+		Start:  time.Now(),
+		Phases: map[Phase]time.Duration{},
+	}
+	c.results[t] = result
+	// registered before the cleaning tasks of the test (unless recorded during the cleanup), hence executed after them
+	t.Cleanup(func() {
+		c.Lock()
+		defer c.Unlock()
+		result.Duration = time.Since(result.Start)
+		switch {
+		case t.Skipped():
+			result.Outcome = Skipped
+		case t.Failed():
+			result.Outcome = Failed
+		default:
+			result.Outcome = Passed
+		}
+		result.done = true
+	})
+	return result
+}
+
+// Results returns the results of the completed tests recorded so far, in the order in which they started
+func (c *Collector) Results(suiteName string) Suite {
+	c.Lock()
+	defer c.Unlock()
+	suite := Suite{
+		Name:  suiteName,
+		Tests: make([]TestResult, 0, len(c.results)),
+	}
+	for _, result := range c.results {
+		if !result.done {
+			continue
+		}
+		suite.Tests = append(suite.Tests, copyResult(result))
+	}
+	sortByStart(suite.Tests)
+	return suite
+}
+
+// sortByStart sorts the given results in the order in which the tests started
+func sortByStart(tests []TestResult) {
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Start.Equal(tests[j].Start) {
+			return tests[i].Name < tests[j].Name
+		}
+		return tests[i].Start.Before(tests[j].Start)
+	})
+}
+
+// InProgress returns the results recorded so far for the tests which are not completed yet (ie, without outcome nor duration),
+// in the order in which they started
+func InProgress() []TestResult {
+	return defaultCollector.InProgress()
+}
+
+// InProgress returns the results recorded so far for the tests which are not completed yet (ie, without outcome nor duration),
+// in the order in which they started
+func (c *Collector) InProgress() []TestResult {
+	c.Lock()
+	defer c.Unlock()
+	tests := []TestResult{}
+	for _, result := range c.results {
+		if !result.done {
+			tests = append(tests, copyResult(result))
+		}
+	}
+	sortByStart(tests)
+	return tests
+}
+
+// copyResult returns a deep copy of the given result
+func copyResult(result *TestResult) TestResult {
+	r := *result
+	r.Phases = make(map[Phase]time.Duration, len(result.Phases))
+	for phase, d := range result.Phases {
+		r.Phases[phase] = d
+	}
+	r.Resources = append([]string{}, result.Resources...)
+	r.Provisioning = make([]Provisioning, 0, len(result.Provisioning))
+	for _, p := range result.Provisioning {
+		stages := make(map[Stage]time.Duration, len(p.Stages))
+		for stage, d := range p.Stages {
+			stages[stage] = d
+		}
+		r.Provisioning = append(r.Provisioning, Provisioning{Username: p.Username, Stages: stages})
+	}
+	r.ResourceUsage = make([]NamespaceUsage, 0, len(result.ResourceUsage))
+	for _, u := range result.ResourceUsage {
+		used := make(map[string]string, len(u.Used))
+		for name, quantity := range u.Used {
+			used[name] = quantity
+		}
+		r.ResourceUsage = append(r.ResourceUsage, NamespaceUsage{Namespace: u.Namespace, Used: used})
+	}
+	if result.TransientErrors != nil {
+		r.TransientErrors = make(map[string]int, len(result.TransientErrors))
+		for key, count := range result.TransientErrors {
+			r.TransientErrors[key] = count
+		}
+	}
+	return r
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ErrorKind the kind of a transient error, which was retried by the testsupport packages instead of failing the test
+type ErrorKind string
+
+const (
+	// ErrorConflict the update of an object failed because the object was modified in the meantime
+	ErrorConflict ErrorKind = "conflict"
+	// ErrorThrottled the request was rejected with a `429 Too Many Requests` response
+	ErrorThrottled ErrorKind = "throttled"
+	// ErrorServer the request failed with a 5xx response
+	ErrorServer ErrorKind = "server"
+	// ErrorTransport no response was received (eg, the connection was refused or timed out)
+	ErrorTransport ErrorKind = "transport"
+)
+
+// RecordTransientError records that a transient error of the given kind was observed (and retried) by the given test,
+// while calling the given source (eg, `registration-service` or the kind of the object which was updated)
+func RecordTransientError(t *testing.T, kind ErrorKind, source string) {
+	defaultCollector.RecordTransientError(t, kind, source)
+}
+
+// RecordTransientError records that a transient error of the given kind was observed (and retried) by the given test,
+// while calling the given source (eg, `registration-service` or the kind of the object which was updated)
+func (c *Collector) RecordTransientError(t *testing.T, kind ErrorKind, source string) {
+	if t == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	result := c.result(t)
+	if result.TransientErrors == nil {
+		result.TransientErrors = map[string]int{}
+	}
+	result.TransientErrors[errorKey(kind, source)]++
+}
+
+// TransientErrors returns the total number of transient errors observed by the tests of the suite, by `<kind>/<source>`
+func (s Suite) TransientErrors() map[string]int {
+	total := map[string]int{}
+	for _, test := range s.Tests {
+		for key, count := range test.TransientErrors {
+			total[key] += count
+		}
+	}
+	return total
+}
+
+// TotalTransientErrors returns the total number of transient errors observed by the tests of the suite
+func (s Suite) TotalTransientErrors() int {
+	total := 0
+	for _, count := range s.TransientErrors() {
+		total += count
+	}
+	return total
+}
+
+// CheckErrorBudget prints the number of transient errors which were observed and retried by the tests of the suite (by kind and source,
+// and by test), and returns an error if their total exceeds the given budget. A negative budget means that there is no limit.
+func (s Suite) CheckErrorBudget(out io.Writer, budget int) error {
+	total := s.TotalTransientErrors()
+	if total > 0 {
+		fmt.Fprintf(out, "transient errors retried by the tests of suite '%s': %d\n", s.Name, total)
+		errs := s.TransientErrors()
+		for _, key := range SortedErrorKeys(errs) {
+			fmt.Fprintf(out, "  %-60s %d\n", key, errs[key])
+		}
+		for _, test := range s.Tests {
+			count := 0
+			for _, c := range test.TransientErrors {
+				count += c
+			}
+			if count > 0 {
+				fmt.Fprintf(out, "  %-60s %d\n", test.Name, count)
+			}
+		}
+	}
+	if budget >= 0 && total > budget {
+		return fmt.Errorf("error budget exceeded: %d transient errors were retried by the tests of suite '%s', but the budget is %d", total, s.Name, budget)
+	}
+	return nil
+}
+
+// SortedErrorKeys returns the `<kind>/<source>` keys of the given transient errors, sorted by decreasing count
+func SortedErrorKeys(errs map[string]int) []string {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if errs[keys[i]] == errs[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return errs[keys[i]] > errs[keys[j]]
+	})
+	return keys
+}
+
+// errorKey returns the key of the transient errors of the given kind observed while calling the given source
+func errorKey(kind ErrorKind, source string) string {
+	return fmt.Sprintf("%s/%s", kind, strings.ToLower(source))
+}
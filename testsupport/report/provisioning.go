@@ -0,0 +1,76 @@
+package report
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// Stage a stage of the provisioning of a user, whose latency is recorded
+type Stage string
+
+const (
+	// StageApproved the UserSignup is approved
+	StageApproved Stage = "approved"
+	// StageMasterUserRecord the MasterUserRecord is created
+	StageMasterUserRecord Stage = "masteruserrecord"
+	// StageUserAccount the UserAccount is ready in the member cluster
+	StageUserAccount Stage = "useraccount"
+	// StageSpace the Space is ready
+	StageSpace Stage = "space"
+	// StageNamespaces all the namespaces of the Space are created in the member cluster
+	StageNamespaces Stage = "namespaces"
+	// StageComplete the UserSignup is complete
+	StageComplete Stage = "complete"
+)
+
+// Provisioning the latencies of the stages of the provisioning of a user, each one measured from the creation of its UserSignup
+type Provisioning struct {
+	Username string                  `json:"username"`
+	Stages   map[Stage]time.Duration `json:"stages"`
+}
+
+// RecordProvisioning records the latencies of the stages of the provisioning of the user with the given name, in the given test
+func RecordProvisioning(t *testing.T, username string, stages map[Stage]time.Duration) {
+	defaultCollector.RecordProvisioning(t, username, stages)
+}
+
+// RecordProvisioning records the latencies of the stages of the provisioning of the user with the given name, in the given test.
+// The latencies which were previously recorded for the same user in the same test are replaced.
+func (c *Collector) RecordProvisioning(t *testing.T, username string, stages map[Stage]time.Duration) {
+	if t == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	result := c.result(t)
+	provisioning := Provisioning{
+		Username: username,
+		Stages:   make(map[Stage]time.Duration, len(stages)),
+	}
+	for stage, d := range stages {
+		provisioning.Stages[stage] = d
+	}
+	for i, p := range result.Provisioning {
+		if p.Username == username {
+			result.Provisioning[i] = provisioning
+			return
+		}
+	}
+	result.Provisioning = append(result.Provisioning, provisioning)
+}
+
+// SortedStages returns the stages of the provisioning, sorted by latency
+func (p Provisioning) SortedStages() []Stage {
+	stages := make([]Stage, 0, len(p.Stages))
+	for stage := range p.Stages {
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		if p.Stages[stages[i]] == p.Stages[stages[j]] {
+			return stages[i] < stages[j]
+		}
+		return p.Stages[stages[i]] < p.Stages[stages[j]]
+	})
+	return stages
+}
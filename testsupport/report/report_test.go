@@ -0,0 +1,263 @@
+package report_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	// given
+	collector := report.NewCollector()
+
+	// when
+	t.Run("passing", func(t *testing.T) {
+		collector.RecordPhase(t, report.PhaseSetup, time.Second)
+		collector.RecordPhase(t, report.PhaseWait, 2*time.Second)
+		collector.RecordPhase(t, report.PhaseWait, 3*time.Second)
+		collector.RecordResource(t, "Space", "oddity")
+		collector.RecordResource(t, "Space", "oddity")
+		collector.RecordProvisioning(t, "oddity", map[report.Stage]time.Duration{report.StageApproved: time.Second})
+		collector.RecordProvisioning(t, "oddity", map[report.Stage]time.Duration{report.StageApproved: 2 * time.Second}) // replaces the previous one
+		collector.RecordTransientError(t, report.ErrorConflict, "Space")
+		collector.RecordTransientError(t, report.ErrorConflict, "Space")
+		collector.RecordTransientError(t, report.ErrorServer, "registration-service")
+		collector.RecordResourceUsage(t, "oddity-dev", map[string]string{"limits.cpu": "500m"})
+		collector.RecordResourceUsage(t, "oddity-dev", map[string]string{"limits.cpu": "1500m", "pods": "2"}) // replaces the previous one
+	})
+	t.Run("skipped", func(t *testing.T) {
+		collector.RecordPhase(t, report.PhaseSetup, time.Second)
+		t.Skip("skipped on purpose")
+	})
+
+	// then
+	suite := collector.Results("unit")
+	assert.Equal(t, "unit", suite.Name)
+	require.Len(t, suite.Tests, 2)
+	assert.Equal(t, "TestCollector/passing", suite.Tests[0].Name)
+	assert.Equal(t, report.Passed, suite.Tests[0].Outcome)
+	assert.Equal(t, map[report.Phase]time.Duration{
+		report.PhaseSetup: time.Second,
+		report.PhaseWait:  5 * time.Second,
+	}, suite.Tests[0].Phases)
+	assert.Equal(t, []string{"Space/oddity"}, suite.Tests[0].Resources)
+	assert.Equal(t, []report.Provisioning{
+		{Username: "oddity", Stages: map[report.Stage]time.Duration{report.StageApproved: 2 * time.Second}},
+	}, suite.Tests[0].Provisioning)
+	assert.Equal(t, map[string]int{
+		"conflict/space":              2,
+		"server/registration-service": 1,
+	}, suite.Tests[0].TransientErrors)
+	assert.Equal(t, []report.NamespaceUsage{
+		{Namespace: "oddity-dev", Used: map[string]string{"limits.cpu": "1500m", "pods": "2"}},
+	}, suite.Tests[0].ResourceUsage)
+	assert.Equal(t, "TestCollector/skipped", suite.Tests[1].Name)
+	assert.Equal(t, report.Skipped, suite.Tests[1].Outcome)
+	assert.Nil(t, suite.Tests[1].TransientErrors)
+	assert.Equal(t, 3, suite.TotalTransientErrors())
+	assert.Equal(t, []string{"conflict/space", "server/registration-service"}, report.SortedErrorKeys(suite.TransientErrors()))
+}
+
+func TestCheckErrorBudget(t *testing.T) {
+	// given
+	suite := report.Suite{
+		Name: "unit",
+		Tests: []report.TestResult{
+			{
+				Name: "TestFlaky",
+				TransientErrors: map[string]int{
+					"conflict/space":                 2,
+					"throttled/registration-service": 1,
+				},
+			},
+			{
+				Name: "TestStable",
+			},
+		},
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		// given
+		out := &strings.Builder{}
+
+		// when
+		err := suite.CheckErrorBudget(out, 3)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "transient errors retried by the tests of suite 'unit': 3\n"+
+			"  conflict/space                                               2\n"+
+			"  throttled/registration-service                               1\n"+
+			"  TestFlaky                                                    3\n", out.String())
+	})
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		// when
+		err := suite.CheckErrorBudget(&strings.Builder{}, 2)
+
+		// then
+		require.EqualError(t, err, "error budget exceeded: 3 transient errors were retried by the tests of suite 'unit', but the budget is 2")
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		// when
+		err := suite.CheckErrorBudget(&strings.Builder{}, -1)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("no transient error", func(t *testing.T) {
+		// given
+		out := &strings.Builder{}
+
+		// when
+		err := report.Suite{Name: "unit", Tests: []report.TestResult{{Name: "TestStable"}}}.CheckErrorBudget(out, 0)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestInProgress(t *testing.T) {
+	// given
+	collector := report.NewCollector()
+	t.Run("completed", func(t *testing.T) {
+		collector.RecordResource(t, "Space", "completed")
+	})
+
+	t.Run("running", func(t *testing.T) {
+		// when
+		collector.RecordResource(t, "Space", "oddity")
+
+		// then
+		tests := collector.InProgress()
+		require.Len(t, tests, 1)
+		assert.Equal(t, "TestInProgress/running", tests[0].Name)
+		assert.Empty(t, tests[0].Outcome)
+		assert.Equal(t, []string{"Space/oddity"}, tests[0].Resources)
+	})
+
+	// then
+	assert.Empty(t, collector.InProgress())
+}
+
+func TestReporters(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	suite := report.Suite{
+		Name: "unit",
+		Tests: []report.TestResult{
+			{
+				Name:     "TestPassing",
+				Outcome:  report.Passed,
+				Start:    time.Now(),
+				Duration: 3 * time.Second,
+				Phases: map[report.Phase]time.Duration{
+					report.PhaseWait:    2 * time.Second,
+					report.PhaseCleanup: time.Second,
+				},
+				Resources: []string{"Space/oddity"},
+				Provisioning: []report.Provisioning{
+					{
+						Username: "oddity",
+						Stages: map[report.Stage]time.Duration{
+							report.StageApproved:         time.Second,
+							report.StageMasterUserRecord: 1500 * time.Millisecond,
+							report.StageSpace:            3 * time.Second,
+						},
+					},
+				},
+				TransientErrors: map[string]int{
+					"conflict/space":    1,
+					"server/usersignup": 2,
+				},
+				ResourceUsage: []report.NamespaceUsage{
+					{Namespace: "oddity-dev", Used: map[string]string{"pods": "2", "limits.cpu": "1500m"}},
+				},
+			},
+			{
+				Name:     "TestFailing",
+				Outcome:  report.Failed,
+				Start:    time.Now(),
+				Duration: time.Second,
+			},
+		},
+	}
+
+	// when
+	err := report.Write(dir, suite, "json", "tap", "allure")
+
+	// then
+	require.NoError(t, err)
+
+	t.Run("json", func(t *testing.T) {
+		content, err := os.ReadFile(filepath.Join(dir, "unit-report.json"))
+		require.NoError(t, err)
+		actual := report.Suite{}
+		require.NoError(t, json.Unmarshal(content, &actual))
+		assert.Equal(t, "unit", actual.Name)
+		require.Len(t, actual.Tests, 2)
+		assert.Equal(t, report.Failed, actual.Tests[1].Outcome)
+	})
+
+	t.Run("tap", func(t *testing.T) {
+		content, err := os.ReadFile(filepath.Join(dir, "unit-report.tap"))
+		require.NoError(t, err)
+		assert.Equal(t, `TAP version 13
+1..2
+ok 1 - TestPassing
+  ---
+  duration_ms: 3000
+  cleanup_ms: 1000
+  wait_ms: 2000
+  resources:
+    - Space/oddity
+  provisioning:
+    - username: oddity
+      approved_ms: 1000
+      masteruserrecord_ms: 1500
+      space_ms: 3000
+  transient_errors:
+    server/usersignup: 2
+    conflict/space: 1
+  resource_usage:
+    - namespace: oddity-dev
+      limits.cpu: 1500m
+      pods: 2
+  ...
+not ok 2 - TestFailing
+  ---
+  duration_ms: 1000
+  ...
+`, string(content))
+	})
+
+	t.Run("allure", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join(dir, "allure-results", "*-result.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		statuses := map[string]string{}
+		for _, f := range files {
+			content, err := os.ReadFile(f)
+			require.NoError(t, err)
+			result := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(content, &result))
+			statuses[result["name"].(string)] = result["status"].(string)
+		}
+		assert.Equal(t, map[string]string{"TestPassing": "passed", "TestFailing": "failed"}, statuses)
+	})
+
+	t.Run("unknown reporter", func(t *testing.T) {
+		err := report.Write(dir, suite, "junit")
+		require.EqualError(t, err, "unknown reporter 'junit'")
+	})
+}
@@ -0,0 +1,199 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Reporter writes the results of a suite as artifacts in a given directory
+type Reporter interface {
+	Write(dir string, suite Suite) error
+}
+
+// Reporters the available reporters, by name
+var Reporters = map[string]Reporter{
+	"json":   JSONReporter{},
+	"allure": AllureReporter{},
+	"tap":    TAPReporter{},
+}
+
+// Write writes the results of the given suite in the given directory with each of the reporters with the given names
+func Write(dir string, suite Suite, reporterNames ...string) error {
+	for _, name := range reporterNames {
+		reporter, found := Reporters[name]
+		if !found {
+			return fmt.Errorf("unknown reporter '%s'", name)
+		}
+		if err := reporter.Write(dir, suite); err != nil {
+			return fmt.Errorf("unable to write the '%s' report of suite '%s': %w", name, suite.Name, err)
+		}
+	}
+	return nil
+}
+
+// JSONReporter writes the results of the suite in a `<suite>-report.json` file
+type JSONReporter struct{}
+
+func (JSONReporter) Write(dir string, suite Suite) error {
+	content, err := json.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, suite.Name+"-report.json"), content, 0600)
+}
+
+// TAPReporter writes the results of the suite in a `<suite>-report.tap` file, in the TAP version 13 format
+// (with the phases, resources, provisioning latencies, transient errors and resource usage of each test in its YAML diagnostic block)
+type TAPReporter struct{}
+
+func (TAPReporter) Write(dir string, suite Suite) error {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "TAP version 13\n1..%d\n", len(suite.Tests))
+	for i, test := range suite.Tests {
+		switch test.Outcome {
+		case Failed:
+			fmt.Fprintf(buf, "not ok %d - %s\n", i+1, test.Name)
+		case Skipped:
+			fmt.Fprintf(buf, "ok %d - %s # SKIP\n", i+1, test.Name)
+		default:
+			fmt.Fprintf(buf, "ok %d - %s\n", i+1, test.Name)
+		}
+		fmt.Fprintf(buf, "  ---\n  duration_ms: %d\n", test.Duration.Milliseconds())
+		for _, phase := range sortedPhases(test) {
+			fmt.Fprintf(buf, "  %s_ms: %d\n", phase, test.Phases[phase].Milliseconds())
+		}
+		if len(test.Resources) > 0 {
+			buf.WriteString("  resources:\n")
+			for _, r := range test.Resources {
+				fmt.Fprintf(buf, "    - %s\n", r)
+			}
+		}
+		if len(test.Provisioning) > 0 {
+			buf.WriteString("  provisioning:\n")
+			for _, p := range test.Provisioning {
+				fmt.Fprintf(buf, "    - username: %s\n", p.Username)
+				for _, stage := range p.SortedStages() {
+					fmt.Fprintf(buf, "      %s_ms: %d\n", stage, p.Stages[stage].Milliseconds())
+				}
+			}
+		}
+		if len(test.TransientErrors) > 0 {
+			buf.WriteString("  transient_errors:\n")
+			for _, key := range SortedErrorKeys(test.TransientErrors) {
+				fmt.Fprintf(buf, "    %s: %d\n", key, test.TransientErrors[key])
+			}
+		}
+		if len(test.ResourceUsage) > 0 {
+			buf.WriteString("  resource_usage:\n")
+			for _, u := range test.ResourceUsage {
+				fmt.Fprintf(buf, "    - namespace: %s\n", u.Namespace)
+				for _, name := range u.SortedResources() {
+					fmt.Fprintf(buf, "      %s: %s\n", name, u.Used[name])
+				}
+			}
+		}
+		buf.WriteString("  ...\n")
+	}
+	return os.WriteFile(filepath.Join(dir, suite.Name+"-report.tap"), []byte(buf.String()), 0600)
+}
+
+// AllureReporter writes the result of each test of the suite in a `<uuid>-result.json` file of the `allure-results`
+// sub-directory, in the format of the Allure test results (the phases of the test are reported as steps)
+type AllureReporter struct{}
+
+type allureResult struct {
+	UUID       string            `json:"uuid"`
+	HistoryID  string            `json:"historyId"`
+	Name       string            `json:"name"`
+	FullName   string            `json:"fullName"`
+	Status     string            `json:"status"`
+	Stage      string            `json:"stage"`
+	Start      int64             `json:"start"`
+	Stop       int64             `json:"stop"`
+	Steps      []allureStep      `json:"steps,omitempty"`
+	Labels     []allureParameter `json:"labels"`
+	Parameters []allureParameter `json:"parameters,omitempty"`
+}
+
+type allureStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Stage  string `json:"stage"`
+	Start  int64  `json:"start"`
+	Stop   int64  `json:"stop"`
+}
+
+type allureParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (AllureReporter) Write(dir string, suite Suite) error {
+	resultsDir := filepath.Join(dir, "allure-results")
+	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+		return err
+	}
+	for _, test := range suite.Tests {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		result := allureResult{
+			UUID:      id.String(),
+			HistoryID: suite.Name + "/" + test.Name,
+			Name:      test.Name,
+			FullName:  suite.Name + "/" + test.Name,
+			Status:    string(test.Outcome),
+			Stage:     "finished",
+			Start:     millis(test.Start),
+			Stop:      millis(test.Start.Add(test.Duration)),
+			Labels: []allureParameter{
+				{Name: "suite", Value: suite.Name},
+			},
+		}
+		// the phases are not contiguous, hence each step starts with the test
+		for _, phase := range sortedPhases(test) {
+			result.Steps = append(result.Steps, allureStep{
+				Name:   string(phase),
+				Status: string(Passed),
+				Stage:  "finished",
+				Start:  millis(test.Start),
+				Stop:   millis(test.Start.Add(test.Phases[phase])),
+			})
+		}
+		for _, r := range test.Resources {
+			result.Parameters = append(result.Parameters, allureParameter{Name: "resource", Value: r})
+		}
+		content, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(resultsDir, id.String()+"-result.json"), content, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// sortedPhases returns the phases of the given test, sorted by name
+func sortedPhases(test TestResult) []Phase {
+	phases := make([]Phase, 0, len(test.Phases))
+	for phase := range test.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		return phases[i] < phases[j]
+	})
+	return phases
+}
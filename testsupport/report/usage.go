@@ -0,0 +1,53 @@
+package report
+
+import (
+	"sort"
+	"testing"
+)
+
+// NamespaceUsage the consumption of the resources of a namespace created for a test, as reported by the status of its ResourceQuotas
+type NamespaceUsage struct {
+	Namespace string `json:"namespace"`
+	// Used the quantity used for each quota resource (eg, `limits.cpu: 1500m`)
+	Used map[string]string `json:"used"`
+}
+
+// RecordResourceUsage records the consumption of the resources of the given namespace, created for the given test
+func RecordResourceUsage(t *testing.T, namespace string, used map[string]string) {
+	defaultCollector.RecordResourceUsage(t, namespace, used)
+}
+
+// RecordResourceUsage records the consumption of the resources of the given namespace, created for the given test.
+// The consumption which was previously recorded for the same namespace in the same test is replaced.
+func (c *Collector) RecordResourceUsage(t *testing.T, namespace string, used map[string]string) {
+	if t == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	result := c.result(t)
+	usage := NamespaceUsage{
+		Namespace: namespace,
+		Used:      make(map[string]string, len(used)),
+	}
+	for name, quantity := range used {
+		usage.Used[name] = quantity
+	}
+	for i, u := range result.ResourceUsage {
+		if u.Namespace == namespace {
+			result.ResourceUsage[i] = usage
+			return
+		}
+	}
+	result.ResourceUsage = append(result.ResourceUsage, usage)
+}
+
+// SortedResources returns the names of the resources used in the namespace, sorted alphabetically
+func (u NamespaceUsage) SortedResources() []string {
+	names := make([]string, 0, len(u.Used))
+	for name := range u.Used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,27 @@
+package testsupport
+
+import (
+	"os"
+	"strings"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+)
+
+// ReportersVar the env var with the comma-separated names of the reporters of the suite results (eg, `json,allure,tap`)
+const ReportersVar = "E2E_REPORTERS"
+
+// WriteSuiteReports writes the results of the tests of the suite (with the durations of their setup, wait and cleanup phases,
+// and the resources they touched) with the reporters set in the `E2E_REPORTERS` env var, in the directory set with the
+// `ARTIFACT_DIR` env var (or in a temporary directory if the env var is not set). It is meant to be called at the end
+// of the test suite (eg. in `TestMain`), and does nothing if no reporter is set.
+func WriteSuiteReports(suiteName string) error {
+	names := os.Getenv(ReportersVar)
+	if names == "" {
+		return nil
+	}
+	dir := os.Getenv(ArtifactDirVar)
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return report.Write(dir, report.Results(suiteName), strings.Split(names, ",")...)
+}
@@ -0,0 +1,293 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	userv1 "github.com/openshift/api/user/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ArtifactDirVar the name of the env var with the path to the directory in which the artifacts of the tests are saved
+const ArtifactDirVar = "ARTIFACT_DIR"
+
+// ResourceGraphNode a resource in the graph
+type ResourceGraphNode struct {
+	Cluster   string
+	Kind      string
+	Namespace string
+	Name      string
+	uid       types.UID
+	owners    []types.UID
+}
+
+// ID the unique identifier of the node in the graph
+func (n ResourceGraphNode) ID() string {
+	return nonAlphanumeric.ReplaceAllString(strings.Join([]string{n.Cluster, n.Kind, n.Namespace, n.Name}, "_"), "_")
+}
+
+// Label the label of the node in the rendered graph
+func (n ResourceGraphNode) Label() string {
+	name := n.Name
+	if n.Namespace != "" {
+		name = n.Namespace + "/" + n.Name
+	}
+	return fmt.Sprintf("%s\\n%s\\n(%s)", n.Kind, name, n.Cluster)
+}
+
+// ResourceGraphEdge a relation between two resources in the graph (eg: `owns`)
+type ResourceGraphEdge struct {
+	From     string
+	To       string
+	Relation string
+}
+
+// ResourceGraph the resources related to a user across all the clusters, with their owner references and the toolchain relations between them
+// (eg: the MasterUserRecord is propagated to the UserAccounts in the member clusters)
+type ResourceGraph struct {
+	Nodes []ResourceGraphNode
+	Edges []ResourceGraphEdge
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// BuildResourceGraph returns the graph of the resources related to the user with the given compliant username (ie, the name of its
+// MasterUserRecord and Space). Missing resources are simply not part of the graph, which helps to figure out where the provisioning stopped.
+func BuildResourceGraph(awaitilities wait.Awaitilities, username string) (*ResourceGraph, error) {
+	g := &ResourceGraph{}
+	hostAwait := awaitilities.Host()
+	hostName := hostAwait.ClusterName
+	if hostName == "" {
+		hostName = "host"
+	}
+
+	// host resources
+	userSignups := &toolchainv1alpha1.UserSignupList{}
+	if err := hostAwait.Client.List(context.TODO(), userSignups, client.InNamespace(hostAwait.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range userSignups.Items {
+		if userSignups.Items[i].Status.CompliantUsername == username {
+			g.add(hostName, &userSignups.Items[i])
+			g.relate(nodeOf(hostName, &userSignups.Items[i]), ResourceGraphNode{Cluster: hostName, Kind: "MasterUserRecord", Namespace: hostAwait.Namespace, Name: username}, "provisions")
+		}
+	}
+	mur := &toolchainv1alpha1.MasterUserRecord{}
+	if found, err := g.get(hostName, hostAwait.Client, hostAwait.Namespace, username, mur); err != nil {
+		return nil, err
+	} else if found {
+		for _, ua := range mur.Spec.UserAccounts {
+			g.relate(nodeOf(hostName, mur), ResourceGraphNode{Cluster: ua.TargetCluster, Kind: "UserAccount", Namespace: memberNamespace(awaitilities, ua.TargetCluster), Name: username}, "propagated to")
+		}
+	}
+	space := &toolchainv1alpha1.Space{}
+	if found, err := g.get(hostName, hostAwait.Client, hostAwait.Namespace, username, space); err != nil {
+		return nil, err
+	} else if found && space.Spec.TargetCluster != "" {
+		g.relate(nodeOf(hostName, space), ResourceGraphNode{Cluster: space.Spec.TargetCluster, Kind: "NSTemplateSet", Namespace: memberNamespace(awaitilities, space.Spec.TargetCluster), Name: username}, "provisioned as")
+	}
+	spaceBindings := &toolchainv1alpha1.SpaceBindingList{}
+	if err := hostAwait.Client.List(context.TODO(), spaceBindings, client.InNamespace(hostAwait.Namespace),
+		client.MatchingLabels{toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: username}); err != nil {
+		return nil, err
+	}
+	for i := range spaceBindings.Items {
+		sb := &spaceBindings.Items[i]
+		g.add(hostName, sb)
+		g.relate(nodeOf(hostName, sb), ResourceGraphNode{Cluster: hostName, Kind: "MasterUserRecord", Namespace: hostAwait.Namespace, Name: sb.Spec.MasterUserRecord}, "binds")
+		g.relate(nodeOf(hostName, sb), ResourceGraphNode{Cluster: hostName, Kind: "Space", Namespace: hostAwait.Namespace, Name: sb.Spec.Space}, "binds")
+	}
+
+	// member resources
+	for _, memberAwait := range awaitilities.AllMembers() {
+		if _, err := g.get(memberAwait.ClusterName, memberAwait.Client, memberAwait.Namespace, username, &toolchainv1alpha1.UserAccount{}); err != nil {
+			return nil, err
+		}
+		nsTmplSet := &toolchainv1alpha1.NSTemplateSet{}
+		if found, err := g.get(memberAwait.ClusterName, memberAwait.Client, memberAwait.Namespace, username, nsTmplSet); err != nil {
+			return nil, err
+		} else if found {
+			for _, ns := range nsTmplSet.Status.ProvisionedNamespaces {
+				g.relate(nodeOf(memberAwait.ClusterName, nsTmplSet), ResourceGraphNode{Cluster: memberAwait.ClusterName, Kind: "Namespace", Name: ns.Name}, "provisions")
+			}
+		}
+		owned := client.MatchingLabels{toolchainv1alpha1.OwnerLabelKey: username}
+		for _, list := range []client.ObjectList{&corev1.NamespaceList{}, &userv1.UserList{}, &userv1.IdentityList{}} {
+			if err := memberAwait.Client.List(context.TODO(), list, owned); err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				obj, ok := item.(client.Object)
+				if !ok {
+					continue
+				}
+				g.add(memberAwait.ClusterName, obj)
+				if _, isNamespace := obj.(*corev1.Namespace); !isNamespace {
+					userAccount := ResourceGraphNode{Cluster: memberAwait.ClusterName, Kind: "UserAccount", Namespace: memberAwait.Namespace, Name: username}
+					g.relate(userAccount, nodeOf(memberAwait.ClusterName, obj), "provisions")
+				}
+			}
+		}
+	}
+	g.addOwnerReferences()
+	return g, nil
+}
+
+// get retrieves the object with the given name and adds it to the graph if it exists
+func (g *ResourceGraph) get(cluster string, cl client.Client, namespace, name string, obj client.Object) (bool, error) {
+	if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	g.add(cluster, obj)
+	return true, nil
+}
+
+func (g *ResourceGraph) add(cluster string, obj client.Object) {
+	node := nodeOf(cluster, obj)
+	node.uid = obj.GetUID()
+	for _, ref := range obj.GetOwnerReferences() {
+		node.owners = append(node.owners, ref.UID)
+	}
+	g.Nodes = append(g.Nodes, node)
+}
+
+// relate adds an edge between the given nodes. When a node is not part of the graph (ie, the resource does not exist),
+// it is rendered as missing.
+func (g *ResourceGraph) relate(from, to ResourceGraphNode, relation string) {
+	g.Edges = append(g.Edges, ResourceGraphEdge{From: from.ID(), To: to.ID(), Relation: relation})
+}
+
+func nodeOf(cluster string, obj client.Object) ResourceGraphNode {
+	return ResourceGraphNode{
+		Cluster:   cluster,
+		Kind:      reflect.TypeOf(obj).Elem().Name(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+func (g *ResourceGraph) addOwnerReferences() {
+	byUID := map[types.UID]ResourceGraphNode{}
+	for _, n := range g.Nodes {
+		byUID[n.uid] = n
+	}
+	for _, n := range g.Nodes {
+		for _, owner := range n.owners {
+			if o, found := byUID[owner]; found {
+				g.Edges = append(g.Edges, ResourceGraphEdge{From: o.ID(), To: n.ID(), Relation: "owns"})
+			}
+		}
+	}
+}
+
+// missingNodes returns the IDs of the nodes which are referred to by an edge, but which do not exist
+func (g *ResourceGraph) missingNodes() []string {
+	existing := map[string]bool{}
+	for _, n := range g.Nodes {
+		existing[n.ID()] = true
+	}
+	missing := map[string]bool{}
+	for _, e := range g.Edges {
+		for _, id := range []string{e.From, e.To} {
+			if !existing[id] {
+				missing[id] = true
+			}
+		}
+	}
+	result := make([]string, 0, len(missing))
+	for id := range missing {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, in which the missing resources are highlighted
+func (g *ResourceGraph) Mermaid() string {
+	buf := &strings.Builder{}
+	buf.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(buf, "  %s[\"%s\"]\n", n.ID(), strings.ReplaceAll(n.Label(), "\\n", "<br/>"))
+	}
+	for _, id := range g.missingNodes() {
+		fmt.Fprintf(buf, "  %s[\"missing: %s\"]:::missing\n", id, id)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(buf, "  %s -->|%s| %s\n", e.From, e.Relation, e.To)
+	}
+	buf.WriteString("  classDef missing stroke:#f00,stroke-dasharray: 5 5\n")
+	return buf.String()
+}
+
+// DOT renders the graph in the DOT language of Graphviz, in which the missing resources are highlighted
+func (g *ResourceGraph) DOT() string {
+	buf := &strings.Builder{}
+	buf.WriteString("digraph resources {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(buf, "  %s [label=\"%s\"];\n", n.ID(), n.Label())
+	}
+	for _, id := range g.missingNodes() {
+		fmt.Fprintf(buf, "  %s [label=\"missing: %s\", color=red, style=dashed];\n", id, id)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(buf, "  %s -> %s [label=\"%s\"];\n", e.From, e.To, e.Relation)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// SaveResourceGraphOnFailure renders the graph of the resources of the user with the given compliant username as a Mermaid file
+// in the directory set with the `ARTIFACT_DIR` env var (or in a temporary directory if the env var is not set), if the test failed.
+// It is meant to be deferred right after the setup of the test, eg:
+//
+//	defer SaveResourceGraphOnFailure(t, awaitilities, username)
+//
+// so that the graph is built even when the test fails before the end of the setup, and before the resources are deleted
+// (the deferred calls run before the cleanup functions of the test, even when the test stops with `t.FailNow`)
+func SaveResourceGraphOnFailure(t *testing.T, awaitilities wait.Awaitilities, username string) {
+	if !t.Failed() {
+		return
+	}
+	g, err := BuildResourceGraph(awaitilities, username)
+	if err != nil {
+		t.Logf("unable to build the resource graph of user '%s': %s", username, err)
+		return
+	}
+	dir := os.Getenv(ArtifactDirVar)
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	path := filepath.Join(dir, fmt.Sprintf("resource-graph-%s-%s.mmd", nonAlphanumeric.ReplaceAllString(t.Name(), "_"), username))
+	if err := os.WriteFile(path, []byte(g.Mermaid()), 0600); err != nil {
+		t.Logf("unable to save the resource graph of user '%s': %s", username, err)
+		return
+	}
+	t.Logf("resource graph of user '%s' saved in '%s'", username, path)
+}
+
+func memberNamespace(awaitilities wait.Awaitilities, clusterName string) string {
+	if memberAwait, err := awaitilities.Member(clusterName); err == nil {
+		return memberAwait.Namespace
+	}
+	return ""
+}
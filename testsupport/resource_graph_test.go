@@ -0,0 +1,98 @@
+package testsupport_test
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestResourceGraph(t *testing.T) {
+	// given
+	require.NoError(t, userv1.AddToScheme(scheme.Scheme))
+	userSignup := &toolchainv1alpha1.UserSignup{
+		ObjectMeta: metav1.ObjectMeta{Name: "johnsmith-signup", Namespace: commontest.HostOperatorNs},
+		Status:     toolchainv1alpha1.UserSignupStatus{CompliantUsername: "johnsmith"},
+	}
+	mur := &toolchainv1alpha1.MasterUserRecord{
+		ObjectMeta: metav1.ObjectMeta{Name: "johnsmith", Namespace: commontest.HostOperatorNs, UID: "mur-uid"},
+		Spec: toolchainv1alpha1.MasterUserRecordSpec{
+			UserAccounts: []toolchainv1alpha1.UserAccountEmbedded{{TargetCluster: "member-cluster"}},
+		},
+	}
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "johnsmith", Namespace: commontest.HostOperatorNs},
+		Spec:       toolchainv1alpha1.SpaceSpec{TargetCluster: "member-cluster"},
+	}
+	spaceBinding := &toolchainv1alpha1.SpaceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "johnsmith-johnsmith",
+			Namespace: commontest.HostOperatorNs,
+			Labels:    map[string]string{toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: "johnsmith"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: toolchainv1alpha1.GroupVersion.String(),
+				Kind:       "MasterUserRecord",
+				Name:       "johnsmith",
+				UID:        "mur-uid",
+			}},
+		},
+		Spec: toolchainv1alpha1.SpaceBindingSpec{MasterUserRecord: "johnsmith", Space: "johnsmith", SpaceRole: "admin"},
+	}
+	userAccount := &toolchainv1alpha1.UserAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "johnsmith", Namespace: commontest.MemberOperatorNs},
+	}
+	hostAwait := wait.NewHostAwaitility(nil, commontest.NewFakeClient(t, userSignup, mur, space, spaceBinding), commontest.HostOperatorNs, "registration-service")
+	// the NSTemplateSet of the Space is missing in the member cluster
+	memberAwait := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t, userAccount), commontest.MemberOperatorNs, "member-cluster")
+
+	// when
+	g, err := testsupport.BuildResourceGraph(wait.NewAwaitilities(hostAwait, memberAwait), "johnsmith")
+
+	// then
+	require.NoError(t, err)
+	nodes := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n.ID())
+	}
+	assert.ElementsMatch(t, []string{
+		"host_UserSignup_toolchain_host_operator_johnsmith_signup",
+		"host_MasterUserRecord_toolchain_host_operator_johnsmith",
+		"host_Space_toolchain_host_operator_johnsmith",
+		"host_SpaceBinding_toolchain_host_operator_johnsmith_johnsmith",
+		"member_cluster_UserAccount_toolchain_member_operator_johnsmith",
+	}, nodes)
+
+	t.Run("mermaid", func(t *testing.T) {
+		// when
+		mermaid := g.Mermaid()
+
+		// then
+		assert.Contains(t, mermaid, "flowchart LR\n")
+		assert.Contains(t, mermaid, `  host_MasterUserRecord_toolchain_host_operator_johnsmith["MasterUserRecord<br/>toolchain-host-operator/johnsmith<br/>(host)"]`+"\n")
+		assert.Contains(t, mermaid, "  host_UserSignup_toolchain_host_operator_johnsmith_signup -->|provisions| host_MasterUserRecord_toolchain_host_operator_johnsmith\n")
+		assert.Contains(t, mermaid, "  host_MasterUserRecord_toolchain_host_operator_johnsmith -->|propagated to| member_cluster_UserAccount_toolchain_member_operator_johnsmith\n")
+		assert.Contains(t, mermaid, "  host_MasterUserRecord_toolchain_host_operator_johnsmith -->|owns| host_SpaceBinding_toolchain_host_operator_johnsmith_johnsmith\n")
+		assert.Contains(t, mermaid, "  host_Space_toolchain_host_operator_johnsmith -->|provisioned as| member_cluster_NSTemplateSet_toolchain_member_operator_johnsmith\n")
+		// the missing NSTemplateSet is highlighted
+		assert.Contains(t, mermaid, `  member_cluster_NSTemplateSet_toolchain_member_operator_johnsmith["missing: member_cluster_NSTemplateSet_toolchain_member_operator_johnsmith"]:::missing`+"\n")
+		assert.NotContains(t, mermaid, `member_cluster_UserAccount_toolchain_member_operator_johnsmith["missing`)
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		// when
+		dot := g.DOT()
+
+		// then
+		assert.Contains(t, dot, "digraph resources {\n")
+		assert.Contains(t, dot, `  host_Space_toolchain_host_operator_johnsmith [label="Space\ntoolchain-host-operator/johnsmith\n(host)"];`+"\n")
+		assert.Contains(t, dot, `  host_MasterUserRecord_toolchain_host_operator_johnsmith -> host_SpaceBinding_toolchain_host_operator_johnsmith_johnsmith [label="owns"];`+"\n")
+		assert.Contains(t, dot, `  member_cluster_NSTemplateSet_toolchain_member_operator_johnsmith [label="missing: member_cluster_NSTemplateSet_toolchain_member_operator_johnsmith", color=red, style=dashed];`+"\n")
+	})
+}
@@ -0,0 +1,145 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestNameAnnotationKey the annotation set on the namespaces provisioned for a test, with the (full) name of the test as the value
+const TestNameAnnotationKey = "toolchain.dev.openshift.com/e2e-test"
+
+// usageRankingResources the quota resources by which the tests are ranked in the resource usage report, in order of precedence
+var usageRankingResources = []corev1.ResourceName{corev1.ResourceLimitsCPU, corev1.ResourceLimitsMemory, corev1.ResourcePods}
+
+// TagNamespacesForTest sets the annotation with the name of the given test on the given namespaces of the member cluster, unless they were
+// already tagged (eg, by the test which created the user whose namespaces are reused by another test), and records the consumption of
+// their resources (as reported by the status of their ResourceQuotas) at the end of the test, before its resources are cleaned up.
+func TagNamespacesForTest(t *testing.T, memberAwait *wait.MemberAwaitility, namespaces ...string) {
+	for _, name := range namespaces {
+		ns := &corev1.Namespace{}
+		require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: name}, ns))
+		if _, tagged := ns.Annotations[TestNameAnnotationKey]; tagged {
+			continue
+		}
+		patch := client.MergeFrom(ns.DeepCopy())
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[TestNameAnnotationKey] = t.Name()
+		require.NoError(t, memberAwait.Client.Patch(context.TODO(), ns, patch))
+
+		name := name
+		// registered after the cleaning tasks of the resources which led to the creation of the namespace, hence executed before them
+		t.Cleanup(func() {
+			used, err := namespaceQuotaUsage(memberAwait, name)
+			if err != nil {
+				t.Logf("unable to record the resource usage of namespace '%s': %v", name, err)
+				return
+			}
+			report.RecordResourceUsage(t, name, used)
+		})
+	}
+}
+
+// namespaceQuotaUsage returns the quantity used for each resource of the quotas of the given namespace. When several quotas
+// limit the same resource (eg, with different scopes), the highest used quantity is returned.
+// Returns an empty map if the namespace does not exist anymore.
+func namespaceQuotaUsage(memberAwait *wait.MemberAwaitility, namespace string) (map[string]string, error) {
+	quotas := &corev1.ResourceQuotaList{}
+	if err := memberAwait.Client.List(context.TODO(), quotas, client.InNamespace(namespace)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	used := map[corev1.ResourceName]resource.Quantity{}
+	for _, quota := range quotas.Items {
+		for name, quantity := range quota.Status.Used {
+			if current, found := used[name]; !found || quantity.Cmp(current) > 0 {
+				used[name] = quantity
+			}
+		}
+	}
+	result := make(map[string]string, len(used))
+	for name, quantity := range used {
+		result[string(name)] = quantity.String()
+	}
+	return result, nil
+}
+
+// testUsage the total consumption of the resources of the namespaces tagged for a test
+type testUsage struct {
+	name       string
+	namespaces int
+	used       map[corev1.ResourceName]resource.Quantity
+}
+
+// PrintResourceUsageReport prints the total consumption of the resources (as reported by the ResourceQuotas) of the namespaces tagged
+// for each test of the suite (see TagNamespacesForTest), starting with the most expensive tests, ie, the ones which used the most CPU,
+// then memory, then pods. It is meant to be called at the end of the test suite (eg. in `TestMain`), and does nothing if no usage was recorded.
+func PrintResourceUsageReport(out io.Writer, suiteName string) {
+	var usages []testUsage
+	for _, test := range report.Results(suiteName).Tests {
+		if len(test.ResourceUsage) == 0 {
+			continue
+		}
+		usage := testUsage{
+			name:       test.Name,
+			namespaces: len(test.ResourceUsage),
+			used:       map[corev1.ResourceName]resource.Quantity{},
+		}
+		for _, ns := range test.ResourceUsage {
+			for name, value := range ns.Used {
+				quantity, err := resource.ParseQuantity(value)
+				if err != nil {
+					continue
+				}
+				total := usage.used[corev1.ResourceName(name)]
+				total.Add(quantity)
+				usage.used[corev1.ResourceName(name)] = total
+			}
+		}
+		usages = append(usages, usage)
+	}
+	if len(usages) == 0 {
+		return
+	}
+	sort.SliceStable(usages, func(i, j int) bool {
+		for _, name := range usageRankingResources {
+			qi, qj := usages[i].used[name], usages[j].used[name]
+			if c := qi.Cmp(qj); c != 0 {
+				return c > 0
+			}
+		}
+		return usages[i].name < usages[j].name
+	})
+	fmt.Fprintf(out, "resource usage report: %d tests with tagged namespaces\n", len(usages))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "TEST\tNAMESPACES")
+	for _, name := range usageRankingResources {
+		fmt.Fprintf(w, "\t%s", name)
+	}
+	fmt.Fprintln(w)
+	for _, usage := range usages {
+		fmt.Fprintf(w, "%s\t%d", usage.name, usage.namespaces)
+		for _, name := range usageRankingResources {
+			quantity := usage.used[name]
+			fmt.Fprintf(w, "\t%s", quantity.String())
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
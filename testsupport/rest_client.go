@@ -0,0 +1,257 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// defaultRESTRetries the default number of retries of a request which failed with a transport error, a 429 or a 5xx response
+	defaultRESTRetries = 3
+	// defaultRESTRetryInterval the default interval before the first retry, which is doubled after each retry
+	defaultRESTRetryInterval = 500 * time.Millisecond
+	// defaultRESTRequestTimeout the default timeout of each attempt of a request
+	defaultRESTRequestTimeout = 10 * time.Second
+	// maxCapturedBodySize the max size of the request and response bodies included in the failure context
+	maxCapturedBodySize = 2048
+)
+
+// RESTClient a client for the REST endpoints of the registration service and the proxy, which:
+// - retries the throttled requests (429) and the idempotent requests which failed with a transport error or a 5xx response (with a backoff)
+// - applies a timeout on each attempt of a request
+// - keeps the requests and responses of all the attempts, so that they can be included in the failure messages
+type RESTClient struct {
+	httpClient     *http.Client
+	retries        int
+	retryInterval  time.Duration
+	requestTimeout time.Duration
+	// retryNonIdempotent whether the non-idempotent requests (eg, `POST /api/v1/signup`) are retried too
+	retryNonIdempotent bool
+}
+
+// RESTClientOption an option to configure a RESTClient
+type RESTClientOption func(*RESTClient)
+
+// WithRetries sets the number of retries of the requests which failed with a transport error, a 429 or a 5xx response,
+// and the interval before the first retry (which is doubled after each retry). Use `0` to disable the retries.
+func WithRetries(retries int, interval time.Duration) RESTClientOption {
+	return func(c *RESTClient) {
+		c.retries = retries
+		c.retryInterval = interval
+	}
+}
+
+// WithRequestTimeout sets the timeout of each attempt of a request
+func WithRequestTimeout(timeout time.Duration) RESTClientOption {
+	return func(c *RESTClient) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithNonIdempotentRetries enables the retries of the non-idempotent requests (eg, `POST`), which are not retried by default
+// since a request which failed with a 5xx response or a transport error may still have been processed by the server
+func WithNonIdempotentRetries() RESTClientOption {
+	return func(c *RESTClient) {
+		c.retryNonIdempotent = true
+	}
+}
+
+// NewRESTClient returns a new RESTClient which uses the shared HTTPClient (ie, which skips the TLS verification)
+func NewRESTClient(options ...RESTClientOption) *RESTClient {
+	c := &RESTClient{
+		httpClient:     HTTPClient,
+		retries:        defaultRESTRetries,
+		retryInterval:  defaultRESTRetryInterval,
+		requestTimeout: defaultRESTRequestTimeout,
+	}
+	for _, apply := range options {
+		apply(c)
+	}
+	return c
+}
+
+// RESTRequest a request to send with a RESTClient
+type RESTRequest struct {
+	Method string
+	URL    string
+	// Token the token to set in the `Authorization` header (if not empty)
+	Token string
+	// Body the JSON body of the request (if not empty)
+	Body        string
+	Header      http.Header
+	QueryParams map[string]string
+}
+
+// String returns a description of the request, in which the token is redacted
+func (r RESTRequest) String() string {
+	s := &strings.Builder{}
+	fmt.Fprintf(s, "%s %s", r.Method, r.URL)
+	if len(r.QueryParams) > 0 {
+		fmt.Fprintf(s, " (query params: %v)", r.QueryParams)
+	}
+	if r.Token != "" {
+		s.WriteString("\nAuthorization: Bearer <redacted>")
+	}
+	for key, values := range r.Header {
+		fmt.Fprintf(s, "\n%s: %s", key, strings.Join(values, ","))
+	}
+	if r.Body != "" {
+		fmt.Fprintf(s, "\n\n%s", truncate(r.Body))
+	}
+	return s.String()
+}
+
+// RESTResponse the response of a request sent with a RESTClient
+type RESTResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// exchanges the description of the request and the response (or error) of each attempt
+	exchanges []string
+}
+
+// String returns the description of the request and the response (or error) of each attempt, to be included in the failure messages
+func (r *RESTResponse) String() string {
+	return strings.Join(r.exchanges, "\n---\n")
+}
+
+// Do sends the given request, and retries it while it fails with a transport error, a 429 or a 5xx response (as configured in the client).
+// Since a throttled request was not processed by the server, the 429 responses are retried regardless of the method of the request,
+// whereas the other failures of the non-idempotent requests are only retried if the client was configured with WithNonIdempotentRetries.
+// Fails the test if no response was received after the last attempt. The failed attempts which are retried are recorded as transient errors.
+func (c *RESTClient) Do(t *testing.T, req RESTRequest) *RESTResponse {
+	t.Logf("invoking http request: %s %s", req.Method, req.URL)
+	result := &RESTResponse{}
+	interval := c.retryInterval
+	retryable := c.retryNonIdempotent || isIdempotent(req.Method)
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		exchange := fmt.Sprintf("attempt #%d:\n%s\n", attempt+1, req)
+		if err != nil {
+			exchange += fmt.Sprintf("=> error: %s", err)
+		} else {
+			exchange += fmt.Sprintf("=> %d %s\n%s", resp.StatusCode, http.StatusText(resp.StatusCode), truncate(string(resp.Body)))
+		}
+		result.exchanges = append(result.exchanges, exchange)
+		throttled := err == nil && resp.StatusCode == http.StatusTooManyRequests
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if (!throttled && !(failed && retryable)) || attempt >= c.retries {
+			require.NoError(t, err, "no response received:\n%s", result)
+			t.Logf("response status code: %d", resp.StatusCode)
+			result.StatusCode = resp.StatusCode
+			result.Header = resp.Header
+			result.Body = resp.Body
+			return result
+		}
+		delay := interval
+		switch {
+		case err != nil:
+			report.RecordTransientError(t, report.ErrorTransport, requestSource(req.URL))
+		case throttled:
+			report.RecordTransientError(t, report.ErrorThrottled, requestSource(req.URL))
+			if retryAfter, ok := retryAfterDelay(resp.Header); ok {
+				delay = retryAfter
+			}
+		default:
+			report.RecordTransientError(t, report.ErrorServer, requestSource(req.URL))
+		}
+		t.Logf("retrying http request %s %s in %s after failed attempt #%d", req.Method, req.URL, delay, attempt+1)
+		time.Sleep(delay)
+		interval *= 2
+	}
+}
+
+// send sends the given request once, with the timeout of the client, and returns the response with its body read
+func (c *RESTClient) send(r RESTRequest) (*RESTResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
+	defer cancel()
+	var body io.Reader
+	if r.Body != "" {
+		body = strings.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range r.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if r.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+	if req.Header.Get("content-type") == "" {
+		req.Header.Set("content-type", "application/json")
+	}
+	if len(r.QueryParams) > 0 {
+		q := req.URL.Query()
+		for key, val := range r.QueryParams {
+			q.Add(key, val)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+	req.Close = true
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &RESTResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+	}, nil
+}
+
+// isIdempotent returns `true` if the requests with the given method can be safely retried (see RFC 9110, section 9.2.2)
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfterDelay returns the delay set in the `Retry-After` header of a 429 response, if it is set in seconds
+// (the HTTP-date format is not used by the registration service nor the proxy)
+func retryAfterDelay(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// requestSource returns the source of the transient errors of the requests sent to the given URL, ie, the first label of its host
+// (eg, `registration-service-toolchain-host-operator` for the registration service)
+func requestSource(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "unknown"
+	}
+	return strings.SplitN(u.Hostname(), ".", 2)[0]
+}
+
+// truncate truncates the given body to the max size of the bodies included in the failure context
+func truncate(body string) string {
+	if len(body) <= maxCapturedBodySize {
+		return body
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxCapturedBodySize], len(body)-maxCapturedBodySize)
+}
@@ -0,0 +1,36 @@
+package testsupport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+// RecordScenario returns a copy of the given awaitilities whose clients record every mutating API call made by the test,
+// and registers a cleanup function which, if the test failed, saves the recorded calls as a YAML replay script in the directory
+// set with the `ARTIFACT_DIR` env var (or in a temporary directory if the env var is not set).
+// The script can be replayed against a dev cluster with the `replay` command of the setup tool (see `setup/README.adoc`).
+// Only the calls made with the returned awaitilities are recorded.
+func RecordScenario(t *testing.T, awaitilities wait.Awaitilities) wait.Awaitilities {
+	recorder := wait.NewScenarioRecorder()
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		dir := os.Getenv(ArtifactDirVar)
+		if dir == "" {
+			dir = os.TempDir()
+		}
+		path := filepath.Join(dir, fmt.Sprintf("replay-%s.yaml", nonAlphanumeric.ReplaceAllString(t.Name(), "_")))
+		script := recorder.Script(t.Name())
+		if err := script.Save(path); err != nil {
+			t.Logf("unable to save the replay script of the test: %s", err)
+			return
+		}
+		t.Logf("replay script with %d steps saved in '%s'", len(script.Steps), path)
+	})
+	return awaitilities.WithRecorder(recorder)
+}
@@ -21,8 +21,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var httpClient = HTTPClient
-
 // NewSignupRequest creates a new signup request for the registration service
 func NewSignupRequest(awaitilities wait.Awaitilities) *SignupRequest {
 	defaultUsername := fmt.Sprintf("testuser-%s", uuid.Must(uuid.NewV4()).String())
@@ -67,6 +65,7 @@ type SignupRequest struct {
 	cleanupDisabled      bool
 	noSpace              bool
 	activationCode       string
+	extraClaims          []commonauth.ExtraClaim
 }
 
 // IdentityID specifies the ID value for the user's Identity.  This value if set will be used to set both the
@@ -105,6 +104,13 @@ func (r *SignupRequest) AccountID(accountID string) *SignupRequest {
 	return r
 }
 
+// Claims specifies additional claims to set in the user's token (eg. to mimic the tokens issued by a given identity provider).
+// These claims are set last, so they override the default ones
+func (r *SignupRequest) Claims(claims ...commonauth.ExtraClaim) *SignupRequest {
+	r.extraClaims = append(r.extraClaims, claims...)
+	return r
+}
+
 // Resources may be called only after a call to Execute(t).  It returns two parameters; the first is the UserSignup
 // instance that was created, the second is the MasterUserRecord instance, HOWEVER the MUR will only be returned
 // here if EnsureMUR() was also called previously, otherwise a nil value will be returned
@@ -225,6 +231,7 @@ func (r *SignupRequest) Execute(t *testing.T) *SignupRequest {
 	if r.accountID != "" {
 		claims = append(claims, commonauth.WithAccountIDClaim(r.accountID))
 	}
+	claims = append(claims, r.extraClaims...)
 	r.token, err = authsupport.NewTokenFromIdentity(userIdentity, claims...)
 	require.NoError(t, err)
 
@@ -249,26 +256,27 @@ func (r *SignupRequest) Execute(t *testing.T) *SignupRequest {
 			"cannot specify a target cluster for new signup requests while automatic approval is enabled")
 	}
 
-	if r.manuallyApprove || r.targetCluster != nil || (r.verificationRequired != states.VerificationRequired(userSignup)) {
-		doUpdate := func(instance *toolchainv1alpha1.UserSignup) {
-			// We set the VerificationRequired state first, because if manuallyApprove is also set then it will
-			// reset the VerificationRequired state to false.
-			if r.verificationRequired != states.VerificationRequired(instance) {
-				states.SetVerificationRequired(userSignup, r.verificationRequired)
-			}
-
-			if r.manuallyApprove {
-				states.SetApprovedManually(instance, r.manuallyApprove)
-			}
-			if r.targetCluster != nil {
-				instance.Spec.TargetCluster = r.targetCluster.ClusterName
-			}
+	// the UserSignup is created by the registration service, so the run labels need to be set afterwards
+	doUpdate := func(instance *toolchainv1alpha1.UserSignup) {
+		wait.AddRunLabels(t, instance)
+
+		// We set the VerificationRequired state first, because if manuallyApprove is also set then it will
+		// reset the VerificationRequired state to false.
+		if r.verificationRequired != states.VerificationRequired(instance) {
+			states.SetVerificationRequired(userSignup, r.verificationRequired)
 		}
 
-		userSignup, err = hostAwait.UpdateUserSignup(t, userSignup.Name, doUpdate)
-		require.NoError(t, err)
+		if r.manuallyApprove {
+			states.SetApprovedManually(instance, r.manuallyApprove)
+		}
+		if r.targetCluster != nil {
+			instance.Spec.TargetCluster = r.targetCluster.ClusterName
+		}
 	}
 
+	userSignup, err = hostAwait.UpdateUserSignup(t, userSignup.Name, doUpdate)
+	require.NoError(t, err)
+
 	t.Logf("user signup '%s' created", userSignup.Name)
 
 	// If any required conditions have been specified, confirm the UserSignup has them
@@ -309,37 +317,19 @@ func (r *SignupRequest) Execute(t *testing.T) *SignupRequest {
 }
 
 func invokeEndpoint(t *testing.T, method, path, authToken, requestBody string, requiredStatus int, queryParams map[string]string) map[string]interface{} {
-	var reqBody io.Reader
-	if requestBody != "" {
-		reqBody = strings.NewReader(requestBody)
-	}
-	req, err := http.NewRequest(method, path, reqBody)
-	require.NoError(t, err)
-	req.Header.Set("Authorization", "Bearer "+authToken)
-	req.Header.Set("content-type", "application/json")
-
-	if len(queryParams) > 0 {
-		q := req.URL.Query()
-		for key, val := range queryParams {
-			q.Add(key, val)
-		}
-		req.URL.RawQuery = q.Encode()
-	}
-
-	req.Close = true
-	resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer Close(t, resp)`
-	require.NoError(t, err, "error posting signup request.\nmethod : %s\npath : %s\nauthToken : %s\nbody : %s", method, path, authToken, requestBody)
-	defer Close(t, resp)
-
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
-	require.NotNil(t, body)
-	require.Equal(t, requiredStatus, resp.StatusCode, "unexpected response status with body: %s", body)
+	resp := NewRESTClient().Do(t, RESTRequest{
+		Method:      method,
+		URL:         path,
+		Token:       authToken,
+		Body:        requestBody,
+		QueryParams: queryParams,
+	})
+	require.Equal(t, requiredStatus, resp.StatusCode, "unexpected response status:\n%s", resp)
 
 	mp := make(map[string]interface{})
-	if len(body) > 0 {
-		err = json.Unmarshal(body, &mp)
-		require.NoError(t, err)
+	if len(resp.Body) > 0 {
+		err := json.Unmarshal(resp.Body, &mp)
+		require.NoError(t, err, "unable to decode the response:\n%s", resp)
 	}
 	return mp
 }
@@ -0,0 +1,118 @@
+package testsupport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+)
+
+// operatorRuntimeMetrics the metrics of the Go runtime of the operators which are tracked during a soak test
+var operatorRuntimeMetrics = []string{
+	"go_goroutines",
+	"go_memstats_heap_alloc_bytes",
+}
+
+// SoakSnapshot the number of toolchain resources and the runtime metrics of the operators at a given time of a soak test,
+// by key (eg, `host/UserSignup` or `member-operator/go_goroutines`)
+type SoakSnapshot struct {
+	Time   time.Time
+	Values map[string]float64
+}
+
+// TakeSoakSnapshot counts the toolchain resources in the host and member clusters, and collects the runtime metrics of the operators
+// (when their metrics endpoint is exposed)
+func TakeSoakSnapshot(t *testing.T, awaitilities wait.Awaitilities) SoakSnapshot {
+	snapshot := SoakSnapshot{
+		Time:   time.Now(),
+		Values: map[string]float64{},
+	}
+	hostAwait := awaitilities.Host()
+	for kind, count := range hostAwait.CountToolchainObjects(t) {
+		snapshot.Values["host/"+kind] = float64(count)
+	}
+	collectRuntimeMetrics(t, hostAwait.Awaitility, "host-operator", snapshot.Values)
+	for _, memberAwait := range awaitilities.AllMembers() {
+		for kind, count := range memberAwait.CountToolchainObjects(t) {
+			snapshot.Values[memberAwait.ClusterName+"/"+kind] = float64(count)
+		}
+		collectRuntimeMetrics(t, memberAwait.Awaitility, memberAwait.ClusterName+"-operator", snapshot.Values)
+	}
+	return snapshot
+}
+
+func collectRuntimeMetrics(t *testing.T, a *wait.Awaitility, prefix string, values map[string]float64) {
+	if a.MetricsURL == "" {
+		return
+	}
+	for _, family := range operatorRuntimeMetrics {
+		values[prefix+"/"+family] = a.GetMetricValue(t, family)
+	}
+}
+
+// UnboundedGrowths returns the keys of the values which keep growing during the whole soak test. The snapshots are split
+// in 3 consecutive periods, and a value is considered as growing if its minimum in each period exceeds its maximum in the
+// previous period by more than the given tolerance ratio (so that the fluctuations, such as the garbage collections, are ignored).
+// At least 3 snapshots are needed to detect a growth.
+func UnboundedGrowths(snapshots []SoakSnapshot, tolerance float64) []string {
+	if len(snapshots) < 3 {
+		return nil
+	}
+	var growths []string
+	for key := range snapshots[0].Values {
+		growing := true
+		previousMax := 0.0
+		for period := 0; period < 3 && growing; period++ {
+			min, max := valueRange(snapshots[period*len(snapshots)/3:(period+1)*len(snapshots)/3], key)
+			if period > 0 && min <= previousMax*(1+tolerance) {
+				growing = false
+			}
+			previousMax = max
+		}
+		if growing {
+			growths = append(growths, key)
+		}
+	}
+	sort.Strings(growths)
+	return growths
+}
+
+// valueRange returns the minimum and the maximum of the value with the given key in the given snapshots
+func valueRange(snapshots []SoakSnapshot, key string) (float64, float64) {
+	min, max := snapshots[0].Values[key], snapshots[0].Values[key]
+	for _, s := range snapshots[1:] {
+		if s.Values[key] < min {
+			min = s.Values[key]
+		}
+		if s.Values[key] > max {
+			max = s.Values[key]
+		}
+	}
+	return min, max
+}
+
+// AssertNoUnboundedGrowth logs the evolution of the values of the given snapshots, and verifies that none of them keeps growing
+// during the soak test (see UnboundedGrowths)
+func AssertNoUnboundedGrowth(t *testing.T, snapshots []SoakSnapshot, tolerance float64) {
+	if len(snapshots) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(snapshots[0].Values))
+	for key := range snapshots[0].Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values := make([]string, len(snapshots))
+		for i, s := range snapshots {
+			values[i] = fmt.Sprintf("%.0f", s.Values[key])
+		}
+		t.Logf("%s: %s", key, strings.Join(values, " "))
+	}
+	assert.Empty(t, UnboundedGrowths(snapshots, tolerance), "unbounded growth detected over %d snapshots between %s and %s",
+		len(snapshots), snapshots[0].Time.Format(time.RFC3339), snapshots[len(snapshots)-1].Time.Format(time.RFC3339))
+}
@@ -0,0 +1,139 @@
+// Package socialevent provides the helpers to create the SocialEvents (ie, the events whose name is the activation code
+// that the attendees use to sign up), to wait for their activations, and to verify the rejected activations
+// (eg, with an unknown code, or for an event which is full, not open yet or already closed).
+package socialevent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commonsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/socialevent"
+	testsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/test/socialevent"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ActivationCodePath the path of the registration service endpoint to verify an activation code
+	ActivationCodePath = "/api/v1/signup/verification/activation-code"
+	// UnknownCode an activation code which does not match any SocialEvent
+	UnknownCode = "unknown"
+)
+
+// Option an option to configure the SocialEvent to create
+type Option = testsocialevent.Option
+
+var (
+	// WithUserTier sets the tier of the users who sign up with the activation code of the event
+	WithUserTier = testsocialevent.WithUserTier
+	// WithSpaceTier sets the tier of the spaces of the users who sign up with the activation code of the event
+	WithSpaceTier = testsocialevent.WithSpaceTier
+	// WithStartTime sets the time from which the users can sign up with the activation code of the event
+	WithStartTime = testsocialevent.WithStartTime
+	// WithEndTime sets the time after which the users can no longer sign up with the activation code of the event
+	WithEndTime = testsocialevent.WithEndTime
+	// WithMaxAttendees sets the capacity of the event
+	WithMaxAttendees = testsocialevent.WithMaxAttendees
+)
+
+// NotOpenedYet configures the event to start in one hour
+func NotOpenedYet() Option {
+	return WithStartTime(time.Now().Add(time.Hour))
+}
+
+// AlreadyClosed configures the event to have ended one hour ago (ie, its activation code is expired)
+func AlreadyClosed() Option {
+	return WithEndTime(time.Now().Add(-time.Hour))
+}
+
+// New returns a new SocialEvent in the host operator namespace, with a generated name (ie, activation code) and the given options.
+// By default, the event is open (from one hour ago to one hour from now) to 10 attendees, in the `deactivate30` user tier
+// and the `base1ns` space tier.
+func New(hostAwait *wait.HostAwaitility, opts ...Option) *toolchainv1alpha1.SocialEvent {
+	return testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(), opts...)
+}
+
+// Create creates a new SocialEvent with the given options (see New), schedules its cleanup at the end of the test,
+// and waits until it is ready
+func Create(t *testing.T, hostAwait *wait.HostAwaitility, opts ...Option) *toolchainv1alpha1.SocialEvent {
+	event := New(hostAwait, opts...)
+	err := hostAwait.CreateWithCleanup(t, event)
+	require.NoError(t, err)
+	event, err = hostAwait.WaitForSocialEvent(t, event.Name, wait.UntilSocialEventHasConditions(Ready()))
+	require.NoError(t, err)
+	return event
+}
+
+// CreateFull creates a new, ready SocialEvent with the given options (see Create), whose activation count
+// has already reached the max number of attendees
+func CreateFull(t *testing.T, hostAwait *wait.HostAwaitility, opts ...Option) *toolchainv1alpha1.SocialEvent {
+	event := Create(t, hostAwait, opts...)
+	event.Status.ActivationCount = event.Spec.MaxAttendees
+	err := hostAwait.Client.Status().Update(context.TODO(), event)
+	require.NoError(t, err)
+	return event
+}
+
+// Ready the condition of a SocialEvent whose tiers are valid
+func Ready() toolchainv1alpha1.Condition {
+	return toolchainv1alpha1.Condition{
+		Type:   toolchainv1alpha1.ConditionReady,
+		Status: corev1.ConditionTrue,
+	}
+}
+
+// InvalidUserTier the condition of a SocialEvent whose user tier does not exist
+func InvalidUserTier(tier string) toolchainv1alpha1.Condition {
+	return toolchainv1alpha1.Condition{
+		Type:    toolchainv1alpha1.ConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  toolchainv1alpha1.SocialEventInvalidUserTierReason,
+		Message: fmt.Sprintf("UserTier '%s' not found", tier),
+	}
+}
+
+// InvalidSpaceTier the condition of a SocialEvent whose space tier does not exist
+func InvalidSpaceTier(tier string) toolchainv1alpha1.Condition {
+	return toolchainv1alpha1.Condition{
+		Type:    toolchainv1alpha1.ConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  toolchainv1alpha1.SocialEventInvalidSpaceTierReason,
+		Message: fmt.Sprintf("NSTemplateTier '%s' not found", tier),
+	}
+}
+
+// WaitForActivationCount waits until the SocialEvent with the given name has the expected activation count
+func WaitForActivationCount(t *testing.T, hostAwait *wait.HostAwaitility, name string, expected int) *toolchainv1alpha1.SocialEvent {
+	event, err := hostAwait.WaitForSocialEvent(t, name, wait.UntilSocialEventHasActivationCount(expected))
+	require.NoError(t, err)
+	return event
+}
+
+// Activate calls the registration service endpoint to verify the given activation code, on behalf of the user with the given token
+func Activate(t *testing.T, hostAwait *wait.HostAwaitility, token, code string) *testsupport.RegistrationServiceResponse {
+	return testsupport.NewRegistrationServiceClient(hostAwait).Invoke(t, http.MethodPost, ActivationCodePath, token, fmt.Sprintf(`{"code":"%s"}`, code))
+}
+
+// RequireActivationRejected verifies that the given activation code is rejected for the user with the given UserSignup and token
+// (eg, because the code is unknown, or because the event is full, not open yet or already closed), and that the UserSignup
+// still requires a verification, with one more verification attempt. Returns the UserSignup.
+func RequireActivationRejected(t *testing.T, hostAwait *wait.HostAwaitility, userSignup *toolchainv1alpha1.UserSignup, token, code string) *toolchainv1alpha1.UserSignup {
+	attempts, _ := strconv.Atoi(userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey])
+
+	resp := Activate(t, hostAwait, token, code)
+
+	require.Equal(t, http.StatusForbidden, resp.StatusCode, "unexpected response status:\n%s", resp)
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name,
+		wait.UntilUserSignupHasConditions(testsupport.ConditionSet(testsupport.Default(), testsupport.VerificationRequired())...))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(attempts+1), userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey])
+	return userSignup
+}
@@ -0,0 +1,51 @@
+package testsupport
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SoftAssertionsVar the env var to set to enable the soft-assertion mode of the broad verification helpers (eg,
+// VerifyResourcesProvisionedForSignup), in which all the discrepancies are reported in a single run instead of stopping at the first one
+const SoftAssertionsVar = "E2E_SOFT_ASSERTIONS"
+
+// SoftAssertions runs the independent steps of a verification. By default, a step which fails stops the test as usual.
+// In soft-assertion mode, each step runs in its own subtest, so that a failed step (including a failed `require`) only stops
+// this step, and the failed steps are all reported at the end by `Report`.
+type SoftAssertions struct {
+	t       *testing.T
+	enabled bool
+	failed  []string
+}
+
+// NewSoftAssertions returns a new SoftAssertions for the given test, in soft-assertion mode if the `E2E_SOFT_ASSERTIONS` env var is set
+func NewSoftAssertions(t *testing.T) *SoftAssertions {
+	_, enabled := os.LookupEnv(SoftAssertionsVar)
+	return &SoftAssertions{
+		t:       t,
+		enabled: enabled,
+	}
+}
+
+// Check runs the given verification step. In soft-assertion mode, the step runs in a subtest with the given name,
+// and its failure is recorded instead of stopping the test.
+func (s *SoftAssertions) Check(name string, step func(t *testing.T)) {
+	if !s.enabled {
+		step(s.t)
+		return
+	}
+	if !s.t.Run(name, step) {
+		s.failed = append(s.failed, name)
+	}
+}
+
+// Report stops the test if any step failed in soft-assertion mode, with the list of the failed steps
+// (the details of each failure are reported in the subtest of the step)
+func (s *SoftAssertions) Report() {
+	if len(s.failed) > 0 {
+		require.FailNowf(s.t, "verification failed", "%d failed step(s):\n  %s", len(s.failed), strings.Join(s.failed, "\n  "))
+	}
+}
@@ -0,0 +1,118 @@
+package testsupport_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// softAssertionsScenarioVar the env var which selects the scenario run by `TestSoftAssertionsScenario`. Since a failed step
+// fails the test, the scenarios run in a separate process, whose output is then verified by `TestSoftAssertions`
+const softAssertionsScenarioVar = "SOFT_ASSERTIONS_SCENARIO"
+
+func TestSoftAssertionsScenario(t *testing.T) {
+	scenario, found := os.LookupEnv(softAssertionsScenarioVar)
+	if !found {
+		t.Skip("only run by TestSoftAssertions")
+	}
+	s := testsupport.NewSoftAssertions(t)
+	s.Check("first", func(t *testing.T) {
+		t.Log("first step ran")
+		if scenario == "failing" {
+			require.Fail(t, "first step failed")
+		}
+	})
+	s.Check("second", func(t *testing.T) {
+		t.Log("second step ran")
+	})
+	s.Check("third", func(t *testing.T) {
+		if scenario == "failing" {
+			assert.Fail(t, "third step failed")
+		}
+		t.Log("third step ran")
+	})
+	s.Report()
+	t.Log("report passed")
+}
+
+func TestSoftAssertions(t *testing.T) {
+	// runScenario runs the given scenario in a separate process, in soft-assertion mode if enabled, and returns its output
+	runScenario := func(t *testing.T, scenario string, enabled bool) (string, error) {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestSoftAssertionsScenario$", "-test.v")
+		for _, e := range os.Environ() {
+			if !strings.HasPrefix(e, testsupport.SoftAssertionsVar+"=") {
+				cmd.Env = append(cmd.Env, e)
+			}
+		}
+		cmd.Env = append(cmd.Env, softAssertionsScenarioVar+"="+scenario)
+		if enabled {
+			cmd.Env = append(cmd.Env, testsupport.SoftAssertionsVar+"=true")
+		}
+		out, err := cmd.CombinedOutput()
+		t.Logf("output of scenario '%s':\n%s", scenario, out)
+		return string(out), err
+	}
+
+	t.Run("soft-assertion mode", func(t *testing.T) {
+
+		t.Run("failures are collected", func(t *testing.T) {
+			// when
+			out, err := runScenario(t, "failing", true)
+
+			// then
+			require.Error(t, err)
+			// the steps after the failed ones still ran
+			assert.Contains(t, out, "first step ran")
+			assert.Contains(t, out, "second step ran")
+			assert.Contains(t, out, "third step ran")
+			assert.Contains(t, out, "--- FAIL: TestSoftAssertionsScenario/first")
+			assert.Contains(t, out, "--- PASS: TestSoftAssertionsScenario/second")
+			assert.Contains(t, out, "--- FAIL: TestSoftAssertionsScenario/third")
+			// and the failed steps are all reported at the end
+			assert.Contains(t, out, "2 failed step(s):")
+			assert.Regexp(t, `\s+first\n\s+third\n`, out)
+			assert.NotContains(t, out, "report passed")
+		})
+
+		t.Run("no failure", func(t *testing.T) {
+			// when
+			out, err := runScenario(t, "passing", true)
+
+			// then
+			require.NoError(t, err)
+			assert.Contains(t, out, "--- PASS: TestSoftAssertionsScenario/first")
+			assert.Contains(t, out, "report passed")
+		})
+	})
+
+	t.Run("default mode", func(t *testing.T) {
+
+		t.Run("first failure stops the test", func(t *testing.T) {
+			// when
+			out, err := runScenario(t, "failing", false)
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, out, "first step ran")
+			assert.NotContains(t, out, "second step ran")
+			assert.NotContains(t, out, "failed step(s)")
+			// no subtest is run
+			assert.NotContains(t, out, "TestSoftAssertionsScenario/first")
+		})
+
+		t.Run("no failure", func(t *testing.T) {
+			// when
+			out, err := runScenario(t, "passing", false)
+
+			// then
+			require.NoError(t, err)
+			assert.Contains(t, out, "third step ran")
+			assert.Contains(t, out, "report passed")
+		})
+	})
+}
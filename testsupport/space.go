@@ -227,6 +227,13 @@ func verifyResourcesProvisionedForSpace(t *testing.T, hostAwait *wait.HostAwaiti
 		wait.UntilSpaceHasProvisionedNamespaces(nsTmplSet.Status.ProvisionedNamespaces))
 	require.NoError(t, err)
 
+	// tag the namespaces with the name of the test, so that their resource usage can be attributed to it
+	namespaces := make([]string, 0, len(nsTmplSet.Status.ProvisionedNamespaces))
+	for _, ns := range nsTmplSet.Status.ProvisionedNamespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	TagNamespacesForTest(t, targetCluster, namespaces...)
+
 	return space, nsTmplSet
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // VerifySpaceBinding waits until a spacebinding with the given mur and space name exists and then verifies the contents are correct
@@ -22,6 +23,21 @@ func VerifySpaceBinding(t *testing.T, hostAwait *wait.HostAwaitility, murName, s
 	return spaceBinding
 }
 
+// VerifyNoOrphanSpaceBindings verifies that all the SpaceBindings refer to an existing MasterUserRecord and Space,
+// ie, that the SpaceBindings were deleted along with their MasterUserRecord or Space.
+// It is meant to be called at the end of the space-related test suites.
+func VerifyNoOrphanSpaceBindings(t *testing.T, hostAwait *wait.HostAwaitility) {
+	err := hostAwait.WaitUntilNoOrphanSpaceBindings(t)
+	require.NoError(t, err)
+}
+
+// VerifyNoOrphanSpaceBindingsWithLabel is the same as VerifyNoOrphanSpaceBindings, for the SpaceBindings with the given label only
+// (eg, the SpaceBindings of the MasterUserRecords or Spaces of a test which runs in parallel with other tests)
+func VerifyNoOrphanSpaceBindingsWithLabel(t *testing.T, hostAwait *wait.HostAwaitility, key, value string) {
+	err := hostAwait.WaitUntilNoOrphanSpaceBindings(t, client.MatchingLabels{key: value})
+	require.NoError(t, err)
+}
+
 // CreateSpaceBinding creates SpaceBinding resource for the given MUR & Space with the given space role
 func CreateSpaceBinding(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolchainv1alpha1.MasterUserRecord, space *toolchainv1alpha1.Space, spaceRole string) *toolchainv1alpha1.SpaceBinding {
 	spaceBinding := NewSpaceBinding(mur, space, spaceRole)
@@ -33,6 +49,7 @@ func CreateSpaceBinding(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolc
 // CreateSpaceBindingWithoutCleanup creates SpaceBinding resource for the given MUR & Space with the given space role; and doesn't mark the resource to be ready for cleanup
 func CreateSpaceBindingWithoutCleanup(t *testing.T, hostAwait *wait.HostAwaitility, mur *toolchainv1alpha1.MasterUserRecord, space *toolchainv1alpha1.Space, spaceRole string) *toolchainv1alpha1.SpaceBinding {
 	spaceBinding := NewSpaceBinding(mur, space, spaceRole)
+	wait.AddRunLabels(t, spaceBinding)
 	err := hostAwait.Client.Create(context.TODO(), spaceBinding)
 	require.NoError(t, err)
 
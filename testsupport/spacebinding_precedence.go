@@ -0,0 +1,113 @@
+package testsupport
+
+import (
+	"sort"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EffectiveSpaceRoles the expected effective space role (eg, `admin` or `maintainer`) of each user in a Space, by MasterUserRecord name
+type EffectiveSpaceRoles map[string]string
+
+// SpaceRoles returns the space roles of an NSTemplateSet of the given tier which correspond to the effective roles
+func (r EffectiveSpaceRoles) SpaceRoles(t *testing.T, tier *toolchainv1alpha1.NSTemplateTier) []toolchainv1alpha1.NSTemplateSetSpaceRole {
+	usernamesByRole := map[string][]string{}
+	for murName, role := range r {
+		usernamesByRole[role] = append(usernamesByRole[role], murName)
+	}
+	roles := make([]string, 0, len(usernamesByRole))
+	for role := range usernamesByRole {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	spaceRoles := make([]toolchainv1alpha1.NSTemplateSetSpaceRole, 0, len(roles))
+	for _, role := range roles {
+		tmpl, found := tier.Spec.SpaceRoles[role]
+		require.True(t, found, "no space role '%s' in tier '%s'", role, tier.Name)
+		spaceRoles = append(spaceRoles, wait.SpaceRole(tmpl.TemplateRef, usernamesByRole[role]...))
+	}
+	return spaceRoles
+}
+
+// VerifyEffectiveSpaceRoles verifies that the users have the given effective roles in the Space with the given name,
+// ie, that the NSTemplateSet of the Space has the corresponding space roles, and that these roles were applied in each namespace
+// of the Space
+func VerifyEffectiveSpaceRoles(t *testing.T, awaitilities wait.Awaitilities, spaceName string, expected EffectiveSpaceRoles) {
+	hostAwait := awaitilities.Host()
+	space, err := hostAwait.WaitForSpace(t, spaceName, wait.UntilSpaceHasAnyTargetClusterSet(), wait.UntilSpaceHasAnyTierNameSet())
+	require.NoError(t, err)
+	tier, err := hostAwait.WaitForNSTemplateTier(t, space.Spec.TierName)
+	require.NoError(t, err)
+	memberAwait := getSpaceTargetMember(t, awaitilities, space)
+	spaceRoles := expected.SpaceRoles(t, tier)
+
+	nsTemplateSet, err := memberAwait.WaitForNSTmplSet(t, space.Name,
+		wait.UntilNSTemplateSetHasConditions(Provisioned()),
+		wait.UntilNSTemplateSetHasSpaceRolesInAnyOrder(spaceRoles...))
+	require.NoError(t, err)
+	for _, ns := range nsTemplateSet.Status.ProvisionedNamespaces {
+		_, err := memberAwait.WaitForNamespaceWithName(t, ns.Name, wait.UntilObjectHasLastAppliedSpaceRolesInAnyOrder(spaceRoles...))
+		require.NoError(t, err, "unexpected space roles in namespace '%s' of Space '%s'", ns.Name, space.Name)
+	}
+}
+
+// SpaceBindingPrecedence a sub-space created with a SpaceRequest, in which the same user has a role inherited from the SpaceBinding
+// of the parent Space and another role from a SpaceBinding of the sub-space itself
+type SpaceBindingPrecedence struct {
+	ParentSpace     *toolchainv1alpha1.Space
+	SubSpace        *toolchainv1alpha1.Space
+	MUR             *toolchainv1alpha1.MasterUserRecord
+	ParentBinding   *toolchainv1alpha1.SpaceBinding
+	SubSpaceBinding *toolchainv1alpha1.SpaceBinding
+}
+
+// CreateOverlappingSpaceBindings creates a parent Space in the given member cluster, in which the user has the given parent role,
+// then a sub-space via a SpaceRequest in the default namespace of the parent Space, and finally a SpaceBinding of the same user
+// with the given sub-space role in the sub-space
+func CreateOverlappingSpaceBindings(t *testing.T, awaitilities wait.Awaitilities, memberName, parentRole, subSpaceRole string) *SpaceBindingPrecedence {
+	hostAwait := awaitilities.Host()
+	memberAwait, err := awaitilities.Member(memberName)
+	require.NoError(t, err)
+	spaceRequest, parentSpace := CreateSpaceRequest(t, awaitilities, memberName, WithSpecTierName("appstudio"))
+
+	bindings, err := hostAwait.ListSpaceBindings(parentSpace.Name)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1, "unexpected SpaceBindings for parent Space '%s'", parentSpace.Name)
+	parentBinding, err := wait.Update(t, hostAwait.Awaitility, bindings[0].Name, func(b *toolchainv1alpha1.SpaceBinding) {
+		b.Spec.SpaceRole = parentRole
+	})
+	require.NoError(t, err)
+	mur, err := hostAwait.WaitForMasterUserRecord(t, parentBinding.Spec.MasterUserRecord)
+	require.NoError(t, err)
+
+	_, err = memberAwait.WaitForSpaceRequest(t, types.NamespacedName{Namespace: spaceRequest.Namespace, Name: spaceRequest.Name},
+		wait.UntilSpaceRequestHasConditions(Provisioned()))
+	require.NoError(t, err)
+	subSpace, err := hostAwait.WaitForSubSpace(t, spaceRequest.Name, spaceRequest.Namespace, parentSpace.Name,
+		wait.UntilSpaceHasConditions(Provisioned()))
+	require.NoError(t, err)
+
+	return &SpaceBindingPrecedence{
+		ParentSpace:     parentSpace,
+		SubSpace:        subSpace,
+		MUR:             mur,
+		ParentBinding:   parentBinding,
+		SubSpaceBinding: CreateSpaceBinding(t, hostAwait, mur, subSpace, subSpaceRole),
+	}
+}
+
+// VerifySpaceBindingPrecedence verifies the documented precedence of the SpaceBindings: in the sub-space, the role of the user
+// is the one of the SpaceBinding of the sub-space (which overrides the one inherited from the parent Space), while in the parent
+// Space the role of the user is the one of its own SpaceBinding
+func VerifySpaceBindingPrecedence(t *testing.T, awaitilities wait.Awaitilities, p *SpaceBindingPrecedence) {
+	VerifyEffectiveSpaceRoles(t, awaitilities, p.SubSpace.Name, EffectiveSpaceRoles{
+		p.MUR.Name: p.SubSpaceBinding.Spec.SpaceRole,
+	})
+	VerifyEffectiveSpaceRoles(t, awaitilities, p.ParentSpace.Name, EffectiveSpaceRoles{
+		p.MUR.Name: p.ParentBinding.Spec.SpaceRole,
+	})
+}
@@ -0,0 +1,28 @@
+package testsupport
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AssertConditionStable verifies that the condition of the given object keeps the status (and reason, if set) of the
+// expected condition during the whole window, and fails the test with the observed transitions otherwise.
+// It is meant to be called after a plain waiter returned, to catch the reconciliation loops which make a condition
+// oscillate (eg, Ready true/false churn) while still reaching the expected state from time to time.
+func AssertConditionStable(t *testing.T, await *wait.Awaitility, obj client.Object, expected toolchainv1alpha1.Condition, window time.Duration) {
+	err := await.WaitForConditionStable(t, obj, expected, window)
+	require.NoError(t, err)
+}
+
+// AssertNoReconcileChurn verifies that the given object is not updated more than maxUpdates times during the whole
+// window, and fails the test otherwise. It is meant to be called once the object is expected to be settled
+// (eg, after it was provisioned), to catch the regressions which make a controller reconcile in a hot-loop.
+func AssertNoReconcileChurn(t *testing.T, await *wait.Awaitility, obj client.Object, window time.Duration, maxUpdates int) {
+	err := await.WaitForNoReconcileChurn(t, obj, window, maxUpdates)
+	require.NoError(t, err)
+}
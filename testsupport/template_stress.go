@@ -0,0 +1,78 @@
+package testsupport
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemplateApplyMeasurement the cost of the apply of the templates of an NSTemplateSet by the member operator
+type TemplateApplyMeasurement struct {
+	// ApplyDuration the duration between the creation of the NSTemplateSet and its `Ready` condition
+	// (based on the timestamps set by the API server, so with a precision of one second)
+	ApplyDuration time.Duration
+	// MemoryUsageKB the memory usage of the member operator (leader) pod once the templates were applied
+	MemoryUsageKB int64
+}
+
+// TemplateApplyThresholds the maximum acceptable cost of the apply of the templates of an NSTemplateSet (a zero value disables the threshold)
+type TemplateApplyThresholds struct {
+	MaxApplyDuration time.Duration
+	MaxMemoryUsageKB int64
+}
+
+// MeasureTemplateApply creates a Space of the given tier in the given member cluster, waits until its NSTemplateSet is provisioned
+// (with the given timeout, since large templates may take longer to apply than the default timeout of the awaitility),
+// verifies that the given number of objects added with `tiers.WithLargeNamespaceTemplates` exist in each namespace,
+// and returns the cost of the apply
+func MeasureTemplateApply(t *testing.T, awaitilities wait.Awaitilities, tier *tiers.CustomNSTemplateTier, memberAwait *wait.MemberAwaitility, objectsPerNamespace int, timeout time.Duration) TemplateApplyMeasurement {
+	space, _, _ := CreateSpace(t, awaitilities, WithTierName(tier.Name), WithTargetCluster(memberAwait.ClusterName))
+	nsTmplSet, err := memberAwait.WithRetryOptions(wait.TimeoutOption(timeout)).WaitForNSTmplSet(t, space.Name,
+		wait.UntilNSTemplateSetHasConditions(Provisioned()))
+	require.NoError(t, err)
+
+	ready, found := condition.FindConditionByType(nsTmplSet.Status.Conditions, toolchainv1alpha1.ConditionReady)
+	require.True(t, found)
+	measurement := TemplateApplyMeasurement{
+		ApplyDuration: ready.LastTransitionTime.Sub(nsTmplSet.CreationTimestamp.Time),
+	}
+	leader, err := memberAwait.GetLeaderPod()
+	require.NoError(t, err)
+	measurement.MemoryUsageKB, err = memberAwait.GetMemoryUsage(leader.Name, memberAwait.Namespace)
+	require.NoError(t, err)
+	t.Logf("templates of NSTemplateSet '%s' with %d extra objects per namespace applied in %s (member operator memory usage: %dKB)",
+		nsTmplSet.Name, objectsPerNamespace, measurement.ApplyDuration, measurement.MemoryUsageKB)
+
+	for _, ns := range nsTmplSet.Status.ProvisionedNamespaces {
+		configMaps := &corev1.ConfigMapList{}
+		require.NoError(t, memberAwait.Client.List(context.TODO(), configMaps, client.InNamespace(ns.Name)))
+		count := 0
+		for _, cm := range configMaps.Items {
+			if strings.HasPrefix(cm.Name, tiers.LargeTemplateObjectNamePrefix) {
+				count++
+			}
+		}
+		assert.Equal(t, objectsPerNamespace, count, "unexpected number of templated objects in namespace '%s'", ns.Name)
+	}
+	return measurement
+}
+
+// AssertTemplateApplyWithinThresholds verifies that the given cost of the apply of the templates does not exceed the given thresholds
+func AssertTemplateApplyWithinThresholds(t *testing.T, measurement TemplateApplyMeasurement, thresholds TemplateApplyThresholds) {
+	if thresholds.MaxApplyDuration > 0 {
+		assert.LessOrEqual(t, measurement.ApplyDuration, thresholds.MaxApplyDuration, "the apply of the templates is too slow")
+	}
+	if thresholds.MaxMemoryUsageKB > 0 {
+		assert.LessOrEqual(t, measurement.MemoryUsageKB, thresholds.MaxMemoryUsageKB, "the member operator uses too much memory")
+	}
+}
@@ -0,0 +1,76 @@
+package testsupport
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// PromotionOption an option to configure the promotion of a user with PromoteUser
+type PromotionOption func(*promotion)
+
+type promotion struct {
+	userTier string
+	metrics  *MetricsAssertionHelper
+}
+
+// WithUserTier also moves the MasterUserRecord of the user to the given UserTier
+func WithUserTier(tierName string) PromotionOption {
+	return func(p *promotion) {
+		p.userTier = tierName
+	}
+}
+
+// WithTierMetrics verifies that the `sandbox_spaces_current` metric of the previous tier of the Space was decremented
+// and the one of the target tier was incremented, using the given helper (which must be initialized by the test which created the user)
+func WithTierMetrics(m *MetricsAssertionHelper) PromotionOption {
+	return func(p *promotion) {
+		p.metrics = m
+	}
+}
+
+// PromoteUser moves the Space of the user with the given name to the given tier, then waits until the NSTemplateSet
+// is updated and verifies all the resources provisioned for the Space with the checks of the target tier (including
+// the ClusterResourceQuotas and ResourceQuotas, which are labeled with the target tier and have its limits)
+func PromoteUser(t *testing.T, awaitilities wait.Awaitilities, username, targetTier string, opts ...PromotionOption) (*toolchainv1alpha1.Space, *toolchainv1alpha1.NSTemplateSet) {
+	p := &promotion{}
+	for _, apply := range opts {
+		apply(p)
+	}
+	hostAwait := awaitilities.Host()
+	space, err := hostAwait.WaitForSpace(t, username,
+		wait.UntilSpaceHasAnyTargetClusterSet(),
+		wait.UntilSpaceHasAnyTierNameSet())
+	require.NoError(t, err)
+	previousTier := space.Spec.TierName
+	_, err = hostAwait.WaitForNSTemplateTier(t, targetTier)
+	require.NoError(t, err)
+
+	if p.metrics != nil && previousTier != targetTier {
+		p.metrics.CaptureSpacesMetricBaseline(t, "tier", previousTier)
+		p.metrics.CaptureSpacesMetricBaseline(t, "tier", targetTier)
+	}
+
+	// when
+	tiers.MoveSpaceToTier(t, hostAwait, space.Name, targetTier)
+	if p.userTier != "" {
+		tiers.MoveMURToTier(t, hostAwait, username, p.userTier)
+	}
+
+	// then
+	space, nsTmplSet := VerifyResourcesProvisionedForSpace(t, awaitilities, space.Name, wait.UntilSpaceHasTier(targetTier))
+	if p.userTier != "" {
+		_, err = hostAwait.WaitForMasterUserRecord(t, username,
+			wait.UntilMasterUserRecordHasTierName(p.userTier),
+			wait.UntilMasterUserRecordHasCondition(Provisioned()))
+		require.NoError(t, err)
+	}
+	if p.metrics != nil && previousTier != targetTier {
+		p.metrics.WaitForSpacesMetricDelta(t, -1, "tier", previousTier)
+		p.metrics.WaitForSpacesMetricDelta(t, 1, "tier", targetTier)
+	}
+	return space, nsTmplSet
+}
@@ -0,0 +1,117 @@
+package tiers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// toolchainProvider the value of the provider label set on all the resources provisioned by the toolchain
+const toolchainProvider = "codeready-toolchain"
+
+// namespaceRequiredLabels the labels which must be set on all the namespaces provisioned by the toolchain
+var namespaceRequiredLabels = []string{
+	toolchainv1alpha1.ProviderLabelKey,
+	toolchainv1alpha1.OwnerLabelKey,
+	toolchainv1alpha1.TierLabelKey,
+	toolchainv1alpha1.TypeLabelKey,
+	toolchainv1alpha1.TemplateRefLabelKey,
+}
+
+// VerifyNamespacesConventions verifies that each namespace provisioned for the NSTemplateSet with the given name follows
+// the labeling and ownership conventions (see VerifyNamespaceConventions), with the name of the Space as the owner
+// and the tier of the NSTemplateSet
+func VerifyNamespacesConventions(t *testing.T, memberAwait *wait.MemberAwaitility, nsTmplSetName string) {
+	nsTmplSet, err := memberAwait.WaitForNSTmplSet(t, nsTmplSetName)
+	require.NoError(t, err)
+	for _, ns := range nsTmplSet.Status.ProvisionedNamespaces {
+		namespace := &corev1.Namespace{}
+		require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: ns.Name}, namespace))
+		assert.Equal(t, nsTmplSet.Name, namespace.Labels[toolchainv1alpha1.OwnerLabelKey], "unexpected owner of namespace '%s'", ns.Name)
+		assert.Equal(t, nsTmplSet.Spec.TierName, namespace.Labels[toolchainv1alpha1.TierLabelKey], "unexpected tier of namespace '%s'", ns.Name)
+		VerifyNamespaceConventions(t, memberAwait, ns.Name)
+	}
+}
+
+// VerifyNamespaceConventions verifies that the namespace with the given name and the objects provisioned by the toolchain in it
+// (ie, the objects with the provider label) follow the conventions, whatever the tier of the namespace:
+// - the namespace has the provider, owner (ie, the name of the Space), tier, type and templateref labels
+// - the objects have the provider label and the same owner label as the namespace
+// - the owner references of the objects refer to existing objects (with the same UID)
+// All the violations are reported at once.
+func VerifyNamespaceConventions(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) {
+	t.Logf("verifying the labeling and ownership conventions in namespace '%s'", namespace)
+	ns := &corev1.Namespace{}
+	require.NoError(t, memberAwait.Client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns))
+	var violations []string
+	for _, key := range namespaceRequiredLabels {
+		if ns.Labels[key] == "" {
+			violations = append(violations, fmt.Sprintf("Namespace/%s: missing label '%s'", namespace, key))
+		}
+	}
+	owner := ns.Labels[toolchainv1alpha1.OwnerLabelKey]
+
+	for _, gvk := range listableNamespacedResources(t, memberAwait) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := memberAwait.Client.List(context.TODO(), list, client.InNamespace(namespace), providerMatchingLabels); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
+				continue
+			}
+			require.NoError(t, err)
+		}
+		for i := range list.Items {
+			violations = append(violations, objectConventionViolations(memberAwait, &list.Items[i], owner)...)
+		}
+	}
+	assert.Empty(t, violations, "conventions violated in namespace '%s':\n%s", namespace, strings.Join(violations, "\n"))
+}
+
+// objectConventionViolations returns the violations of the labeling and ownership conventions of the given object
+func objectConventionViolations(memberAwait *wait.MemberAwaitility, obj *unstructured.Unstructured, owner string) []string {
+	key := objectKey(obj.GetKind(), obj.GetName())
+	var violations []string
+	if provider := obj.GetLabels()[toolchainv1alpha1.ProviderLabelKey]; provider != toolchainProvider {
+		violations = append(violations, fmt.Sprintf("%s: unexpected label '%s=%s'", key, toolchainv1alpha1.ProviderLabelKey, provider))
+	}
+	if actual := obj.GetLabels()[toolchainv1alpha1.OwnerLabelKey]; actual != owner {
+		violations = append(violations, fmt.Sprintf("%s: expected label '%s=%s' but was '%s'", key, toolchainv1alpha1.OwnerLabelKey, owner, actual))
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if violation := ownerReferenceViolation(memberAwait, obj.GetNamespace(), ref); violation != "" {
+			violations = append(violations, fmt.Sprintf("%s: %s", key, violation))
+		}
+	}
+	return violations
+}
+
+// ownerReferenceViolation returns a description of the problem if the given owner reference does not refer to an existing object
+// in the given namespace (or at the cluster scope)
+func ownerReferenceViolation(memberAwait *wait.MemberAwaitility, namespace string, ref metav1.OwnerReference) string {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return fmt.Sprintf("invalid API version in owner reference to %s/%s: %s", ref.Kind, ref.Name, err)
+	}
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	if err := memberAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, owner); err != nil {
+		return fmt.Sprintf("unable to get the owner %s/%s: %s", ref.Kind, ref.Name, err)
+	}
+	if owner.GetUID() != ref.UID {
+		return fmt.Sprintf("owner reference to %s/%s has UID '%s' but the owner has UID '%s'", ref.Kind, ref.Name, ref.UID, owner.GetUID())
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package tiers
+
+import (
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/wait" // nolint:revive
+)
+
+// LargeTemplateObjectNamePrefix the prefix of the names of the ConfigMaps added in the namespace templates by `WithLargeNamespaceTemplates`
+const LargeTemplateObjectNamePrefix = "stress-"
+
+// WithLargeNamespaceTemplates replaces the namespace templates of the tier with copies of the namespace templates of the given tier,
+// in which the given number of (templated) ConfigMaps are added, to stress the apply of the templates by the member operator
+func WithLargeNamespaceTemplates(t *testing.T, otherTier *toolchainv1alpha1.NSTemplateTier, objectsPerNamespace int) CustomNSTemplateTierModifier {
+	return func(hostAwait *HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.NamespaceResourcesTier = otherTier
+		tier.Spec.Namespaces = make([]toolchainv1alpha1.NSTemplateTierNamespace, len(otherTier.Spec.Namespaces))
+		for i, def := range otherTier.Spec.Namespaces {
+			tmplRef, err := largeTierTemplate(t, hostAwait, otherTier.Namespace, tier.Name, def.TemplateRef, objectsPerNamespace)
+			if err != nil {
+				return err
+			}
+			tier.Spec.Namespaces[i].TemplateRef = tmplRef
+		}
+		return nil
+	}
+}
+
+// largeTierTemplate duplicates the TierTemplate with the given name, and adds the given number of ConfigMaps in its namespace
+func largeTierTemplate(t *testing.T, hostAwait *HostAwaitility, namespace, tierName, origTemplateRef string, count int) (string, error) {
+	configMaps := make([]func(namespace string) map[string]interface{}, count)
+	for i := range configMaps {
+		index := i
+		configMaps[i] = func(namespace string) map[string]interface{} {
+			return map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      fmt.Sprintf("%s%d", LargeTemplateObjectNamePrefix, index),
+					"namespace": namespace,
+				},
+				"data": map[string]interface{}{
+					// a templated value, so that the parameters are processed for each object
+					"space": "${SPACE_NAME}",
+					"index": fmt.Sprintf("%d", index),
+				},
+			}
+		}
+	}
+	return duplicateAndModifyTierTemplate(t, hostAwait, namespace, tierName, "large", origTemplateRef, withNamespaceObjects(configMaps...))
+}
@@ -0,0 +1,109 @@
+package tiers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VerifyNamespacesObjectsBudget verifies that each namespace provisioned for the NSTemplateSet with the given name contains exactly
+// the objects of the templates of the tier (ie, the namespace template and the space role templates): all the objects labelled
+// with the `toolchain.dev.openshift.com/provider` label in the namespaces are listed, whatever their kind, so that any
+// missing object (template drift) or extra object (eg, a leftover from a previous tier or a debug resource) is reported.
+func VerifyNamespacesObjectsBudget(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility, nsTmplSetName string) {
+	nsTmplSet, err := memberAwait.WaitForNSTmplSet(t, nsTmplSetName)
+	require.NoError(t, err)
+	resources := listableNamespacedResources(t, memberAwait)
+
+	for _, ns := range nsTmplSet.Spec.Namespaces {
+		namespace, err := memberAwait.WaitForNamespace(t, nsTmplSet.Name, ns.TemplateRef, nsTmplSet.Spec.TierName, wait.UntilNamespaceIsActive())
+		require.NoError(t, err)
+		expected := expectedNamespaceObjects(t, hostAwait, nsTmplSet, ns.TemplateRef, namespace.Name)
+
+		t.Logf("verifying the budget of objects in namespace '%s'", namespace.Name)
+		var missing, extra []string
+		err = memberAwait.Poll(func() (done bool, err error) {
+			actual, err := actualNamespaceObjects(memberAwait, resources, namespace.Name)
+			if err != nil {
+				return false, err
+			}
+			missing = expected.Difference(actual).List()
+			extra = actual.Difference(expected).List()
+			return len(missing) == 0 && len(extra) == 0, nil
+		})
+		require.NoError(t, err, "unexpected objects in namespace '%s' provisioned with template '%s':\nmissing: %s\nextra: %s",
+			namespace.Name, ns.TemplateRef, strings.Join(missing, ", "), strings.Join(extra, ", "))
+	}
+}
+
+// expectedNamespaceObjects returns the `kind/name` of the objects in the given namespace, once the namespace template
+// and the space role templates of the NSTemplateSet are processed
+func expectedNamespaceObjects(t *testing.T, hostAwait *wait.HostAwaitility, nsTmplSet *toolchainv1alpha1.NSTemplateSet, templateRef, namespace string) sets.String {
+	expected := sets.NewString()
+	for _, obj := range templatedNamespaceObjects(t, hostAwait, nsTmplSet, templateRef, namespace) {
+		expected.Insert(objectKey(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName()))
+	}
+	return expected
+}
+
+// actualNamespaceObjects returns the `kind/name` of the objects of the given resources in the given namespace,
+// which were provisioned by the toolchain
+func actualNamespaceObjects(memberAwait *wait.MemberAwaitility, resources []schema.GroupVersionKind, namespace string) (sets.String, error) {
+	actual := sets.NewString()
+	for _, gvk := range resources {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := memberAwait.Client.List(context.TODO(), list, client.InNamespace(namespace), providerMatchingLabels); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, item := range list.Items {
+			actual.Insert(objectKey(gvk.Kind, item.GetName()))
+		}
+	}
+	return actual, nil
+}
+
+// listableNamespacedResources returns the kinds of all the namespaced resources served by the API server of the member cluster
+// which can be listed
+func listableNamespacedResources(t *testing.T, memberAwait *wait.MemberAwaitility) []schema.GroupVersionKind {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(memberAwait.RestConfig)
+	require.NoError(t, err)
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) { // ignore the API groups which are temporarily unavailable
+		require.NoError(t, err)
+	}
+	var resources []schema.GroupVersionKind
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		require.NoError(t, err)
+		for _, resource := range resourceList.APIResources {
+			if !sets.NewString(resource.Verbs...).Has("list") || strings.Contains(resource.Name, "/") {
+				continue
+			}
+			resources = append(resources, gv.WithKind(resource.Kind))
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].String() < resources[j].String()
+	})
+	return resources
+}
+
+func objectKey(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
@@ -30,6 +30,11 @@ func VerifyNSTemplateSet(t *testing.T, hostAwait *wait.HostAwaitility, memberAwa
 		require.NoError(t, err)
 		_, nsType, err := wait.TierAndType(templateRef)
 		require.NoError(t, err)
+		namespaceObjectChecks.Add(1)
+		go func(templateRef string) {
+			defer namespaceObjectChecks.Done()
+			verifyPodSecurityLabels(t, hostAwait, memberAwait, nsTmplSet, templateRef, ns)
+		}(templateRef)
 		namespaceChecks := checks.GetNamespaceObjectChecks(nsType)
 		for _, check := range namespaceChecks {
 			namespaceObjectChecks.Add(1)
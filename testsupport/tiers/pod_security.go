@@ -0,0 +1,105 @@
+package tiers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/template"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PodSecurityLabelPrefix the prefix of the labels of the namespaces which configure the Pod Security Admission
+	PodSecurityLabelPrefix = "pod-security.kubernetes.io/"
+	// PodSecurityEnforceLabel the label of the namespaces which sets the Pod Security level which is enforced
+	PodSecurityEnforceLabel = PodSecurityLabelPrefix + "enforce"
+)
+
+// expectedPodSecurityLabels returns the Pod Security Admission labels of the namespace defined in the tier template with the given ref
+func expectedPodSecurityLabels(t *testing.T, hostAwait *wait.HostAwaitility, nsTmplSet *toolchainv1alpha1.NSTemplateSet, templateRef string) map[string]string {
+	tierTemplate, err := hostAwait.WaitForTierTemplate(t, templateRef)
+	require.NoError(t, err)
+	objs, err := template.NewProcessor(hostAwait.Client.Scheme()).Process(tierTemplate.Spec.Template.DeepCopy(), map[string]string{
+		"SPACE_NAME": nsTmplSet.Name,
+		"USERNAME":   nsTmplSet.Name,
+	})
+	require.NoError(t, err)
+	labels := map[string]string{}
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().Kind != "Namespace" {
+			continue
+		}
+		for k, v := range obj.GetLabels() {
+			if strings.HasPrefix(k, PodSecurityLabelPrefix) {
+				labels[k] = v
+			}
+		}
+	}
+	return labels
+}
+
+// verifyPodSecurityLabels verifies that the given namespace has the Pod Security Admission labels defined in the tier template with the given ref
+func verifyPodSecurityLabels(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility, nsTmplSet *toolchainv1alpha1.NSTemplateSet, templateRef string, ns *corev1.Namespace) {
+	expected := expectedPodSecurityLabels(t, hostAwait, nsTmplSet, templateRef)
+	if len(expected) == 0 {
+		return
+	}
+	criteria := make([]wait.LabelWaitCriterion, 0, len(expected))
+	for k, v := range expected {
+		criteria = append(criteria, wait.UntilObjectHasLabel(k, v))
+	}
+	_, err := memberAwait.WaitForNamespaceWithName(t, ns.Name, criteria...)
+	require.NoError(t, err, "namespace '%s' does not have the Pod Security labels of template '%s'", ns.Name, templateRef)
+}
+
+// VerifyPrivilegedPodRejected verifies that the given user cannot create a privileged pod in the given namespace,
+// when the namespace enforces the `baseline` or `restricted` Pod Security level. The test is skipped if the cluster
+// has no Pod Security Admission or if the namespace enforces the `privileged` level (or no level at all).
+func VerifyPrivilegedPodRejected(t *testing.T, memberAwait *wait.MemberAwaitility, namespace, username string) {
+	memberAwait.SkipUnlessCapable(t, wait.CapabilityPodSecurityAdmission)
+	ns, err := memberAwait.WaitForNamespaceWithName(t, namespace)
+	require.NoError(t, err)
+	if level := ns.Labels[PodSecurityEnforceLabel]; level != "baseline" && level != "restricted" {
+		t.Skipf("skipping test because namespace '%s' enforces the '%s' Pod Security level", namespace, level)
+	}
+
+	userAwait, err := memberAwait.Impersonate(username)
+	require.NoError(t, err)
+	privileged := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "privileged-" + uuid.Must(uuid.NewV4()).String()[:8],
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "sleep",
+				Image:   "busybox",
+				Command: []string{"sleep", "36000"},
+				SecurityContext: &corev1.SecurityContext{
+					Privileged: &privileged,
+				},
+			}},
+		},
+	}
+
+	// when
+	err = userAwait.Client.Create(context.TODO(), pod)
+
+	// then
+	if err == nil {
+		_ = memberAwait.Client.Delete(context.TODO(), pod)
+	}
+	require.Error(t, err, "privileged pod was not rejected in namespace '%s'", namespace)
+	require.True(t, apierrors.IsForbidden(err), "unexpected error: %v", err)
+	// on OpenShift, the pod may be rejected by the Security Context Constraints before reaching the Pod Security Admission
+	assert.Regexp(t, "violates PodSecurity|unable to validate against any security context constraint", err.Error())
+}
@@ -0,0 +1,193 @@
+package tiers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/template"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DecodeTemplateObjects processes the template of the given TierTemplate with the given parameters, and decodes the resulting objects
+// into the typed objects registered in the scheme (eg, `*rbacv1.Role`). The objects whose kind is not registered in the scheme are
+// returned as `*unstructured.Unstructured`.
+func DecodeTemplateObjects(scheme *runtime.Scheme, tierTemplate *toolchainv1alpha1.TierTemplate, params map[string]string) ([]client.Object, error) {
+	objs, err := template.NewProcessor(scheme).Process(tierTemplate.Spec.Template.DeepCopy(), params)
+	if err != nil {
+		return nil, err
+	}
+	decoded := make([]client.Object, len(objs))
+	for i, obj := range objs {
+		decoded[i] = obj
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		gvk := u.GroupVersionKind()
+		if !scheme.Recognizes(gvk) {
+			continue
+		}
+		typed, err := scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+			return nil, fmt.Errorf("unable to decode %s '%s' of template '%s': %w", gvk.Kind, u.GetName(), tierTemplate.Name, err)
+		}
+		typedObj, ok := typed.(client.Object)
+		if !ok {
+			continue
+		}
+		typedObj.GetObjectKind().SetGroupVersionKind(gvk)
+		decoded[i] = typedObj
+	}
+	return decoded, nil
+}
+
+// TemplateDiffs returns the differences between the templated object and the actual one, as a list of `path: expected != actual`.
+// Only the fields set in the templated object are compared (ie, the fields added by the API server or the controllers are ignored),
+// once both objects are converted to their unstructured form (so that the typed fields such as the quantities are normalized).
+func TemplateDiffs(expected, actual client.Object) ([]string, error) {
+	e, err := runtime.DefaultUnstructuredConverter.ToUnstructured(expected)
+	if err != nil {
+		return nil, err
+	}
+	a, err := runtime.DefaultUnstructuredConverter.ToUnstructured(actual)
+	if err != nil {
+		return nil, err
+	}
+	// the type meta is not always set in the objects returned by the client
+	delete(e, "apiVersion")
+	delete(e, "kind")
+	var diffs []string
+	collectTemplateDiffs("", e, a, &diffs)
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+func collectTemplateDiffs(path string, expected, actual interface{}, diffs *[]string) {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, expected, actual))
+			return
+		}
+		for k, v := range e {
+			collectTemplateDiffs(path+"."+k, v, a[k], diffs)
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, expected, actual))
+			return
+		}
+		for i := range e {
+			collectTemplateDiffs(fmt.Sprintf("%s[%d]", path, i), e[i], a[i], diffs)
+		}
+	case nil:
+		// unset field in the template
+	default:
+		if !reflect.DeepEqual(normalizeNumber(expected), normalizeNumber(actual)) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, expected, actual))
+		}
+	}
+}
+
+// normalizeNumber converts the numbers to float64, since the unstructured objects may contain int64 or float64 values for the same field
+func normalizeNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return value
+}
+
+// VerifyNamespaceObjectsAsTemplated verifies that every object of the namespace templates (and the space role templates) of the NSTemplateSet
+// with the given name exists in the namespaces exactly as templated (see TemplateDiffs)
+func VerifyNamespaceObjectsAsTemplated(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility, nsTmplSetName string) {
+	nsTmplSet, err := memberAwait.WaitForNSTmplSet(t, nsTmplSetName)
+	require.NoError(t, err)
+
+	for _, ns := range nsTmplSet.Spec.Namespaces {
+		namespace, err := memberAwait.WaitForNamespace(t, nsTmplSet.Name, ns.TemplateRef, nsTmplSet.Spec.TierName, wait.UntilNamespaceIsActive())
+		require.NoError(t, err)
+		expected := templatedNamespaceObjects(t, hostAwait, nsTmplSet, ns.TemplateRef, namespace.Name)
+
+		t.Logf("verifying that the objects in namespace '%s' match template '%s'", namespace.Name, ns.TemplateRef)
+		var diffs []string
+		err = memberAwait.Poll(func() (done bool, err error) {
+			diffs = []string{}
+			for _, obj := range expected {
+				actual, ok := obj.DeepCopyObject().(client.Object)
+				if !ok {
+					return false, fmt.Errorf("unexpected object: %T", obj)
+				}
+				if err := memberAwait.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), actual); err != nil {
+					if apierrors.IsNotFound(err) {
+						diffs = append(diffs, fmt.Sprintf("%s: not found", objectKey(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())))
+						continue
+					}
+					return false, err
+				}
+				objDiffs, err := TemplateDiffs(obj, actual)
+				if err != nil {
+					return false, err
+				}
+				for _, d := range objDiffs {
+					diffs = append(diffs, fmt.Sprintf("%s: %s", objectKey(obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName()), d))
+				}
+			}
+			return len(diffs) == 0, nil
+		})
+		require.NoError(t, err, "objects in namespace '%s' do not match template '%s':\n%s", namespace.Name, ns.TemplateRef, strings.Join(diffs, "\n"))
+	}
+}
+
+// templatedNamespaceObjects returns the decoded objects in the given namespace, once the namespace template and the space role
+// templates of the NSTemplateSet are processed
+func templatedNamespaceObjects(t *testing.T, hostAwait *wait.HostAwaitility, nsTmplSet *toolchainv1alpha1.NSTemplateSet, templateRef, namespace string) []client.Object {
+	var result []client.Object
+	add := func(templateRef string, params map[string]string) {
+		tierTemplate, err := hostAwait.WaitForTierTemplate(t, templateRef)
+		require.NoError(t, err)
+		objs, err := DecodeTemplateObjects(hostAwait.Client.Scheme(), tierTemplate, params)
+		require.NoError(t, err)
+		for _, obj := range objs {
+			if obj.GetObjectKind().GroupVersionKind().Kind == "Namespace" || (obj.GetNamespace() != "" && obj.GetNamespace() != namespace) {
+				continue
+			}
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+			result = append(result, obj)
+		}
+	}
+	add(templateRef, map[string]string{
+		"SPACE_NAME": nsTmplSet.Name,
+		"USERNAME":   nsTmplSet.Name,
+	})
+	for _, role := range nsTmplSet.Spec.SpaceRoles {
+		for _, username := range role.Usernames {
+			add(role.TemplateRef, map[string]string{
+				"NAMESPACE": namespace,
+				"USERNAME":  username,
+			})
+		}
+	}
+	return result
+}
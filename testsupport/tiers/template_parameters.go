@@ -0,0 +1,139 @@
+package tiers
+
+import (
+	"sort"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/wait" // nolint:revive
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// SizingResourceQuotaName the name of the ResourceQuota added in the namespace templates by `WithSizingParameters`
+	SizingResourceQuotaName = "sizing"
+	// CPULimitParameter the name of the template parameter with the limit of CPU of the namespaces
+	CPULimitParameter = "SIZING_CPU_LIMIT"
+	// MemoryLimitParameter the name of the template parameter with the limit of memory of the namespaces
+	MemoryLimitParameter = "SIZING_MEMORY_LIMIT"
+)
+
+// Sizing the values of the CPU/memory sizing parameters of the namespace templates
+type Sizing struct {
+	CPU    string
+	Memory string
+}
+
+// Parameters returns the values of the template parameters of the sizing
+func (s Sizing) Parameters() map[string]string {
+	return map[string]string{
+		CPULimitParameter:    s.CPU,
+		MemoryLimitParameter: s.Memory,
+	}
+}
+
+// WithSizingParameters replaces the namespace templates of the tier with copies of the namespace templates of the given tier,
+// in which a ResourceQuota whose limits are set by the CPU/memory sizing parameters is added, with the given values for these parameters
+func WithSizingParameters(t *testing.T, otherTier *toolchainv1alpha1.NSTemplateTier, sizing Sizing) CustomNSTemplateTierModifier {
+	return WithNamespaceTemplateParameters(t, otherTier, sizing.Parameters(), sizingResourceQuota)
+}
+
+// WithNamespaceTemplateParameters replaces the namespace templates of the tier with copies of the namespace templates of the given tier,
+// in which the given parameters are declared with the given values (or set to the given values, if they are already declared), and in
+// which the objects returned by the given function (for the templated name of the namespace) are added
+func WithNamespaceTemplateParameters(t *testing.T, otherTier *toolchainv1alpha1.NSTemplateTier, params map[string]string, objects ...func(namespace string) map[string]interface{}) CustomNSTemplateTierModifier {
+	return func(hostAwait *HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.NamespaceResourcesTier = otherTier
+		tier.Spec.Namespaces = make([]toolchainv1alpha1.NSTemplateTierNamespace, len(otherTier.Spec.Namespaces))
+		for i, def := range otherTier.Spec.Namespaces {
+			tmplRef, err := parameterizedTierTemplate(t, hostAwait, otherTier.Namespace, tier.Name, def.TemplateRef, params, objects...)
+			if err != nil {
+				return err
+			}
+			tier.Spec.Namespaces[i].TemplateRef = tmplRef
+		}
+		return nil
+	}
+}
+
+// parameterizedTierTemplate duplicates the TierTemplate with the given name, sets the given parameters and adds the given objects
+func parameterizedTierTemplate(t *testing.T, hostAwait *HostAwaitility, namespace, tierName, origTemplateRef string, params map[string]string, objects ...func(namespace string) map[string]interface{}) (string, error) {
+	return duplicateAndModifyTierTemplate(t, hostAwait, namespace, tierName, "params", origTemplateRef,
+		func(tierTemplate *toolchainv1alpha1.TierTemplate) error {
+			setTemplateParameters(&tierTemplate.Spec.Template, params)
+			return nil
+		},
+		withNamespaceObjects(objects...))
+}
+
+// setTemplateParameters sets the values of the given parameters in the template, and declares the ones which are missing
+func setTemplateParameters(tmpl *templatev1.Template, params map[string]string) {
+	declared := map[string]bool{}
+	for i, p := range tmpl.Parameters {
+		if value, found := params[p.Name]; found {
+			tmpl.Parameters[i].Value = value
+			declared[p.Name] = true
+		}
+	}
+	for _, name := range sortedParameterNames(params) {
+		if !declared[name] {
+			tmpl.Parameters = append(tmpl.Parameters, templatev1.Parameter{
+				Name:     name,
+				Value:    params[name],
+				Required: true,
+			})
+		}
+	}
+}
+
+// sizingResourceQuota returns the ResourceQuota whose limits are set by the CPU/memory sizing parameters
+// (the quantities are templated, so the object cannot be built with the typed ResourceQuota)
+func sizingResourceQuota(namespace string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ResourceQuota",
+		"metadata": map[string]interface{}{
+			"name":      SizingResourceQuotaName,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"hard": map[string]interface{}{
+				"limits.cpu":    "${" + CPULimitParameter + "}",
+				"limits.memory": "${" + MemoryLimitParameter + "}",
+			},
+		},
+	}
+}
+
+// sortedParameterNames returns the names of the given parameters, sorted alphabetically
+func sortedParameterNames(params map[string]string) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VerifySizingParameters verifies that the ResourceQuota added by `WithSizingParameters` in each namespace provisioned for the NSTemplateSet
+// with the given name has the limits of the given sizing, ie, that the parameters of the templates were rendered with the values of the tier
+func VerifySizingParameters(t *testing.T, memberAwait *MemberAwaitility, nsTmplSetName string, sizing Sizing) {
+	nsTmplSet, err := memberAwait.WaitForNSTmplSet(t, nsTmplSetName)
+	require.NoError(t, err)
+	for _, ns := range nsTmplSet.Spec.Namespaces {
+		namespace, err := memberAwait.WaitForNamespace(t, nsTmplSet.Name, ns.TemplateRef, nsTmplSet.Spec.TierName, UntilNamespaceIsActive())
+		require.NoError(t, err)
+		quota, err := memberAwait.WaitForResourceQuota(t, namespace.Name, SizingResourceQuotaName)
+		require.NoError(t, err, "no sizing ResourceQuota in namespace '%s'", namespace.Name)
+		assertQuantity(t, sizing.CPU, quota.Spec.Hard[corev1.ResourceLimitsCPU], "limits.cpu", namespace.Name)
+		assertQuantity(t, sizing.Memory, quota.Spec.Hard[corev1.ResourceLimitsMemory], "limits.memory", namespace.Name)
+	}
+}
+
+func assertQuantity(t *testing.T, expected string, actual resource.Quantity, name, namespace string) {
+	assert.Zero(t, resource.MustParse(expected).Cmp(actual), "unexpected %s in namespace '%s': expected %s but was %s", name, namespace, expected, actual.String())
+}
@@ -2,13 +2,15 @@ package tiers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/wait" // nolint:revive
-	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -61,6 +63,15 @@ func WithNamespaceResources(t *testing.T, otherTier *toolchainv1alpha1.NSTemplat
 	}
 }
 
+// WithoutNamespaces removes the namespace templates of the tier, so that only the cluster resources are provisioned
+// on the member cluster for the Spaces of the tier (ie, "member-less" Spaces, without any namespace)
+func WithoutNamespaces() CustomNSTemplateTierModifier {
+	return func(hostAwait *HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.Spec.Namespaces = nil
+		return nil
+	}
+}
+
 func WithSpaceRoles(t *testing.T, otherTier *toolchainv1alpha1.NSTemplateTier) CustomNSTemplateTierModifier {
 	return func(hostAwait *HostAwaitility, tier *CustomNSTemplateTier) error {
 		tier.SpaceRolesTier = otherTier
@@ -109,6 +120,19 @@ func CreateCustomNSTemplateTier(t *testing.T, hostAwait *HostAwaitility, name st
 	return tier
 }
 
+// CreateCustomTier creates a custom NSTemplateTier based on the existing NSTemplateTier with the given name, under a unique name
+// (so that tests don't have to modify the shared tiers and can run in parallel). The TierTemplates of the base tier are duplicated
+// for the new tier, unless other modifiers are specified. All the resources are deleted at the end of the test.
+// Returns the custom tier along with the checks to verify the resources provisioned for the tier.
+func CreateCustomTier(t *testing.T, hostAwait *HostAwaitility, basedOn string, modifiers ...CustomNSTemplateTierModifier) (*CustomNSTemplateTier, TierChecks) {
+	baseTier, err := hostAwait.WaitForNSTemplateTier(t, basedOn)
+	require.NoError(t, err)
+	// keep the name short, since it is used as a prefix for the names of the TierTemplates
+	name := fmt.Sprintf("%s-%s", basedOn, rand.String(5))
+	tier := CreateCustomNSTemplateTier(t, hostAwait, name, baseTier, modifiers...)
+	return tier, NewChecksForCustomTier(t, tier)
+}
+
 // createCustomNSTemplateTier updates the given "tier" using the modifiers
 // returns the latest version of the NSTemplateTier
 func UpdateCustomNSTemplateTier(t *testing.T, hostAwait *HostAwaitility, tier *CustomNSTemplateTier, modifiers ...CustomNSTemplateTierModifier) *CustomNSTemplateTier {
@@ -127,6 +151,15 @@ func UpdateCustomNSTemplateTier(t *testing.T, hostAwait *HostAwaitility, tier *C
 }
 
 func duplicateTierTemplate(t *testing.T, hostAwait *HostAwaitility, namespace, tierName, origTemplateRef string) (string, error) {
+	return duplicateAndModifyTierTemplate(t, hostAwait, namespace, tierName, "from", origTemplateRef)
+}
+
+// tierTemplateModifier modifies the duplicate of a TierTemplate before it is created
+type tierTemplateModifier func(tierTemplate *toolchainv1alpha1.TierTemplate) error
+
+// duplicateAndModifyTierTemplate duplicates the TierTemplate with the given name for the given tier, applies the given modifiers
+// on the duplicate and creates it. The name of the duplicate is made of the name of the tier, the given infix and the original name.
+func duplicateAndModifyTierTemplate(t *testing.T, hostAwait *HostAwaitility, namespace, tierName, infix, origTemplateRef string, modifiers ...tierTemplateModifier) (string, error) {
 	origTierTemplate := &toolchainv1alpha1.TierTemplate{}
 	if err := hostAwait.Client.Get(context.TODO(), test.NamespacedName(hostAwait.Namespace, origTemplateRef), origTierTemplate); err != nil {
 		return "", err
@@ -134,20 +167,61 @@ func duplicateTierTemplate(t *testing.T, hostAwait *HostAwaitility, namespace, t
 	newTierTemplate := &toolchainv1alpha1.TierTemplate{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      fmt.Sprintf("%sfrom%s", tierName, origTierTemplate.Name),
+			Name:      fmt.Sprintf("%s%s%s", tierName, infix, origTierTemplate.Name),
 			Labels:    map[string]string{"producer": "toolchain-e2e"},
 		},
-		Spec: origTierTemplate.Spec,
+		Spec: *origTierTemplate.Spec.DeepCopy(),
 	}
 	newTierTemplate.Spec.TierName = tierName
-	if err := hostAwait.CreateWithCleanup(t, newTierTemplate); err != nil {
-		if !errors.IsAlreadyExists(err) {
+	for _, modify := range modifiers {
+		if err := modify(newTierTemplate); err != nil {
 			return "", err
 		}
 	}
+	// applied, so that a TierTemplate which already exists for the tier is updated instead of failing the creation
+	if err := hostAwait.ApplyWithCleanup(t, newTierTemplate); err != nil {
+		return "", err
+	}
 	return newTierTemplate.Name, nil
 }
 
+// withNamespaceObjects adds the objects returned by the given functions (for the templated name of the namespace) in the namespace TierTemplate
+func withNamespaceObjects(objects ...func(namespace string) map[string]interface{}) tierTemplateModifier {
+	return func(tierTemplate *toolchainv1alpha1.TierTemplate) error {
+		nsName, err := templateNamespaceName(tierTemplate)
+		if err != nil {
+			return err
+		}
+		for _, newObject := range objects {
+			raw, err := json.Marshal(newObject(nsName))
+			if err != nil {
+				return err
+			}
+			tierTemplate.Spec.Template.Objects = append(tierTemplate.Spec.Template.Objects, runtime.RawExtension{Raw: raw})
+		}
+		return nil
+	}
+}
+
+// templateNamespaceName returns the (templated) name of the Namespace object of the given namespace TierTemplate (eg, `${SPACE_NAME}-dev`)
+func templateNamespaceName(tierTemplate *toolchainv1alpha1.TierTemplate) (string, error) {
+	for _, obj := range tierTemplate.Spec.Template.Objects {
+		meta := struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		if err := json.Unmarshal(obj.Raw, &meta); err != nil {
+			return "", err
+		}
+		if meta.Kind == "Namespace" {
+			return meta.Metadata.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no Namespace object in TierTemplate '%s'", tierTemplate.Name)
+}
+
 func MoveSpaceToTier(t *testing.T, hostAwait *HostAwaitility, spacename, tierName string) {
 	t.Logf("moving space '%s' to space tier '%s'", spacename, tierName)
 	_, err := hostAwait.WaitForSpace(t, spacename)
@@ -0,0 +1,156 @@
+package testsupport
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ToolchainConfigFuzzIterationsVar the env var to set to enable the ToolchainConfig fuzzing, with the number of random configurations to apply
+	ToolchainConfigFuzzIterationsVar = "E2E_TOOLCHAINCONFIG_FUZZ_ITERATIONS"
+	// ToolchainConfigFuzzSeedVar the env var to set to replay a previous ToolchainConfig fuzzing with the same random configurations
+	ToolchainConfigFuzzSeedVar = "E2E_TOOLCHAINCONFIG_FUZZ_SEED"
+)
+
+// ToolchainConfigMutation a random (but valid) modification of the ToolchainConfig
+type ToolchainConfigMutation struct {
+	Description string
+	Option      testconfig.ToolchainConfigOption
+}
+
+// toolchainConfigMutators the generators of mutations. They only produce values which satisfy the constraints of the ToolchainConfig schema,
+// and they leave untouched the settings which would change the outcome of the other tests (eg. automatic approval or default tiers)
+var toolchainConfigMutators = []func(r *rand.Rand) ToolchainConfigMutation{
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := 1 + r.Intn(10)
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("deactivation.deactivatingNotificationDays=%d", value),
+			Option:      testconfig.Deactivation().DeactivatingNotificationDays(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := 1 + r.Intn(365)
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("deactivation.userSignupDeactivatedRetentionDays=%d", value),
+			Option:      testconfig.Deactivation().UserSignupDeactivatedRetentionDays(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := 1 + r.Intn(100)
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("registrationService.verification.attemptsAllowed=%d", value),
+			Option:      testconfig.RegistrationService().Verification().AttemptsAllowed(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := 1 + r.Intn(60)
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("registrationService.verification.codeExpiresInMin=%d", value),
+			Option:      testconfig.RegistrationService().Verification().CodeExpiresInMin(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := fmt.Sprintf("%ds", 1+r.Intn(30))
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("toolchainStatus.toolchainStatusRefreshTime=%s", value),
+			Option:      testconfig.ToolchainStatus().ToolchainStatusRefreshTime(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := fmt.Sprintf("%ds", 1+r.Intn(600))
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("notifications.durationBeforeNotificationDeletion=%s", value),
+			Option:      testconfig.Notifications().DurationBeforeNotificationDeletion(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := fmt.Sprintf("%ds", 1+r.Intn(600))
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("tiers.durationBeforeChangeTierRequestDeletion=%s", value),
+			Option:      testconfig.Tiers().DurationBeforeChangeTierRequestDeletion(value),
+		}
+	},
+	func(r *rand.Rand) ToolchainConfigMutation {
+		value := 1 + r.Intn(10)
+		return ToolchainConfigMutation{
+			Description: fmt.Sprintf("users.masterUserRecordUpdateFailureThreshold=%d", value),
+			Option:      testconfig.Users().MasterUserRecordUpdateFailureThreshold(value),
+		}
+	},
+}
+
+// RandomToolchainConfigMutations returns between 1 and `max` distinct mutations of the ToolchainConfig, generated with the given source of randomness
+func RandomToolchainConfigMutations(r *rand.Rand, max int) []ToolchainConfigMutation {
+	count := 1 + r.Intn(max)
+	if count > len(toolchainConfigMutators) {
+		count = len(toolchainConfigMutators)
+	}
+	mutations := make([]ToolchainConfigMutation, 0, count)
+	for _, i := range r.Perm(len(toolchainConfigMutators))[:count] {
+		mutations = append(mutations, toolchainConfigMutators[i](r))
+	}
+	return mutations
+}
+
+// ToolchainConfigFuzzSettings returns the number of iterations and the seed of the ToolchainConfig fuzzing,
+// or skips the test if the fuzzing is not enabled
+func ToolchainConfigFuzzSettings(t *testing.T) (int, int64) {
+	iterations, found := os.LookupEnv(ToolchainConfigFuzzIterationsVar)
+	if !found {
+		t.Skipf("ToolchainConfig fuzzing is disabled, set the '%s' env var to enable it", ToolchainConfigFuzzIterationsVar)
+	}
+	count, err := strconv.Atoi(iterations)
+	require.NoError(t, err, "invalid value of '%s'", ToolchainConfigFuzzIterationsVar)
+	seed := time.Now().UnixNano()
+	if s, found := os.LookupEnv(ToolchainConfigFuzzSeedVar); found {
+		seed, err = strconv.ParseInt(s, 10, 64)
+		require.NoError(t, err, "invalid value of '%s'", ToolchainConfigFuzzSeedVar)
+	}
+	t.Logf("fuzzing the ToolchainConfig with %d iterations, use %s=%d to replay them", count, ToolchainConfigFuzzSeedVar, seed)
+	return count, seed
+}
+
+// GetOperatorPodRestartCounts returns the restart counts of the pods of the host and member operators, indexed by namespace and pod name
+func GetOperatorPodRestartCounts(t *testing.T, awaitilities wait.Awaitilities) map[string]map[string]int32 {
+	restarts := map[string]map[string]int32{}
+	for _, a := range operatorAwaitilities(awaitilities) {
+		podRestarts, err := a.GetPodRestartCounts(client.InNamespace(a.Namespace), client.MatchingLabels{"control-plane": "controller-manager"})
+		require.NoError(t, err)
+		restarts[a.Namespace] = podRestarts
+	}
+	return restarts
+}
+
+// VerifyOperatorsSettled verifies that, within the given grace period, the ToolchainConfig is synced to the members and the ToolchainStatus is ready,
+// and that none of the operator pods restarted since the given restart counts were retrieved
+func VerifyOperatorsSettled(t *testing.T, awaitilities wait.Awaitilities, restarts map[string]map[string]int32, gracePeriod time.Duration) {
+	hostAwait := awaitilities.Host().WithRetryOptions(wait.TimeoutOption(gracePeriod))
+	_, err := hostAwait.WaitForToolchainConfig(t, wait.UntilToolchainConfigHasSyncedStatus(ToolchainConfigSyncComplete()))
+	require.NoError(t, err, "ToolchainConfig was not synced within %s", gracePeriod)
+	_, err = hostAwait.WaitForToolchainStatus(t, wait.UntilToolchainStatusHasConditions(ToolchainStatusReadyAndUnreadyNotificationNotCreated()...))
+	require.NoError(t, err, "ToolchainStatus was not ready within %s", gracePeriod)
+
+	for namespace, podRestarts := range GetOperatorPodRestartCounts(t, awaitilities) {
+		for pod, count := range podRestarts {
+			assert.Equal(t, restarts[namespace][pod], count, "operator pod '%s' in namespace '%s' restarted", pod, namespace)
+		}
+	}
+}
+
+func operatorAwaitilities(awaitilities wait.Awaitilities) []*wait.Awaitility {
+	all := []*wait.Awaitility{awaitilities.Host().Awaitility}
+	for _, memberAwait := range awaitilities.AllMembers() {
+		all = append(all, memberAwait.Awaitility)
+	}
+	return all
+}
@@ -10,7 +10,6 @@ import (
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
-	identitypkg "github.com/codeready-toolchain/toolchain-common/pkg/identity"
 	testtier "github.com/codeready-toolchain/toolchain-common/pkg/test/tier"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
@@ -26,8 +25,15 @@ func VerifyMultipleSignups(t *testing.T, awaitilities wait.Awaitilities, signups
 }
 
 func VerifyResourcesProvisionedForSignup(t *testing.T, awaitilities wait.Awaitilities, signup *toolchainv1alpha1.UserSignup, userTierName, spaceTierName string) {
-	VerifyUserRelatedResources(t, awaitilities, signup, userTierName)
-	VerifySpaceRelatedResources(t, awaitilities, signup, spaceTierName)
+	soft := NewSoftAssertions(t)
+	soft.Check("user related resources", func(t *testing.T) {
+		VerifyUserRelatedResources(t, awaitilities, signup, userTierName)
+	})
+	soft.Check("space related resources", func(t *testing.T) {
+		VerifySpaceRelatedResources(t, awaitilities, signup, spaceTierName)
+	})
+	soft.Report()
+	RecordProvisioningLatencies(t, awaitilities, signup.Name)
 }
 
 func VerifyResourcesProvisionedForSignupWithoutSpace(t *testing.T, awaitilities wait.Awaitilities, signup *toolchainv1alpha1.UserSignup, userTierName string) {
@@ -37,6 +43,7 @@ func VerifyResourcesProvisionedForSignupWithoutSpace(t *testing.T, awaitilities
 	space, err := awaitilities.Host().WithRetryOptions(wait.TimeoutOption(3*time.Second)).WaitForSpace(t, signup.Status.CompliantUsername)
 	require.Error(t, err)
 	require.Nil(t, space)
+	RecordProvisioningLatencies(t, awaitilities, signup.Name)
 }
 
 func VerifyUserRelatedResources(t *testing.T, awaitilities wait.Awaitilities, signup *toolchainv1alpha1.UserSignup, tierName string) (*toolchainv1alpha1.UserSignup, *toolchainv1alpha1.MasterUserRecord) {
@@ -67,18 +74,31 @@ func VerifyUserRelatedResources(t *testing.T, awaitilities wait.Awaitilities, si
 	require.NoError(t, err)
 	require.NotNil(t, userAccount)
 
-	// Verify last target cluster annotation is set
-	lastCluster, foundLastCluster := userSignup.Annotations[toolchainv1alpha1.UserSignupLastTargetClusterAnnotationKey]
-	require.True(t, foundLastCluster)
-	require.Equal(t, memberAwait.ClusterName, lastCluster)
+	soft := NewSoftAssertions(t)
+	soft.Check("last target cluster annotation", func(t *testing.T) {
+		// Verify last target cluster annotation is set
+		lastCluster, foundLastCluster := userSignup.Annotations[toolchainv1alpha1.UserSignupLastTargetClusterAnnotationKey]
+		require.True(t, foundLastCluster)
+		require.Equal(t, memberAwait.ClusterName, lastCluster)
+	})
 
-	// Check the second identity
-	originalSubIdentityName := ""
-	if userAccount.Spec.OriginalSub != "" {
-		originalSubIdentityName = identitypkg.NewIdentityNamingStandard(userAccount.Spec.OriginalSub, "rhd").IdentityName()
-	}
+	soft.Check("user and identities", func(t *testing.T) {
+		verifyUserAndIdentities(t, memberAwait, signup, userSignup, userAccount)
+	})
+
+	soft.Check("embedded UserAccount status", func(t *testing.T) {
+		mur = verifyEmbeddedUserAccountStatus(t, hostAwait, memberAwait, mur, userAccount)
+	})
+	soft.Report()
+
+	return userSignup, mur
+}
 
+// verifyUserAndIdentities verifies the User and Identities provisioned for the given UserAccount, unless the member operator is configured
+// to skip their creation (in which case it verifies that they do not exist)
+func verifyUserAndIdentities(t *testing.T, memberAwait *wait.MemberAwaitility, signup, userSignup *toolchainv1alpha1.UserSignup, userAccount *toolchainv1alpha1.UserAccount) {
 	memberConfiguration := memberAwait.GetMemberOperatorConfig(t)
+	identityProvider := IdentityProviderOf(memberConfiguration)
 
 	// Verify User and Identity if SkipUserCreation is not set or it is set to false
 	if memberConfiguration.Spec.SkipUserCreation == nil || !*memberConfiguration.Spec.SkipUserCreation {
@@ -103,35 +123,19 @@ func VerifyUserRelatedResources(t *testing.T, awaitilities wait.Awaitilities, si
 			require.NotContains(t, user.Annotations, toolchainv1alpha1.SSOAccountIDAnnotationKey)
 		}
 
-		// Verify provisioned Identity
-		identityName := identitypkg.NewIdentityNamingStandard(userAccount.Spec.UserID, "rhd").IdentityName()
-
-		_, err = memberAwait.WaitForIdentity(t, identityName,
-			wait.UntilIdentityHasLabel(toolchainv1alpha1.ProviderLabelKey, toolchainv1alpha1.ProviderLabelValue),
-			wait.UntilIdentityHasLabel(toolchainv1alpha1.OwnerLabelKey, userAccount.Name))
-		assert.NoError(t, err, fmt.Sprintf("no identity with name '%s' found", identityName))
-
-		// Verify the second identity
-		if originalSubIdentityName != "" {
-			_, err = memberAwait.WaitForIdentity(t, identityName,
-				wait.UntilIdentityHasLabel(toolchainv1alpha1.ProviderLabelKey, toolchainv1alpha1.ProviderLabelValue),
-				wait.UntilIdentityHasLabel(toolchainv1alpha1.OwnerLabelKey, userAccount.Name))
-			assert.NoError(t, err, fmt.Sprintf("no encoded identity with name '%s' found", identityName))
-		}
+		// Verify provisioned Identities (for the `sub` and `original_sub` claims)
+		VerifyIdentities(t, memberAwait, userAccount, identityProvider)
 	} else {
 		// we don't expect User nor Identity resources to be present for AppStudio tier
 		// This can be removed as soon as we don't create UserAccounts in AppStudio environment.
 		err := memberAwait.WaitUntilUserDeleted(t, userAccount.Name)
 		assert.NoError(t, err)
-		err = memberAwait.WaitUntilIdentityDeleted(t, identitypkg.NewIdentityNamingStandard(userAccount.Spec.UserID, "rhd").IdentityName())
-		assert.NoError(t, err)
-		// Verify the second identity
-		if originalSubIdentityName != "" {
-			err = memberAwait.WaitUntilIdentityDeleted(t, originalSubIdentityName)
-			assert.NoError(t, err)
-		}
+		VerifyIdentitiesDeleted(t, memberAwait, userAccount, identityProvider)
 	}
+}
 
+// verifyEmbeddedUserAccountStatus verifies that the given MasterUserRecord has the status of the given UserAccount, and returns its latest version
+func verifyEmbeddedUserAccountStatus(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility, mur *toolchainv1alpha1.MasterUserRecord, userAccount *toolchainv1alpha1.UserAccount) *toolchainv1alpha1.MasterUserRecord {
 	// Get member cluster to verify that it was used to provision user accounts
 	memberCluster, ok, err := hostAwait.GetToolchainCluster(t, cluster.Member, memberAwait.Namespace, nil)
 	require.NoError(t, err)
@@ -146,12 +150,14 @@ func VerifyUserRelatedResources(t *testing.T, awaitilities wait.Awaitilities, si
 		},
 		UserAccountStatus: userAccount.Status,
 	}
-	mur, err = hostAwait.WaitForMasterUserRecord(t, mur.Name,
+	updated, err := hostAwait.WaitForMasterUserRecord(t, mur.Name,
 		wait.UntilMasterUserRecordHasConditions(Provisioned(), ProvisionedNotificationCRCreated()),
 		wait.UntilMasterUserRecordHasUserAccountStatuses(expectedEmbeddedUaStatus))
 	assert.NoError(t, err)
-
-	return userSignup, mur
+	if updated == nil {
+		return mur
+	}
+	return updated
 }
 
 func VerifySpaceRelatedResources(t *testing.T, awaitilities wait.Awaitilities, userSignup *toolchainv1alpha1.UserSignup, spaceTierName string) {
@@ -181,20 +187,26 @@ func VerifySpaceRelatedResources(t *testing.T, awaitilities wait.Awaitilities, u
 		wait.UntilSpaceHasStatusTargetCluster(mur.Spec.UserAccounts[0].TargetCluster))
 	require.NoError(t, err)
 
-	VerifySpaceBinding(t, hostAwait, mur.Name, space.Name, "admin")
-
-	bindings, err := hostAwait.ListSpaceBindings(space.Name)
-	require.NoError(t, err)
-	memberAwait := GetMurTargetMember(t, awaitilities, mur)
-	// Verify provisioned NSTemplateSet
-	nsTemplateSet, err := memberAwait.WaitForNSTmplSet(t, space.Name,
-		wait.UntilNSTemplateSetHasTier(tier.Name),
-		wait.UntilNSTemplateSetHasSpaceRolesFromBindings(tier, bindings),
-	)
-	require.NoError(t, err)
-	tierChecks, err := tiers.NewChecksForTier(tier)
-	require.NoError(t, err)
-	tiers.VerifyNSTemplateSet(t, hostAwait, memberAwait, nsTemplateSet, tierChecks)
+	soft := NewSoftAssertions(t)
+	soft.Check("space binding", func(t *testing.T) {
+		VerifySpaceBinding(t, hostAwait, mur.Name, space.Name, "admin")
+	})
+
+	soft.Check("NSTemplateSet", func(t *testing.T) {
+		bindings, err := hostAwait.ListSpaceBindings(space.Name)
+		require.NoError(t, err)
+		memberAwait := GetMurTargetMember(t, awaitilities, mur)
+		// Verify provisioned NSTemplateSet
+		nsTemplateSet, err := memberAwait.WaitForNSTmplSet(t, space.Name,
+			wait.UntilNSTemplateSetHasTier(tier.Name),
+			wait.UntilNSTemplateSetHasSpaceRolesFromBindings(tier, bindings),
+		)
+		require.NoError(t, err)
+		tierChecks, err := tiers.NewChecksForTier(tier)
+		require.NoError(t, err)
+		tiers.VerifyNSTemplateSet(t, hostAwait, memberAwait, nsTemplateSet, tierChecks)
+	})
+	soft.Report()
 }
 
 func ExpectedUserAccount(userID string, originalSub string) toolchainv1alpha1.UserAccountSpec {
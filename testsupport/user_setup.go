@@ -9,8 +9,8 @@ import (
 	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	authsupport "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	"github.com/gofrs/uuid"
@@ -59,7 +59,7 @@ func NewUserSignup(namespace, username string, email string) *toolchainv1alpha1.
 				toolchainv1alpha1.UserSignupUserEmailAnnotationKey: email,
 			},
 			Labels: map[string]string{
-				toolchainv1alpha1.UserSignupUserEmailHashLabelKey: hash.EncodeString(email),
+				toolchainv1alpha1.UserSignupUserEmailHashLabelKey: hash.EmailHash(email),
 			},
 		},
 		Spec: toolchainv1alpha1.UserSignupSpec{
@@ -0,0 +1,88 @@
+package testsupport
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EmailMissingError the error returned by the authentication middleware of the registration service when the token has no `email` claim
+const EmailMissingError = "email missing"
+
+// SignupWithoutEmail calls the signup endpoint of the registration service with a token which has no `email` claim
+// (as the tokens issued for service accounts, or by some identity providers), and with the given other claims.
+// Returns the identity of the token along with the response.
+func SignupWithoutEmail(t *testing.T, hostAwait *wait.HostAwaitility, claims ...authsupport.Claim) (*commonauth.Identity, *RegistrationServiceResponse) {
+	identity := commonauth.NewIdentity()
+	token, err := authsupport.NewTokenFromIdentity(identity, append(claims, authsupport.WithoutEmail())...)
+	require.NoError(t, err)
+	return identity, NewRegistrationServiceClient(hostAwait).Invoke(t, http.MethodPost, "/api/v1/signup", token, "")
+}
+
+// VerifySignupRejectedWithoutEmail verifies that a signup with a token which has no `email` claim (and the given other claims)
+// is rejected by the registration service, and that no UserSignup is created for the identity of the token
+func VerifySignupRejectedWithoutEmail(t *testing.T, hostAwait *wait.HostAwaitility, claims ...authsupport.Claim) {
+	// when
+	identity, resp := SignupWithoutEmail(t, hostAwait, claims...)
+
+	// then
+	respErr := resp.RequireAuthError(t, http.StatusUnauthorized)
+	assert.Contains(t, respErr.Error, EmailMissingError)
+	err := hostAwait.WaitUntilGoneAndStaysGone(t, &toolchainv1alpha1.UserSignup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      identity.Username,
+			Namespace: hostAwait.Namespace,
+		},
+	}, 5*time.Second)
+	require.NoError(t, err)
+}
+
+// NewUserSignupWithoutEmail returns a new, approved UserSignup without the email annotation nor the email hash label,
+// as created directly in the host namespace for a user whose identity provider does not issue the `email` claim
+func NewUserSignupWithoutEmail(namespace, username string) *toolchainv1alpha1.UserSignup {
+	name := uuid.Must(uuid.NewV4()).String()
+	return &toolchainv1alpha1.UserSignup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: toolchainv1alpha1.UserSignupSpec{
+			Username: username,
+			Userid:   name,
+			States:   []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateApproved},
+		},
+	}
+}
+
+// VerifyUserSignupWithoutEmailNotProvisioned verifies that the given UserSignup, which has no email annotation,
+// is marked as incomplete by the host operator, and that no MasterUserRecord is created for it
+func VerifyUserSignupWithoutEmailNotProvisioned(t *testing.T, hostAwait *wait.HostAwaitility, userSignup *toolchainv1alpha1.UserSignup) *toolchainv1alpha1.UserSignup {
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name,
+		wait.UntilUserSignupContainsConditions(toolchainv1alpha1.Condition{
+			Type:    toolchainv1alpha1.UserSignupComplete,
+			Status:  corev1.ConditionFalse,
+			Reason:  toolchainv1alpha1.UserSignupMissingUserEmailAnnotationReason,
+			Message: "missing annotation at usersignup",
+		}))
+	require.NoError(t, err)
+	assert.Empty(t, userSignup.Status.CompliantUsername)
+	// the name of the MasterUserRecord would be the (compliant) username
+	err = hostAwait.WaitUntilGoneAndStaysGone(t, &toolchainv1alpha1.MasterUserRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      userSignup.Spec.Username,
+			Namespace: hostAwait.Namespace,
+		},
+	}, 5*time.Second)
+	require.NoError(t, err)
+	return userSignup
+}
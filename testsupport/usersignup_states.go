@@ -0,0 +1,162 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UserSignupStates the states of the UserSignups which are cross-checked by VerifyUserSignupStates
+var UserSignupStates = []string{
+	toolchainv1alpha1.UserSignupStateLabelValuePending,
+	toolchainv1alpha1.UserSignupStateLabelValueApproved,
+	toolchainv1alpha1.UserSignupStateLabelValueBanned,
+	toolchainv1alpha1.UserSignupStateLabelValueDeactivated,
+}
+
+// UserSignupStatesSnapshot the number of UserSignups per state, as reported by the state label and by the status of the UserSignups,
+// along with the aggregated counters of the ToolchainStatus and the Prometheus gauges of the host operator
+type UserSignupStatesSnapshot struct {
+	// ByLabel the number of UserSignups per value of their `toolchain.dev.openshift.com/state` label
+	ByLabel map[string]int
+	// ByStatus the number of UserSignups per state, as derived from their status conditions
+	ByStatus map[string]int
+	// Inconsistencies the UserSignups whose MasterUserRecord does not match their state (eg, a banned user who still has a MUR)
+	Inconsistencies []string
+	// MasterUserRecords the number of MasterUserRecords
+	MasterUserRecords int
+	// ToolchainStatusMasterUserRecords the sum of the `masterUserRecordsPerDomain` counters of the ToolchainStatus
+	ToolchainStatusMasterUserRecords int
+	// MasterUserRecordsGauge the sum of the `sandbox_master_user_records` gauges
+	MasterUserRecordsGauge int
+	// ToolchainStatusUserSignups the sum of the `userSignupsPerActivationAndDomain` counters of the ToolchainStatus
+	ToolchainStatusUserSignups int
+	// UserSignupsGauge the sum of the `sandbox_users_per_activations_and_domain` gauges
+	UserSignupsGauge int
+}
+
+// mismatches returns the mismatches between the states and the counters of the snapshot
+func (s UserSignupStatesSnapshot) mismatches() []string {
+	result := append([]string{}, s.Inconsistencies...)
+	for _, state := range UserSignupStates {
+		if s.ByLabel[state] != s.ByStatus[state] {
+			result = append(result, fmt.Sprintf("%d UserSignups with the '%s' state label but %d with the '%s' state in their status", s.ByLabel[state], state, s.ByStatus[state], state))
+		}
+	}
+	if s.MasterUserRecords != s.ToolchainStatusMasterUserRecords || s.MasterUserRecords != s.MasterUserRecordsGauge {
+		result = append(result, fmt.Sprintf("%d MasterUserRecords but %d in the ToolchainStatus and %d in the '%s' gauges", s.MasterUserRecords, s.ToolchainStatusMasterUserRecords, s.MasterUserRecordsGauge, MasterUserRecordsPerDomainMetric))
+	}
+	if s.ToolchainStatusUserSignups != s.UserSignupsGauge {
+		result = append(result, fmt.Sprintf("%d UserSignups per activations and domain in the ToolchainStatus but %d in the '%s' gauges", s.ToolchainStatusUserSignups, s.UserSignupsGauge, UsersPerActivationsAndDomainMetric))
+	}
+	return result
+}
+
+// VerifyUserSignupStates verifies that, for every state (pending, approved, banned, deactivated), the number of UserSignups
+// with the state label matches the number of UserSignups in this state according to their status, that only the approved
+// UserSignups have a MasterUserRecord, and that the number of MasterUserRecords and the UserSignups per activations
+// and domain match the counters of the ToolchainStatus and the Prometheus gauges of the host operator.
+// Since the counters and the gauges are updated asynchronously, the verification is retried until the timeout.
+// Note: the host operator does not expose a gauge per UserSignup state (only the `sandbox_user_signups_*_total` counters,
+// which are not decremented), hence the states are cross-checked via the MasterUserRecords.
+// It is meant to be called after the major state transitions, in tests which do not run in parallel with other tests.
+func VerifyUserSignupStates(t *testing.T, hostAwait *wait.HostAwaitility) UserSignupStatesSnapshot {
+	t.Log("verifying the UserSignup states against the ToolchainStatus counters and the metrics")
+	var snapshot UserSignupStatesSnapshot
+	err := hostAwait.Poll(func() (done bool, err error) {
+		snapshot, err = takeUserSignupStatesSnapshot(t, hostAwait)
+		if err != nil {
+			return false, err
+		}
+		return len(snapshot.mismatches()) == 0, nil
+	})
+	require.NoError(t, err, "UserSignup states do not match the counters and the metrics:\n%s", strings.Join(snapshot.mismatches(), "\n"))
+	return snapshot
+}
+
+func takeUserSignupStatesSnapshot(t *testing.T, hostAwait *wait.HostAwaitility) (UserSignupStatesSnapshot, error) {
+	snapshot := UserSignupStatesSnapshot{
+		ByLabel:  map[string]int{},
+		ByStatus: map[string]int{},
+	}
+	userSignups := &toolchainv1alpha1.UserSignupList{}
+	if err := hostAwait.Client.List(context.TODO(), userSignups, client.InNamespace(hostAwait.Namespace)); err != nil {
+		return snapshot, err
+	}
+	murs := &toolchainv1alpha1.MasterUserRecordList{}
+	if err := hostAwait.Client.List(context.TODO(), murs, client.InNamespace(hostAwait.Namespace)); err != nil {
+		return snapshot, err
+	}
+	murOwners := map[string]bool{}
+	for _, mur := range murs.Items {
+		murOwners[mur.Labels[toolchainv1alpha1.MasterUserRecordOwnerLabelKey]] = true
+	}
+	snapshot.MasterUserRecords = len(murs.Items)
+
+	for _, userSignup := range userSignups.Items {
+		snapshot.ByLabel[userSignup.Labels[toolchainv1alpha1.UserSignupStateLabelKey]]++
+		state := userSignupStateFromStatus(userSignup)
+		snapshot.ByStatus[state]++
+		switch {
+		case state == "":
+			// the UserSignup is being provisioned (or is not ready yet): the MUR may or may not exist
+		case state == toolchainv1alpha1.UserSignupStateLabelValueApproved && !murOwners[userSignup.Name]:
+			snapshot.Inconsistencies = append(snapshot.Inconsistencies, fmt.Sprintf("UserSignup '%s' is approved but has no MasterUserRecord", userSignup.Name))
+		case state != toolchainv1alpha1.UserSignupStateLabelValueApproved && murOwners[userSignup.Name]:
+			snapshot.Inconsistencies = append(snapshot.Inconsistencies, fmt.Sprintf("UserSignup '%s' is %s but has a MasterUserRecord", userSignup.Name, state))
+		}
+	}
+	sort.Strings(snapshot.Inconsistencies)
+
+	toolchainStatus := &toolchainv1alpha1.ToolchainStatus{}
+	if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: "toolchain-status"}, toolchainStatus); err != nil {
+		return snapshot, err
+	}
+	for _, count := range toolchainStatus.Status.Metrics[toolchainv1alpha1.MasterUserRecordsPerDomainMetricKey] {
+		snapshot.ToolchainStatusMasterUserRecords += count
+	}
+	for _, count := range toolchainStatus.Status.Metrics[toolchainv1alpha1.UserSignupsPerActivationAndDomainMetricKey] {
+		snapshot.ToolchainStatusUserSignups += count
+	}
+	// same activations and domains as in the MetricsAssertionHelper
+	for _, domain := range []string{"internal", "external"} {
+		snapshot.MasterUserRecordsGauge += int(hostAwait.GetMetricValueOrZero(t, MasterUserRecordsPerDomainMetric, "domain", domain))
+		for i := 1; i <= 10; i++ {
+			snapshot.UserSignupsGauge += int(hostAwait.GetMetricValueOrZero(t, UsersPerActivationsAndDomainMetric, "activations", strconv.Itoa(i), "domain", domain))
+		}
+	}
+	return snapshot, nil
+}
+
+// userSignupStateFromStatus returns the state of the given UserSignup according to its status conditions,
+// or an empty string if the UserSignup is in none of the verified states (eg, it is being provisioned)
+func userSignupStateFromStatus(userSignup toolchainv1alpha1.UserSignup) string {
+	complete, _ := condition.FindConditionByType(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupComplete)
+	switch complete.Reason {
+	case toolchainv1alpha1.UserSignupUserBannedReason:
+		return toolchainv1alpha1.UserSignupStateLabelValueBanned
+	case toolchainv1alpha1.UserSignupUserDeactivatedReason:
+		return toolchainv1alpha1.UserSignupStateLabelValueDeactivated
+	}
+	approved, found := condition.FindConditionByType(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupApproved)
+	if !found {
+		return ""
+	}
+	if approved.Reason == toolchainv1alpha1.UserSignupPendingApprovalReason {
+		return toolchainv1alpha1.UserSignupStateLabelValuePending
+	}
+	if condition.IsTrue(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupApproved) && condition.IsTrue(userSignup.Status.Conditions, toolchainv1alpha1.UserSignupComplete) {
+		return toolchainv1alpha1.UserSignupStateLabelValueApproved
+	}
+	return ""
+}
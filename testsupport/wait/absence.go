@@ -0,0 +1,83 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrRecreated the error returned by WaitUntilGoneAndStaysGone when the object was re-created during the stability window
+type ErrRecreated struct {
+	// Kind the kind of the object (eg, `Namespace`)
+	Kind string
+	// Name the name of the object
+	Name string
+	// After the duration after which the object was found again, since it was observed as deleted
+	After time.Duration
+	// UID the UID of the re-created object
+	UID types.UID
+}
+
+var _ error = &ErrRecreated{}
+
+func (e *ErrRecreated) Error() string {
+	return fmt.Sprintf("%s '%s' was re-created (with UID '%s') %s after it was deleted", e.Kind, e.Name, e.UID, e.After)
+}
+
+// IsRecreated returns true if the given error is an ErrRecreated
+func IsRecreated(err error) bool {
+	recreatedErr := &ErrRecreated{}
+	return errors.As(err, &recreatedErr)
+}
+
+// WaitUntilGoneAndStaysGone waits until the given object is deleted (ie, not found), and then verifies that it is not
+// re-created during the given stability window (eg, by a controller which erroneously re-creates the resources it
+// should have deleted). The object is looked up by the namespace and name of the given object, and with its type.
+// Returns an ErrRecreated if the object was found again during the window.
+// Note: an object which would be re-created and deleted again between two checks would not be noticed.
+func (a *Awaitility) WaitUntilGoneAndStaysGone(t *testing.T, obj client.Object, window time.Duration) error {
+	kind := reflect.TypeOf(obj).Elem().Name()
+	key := client.ObjectKeyFromObject(obj)
+	t.Logf("waiting until %s '%s' is deleted and stays deleted for %s", kind, key, window)
+	get := func() (bool, error) {
+		actual := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+		if err := a.Client.Get(context.TODO(), key, actual); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		obj = actual
+		return true, nil
+	}
+	if err := a.poll(func() (done bool, err error) {
+		found, err := get()
+		return !found, err
+	}); err != nil {
+		return err
+	}
+	gone := time.Now()
+	for time.Since(gone) < window {
+		time.Sleep(a.RetryInterval)
+		found, err := get()
+		if err != nil {
+			return err
+		}
+		if found {
+			return &ErrRecreated{
+				Kind:  kind,
+				Name:  key.String(),
+				After: time.Since(gone).Round(time.Millisecond),
+				UID:   obj.GetUID(),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestWaitUntilGoneAndStaysGone(t *testing.T) {
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config",
+				Namespace: commontest.HostOperatorNs,
+			},
+		}
+	}
+	newAwaitility := func(t *testing.T, initObjs ...*corev1.ConfigMap) *wait.Awaitility {
+		cl := commontest.NewFakeClient(t)
+		for _, obj := range initObjs {
+			require.NoError(t, cl.Create(context.TODO(), obj))
+		}
+		return &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: 5 * time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}
+	}
+
+	t.Run("gone and stays gone", func(t *testing.T) {
+		// given
+		await := newAwaitility(t)
+
+		// when
+		start := time.Now()
+		err := await.WaitUntilGoneAndStaysGone(t, newConfigMap(), 50*time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("re-created during the window", func(t *testing.T) {
+		// given
+		await := newAwaitility(t)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			_ = await.Client.Create(context.TODO(), newConfigMap())
+		}()
+
+		// when
+		err := await.WaitUntilGoneAndStaysGone(t, newConfigMap(), time.Second)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, wait.IsRecreated(err))
+		assert.Contains(t, err.Error(), "ConfigMap 'toolchain-host-operator/config' was re-created")
+	})
+
+	t.Run("never deleted", func(t *testing.T) {
+		// given
+		await := newAwaitility(t, newConfigMap())
+
+		// when
+		err := await.WaitUntilGoneAndStaysGone(t, newConfigMap(), 50*time.Millisecond)
+
+		// then
+		require.Equal(t, k8swait.ErrWaitTimeout, err)
+		assert.False(t, wait.IsRecreated(err))
+	})
+}
@@ -0,0 +1,101 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AccessReview a review of the access of a subject to a resource, whose decision is made by the API server
+type AccessReview struct {
+	// Subject the subject of the review, for the logs and the error messages
+	Subject   string
+	Verb      string
+	Resource  schema.GroupVersionResource
+	Namespace string
+	// review returns the decision of the API server (and its reason)
+	review func() (allowed bool, reason string, err error)
+}
+
+func (r AccessReview) String() string {
+	return fmt.Sprintf("%s to '%s' resource '%s' in namespace '%s'", r.Subject, r.Verb, r.Resource.GroupResource().String(), r.Namespace)
+}
+
+// SelfSubjectAccessReview returns a review of the access of the user of the given client to the given resource in the given
+// namespace (or at the cluster scope if the namespace is empty), using a SelfSubjectAccessReview
+func SelfSubjectAccessReview(userClient client.Client, verb string, gvr schema.GroupVersionResource, namespace string) AccessReview {
+	return AccessReview{
+		Subject:   "current user",
+		Verb:      verb,
+		Resource:  gvr,
+		Namespace: namespace,
+		review: func() (bool, string, error) {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: resourceAttributes(verb, gvr, namespace),
+				},
+			}
+			if err := userClient.Create(context.TODO(), review); err != nil {
+				return false, "", err
+			}
+			return review.Status.Allowed, review.Status.Reason, nil
+		},
+	}
+}
+
+// SubjectAccessReview returns a review of the access of the given user to the given resource in the given namespace,
+// using a SubjectAccessReview created with the given (admin) client
+func SubjectAccessReview(cl client.Client, username, verb string, gvr schema.GroupVersionResource, namespace string) AccessReview {
+	return AccessReview{
+		Subject:   fmt.Sprintf("user '%s'", username),
+		Verb:      verb,
+		Resource:  gvr,
+		Namespace: namespace,
+		review: func() (bool, string, error) {
+			review := &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User:               username,
+					ResourceAttributes: resourceAttributes(verb, gvr, namespace),
+				},
+			}
+			if err := cl.Create(context.TODO(), review); err != nil {
+				return false, "", err
+			}
+			return review.Status.Allowed, review.Status.Reason, nil
+		},
+	}
+}
+
+func resourceAttributes(verb string, gvr schema.GroupVersionResource, namespace string) *authorizationv1.ResourceAttributes {
+	return &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+	}
+}
+
+// WaitForAccessDecision waits until the given review returns the expected decision. Since the roles are propagated (and revoked)
+// asynchronously, the review is retried until the decision matches or the timeout is reached, in which case the returned error
+// contains the reason of the last decision.
+func (a *Awaitility) WaitForAccessDecision(t *testing.T, review AccessReview, allowed bool) error {
+	t.Logf("waiting until %s is allowed=%t", review, allowed)
+	var reason string
+	err := a.poll(func() (done bool, err error) {
+		var decision bool
+		decision, reason, err = review.review()
+		if err != nil {
+			return false, err
+		}
+		return decision == allowed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("expected %s to be allowed=%t (reason: '%s'): %w", review, allowed, reason, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWaitForAccessDecision(t *testing.T) {
+	configMaps := corev1.SchemeGroupVersion.WithResource("configmaps")
+
+	// newAwaitility returns an awaitility whose client allows the access after the given number of SubjectAccessReviews,
+	// and the specs of the reviews it received
+	newAwaitility := func(t *testing.T, allowedAfter int) (*wait.Awaitility, *[]authorizationv1.SubjectAccessReviewSpec) {
+		var specs []authorizationv1.SubjectAccessReviewSpec
+		cl := commontest.NewFakeClient(t)
+		cl.MockCreate = func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			require.True(t, ok)
+			specs = append(specs, review.Spec)
+			review.Status.Allowed = len(specs) > allowedAfter
+			review.Status.Reason = "reviewed"
+			return nil
+		}
+		return &wait.Awaitility{
+			Client:        cl,
+			RetryInterval: time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}, &specs
+	}
+
+	t.Run("allowed after a few reviews", func(t *testing.T) {
+		// given
+		await, specs := newAwaitility(t, 2)
+
+		// when
+		err := await.WaitForAccessDecision(t, wait.SubjectAccessReview(await.Client, "johnsmith", "create", configMaps, "johnsmith-dev"), true)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, *specs, 3)
+		assert.Equal(t, "johnsmith", (*specs)[0].User)
+		assert.Equal(t, &authorizationv1.ResourceAttributes{
+			Namespace: "johnsmith-dev",
+			Verb:      "create",
+			Version:   "v1",
+			Resource:  "configmaps",
+		}, (*specs)[0].ResourceAttributes)
+	})
+
+	t.Run("never denied", func(t *testing.T) {
+		// given
+		await, _ := newAwaitility(t, 0)
+
+		// when
+		err := await.WaitForAccessDecision(t, wait.SubjectAccessReview(await.Client, "johnsmith", "list", configMaps, "toolchain-member-operator"), false)
+
+		// then
+		require.EqualError(t, err, "expected user 'johnsmith' to 'list' resource 'configmaps' in namespace 'toolchain-member-operator' to be allowed=false (reason: 'reviewed'): timed out waiting for the condition")
+	})
+
+	t.Run("review fails", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t)
+		cl.MockCreate = func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+			return assert.AnError
+		}
+		await := &wait.Awaitility{
+			Client:        cl,
+			RetryInterval: time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}
+
+		// when
+		err := await.WaitForAccessDecision(t, wait.SelfSubjectAccessReview(cl, "get", configMaps, ""), true)
+
+		// then
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,336 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ValidatingAdmissionPolicyKind the kind of the ValidatingAdmissionPolicy resources
+	ValidatingAdmissionPolicyKind = "ValidatingAdmissionPolicy"
+	// ValidatingAdmissionPolicyBindingKind the kind of the ValidatingAdmissionPolicyBinding resources
+	ValidatingAdmissionPolicyBindingKind = "ValidatingAdmissionPolicyBinding"
+
+	// ValidationActionDeny the validation action of a binding which rejects the requests that fail the validations of the policy
+	ValidationActionDeny = "Deny"
+	// ValidationActionWarn the validation action of a binding which returns a warning to the client for the requests that fail the validations of the policy
+	ValidationActionWarn = "Warn"
+	// ValidationActionAudit the validation action of a binding which adds an annotation in the audit event of the requests that fail the validations of the policy
+	ValidationActionAudit = "Audit"
+)
+
+// ValidatingAdmissionPolicyVersions the versions of the `admissionregistration.k8s.io` API group in which the ValidatingAdmissionPolicy
+// resources may be served, by order of preference. The client-go version used in this repository does not provide the corresponding
+// types, hence the resources are handled as unstructured objects.
+var ValidatingAdmissionPolicyVersions = []string{"v1", "v1beta1", "v1alpha1"}
+
+// ValidatingAdmissionPolicyGroupVersion returns the group/version in which the ValidatingAdmissionPolicy resources are served
+// by the API server of the cluster, and `false` if they are not served at all
+func (a *Awaitility) ValidatingAdmissionPolicyGroupVersion() (schema.GroupVersion, bool, error) {
+	for _, version := range ValidatingAdmissionPolicyVersions {
+		gv := schema.GroupVersion{Group: "admissionregistration.k8s.io", Version: version}
+		supported, err := a.SupportsAPI(gv.WithKind(ValidatingAdmissionPolicyKind))
+		if err != nil || supported {
+			return gv, supported, err
+		}
+	}
+	return schema.GroupVersion{}, false, nil
+}
+
+func (a *Awaitility) requireValidatingAdmissionPolicyGroupVersion() (schema.GroupVersion, error) {
+	gv, supported, err := a.ValidatingAdmissionPolicyGroupVersion()
+	if err == nil && !supported {
+		err = fmt.Errorf("ValidatingAdmissionPolicies are not supported in the %s cluster", a.Type)
+	}
+	return gv, err
+}
+
+// ValidatingAdmissionPolicyWaitCriterion a struct to compare with a given ValidatingAdmissionPolicy
+type ValidatingAdmissionPolicyWaitCriterion struct {
+	Match func(*unstructured.Unstructured) bool
+	Diff  func(*unstructured.Unstructured) string
+}
+
+func matchValidatingAdmissionPolicyWaitCriterion(actual *unstructured.Unstructured, criteria ...ValidatingAdmissionPolicyWaitCriterion) bool {
+	for _, c := range criteria {
+		if !c.Match(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Awaitility) printValidatingAdmissionPolicyWaitCriterionDiffs(t *testing.T, actual *unstructured.Unstructured, criteria ...ValidatingAdmissionPolicyWaitCriterion) {
+	buf := &strings.Builder{}
+	if actual == nil {
+		buf.WriteString("failed to find ValidatingAdmissionPolicy\n")
+	} else {
+		buf.WriteString("failed to find ValidatingAdmissionPolicy with matching criteria:\n")
+		buf.WriteString("----\n")
+		buf.WriteString("actual:\n")
+		y, _ := StringifyObject(actual)
+		buf.Write(y)
+		buf.WriteString("\n----\n")
+		buf.WriteString("diffs:\n")
+		for _, c := range criteria {
+			if !c.Match(actual) {
+				buf.WriteString(c.Diff(actual))
+				buf.WriteString("\n")
+			}
+		}
+	}
+	t.Log(buf.String())
+}
+
+// UntilValidatingAdmissionPolicyHasParamKind returns a `ValidatingAdmissionPolicyWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicy expects parameters of the given apiVersion and kind
+func UntilValidatingAdmissionPolicyHasParamKind(apiVersion, kind string) ValidatingAdmissionPolicyWaitCriterion {
+	return ValidatingAdmissionPolicyWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			actualAPIVersion, _, _ := unstructured.NestedString(actual.Object, "spec", "paramKind", "apiVersion")
+			actualKind, _, _ := unstructured.NestedString(actual.Object, "spec", "paramKind", "kind")
+			return actualAPIVersion == apiVersion && actualKind == kind
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			paramKind, _, _ := unstructured.NestedMap(actual.Object, "spec", "paramKind")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicy to have paramKind '%s/%s'. Actual: %v", apiVersion, kind, paramKind)
+		},
+	}
+}
+
+// UntilValidatingAdmissionPolicyHasMatchCondition returns a `ValidatingAdmissionPolicyWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicy has a match condition with the given name and CEL expression
+func UntilValidatingAdmissionPolicyHasMatchCondition(name, expression string) ValidatingAdmissionPolicyWaitCriterion {
+	return ValidatingAdmissionPolicyWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			return containsEntry(actual, []string{"spec", "matchConditions"}, map[string]string{
+				"name":       name,
+				"expression": expression,
+			})
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			conditions, _, _ := unstructured.NestedSlice(actual.Object, "spec", "matchConditions")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicy to have match condition '%s' with expression '%s'. Actual: %v", name, expression, conditions)
+		},
+	}
+}
+
+// UntilValidatingAdmissionPolicyHasValidation returns a `ValidatingAdmissionPolicyWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicy has a validation with the given CEL expression and message
+func UntilValidatingAdmissionPolicyHasValidation(expression, message string) ValidatingAdmissionPolicyWaitCriterion {
+	return ValidatingAdmissionPolicyWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			return containsEntry(actual, []string{"spec", "validations"}, map[string]string{
+				"expression": expression,
+				"message":    message,
+			})
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			validations, _, _ := unstructured.NestedSlice(actual.Object, "spec", "validations")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicy to have validation with expression '%s' and message '%s'. Actual: %v", expression, message, validations)
+		},
+	}
+}
+
+// UntilValidatingAdmissionPolicyHasNoTypeWarnings returns a `ValidatingAdmissionPolicyWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicy was type-checked by the API server (ie, its status matches its generation) and that there was no warning
+func UntilValidatingAdmissionPolicyHasNoTypeWarnings() ValidatingAdmissionPolicyWaitCriterion {
+	return ValidatingAdmissionPolicyWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			observedGeneration, found, _ := unstructured.NestedInt64(actual.Object, "status", "observedGeneration")
+			if !found || observedGeneration != actual.GetGeneration() {
+				return false
+			}
+			warnings, _, _ := unstructured.NestedSlice(actual.Object, "status", "typeChecking", "expressionWarnings")
+			return len(warnings) == 0
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			status, _, _ := unstructured.NestedMap(actual.Object, "status")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicy with generation %d to be type-checked without warning. Actual status: %v", actual.GetGeneration(), status)
+		},
+	}
+}
+
+// WaitForValidatingAdmissionPolicy waits until there is a ValidatingAdmissionPolicy available with the given name and the optional criteria
+func (a *Awaitility) WaitForValidatingAdmissionPolicy(t *testing.T, name string, criteria ...ValidatingAdmissionPolicyWaitCriterion) (*unstructured.Unstructured, error) {
+	t.Logf("waiting for ValidatingAdmissionPolicy '%s' to match criteria", name)
+	gv, err := a.requireValidatingAdmissionPolicyGroupVersion()
+	if err != nil {
+		return nil, err
+	}
+	var policy *unstructured.Unstructured
+	err = a.poll(func() (done bool, err error) {
+		obj, err := a.getClusterScopedUnstructured(gv.WithKind(ValidatingAdmissionPolicyKind), name)
+		if err != nil || obj == nil {
+			return false, err
+		}
+		policy = obj
+		return matchValidatingAdmissionPolicyWaitCriterion(obj, criteria...), nil
+	})
+	if err != nil {
+		a.printValidatingAdmissionPolicyWaitCriterionDiffs(t, policy, criteria...)
+	}
+	return policy, err
+}
+
+// ValidatingAdmissionPolicyBindingWaitCriterion a struct to compare with a given ValidatingAdmissionPolicyBinding
+type ValidatingAdmissionPolicyBindingWaitCriterion struct {
+	Match func(*unstructured.Unstructured) bool
+	Diff  func(*unstructured.Unstructured) string
+}
+
+func matchValidatingAdmissionPolicyBindingWaitCriterion(actual *unstructured.Unstructured, criteria ...ValidatingAdmissionPolicyBindingWaitCriterion) bool {
+	for _, c := range criteria {
+		if !c.Match(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Awaitility) printValidatingAdmissionPolicyBindingWaitCriterionDiffs(t *testing.T, actual *unstructured.Unstructured, criteria ...ValidatingAdmissionPolicyBindingWaitCriterion) {
+	buf := &strings.Builder{}
+	if actual == nil {
+		buf.WriteString("failed to find ValidatingAdmissionPolicyBinding\n")
+	} else {
+		buf.WriteString("failed to find ValidatingAdmissionPolicyBinding with matching criteria:\n")
+		buf.WriteString("----\n")
+		buf.WriteString("actual:\n")
+		y, _ := StringifyObject(actual)
+		buf.Write(y)
+		buf.WriteString("\n----\n")
+		buf.WriteString("diffs:\n")
+		for _, c := range criteria {
+			if !c.Match(actual) {
+				buf.WriteString(c.Diff(actual))
+				buf.WriteString("\n")
+			}
+		}
+	}
+	t.Log(buf.String())
+}
+
+// UntilValidatingAdmissionPolicyBindingHasPolicy returns a `ValidatingAdmissionPolicyBindingWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicyBinding refers to the policy with the given name
+func UntilValidatingAdmissionPolicyBindingHasPolicy(policyName string) ValidatingAdmissionPolicyBindingWaitCriterion {
+	return ValidatingAdmissionPolicyBindingWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			actualPolicyName, _, _ := unstructured.NestedString(actual.Object, "spec", "policyName")
+			return actualPolicyName == policyName
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			actualPolicyName, _, _ := unstructured.NestedString(actual.Object, "spec", "policyName")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicyBinding to refer to policy '%s'. Actual: '%s'", policyName, actualPolicyName)
+		},
+	}
+}
+
+// UntilValidatingAdmissionPolicyBindingHasParamRef returns a `ValidatingAdmissionPolicyBindingWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicyBinding refers to the parameter resource with the given name in the given namespace
+// (the namespace is empty for cluster-scoped parameter resources)
+func UntilValidatingAdmissionPolicyBindingHasParamRef(name, namespace string) ValidatingAdmissionPolicyBindingWaitCriterion {
+	return ValidatingAdmissionPolicyBindingWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			actualName, _, _ := unstructured.NestedString(actual.Object, "spec", "paramRef", "name")
+			actualNamespace, _, _ := unstructured.NestedString(actual.Object, "spec", "paramRef", "namespace")
+			return actualName == name && actualNamespace == namespace
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			paramRef, _, _ := unstructured.NestedMap(actual.Object, "spec", "paramRef")
+			return fmt.Sprintf("expected ValidatingAdmissionPolicyBinding to refer to param '%s' in namespace '%s'. Actual: %v", name, namespace, paramRef)
+		},
+	}
+}
+
+// UntilValidatingAdmissionPolicyBindingHasValidationActions returns a `ValidatingAdmissionPolicyBindingWaitCriterion` which checks that the given
+// ValidatingAdmissionPolicyBinding has exactly the given validation actions (in any order), eg. `Deny` when the policy is enforced,
+// or `Audit` and `Warn` when it is only audited. A binding without validation actions (which were introduced after the `v1alpha1` version)
+// is considered as enforced.
+func UntilValidatingAdmissionPolicyBindingHasValidationActions(actions ...string) ValidatingAdmissionPolicyBindingWaitCriterion {
+	return ValidatingAdmissionPolicyBindingWaitCriterion{
+		Match: func(actual *unstructured.Unstructured) bool {
+			return reflect.DeepEqual(toSet(validationActions(actual)), toSet(actions))
+		},
+		Diff: func(actual *unstructured.Unstructured) string {
+			return fmt.Sprintf("expected ValidatingAdmissionPolicyBinding to have validation actions %v. Actual: %v", actions, validationActions(actual))
+		},
+	}
+}
+
+func validationActions(binding *unstructured.Unstructured) []string {
+	actions, found, _ := unstructured.NestedStringSlice(binding.Object, "spec", "validationActions")
+	if !found || len(actions) == 0 {
+		return []string{ValidationActionDeny}
+	}
+	return actions
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// WaitForValidatingAdmissionPolicyBinding waits until there is a ValidatingAdmissionPolicyBinding available with the given name and the optional criteria
+func (a *Awaitility) WaitForValidatingAdmissionPolicyBinding(t *testing.T, name string, criteria ...ValidatingAdmissionPolicyBindingWaitCriterion) (*unstructured.Unstructured, error) {
+	t.Logf("waiting for ValidatingAdmissionPolicyBinding '%s' to match criteria", name)
+	gv, err := a.requireValidatingAdmissionPolicyGroupVersion()
+	if err != nil {
+		return nil, err
+	}
+	var binding *unstructured.Unstructured
+	err = a.poll(func() (done bool, err error) {
+		obj, err := a.getClusterScopedUnstructured(gv.WithKind(ValidatingAdmissionPolicyBindingKind), name)
+		if err != nil || obj == nil {
+			return false, err
+		}
+		binding = obj
+		return matchValidatingAdmissionPolicyBindingWaitCriterion(obj, criteria...), nil
+	})
+	if err != nil {
+		a.printValidatingAdmissionPolicyBindingWaitCriterionDiffs(t, binding, criteria...)
+	}
+	return binding, err
+}
+
+// getClusterScopedUnstructured returns the cluster-scoped resource of the given kind with the given name, or `nil` if it does not exist
+func (a *Awaitility) getClusterScopedUnstructured(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+// containsEntry returns `true` if the slice at the given path of the object contains an entry with (at least) the given string fields
+func containsEntry(obj *unstructured.Unstructured, path []string, fields map[string]string) bool {
+	entries, _, _ := unstructured.NestedSlice(obj.Object, path...)
+entries:
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, v := range fields {
+			if actual, _ := entry[k].(string); actual != v {
+				continue entries
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package wait_test
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidatingAdmissionPolicyWaitCriteria(t *testing.T) {
+	// given
+	policy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "admissionregistration.k8s.io/v1beta1",
+			"kind":       "ValidatingAdmissionPolicy",
+			"metadata": map[string]interface{}{
+				"name":       "sandbox-rolebindings",
+				"generation": int64(2),
+			},
+			"spec": map[string]interface{}{
+				"paramKind": map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+				},
+				"matchConditions": []interface{}{
+					map[string]interface{}{
+						"name":       "exclude-admins",
+						"expression": "!('system:masters' in request.userInfo.groups)",
+					},
+				},
+				"validations": []interface{}{
+					map[string]interface{}{
+						"expression": "!object.subjects.exists(s, s.name == 'system:authenticated')",
+						"message":    "this is a Dev Sandbox enforced restriction",
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"observedGeneration": int64(2),
+			},
+		},
+	}
+
+	t.Run("param kind", func(t *testing.T) {
+		assert.True(t, wait.UntilValidatingAdmissionPolicyHasParamKind("v1", "ConfigMap").Match(policy))
+		c := wait.UntilValidatingAdmissionPolicyHasParamKind("v1", "Secret")
+		assert.False(t, c.Match(policy))
+		assert.Contains(t, c.Diff(policy), "expected ValidatingAdmissionPolicy to have paramKind 'v1/Secret'")
+	})
+
+	t.Run("match condition", func(t *testing.T) {
+		assert.True(t, wait.UntilValidatingAdmissionPolicyHasMatchCondition("exclude-admins", "!('system:masters' in request.userInfo.groups)").Match(policy))
+		assert.False(t, wait.UntilValidatingAdmissionPolicyHasMatchCondition("exclude-admins", "true").Match(policy))
+		assert.False(t, wait.UntilValidatingAdmissionPolicyHasMatchCondition("other", "!('system:masters' in request.userInfo.groups)").Match(policy))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		assert.True(t, wait.UntilValidatingAdmissionPolicyHasValidation("!object.subjects.exists(s, s.name == 'system:authenticated')", "this is a Dev Sandbox enforced restriction").Match(policy))
+		assert.False(t, wait.UntilValidatingAdmissionPolicyHasValidation("!object.subjects.exists(s, s.name == 'system:authenticated')", "other message").Match(policy))
+	})
+
+	t.Run("type checking", func(t *testing.T) {
+		assert.True(t, wait.UntilValidatingAdmissionPolicyHasNoTypeWarnings().Match(policy))
+
+		t.Run("outdated status", func(t *testing.T) {
+			// given
+			outdated := policy.DeepCopy()
+			outdated.SetGeneration(3)
+
+			// then
+			assert.False(t, wait.UntilValidatingAdmissionPolicyHasNoTypeWarnings().Match(outdated))
+		})
+
+		t.Run("with warnings", func(t *testing.T) {
+			// given
+			withWarnings := policy.DeepCopy()
+			err := unstructured.SetNestedSlice(withWarnings.Object, []interface{}{
+				map[string]interface{}{
+					"fieldRef": "spec.validations[0].expression",
+					"warning":  "no such key: subjects",
+				},
+			}, "status", "typeChecking", "expressionWarnings")
+			assert.NoError(t, err)
+
+			// then
+			assert.False(t, wait.UntilValidatingAdmissionPolicyHasNoTypeWarnings().Match(withWarnings))
+		})
+	})
+}
+
+func TestValidatingAdmissionPolicyBindingWaitCriteria(t *testing.T) {
+	// given
+	newBinding := func(actions ...interface{}) *unstructured.Unstructured {
+		spec := map[string]interface{}{
+			"policyName": "sandbox-rolebindings",
+			"paramRef": map[string]interface{}{
+				"name":      "sandbox-rolebindings-params",
+				"namespace": "toolchain-member-operator",
+			},
+		}
+		if len(actions) > 0 {
+			spec["validationActions"] = actions
+		}
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "admissionregistration.k8s.io/v1beta1",
+				"kind":       "ValidatingAdmissionPolicyBinding",
+				"metadata": map[string]interface{}{
+					"name": "sandbox-rolebindings",
+				},
+				"spec": spec,
+			},
+		}
+	}
+
+	t.Run("policy and param", func(t *testing.T) {
+		binding := newBinding()
+		assert.True(t, wait.UntilValidatingAdmissionPolicyBindingHasPolicy("sandbox-rolebindings").Match(binding))
+		assert.False(t, wait.UntilValidatingAdmissionPolicyBindingHasPolicy("other").Match(binding))
+		assert.True(t, wait.UntilValidatingAdmissionPolicyBindingHasParamRef("sandbox-rolebindings-params", "toolchain-member-operator").Match(binding))
+		assert.False(t, wait.UntilValidatingAdmissionPolicyBindingHasParamRef("sandbox-rolebindings-params", "").Match(binding))
+	})
+
+	t.Run("validation actions", func(t *testing.T) {
+		t.Run("enforced", func(t *testing.T) {
+			binding := newBinding(wait.ValidationActionDeny)
+			assert.True(t, wait.UntilValidatingAdmissionPolicyBindingHasValidationActions(wait.ValidationActionDeny).Match(binding))
+			assert.False(t, wait.UntilValidatingAdmissionPolicyBindingHasValidationActions(wait.ValidationActionAudit).Match(binding))
+		})
+
+		t.Run("audited", func(t *testing.T) {
+			binding := newBinding(wait.ValidationActionWarn, wait.ValidationActionAudit)
+			assert.True(t, wait.UntilValidatingAdmissionPolicyBindingHasValidationActions(wait.ValidationActionAudit, wait.ValidationActionWarn).Match(binding))
+			c := wait.UntilValidatingAdmissionPolicyBindingHasValidationActions(wait.ValidationActionDeny)
+			assert.False(t, c.Match(binding))
+			assert.Equal(t, "expected ValidatingAdmissionPolicyBinding to have validation actions [Deny]. Actual: [Warn Audit]", c.Diff(binding))
+		})
+
+		t.Run("enforced by default", func(t *testing.T) {
+			binding := newBinding()
+			assert.True(t, wait.UntilValidatingAdmissionPolicyBindingHasValidationActions(wait.ValidationActionDeny).Match(binding))
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package wait
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager the name of the field manager used when applying resources with server-side apply
+const FieldManager = "toolchain-e2e"
+
+// Apply creates or patches the given object using server-side apply with the `toolchain-e2e` field manager.
+// Only the fields set in the given object are owned by the e2e tests, so the object can also be managed by the operators
+// without causing conflict errors. Conflicting fields owned by other managers are forcibly taken over.
+// The object is labelled with the run ID and the name of the current test, and it is updated with the state returned by the server.
+func (a *Awaitility) Apply(t *testing.T, obj client.Object) error {
+	AddRunLabels(t, obj)
+	// server-side apply requires the apiVersion and kind to be set and the managed fields to be empty
+	gvk, err := apiutil.GVKForObject(obj, a.Client.Scheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	t.Logf("applying %s '%s' in namespace '%s'", gvk.Kind, obj.GetName(), obj.GetNamespace())
+	return a.Client.Patch(context.TODO(), obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// ApplyWithCleanup applies the given object with server-side apply (see Apply) and schedules the cleanup of the object at the end of the current test
+func (a *Awaitility) ApplyWithCleanup(t *testing.T, obj client.Object) error {
+	if err := a.Apply(t, obj); err != nil {
+		return err
+	}
+	a.addCleanTasks(t, obj)
+	return nil
+}
@@ -0,0 +1,112 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApply(t *testing.T) {
+	// newAwaitility returns an awaitility whose client records the server-side apply patches and creates the applied objects
+	// (the fake client does not support the server-side apply)
+	newAwaitility := func(t *testing.T) (*wait.Awaitility, *commontest.FakeClient, *[]client.PatchOptions) {
+		var patches []client.PatchOptions
+		cl := commontest.NewFakeClient(t)
+		cl.MockPatch = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			require.Equal(t, types.ApplyPatchType, patch.Type())
+			patchOpts := client.PatchOptions{}
+			patchOpts.ApplyOptions(opts)
+			patches = append(patches, patchOpts)
+			return cl.Client.Create(ctx, obj)
+		}
+		return &wait.Awaitility{
+			Client:         cl,
+			Namespace:      commontest.HostOperatorNs,
+			CleanupManager: cleanup.NewManager(),
+		}, cl, &patches
+	}
+	newTierTemplate := func() *toolchainv1alpha1.TierTemplate {
+		return &toolchainv1alpha1.TierTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "base-dev-123abc",
+				Namespace:       commontest.HostOperatorNs,
+				ResourceVersion: "5",
+				ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "other"}},
+			},
+			Spec: toolchainv1alpha1.TierTemplateSpec{
+				TierName: "base",
+				Type:     "dev",
+			},
+		}
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		// given
+		await, cl, patches := newAwaitility(t)
+		tierTemplate := newTierTemplate()
+
+		// when
+		err := await.Apply(t, tierTemplate)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, *patches, 1)
+		assert.Equal(t, wait.FieldManager, (*patches)[0].FieldManager)
+		require.NotNil(t, (*patches)[0].Force)
+		assert.True(t, *(*patches)[0].Force)
+		// the kind is set, since the server-side apply requires it
+		assert.Equal(t, toolchainv1alpha1.GroupVersion.WithKind("TierTemplate"), tierTemplate.GetObjectKind().GroupVersionKind())
+		assert.Empty(t, tierTemplate.GetManagedFields())
+		assert.Equal(t, wait.RunID(), tierTemplate.Labels[wait.RunIDLabelKey])
+		assert.Equal(t, "TestApply_apply", tierTemplate.Labels[wait.TestNameLabelKey])
+		applied := &toolchainv1alpha1.TierTemplate{}
+		require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(tierTemplate), applied))
+		assert.Equal(t, "base", applied.Spec.TierName)
+	})
+
+	t.Run("apply with cleanup", func(t *testing.T) {
+		// given
+		await, cl, patches := newAwaitility(t)
+		tierTemplate := newTierTemplate()
+
+		// when
+		t.Run("apply", func(t *testing.T) {
+			require.NoError(t, await.ApplyWithCleanup(t, tierTemplate))
+		})
+
+		// then
+		// the object is deleted at the end of the subtest
+		require.Len(t, *patches, 1)
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(tierTemplate), &toolchainv1alpha1.TierTemplate{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("apply fails", func(t *testing.T) {
+		// given
+		await, cl, _ := newAwaitility(t)
+		cl.MockPatch = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			return assert.AnError
+		}
+		tierTemplate := newTierTemplate()
+
+		// when
+		t.Run("apply", func(t *testing.T) {
+			err := await.ApplyWithCleanup(t, tierTemplate)
+
+			// then
+			require.ErrorIs(t, err, assert.AnError)
+		})
+		kinds, _ := await.CleanupManager.Timings()
+		assert.Empty(t, kinds) // nothing to clean up
+	})
+}
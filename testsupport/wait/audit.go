@@ -0,0 +1,244 @@
+package wait
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// AuditLogVar the name of the env var to set to `true` to enable the assertions on the audit log of the API server, which
+	// are disabled by default since they require the permission to read the logs of the control plane nodes (eg, `oc adm node-logs`)
+	// and since each assertion reads the whole audit log of the nodes
+	AuditLogVar = "E2E_AUDIT_LOG"
+
+	// auditLogPollInterval the interval between two reads of the audit log, which is longer than the default retry
+	// interval since the audit log is written asynchronously and its reads are expensive
+	auditLogPollInterval = 5 * time.Second
+)
+
+// AuditLogEnabled returns `true` if the `E2E_AUDIT_LOG` env var is set to `true`
+func AuditLogEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(AuditLogVar))
+	return enabled
+}
+
+// AuditEvent the fields of an event of the API server audit log (`audit.k8s.io/v1`) used by the assertions
+type AuditEvent struct {
+	AuditID                  string           `json:"auditID"`
+	Stage                    string           `json:"stage"`
+	RequestURI               string           `json:"requestURI"`
+	Verb                     string           `json:"verb"`
+	User                     AuditUser        `json:"user"`
+	ImpersonatedUser         *AuditUser       `json:"impersonatedUser,omitempty"`
+	ObjectRef                *AuditObjectRef  `json:"objectRef,omitempty"`
+	ResponseStatus           *metav1.Status   `json:"responseStatus,omitempty"`
+	RequestReceivedTimestamp metav1.MicroTime `json:"requestReceivedTimestamp"`
+}
+
+// AuditUser the user (or impersonated user) of an audit event
+type AuditUser struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// AuditObjectRef the object of an audit event
+type AuditObjectRef struct {
+	Resource  string `json:"resource,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	APIGroup  string `json:"apiGroup,omitempty"`
+}
+
+func (e AuditEvent) String() string {
+	impersonated := ""
+	if e.ImpersonatedUser != nil {
+		impersonated = fmt.Sprintf(" as '%s'", e.ImpersonatedUser.Username)
+	}
+	return fmt.Sprintf("%s %s %s by '%s'%s (%s)", e.RequestReceivedTimestamp.UTC().Format(time.RFC3339), e.Verb, e.RequestURI, e.User.Username, impersonated, e.Stage)
+}
+
+// AuditEventWaitCriterion a criterion to match an event of the audit log
+type AuditEventWaitCriterion func(AuditEvent) bool
+
+// AuditEventHasVerb checks that the event is for a request with the given verb (eg, `create`)
+func AuditEventHasVerb(verb string) AuditEventWaitCriterion {
+	return func(e AuditEvent) bool {
+		return e.Verb == verb
+	}
+}
+
+// AuditEventForObject checks that the event is for a request on the object with the given resource (eg, `configmaps`), namespace and name
+func AuditEventForObject(resource, namespace, name string) AuditEventWaitCriterion {
+	return func(e AuditEvent) bool {
+		return e.ObjectRef != nil && e.ObjectRef.Resource == resource && e.ObjectRef.Namespace == namespace && e.ObjectRef.Name == name
+	}
+}
+
+// AuditEventReceivedAfter checks that the request of the event was received after the given time
+func AuditEventReceivedAfter(since time.Time) AuditEventWaitCriterion {
+	return func(e AuditEvent) bool {
+		return !e.RequestReceivedTimestamp.Time.Before(since)
+	}
+}
+
+// AuditEventCompleted checks that the event is the one of the completed response (the other stages are not logged by all the audit policies)
+func AuditEventCompleted() AuditEventWaitCriterion {
+	return func(e AuditEvent) bool {
+		return e.Stage == "ResponseComplete"
+	}
+}
+
+// ParseAuditEvents returns the events of the given audit log (one JSON event per line) which match all the given criteria.
+// The lines which are not valid events (eg, a truncated line at the end of the log) are ignored.
+func ParseAuditEvents(r io.Reader, criteria ...AuditEventWaitCriterion) ([]AuditEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024) // some events are much larger than the default max token size
+	events := []AuditEvent{}
+lines:
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		e := AuditEvent{}
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		for _, match := range criteria {
+			if !match(e) {
+				continue lines
+			}
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// AuditLogAvailable returns `true` if the assertions on the audit log are enabled (see AuditLogVar) and if the audit log
+// of the API server can be read from the control plane nodes of the cluster
+func (a *Awaitility) AuditLogAvailable(t *testing.T) bool {
+	if !AuditLogEnabled() {
+		t.Logf("assertions on the audit log are disabled, set the '%s' env var to 'true' to enable them", AuditLogVar)
+		return false
+	}
+	clientset, nodes, err := a.controlPlaneNodes()
+	if err != nil || len(nodes) == 0 {
+		t.Logf("cannot find the control plane nodes to read the audit log: %v", err)
+		return false
+	}
+	log, err := openAuditLog(clientset, nodes[0])
+	if err != nil {
+		t.Logf("cannot read the audit log of node '%s': %s", nodes[0], err.Error())
+		return false
+	}
+	_ = log.Close()
+	return true
+}
+
+// WaitForAuditEvent waits until there is an event matching all the given criteria in the audit log of one of the control plane nodes
+// (each API server instance has its own audit log), and returns this event
+func (a *Awaitility) WaitForAuditEvent(t *testing.T, criteria ...AuditEventWaitCriterion) (AuditEvent, error) {
+	t.Logf("waiting for an event in the audit log of the API server")
+	clientset, nodes, err := a.controlPlaneNodes()
+	if err != nil {
+		return AuditEvent{}, err
+	}
+	var found *AuditEvent
+	err = a.WithRetryOptions(RetryInterval(auditLogPollInterval), FixedInterval()).poll(func() (done bool, err error) {
+		for _, node := range nodes {
+			events, err := readAuditEvents(clientset, node, criteria...)
+			if err != nil {
+				t.Logf("cannot read the audit log of node '%s': %s", node, err.Error())
+				continue
+			}
+			if len(events) > 0 {
+				found = &events[0]
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return AuditEvent{}, fmt.Errorf("no matching event found in the audit log of nodes %v: %w", nodes, err)
+	}
+	t.Logf("found event in the audit log: %s", found)
+	return *found, nil
+}
+
+// FindAuditEvents returns all the events matching the given criteria in the audit logs of all the control plane nodes
+func (a *Awaitility) FindAuditEvents(t *testing.T, criteria ...AuditEventWaitCriterion) ([]AuditEvent, error) {
+	clientset, nodes, err := a.controlPlaneNodes()
+	if err != nil {
+		return nil, err
+	}
+	all := []AuditEvent{}
+	for _, node := range nodes {
+		events, err := readAuditEvents(clientset, node, criteria...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events...)
+	}
+	t.Logf("found %d matching event(s) in the audit log of nodes %v", len(all), nodes)
+	return all, nil
+}
+
+// controlPlaneNodes returns the names of the control plane nodes of the cluster
+func (a *Awaitility) controlPlaneNodes() (kubernetes.Interface, []string, error) {
+	clientset, err := kubernetes.NewForConfig(a.RestConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := []string{}
+	for _, role := range []string{"node-role.kubernetes.io/master", "node-role.kubernetes.io/control-plane"} {
+		nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: role})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, n := range nodes.Items {
+			if !contains(names, n.Name) {
+				names = append(names, n.Name)
+			}
+		}
+	}
+	return clientset, names, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// openAuditLog opens the audit log of the API server of the given node, via the `logs` endpoint of the node proxy (on OpenShift,
+// the same as `oc adm node-logs <node> --path=kube-apiserver/audit.log`)
+func openAuditLog(clientset kubernetes.Interface, node string) (io.ReadCloser, error) {
+	return clientset.CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", node, "proxy", "logs", "kube-apiserver", "audit.log").
+		Stream(context.TODO())
+}
+
+// readAuditEvents reads the events matching the given criteria in the audit log of the API server of the given node
+func readAuditEvents(clientset kubernetes.Interface, node string, criteria ...AuditEventWaitCriterion) ([]AuditEvent, error) {
+	log, err := openAuditLog(clientset, node)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = log.Close()
+	}()
+	return ParseAuditEvents(log, criteria...)
+}
@@ -0,0 +1,72 @@
+package wait_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const auditLog = `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a1","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/john-dev/configmaps","verb":"create","user":{"username":"system:serviceaccount:toolchain-member-operator:toolchaincluster-member","groups":["system:serviceaccounts"]},"impersonatedUser":{"username":"john"},"objectRef":{"resource":"configmaps","namespace":"john-dev","name":"config","apiVersion":"v1"},"responseStatus":{"metadata":{},"code":201},"requestReceivedTimestamp":"2023-04-06T10:30:00.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a2","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/john-dev/configmaps/config","verb":"get","user":{"username":"kube:admin"},"objectRef":{"resource":"configmaps","namespace":"john-dev","name":"config","apiVersion":"v1"},"responseStatus":{"metadata":{},"code":200},"requestReceivedTimestamp":"2023-04-06T10:31:00.000000Z"}
+not a json line
+
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a3","stage":"RequestReceived","requestURI":"/api/v1/namespaces/john-dev/configmaps","verb":"create","user":{"username":"system:serviceaccount:toolchain-member-operator:toolchaincluster-member"},"impersonatedUser":{"username":"john"},"objectRef":{"resource":"configmaps","namespace":"john-dev","name":"config","apiVersion":"v1"},"requestReceivedTimestamp":"2023-04-06T10:32:00.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a4","stage":"ResponseComplete","requestURI":"/api/v1/namesp`
+
+func TestParseAuditEvents(t *testing.T) {
+
+	t.Run("all valid events", func(t *testing.T) {
+		// when
+		events, err := wait.ParseAuditEvents(strings.NewReader(auditLog))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, events, 3) // the invalid and truncated lines are ignored
+		assert.Equal(t, "a1", events[0].AuditID)
+		assert.Equal(t, "system:serviceaccount:toolchain-member-operator:toolchaincluster-member", events[0].User.Username)
+		require.NotNil(t, events[0].ImpersonatedUser)
+		assert.Equal(t, "john", events[0].ImpersonatedUser.Username)
+		require.NotNil(t, events[0].ResponseStatus)
+		assert.Equal(t, int32(201), events[0].ResponseStatus.Code)
+		assert.Nil(t, events[1].ImpersonatedUser)
+		assert.Equal(t, "2023-04-06T10:30:00Z create /api/v1/namespaces/john-dev/configmaps by 'system:serviceaccount:toolchain-member-operator:toolchaincluster-member' as 'john' (ResponseComplete)", events[0].String())
+	})
+
+	t.Run("with criteria", func(t *testing.T) {
+		// when
+		events, err := wait.ParseAuditEvents(strings.NewReader(auditLog),
+			wait.AuditEventHasVerb("create"),
+			wait.AuditEventForObject("configmaps", "john-dev", "config"),
+			wait.AuditEventCompleted())
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "a1", events[0].AuditID)
+	})
+
+	t.Run("received after", func(t *testing.T) {
+		// when
+		events, err := wait.ParseAuditEvents(strings.NewReader(auditLog),
+			wait.AuditEventReceivedAfter(time.Date(2023, 4, 6, 10, 31, 0, 0, time.UTC)))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, "a2", events[0].AuditID)
+		assert.Equal(t, "a3", events[1].AuditID)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		// when
+		events, err := wait.ParseAuditEvents(strings.NewReader(auditLog), wait.AuditEventHasVerb("delete"))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
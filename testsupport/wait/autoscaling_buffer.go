@@ -0,0 +1,60 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreemptedEventReason the reason of the event emitted by the scheduler on a pod which is evicted to make room for a pod of higher priority
+const PreemptedEventReason = "Preempted"
+
+// WaitForAutoscalingBufferPods waits until the given number of pods of the autoscaling buffer Deployment are running, and returns them
+func (a *MemberAwaitility) WaitForAutoscalingBufferPods(t *testing.T, replicas int) ([]corev1.Pod, error) {
+	return a.WaitForPods(t, a.Namespace, replicas, WithPodLabel("app", autoscalingBufferName), PodRunning())
+}
+
+// WaitForPodPreemptedEvent waits until the scheduler emitted a `Preempted` event on the given pod (ie, the pod was evicted to make room
+// for a pod of higher priority, such as a user workload evicting an autoscaling buffer pod), and returns this event
+func (a *MemberAwaitility) WaitForPodPreemptedEvent(t *testing.T, pod corev1.Pod) (*corev1.Event, error) {
+	t.Logf("waiting for event '%s' on Pod '%s' in namespace '%s'", PreemptedEventReason, pod.Name, pod.Namespace)
+	var preempted *corev1.Event
+	var observed []string
+	err := a.poll(func() (done bool, err error) {
+		events := &corev1.EventList{}
+		if err := a.Client.List(context.TODO(), events, client.InNamespace(pod.Namespace)); err != nil {
+			return false, err
+		}
+		observed = nil
+		for i, e := range events.Items {
+			if e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != pod.Name || (e.InvolvedObject.UID != "" && e.InvolvedObject.UID != pod.UID) {
+				continue
+			}
+			if e.Reason == PreemptedEventReason {
+				preempted = &events.Items[i]
+				return true, nil
+			}
+			observed = append(observed, fmt.Sprintf("%s: %s", e.Reason, e.Message))
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Logf("no event '%s' on Pod '%s' in namespace '%s', observed events: %v", PreemptedEventReason, pod.Name, pod.Namespace, observed)
+	}
+	return preempted, err
+}
+
+// PodScheduledOnNode checks if the Pod is scheduled on the node with the given name
+func PodScheduledOnNode(node string) PodWaitCriterion {
+	return PodWaitCriterion{
+		Match: func(actual *corev1.Pod) bool {
+			return actual.Spec.NodeName == node
+		},
+		Diff: func(actual *corev1.Pod) string {
+			return fmt.Sprintf("expected Pod to be scheduled on node '%s'\nbut it was scheduled on '%s'", node, actual.Spec.NodeName)
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestWaitForPodPreemptedEvent(t *testing.T) {
+	// given
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: commontest.MemberOperatorNs,
+			Name:      "autoscaling-buffer-abcde",
+			UID:       types.UID("buffer-uid"),
+		},
+	}
+	scheduled := newPodEvent(pod, "scheduled", "Scheduled", "Successfully assigned pod to node-1")
+	preempted := newPodEvent(pod, "preempted", wait.PreemptedEventReason, "Preempted by user-dev/heavy on node node-1")
+
+	t.Run("preempted", func(t *testing.T) {
+		// given
+		await := newMemberAwaitility(t, scheduled, preempted)
+
+		// when
+		event, err := await.WaitForPodPreemptedEvent(t, pod)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "preempted", event.Name)
+	})
+
+	t.Run("not preempted", func(t *testing.T) {
+		// given
+		await := newMemberAwaitility(t, scheduled)
+
+		// when
+		_, err := await.WaitForPodPreemptedEvent(t, pod)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("previous pod with same name preempted", func(t *testing.T) {
+		// given
+		previous := newPodEvent(pod, "previous", wait.PreemptedEventReason, "Preempted by user-dev/heavy on node node-1")
+		previous.InvolvedObject.UID = types.UID("previous-uid")
+		await := newMemberAwaitility(t, previous)
+
+		// when
+		_, err := await.WaitForPodPreemptedEvent(t, pod)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestPodScheduledOnNode(t *testing.T) {
+	// given
+	pod := &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}}
+
+	// then
+	assert.True(t, wait.PodScheduledOnNode("node-1").Match(pod))
+	assert.False(t, wait.PodScheduledOnNode("node-2").Match(pod))
+	assert.Equal(t, "expected Pod to be scheduled on node 'node-2'\nbut it was scheduled on 'node-1'", wait.PodScheduledOnNode("node-2").Diff(pod))
+}
+
+func newMemberAwaitility(t *testing.T, objs ...runtime.Object) *wait.MemberAwaitility {
+	return wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t, objs...), commontest.MemberOperatorNs, "member").
+		WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+}
+
+func newPodEvent(pod corev1.Pod, name, reason, message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      name,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
+		},
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
 	"github.com/redhat-cop/operator-utils/pkg/util"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/kubectl/pkg/util/podutils"
 
 	routev1 "github.com/openshift/api/route/v1"
@@ -28,7 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	k8smetrics "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,7 +54,20 @@ type Awaitility struct {
 	Type          cluster.Type
 	RetryInterval time.Duration
 	Timeout       time.Duration
-	MetricsURL    string
+	Backoff       BackoffPolicy
+	// ProgressInterval the interval between two progress logs during a long wait (0 disables the progress logs)
+	ProgressInterval time.Duration
+	MetricsURL       string
+	// CleanupManager the manager of the cleaning tasks scheduled by the awaitility (the default one of the cleanup package if nil)
+	CleanupManager *cleanup.Manager
+	// ListRateLimiter the client-side rate limiter of the paginated list helpers (the one shared by all the awaitilities if nil)
+	ListRateLimiter flowcontrol.RateLimiter
+	// CapabilityCache the cache of the capabilities detected in the cluster (the one shared by all the awaitilities if nil)
+	CapabilityCache *CapabilityCache
+	// WaiterRegistry the registry of the waits in progress (the one shared by all the awaitilities if nil, see PendingWaiters)
+	WaiterRegistry *WaiterRegistry
+	// DiscoveryClient the client of the discovery API of the cluster (one created from the rest config if nil)
+	DiscoveryClient discovery.DiscoveryInterface
 }
 
 func (a *Awaitility) GetClient() client.Client {
@@ -107,7 +122,7 @@ func (o TimeoutOption) apply(a *Awaitility) {
 func (a *Awaitility) WaitForService(t *testing.T, name string) (corev1.Service, error) {
 	t.Logf("waiting for Service '%s' in namespace '%s'", name, a.Namespace)
 	var metricsSvc *corev1.Service
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		metricsSvc = &corev1.Service{}
 		// retrieve the metrics service from the namespace
 		err = a.Client.Get(context.TODO(),
@@ -137,7 +152,7 @@ func (a *Awaitility) WaitForToolchainClusterWithCondition(t *testing.T, clusterT
 		timeout = ToolchainClusterConditionTimeout
 	}
 	var c toolchainv1alpha1.ToolchainCluster
-	err := wait.Poll(a.RetryInterval, timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(timeout, func() (done bool, err error) {
 		var ready bool
 		if c, ready, err = a.GetToolchainCluster(t, clusterType, namespace, condition); ready {
 			return true, nil
@@ -156,7 +171,7 @@ func (a *Awaitility) WaitForNamedToolchainClusterWithCondition(t *testing.T, nam
 		timeout = ToolchainClusterConditionTimeout
 	}
 	c := toolchainv1alpha1.ToolchainCluster{}
-	err := wait.Poll(a.RetryInterval, timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(timeout, func() (done bool, err error) {
 		c = toolchainv1alpha1.ToolchainCluster{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, &c); err != nil {
 			return false, err
@@ -252,7 +267,7 @@ func (a *Awaitility) WaitForRouteToBeAvailable(t *testing.T, ns, name, endpoint
 	t.Logf("waiting for route '%s' in namespace '%s'", name, ns)
 	route := routev1.Route{}
 	// retrieve the route for the registration service
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		if err = a.Client.Get(context.TODO(),
 			types.NamespacedName{
 				Namespace: ns,
@@ -331,12 +346,28 @@ func (a *Awaitility) GetMetricValueOrZero(t *testing.T, family string, labelAndV
 	return 0
 }
 
+// GetHistogram gets the buckets, count and sum of the histogram metric with the given family and label key-value pair
+// fails if the metric with the given labelAndValues does not exist or is not a histogram
+func (a *Awaitility) GetHistogram(t *testing.T, family string, labelAndValues ...string) *metrics.Histogram {
+	h, err := metrics.GetHistogram(a.RestConfig, a.MetricsURL, family, labelAndValues)
+	require.NoError(t, err)
+	return h
+}
+
+// GetLabelBuckets gets the gauges of the given family with the given label key-value pairs, as the buckets of a histogram whose upper
+// bounds are the values of the given bucket label (eg, the `activations` label of the `sandbox_users_per_activations_and_domain` gauges)
+func (a *Awaitility) GetLabelBuckets(t *testing.T, family, bucketLabel string, labelAndValues ...string) *metrics.Histogram {
+	h, err := metrics.GetLabelBuckets(a.RestConfig, a.MetricsURL, family, bucketLabel, labelAndValues)
+	require.NoError(t, err)
+	return h
+}
+
 // WaitUntiltMetricHasValue asserts that the exposed metric with the given family
 // and label key-value pair reaches the expected value
 func (a *Awaitility) WaitUntiltMetricHasValue(t *testing.T, family string, expectedValue float64, labels ...string) {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v'", family, labels, expectedValue)
 	var value float64
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
 		// if error occurred, ignore and return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		// unless the expected value is `0`, in which case the metric is bot exposed (value==0 and err!= nil), but it's fine too.
@@ -350,7 +381,7 @@ func (a *Awaitility) WaitUntiltMetricHasValue(t *testing.T, family string, expec
 func (a *Awaitility) WaitUntilMetricHasValueOrMore(t *testing.T, family string, expectedValue float64, labels ...string) error {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v' or more", family, labels, expectedValue)
 	var value float64
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
 		// if error occurred, return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		return value >= expectedValue && err == nil, nil
@@ -366,7 +397,7 @@ func (a *Awaitility) WaitUntilMetricHasValueOrMore(t *testing.T, family string,
 func (a *Awaitility) WaitUntilMetricHasValueOrLess(t *testing.T, family string, expectedValue float64, labels ...string) error {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v' or less", family, labels, expectedValue)
 	var value float64
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
 		// if error occurred, return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		return value <= expectedValue && err == nil, nil
@@ -392,10 +423,25 @@ func (a *Awaitility) DeletePods(criteria ...client.ListOption) error {
 	return nil
 }
 
+// GetPodRestartCounts returns the total number of container restarts of each pod matching the given criteria, indexed by pod name
+func (a *Awaitility) GetPodRestartCounts(criteria ...client.ListOption) (map[string]int32, error) {
+	pods := corev1.PodList{}
+	if err := a.Client.List(context.TODO(), &pods, criteria...); err != nil {
+		return nil, err
+	}
+	restarts := make(map[string]int32, len(pods.Items))
+	for _, p := range pods.Items {
+		for _, status := range p.Status.ContainerStatuses {
+			restarts[p.Name] += status.RestartCount
+		}
+	}
+	return restarts, nil
+}
+
 // GetMemoryUsage retrieves the memory usage (in KB) of a given the pod
 func (a *Awaitility) GetMemoryUsage(podname, ns string) (int64, error) {
 	var containerMetrics k8smetrics.ContainerMetrics
-	if err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	if err := a.poll(func() (done bool, err error) {
 		podMetrics := k8smetrics.PodMetrics{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: ns,
@@ -425,9 +471,10 @@ func (a *Awaitility) CreateNamespace(t *testing.T, name string) {
 			Name: name,
 		},
 	}
+	AddRunLabels(t, ns)
 	err := a.Client.Create(context.TODO(), ns)
 	require.NoError(t, err)
-	err = wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err = a.poll(func() (done bool, err error) {
 		ns := &corev1.Namespace{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, ns); err != nil && apierrors.IsNotFound(err) {
 			return false, nil
@@ -448,7 +495,7 @@ func (a *Awaitility) CreateNamespace(t *testing.T, name string) {
 func (a *Awaitility) WaitForDeploymentToGetReady(t *testing.T, name string, replicas int, criteria ...DeploymentCriteria) *appsv1.Deployment {
 	t.Logf("waiting until deployment '%s' in namespace '%s' is ready", name, a.Namespace)
 	deployment := &appsv1.Deployment{}
-	err := wait.Poll(a.RetryInterval, 6*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(6*a.Timeout, func() (done bool, err error) {
 		deploymentConditions := status.GetDeploymentStatusConditions(a.Client, name, a.Namespace)
 		if err := status.ValidateComponentConditionReady(deploymentConditions...); err != nil {
 			return false, nil // nolint:nilerr
@@ -479,6 +526,90 @@ func (a *Awaitility) WaitForDeploymentToGetReady(t *testing.T, name string, repl
 	return deployment
 }
 
+// GetDeploymentRevision returns the current revision of the deployment with the given name, as set by the deployment controller,
+// or 0 if the deployment doesn't exist (yet). The returned value can be used in the WaitForDeploymentToBeRestarted func.
+func (a *Awaitility) GetDeploymentRevision(t *testing.T, name string) int64 {
+	deployment := &appsv1.Deployment{}
+	if err := a.Client.Get(context.TODO(), test.NamespacedName(a.Namespace, name), deployment); err != nil {
+		require.True(t, apierrors.IsNotFound(err), "unable to get deployment '%s' in namespace '%s': %s", name, a.Namespace, err)
+		return 0
+	}
+	revision, err := deploymentRevision(deployment)
+	require.NoError(t, err)
+	return revision
+}
+
+// WaitForDeploymentToBeRestarted waits until the deployment with the given name has been restarted after the given revision,
+// ie, there is a ReplicaSet with a higher revision which has all its replicas ready, and the pods of the previous ReplicaSets are gone
+func (a *Awaitility) WaitForDeploymentToBeRestarted(t *testing.T, name string, previousRevision int64) *appsv1.Deployment {
+	t.Logf("waiting until deployment '%s' in namespace '%s' is restarted after revision '%d'", name, a.Namespace, previousRevision)
+	var deployment *appsv1.Deployment
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
+		obj := &appsv1.Deployment{}
+		if err := a.Client.Get(context.TODO(), test.NamespacedName(a.Namespace, name), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		deployment = obj
+		replicaSets := &appsv1.ReplicaSetList{}
+		if err := a.Client.List(context.TODO(), replicaSets, client.InNamespace(a.Namespace), client.MatchingLabels(obj.Spec.Selector.MatchLabels)); err != nil {
+			return false, err
+		}
+		restarted := false
+		for i := range replicaSets.Items {
+			rs := replicaSets.Items[i]
+			if !metav1.IsControlledBy(&rs, obj) {
+				continue
+			}
+			revision, err := replicaSetRevision(rs)
+			if err != nil {
+				return false, err
+			}
+			if revision > previousRevision && rs.Spec.Replicas != nil && rs.Status.ReadyReplicas == *rs.Spec.Replicas {
+				restarted = true
+			} else if revision <= previousRevision && rs.Status.Replicas > 0 {
+				// pods of the previous revision are still running
+				return false, nil
+			}
+		}
+		return restarted && isDeploymentRolledOut(obj), nil
+	})
+	require.NoError(t, err, "deployment '%s' wasn't restarted after revision '%d': %+v", name, previousRevision, deployment)
+	return deployment
+}
+
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+func deploymentRevision(deployment *appsv1.Deployment) (int64, error) {
+	return parseRevision(deployment.Annotations[deploymentRevisionAnnotation])
+}
+
+func replicaSetRevision(rs appsv1.ReplicaSet) (int64, error) {
+	return parseRevision(rs.Annotations[deploymentRevisionAnnotation])
+}
+
+func parseRevision(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// isDeploymentRolledOut returns true if the latest spec of the given deployment was observed by the deployment controller
+// and all the replicas are updated and available
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
 type DeploymentCriteria func(*appsv1.Deployment) bool
 
 func DeploymentHasContainerWithImage(containerName, image string) DeploymentCriteria {
@@ -502,7 +633,7 @@ func (a *Awaitility) WaitForToolchainCluster(t *testing.T, criteria ...Toolchain
 	t.Logf("waiting for toolchaincluster in namespace '%s' to match criteria", a.Namespace)
 	var clusters *toolchainv1alpha1.ToolchainClusterList
 	var cl *toolchainv1alpha1.ToolchainCluster
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		clusters = &toolchainv1alpha1.ToolchainClusterList{}
 		if err := a.Client.List(context.TODO(), clusters, client.InNamespace(a.Namespace)); err != nil {
 			return false, err
@@ -546,6 +677,38 @@ func UntilToolchainClusterHasCondition(expected toolchainv1alpha1.ToolchainClust
 	}
 }
 
+// UntilToolchainClusterHasReadyConditionProbedAfter checks if ToolchainCluster has a ready condition which was probed after the given time
+func UntilToolchainClusterHasReadyConditionProbedAfter(after time.Time) ToolchainClusterWaitCriterion {
+	return ToolchainClusterWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.ToolchainCluster) bool {
+			for _, c := range actual.Status.Conditions {
+				if c.Type == toolchainv1alpha1.ToolchainClusterReady && c.Status == corev1.ConditionTrue {
+					return c.LastProbeTime.Time.After(after)
+				}
+			}
+			return false
+		},
+	}
+}
+
+// UntilToolchainClusterIsNotReadyProbedAfter checks if ToolchainCluster is not ready (or offline) according to a probe which occurred after the given time
+func UntilToolchainClusterIsNotReadyProbedAfter(after time.Time) ToolchainClusterWaitCriterion {
+	return ToolchainClusterWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.ToolchainCluster) bool {
+			for _, c := range actual.Status.Conditions {
+				if !c.LastProbeTime.Time.After(after) {
+					continue
+				}
+				if (c.Type == toolchainv1alpha1.ToolchainClusterReady && c.Status != corev1.ConditionTrue) ||
+					(c.Type == toolchainv1alpha1.ToolchainClusterOffline && c.Status == corev1.ConditionTrue) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
 // UntilToolchainClusterHasLabels checks if ToolchainCluster has the given labels
 func UntilToolchainClusterHasLabels(expected client.MatchingLabels) ToolchainClusterWaitCriterion {
 	return ToolchainClusterWaitCriterion{
@@ -573,40 +736,49 @@ func UntilToolchainClusterHasNoTenantLabel() ToolchainClusterWaitCriterion {
 }
 
 // UpdateToolchainCluster tries to update the Spec of the given ToolchainCluster
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated ToolchainCluster
 func (a *Awaitility) UpdateToolchainCluster(t *testing.T, toolchainClusterName string, modifyToolchainCluster func(s *toolchainv1alpha1.ToolchainCluster)) (*toolchainv1alpha1.ToolchainCluster, error) {
-	var tc *toolchainv1alpha1.ToolchainCluster
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		newToolchainCluster := &toolchainv1alpha1.ToolchainCluster{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: toolchainClusterName}, newToolchainCluster); err != nil {
-			return true, err
-		}
-		modifyToolchainCluster(newToolchainCluster)
-		if err := a.Client.Update(context.TODO(), newToolchainCluster); err != nil {
-			t.Logf("error updating ToolchainCluster '%s': %s. Will retry again...", toolchainClusterName, err.Error())
-			return false, nil
-		}
-		tc = newToolchainCluster
-		return true, nil
-	})
-	return tc, err
+	return Update(t, a, toolchainClusterName, modifyToolchainCluster)
 }
 
-// CreateWithCleanup creates the given object via client.Client.Create() and schedules the cleanup of the object at the end of the current test
+// CreateWithCleanup creates the given object via client.Client.Create() and schedules the cleanup of the object at the end of the current test.
+// The object is labelled with the run ID and the name of the current test before it is created.
 func (a *Awaitility) CreateWithCleanup(t *testing.T, obj client.Object, opts ...client.CreateOption) error {
+	AddRunLabels(t, obj)
 	if err := a.Client.Create(context.TODO(), obj, opts...); err != nil {
 		return err
 	}
-	cleanup.AddCleanTasks(t, a.GetClient(), obj)
+	a.addCleanTasks(t, obj)
 	return nil
 }
 
 // Clean triggers cleanup of all resources that were marked to be cleaned before that
 func (a *Awaitility) Clean(t *testing.T) {
+	if a.CleanupManager != nil {
+		a.CleanupManager.ExecuteAllCleanTasks(t)
+		return
+	}
 	cleanup.ExecuteAllCleanTasks(t)
 }
 
+// waiterRegistry returns the registry of the waits in progress of the awaitility
+func (a *Awaitility) waiterRegistry() *WaiterRegistry {
+	if a.WaiterRegistry != nil {
+		return a.WaiterRegistry
+	}
+	return defaultWaiterRegistry
+}
+
+// addCleanTasks schedules the cleanup of the given objects at the end of the current test, with the cleanup manager of the awaitility
+func (a *Awaitility) addCleanTasks(t *testing.T, objects ...client.Object) {
+	if a.CleanupManager != nil {
+		a.CleanupManager.AddCleanTasks(t, a.GetClient(), objects...)
+		return
+	}
+	cleanup.AddCleanTasks(t, a.GetClient(), objects...)
+}
+
 func (a *Awaitility) listAndPrint(t *testing.T, resourceKind, namespace string, list client.ObjectList, additionalOptions ...client.ListOption) {
 	t.Logf(a.listAndReturnContent(resourceKind, namespace, list, additionalOptions...))
 }
@@ -0,0 +1,232 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Capability a feature of the cluster on which some tests depend, and which may not be available depending on the
+// version of Kubernetes/OpenShift of the cluster (eg, an API that is only served by recent versions)
+type Capability string
+
+const (
+	// CapabilityOpenShift the cluster is an OpenShift cluster
+	CapabilityOpenShift Capability = "OpenShift"
+	// CapabilityPodSecurityAdmission the Pod Security Admission controller enforces the `pod-security.kubernetes.io/*` labels
+	// of the namespaces (enabled by default since Kubernetes 1.23, GA since 1.25)
+	CapabilityPodSecurityAdmission Capability = "PodSecurityAdmission"
+	// CapabilityValidatingAdmissionPolicy the ValidatingAdmissionPolicies are served by the API server (in any version)
+	CapabilityValidatingAdmissionPolicy Capability = "ValidatingAdmissionPolicy"
+	// CapabilityChe Che (or Red Hat OpenShift Dev Spaces) is installed in the cluster, ie, the CheClusters are served by the API server
+	// and there is a CheCluster (the CRD may remain after an uninstallation, or be installed before the CheCluster is created)
+	CapabilityChe Capability = "Che"
+)
+
+// CheClusterGVK the kind of the resource which configures a Che (or Dev Spaces) installation
+var CheClusterGVK = schema.GroupVersionKind{Group: "org.eclipse.che", Version: "v2", Kind: "CheCluster"}
+
+// CapabilityCheck a function which returns `true` if the cluster of the given Awaitility has the capability
+type CapabilityCheck func(a *Awaitility) (bool, error)
+
+var capabilities = map[Capability]CapabilityCheck{
+	CapabilityOpenShift: func(a *Awaitility) (bool, error) {
+		v, err := a.ClusterVersion()
+		return v.OpenShift != "", err
+	},
+	CapabilityPodSecurityAdmission: func(a *Awaitility) (bool, error) {
+		v, err := a.ClusterVersion()
+		return err == nil && v.AtLeast(1, 25), err
+	},
+	CapabilityValidatingAdmissionPolicy: func(a *Awaitility) (bool, error) {
+		_, supported, err := a.ValidatingAdmissionPolicyGroupVersion()
+		return supported, err
+	},
+	CapabilityChe: func(a *Awaitility) (bool, error) {
+		supported, err := a.SupportsAPI(CheClusterGVK)
+		if err != nil || !supported {
+			return false, err
+		}
+		cheClusters := &unstructured.UnstructuredList{}
+		cheClusters.SetGroupVersionKind(CheClusterGVK.GroupVersion().WithKind(CheClusterGVK.Kind + "List"))
+		if err := a.Client.List(context.TODO(), cheClusters, client.Limit(1)); err != nil {
+			return false, err
+		}
+		return len(cheClusters.Items) > 0, nil
+	},
+}
+
+// RegisterCapability registers the check of a capability, so that the tests can rely on the registry instead of
+// checking the version of the cluster by themselves
+func RegisterCapability(c Capability, check CapabilityCheck) {
+	capabilitiesLock.Lock()
+	defer capabilitiesLock.Unlock()
+	capabilities[c] = check
+}
+
+// Capabilities returns the names of all the registered capabilities
+func Capabilities() []Capability {
+	capabilitiesLock.RLock()
+	defer capabilitiesLock.RUnlock()
+	result := make([]Capability, 0, len(capabilities))
+	for c := range capabilities {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+var capabilitiesLock sync.RWMutex
+
+// CapabilityCache the capabilities detected in the clusters, by cluster (ie, by rest config) since the capabilities do not change
+// during a test run. The awaitilities share a default cache, unless they are given their own cache.
+type CapabilityCache struct {
+	sync.RWMutex
+	detected map[*rest.Config]map[Capability]bool
+}
+
+// NewCapabilityCache returns a new, empty CapabilityCache
+func NewCapabilityCache() *CapabilityCache {
+	return &CapabilityCache{
+		detected: map[*rest.Config]map[Capability]bool{},
+	}
+}
+
+var defaultCapabilityCache = NewCapabilityCache()
+
+func (c *CapabilityCache) get(config *rest.Config, capability Capability) (bool, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	detected, cached := c.detected[config][capability]
+	return detected, cached
+}
+
+func (c *CapabilityCache) set(config *rest.Config, capability Capability, detected bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.detected[config] == nil {
+		c.detected[config] = map[Capability]bool{}
+	}
+	c.detected[config][capability] = detected
+}
+
+// HasCapability returns `true` if the cluster has the given capability. The result is cached for the cluster,
+// in the capability cache of the awaitility.
+func (a *Awaitility) HasCapability(c Capability) (bool, error) {
+	capabilitiesLock.RLock()
+	check, registered := capabilities[c]
+	capabilitiesLock.RUnlock()
+	if !registered {
+		return false, fmt.Errorf("unknown capability '%s'", c)
+	}
+	cache := a.capabilityCache()
+	if detected, cached := cache.get(a.RestConfig, c); cached && a.RestConfig != nil {
+		return detected, nil
+	}
+	detected, err := check(a)
+	if err != nil {
+		return false, err
+	}
+	if a.RestConfig != nil {
+		cache.set(a.RestConfig, c, detected)
+	}
+	return detected, nil
+}
+
+// capabilityCache returns the cache of the capabilities detected by the awaitility
+func (a *Awaitility) capabilityCache() *CapabilityCache {
+	if a.CapabilityCache != nil {
+		return a.CapabilityCache
+	}
+	return defaultCapabilityCache
+}
+
+// SkipUnlessCapable skips the current test if the cluster does not have all the given capabilities
+func (a *Awaitility) SkipUnlessCapable(t *testing.T, capabilities ...Capability) {
+	for _, c := range capabilities {
+		capable, err := a.HasCapability(c)
+		require.NoError(t, err)
+		if !capable {
+			t.Skipf("skipping test because capability '%s' is not available in the %s cluster", c, a.Type)
+		}
+	}
+}
+
+// ClusterVersion the versions of Kubernetes and OpenShift (if applicable) of a cluster
+type ClusterVersion struct {
+	Major int
+	Minor int
+	// GitVersion the full Kubernetes version, eg. `v1.25.7+eab9cc9`
+	GitVersion string
+	// OpenShift the OpenShift version, empty if the cluster is not an OpenShift cluster
+	OpenShift string
+}
+
+// AtLeast returns `true` if the Kubernetes version is greater than or equal to the given major/minor version
+func (v ClusterVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+func (v ClusterVersion) String() string {
+	if v.OpenShift != "" {
+		return fmt.Sprintf("Kubernetes %s (OpenShift %s)", v.GitVersion, v.OpenShift)
+	}
+	return fmt.Sprintf("Kubernetes %s", v.GitVersion)
+}
+
+// ParseClusterVersion returns the ClusterVersion with the given major and minor versions, as reported by the API server
+// (the minor version may have a trailing `+`, eg. `25+`)
+func ParseClusterVersion(major, minor, gitVersion string) (ClusterVersion, error) {
+	v := ClusterVersion{GitVersion: gitVersion}
+	var err error
+	if v.Major, err = strconv.Atoi(strings.TrimSuffix(major, "+")); err != nil {
+		return v, fmt.Errorf("invalid major version '%s'", major)
+	}
+	if v.Minor, err = strconv.Atoi(strings.TrimSuffix(minor, "+")); err != nil {
+		return v, fmt.Errorf("invalid minor version '%s'", minor)
+	}
+	return v, nil
+}
+
+var clusterVersionGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterVersion"}
+
+// ClusterVersion returns the versions of Kubernetes and OpenShift (if applicable) of the cluster
+func (a *Awaitility) ClusterVersion() (ClusterVersion, error) {
+	discoveryClient, err := a.discoveryClient()
+	if err != nil {
+		return ClusterVersion{}, err
+	}
+	info, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return ClusterVersion{}, err
+	}
+	v, err := ParseClusterVersion(info.Major, info.Minor, info.GitVersion)
+	if err != nil {
+		return v, err
+	}
+	clusterVersion := &unstructured.Unstructured{}
+	clusterVersion.SetGroupVersionKind(clusterVersionGVK)
+	if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: "version"}, clusterVersion); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return v, nil
+		}
+		return v, err
+	}
+	v.OpenShift, _, _ = unstructured.NestedString(clusterVersion.Object, "status", "desired", "version")
+	return v, nil
+}
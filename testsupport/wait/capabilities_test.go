@@ -0,0 +1,133 @@
+package wait_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseClusterVersion(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// when
+		v, err := wait.ParseClusterVersion("1", "25+", "v1.25.7+eab9cc9")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, v.Major)
+		assert.Equal(t, 25, v.Minor)
+		assert.True(t, v.AtLeast(1, 25))
+		assert.True(t, v.AtLeast(1, 23))
+		assert.False(t, v.AtLeast(1, 26))
+		assert.False(t, v.AtLeast(2, 0))
+		assert.Equal(t, "Kubernetes v1.25.7+eab9cc9", v.String())
+
+		v.OpenShift = "4.12.9"
+		assert.Equal(t, "Kubernetes v1.25.7+eab9cc9 (OpenShift 4.12.9)", v.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// when
+		_, err := wait.ParseClusterVersion("1", "x", "")
+
+		// then
+		require.EqualError(t, err, "invalid minor version 'x'")
+	})
+}
+
+func TestHasCapability(t *testing.T) {
+	// given
+	await := &wait.Awaitility{
+		Type: "member",
+	}
+	wait.RegisterCapability("CapableForTest", func(a *wait.Awaitility) (bool, error) {
+		return true, nil
+	})
+	wait.RegisterCapability("IncapableForTest", func(a *wait.Awaitility) (bool, error) {
+		return false, nil
+	})
+	wait.RegisterCapability("FailingForTest", func(a *wait.Awaitility) (bool, error) {
+		return false, fmt.Errorf("mock error")
+	})
+
+	t.Run("registered", func(t *testing.T) {
+		assert.Contains(t, wait.Capabilities(), wait.CapabilityPodSecurityAdmission)
+		assert.Contains(t, wait.Capabilities(), wait.Capability("CapableForTest"))
+	})
+
+	t.Run("capable", func(t *testing.T) {
+		// when
+		capable, err := await.HasCapability("CapableForTest")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, capable)
+	})
+
+	t.Run("incapable", func(t *testing.T) {
+		// when
+		capable, err := await.HasCapability("IncapableForTest")
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, capable)
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		// when
+		_, err := await.HasCapability("FailingForTest")
+
+		// then
+		require.EqualError(t, err, "mock error")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		// when
+		_, err := await.HasCapability("Unknown")
+
+		// then
+		require.EqualError(t, err, "unknown capability 'Unknown'")
+	})
+
+	t.Run("skip unless capable", func(t *testing.T) {
+		// when
+		skipped := t.Run("incapable", func(t *testing.T) {
+			await.SkipUnlessCapable(t, "CapableForTest", "IncapableForTest")
+			t.Fatal("test should have been skipped")
+		})
+
+		// then
+		assert.True(t, skipped)
+	})
+
+	t.Run("cached in the cache of the awaitility", func(t *testing.T) {
+		// given
+		checks := 0
+		wait.RegisterCapability("CountedForTest", func(a *wait.Awaitility) (bool, error) {
+			checks++
+			return true, nil
+		})
+		cached := &wait.Awaitility{
+			RestConfig:      &rest.Config{},
+			CapabilityCache: wait.NewCapabilityCache(),
+		}
+		other := &wait.Awaitility{
+			RestConfig:      cached.RestConfig,
+			CapabilityCache: wait.NewCapabilityCache(),
+		}
+
+		// when
+		for i := 0; i < 3; i++ {
+			_, err := cached.HasCapability("CountedForTest")
+			require.NoError(t, err)
+		}
+		_, err := other.HasCapability("CountedForTest")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, checks) // once per cache
+	})
+}
@@ -0,0 +1,81 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrReconcileChurn the error returned by WaitForNoReconcileChurn when the object was updated more often than allowed
+// during the observation window
+type ErrReconcileChurn struct {
+	// Kind the kind of the object (eg, `NSTemplateSet`)
+	Kind string
+	// Name the name of the object
+	Name string
+	// Window the duration during which the object was observed
+	Window time.Duration
+	// Updates the number of changes of the resource version which were observed during the window
+	Updates int
+	// GenerationChanges the number of changes of the generation (ie, of the spec) which were observed during the window
+	GenerationChanges int
+	// MaxUpdates the max number of updates which were allowed during the window
+	MaxUpdates int
+}
+
+var _ error = &ErrReconcileChurn{}
+
+func (e *ErrReconcileChurn) Error() string {
+	return fmt.Sprintf("%s '%s' was updated at least %d time(s) in %s (including %d change(s) of its generation), while at most %d update(s) were expected",
+		e.Kind, e.Name, e.Updates, e.Window, e.GenerationChanges, e.MaxUpdates)
+}
+
+// WaitForNoReconcileChurn samples the resource version and the generation of the given object during the whole window,
+// and verifies that the object was not updated more than maxUpdates times, to catch the controllers which keep on
+// updating a resource (its spec or its status) while nothing should change (ie, a hot-loop during the reconcile).
+// The object is looked up by the namespace and name of the given object, and with its type.
+// Note: the object is sampled at every retry interval, hence several updates between two samples are counted as a
+// single one, and the number of updates reported in the ErrReconcileChurn is a lower bound.
+// Returns an ErrReconcileChurn if the object was updated more than maxUpdates times during the window.
+func (a *Awaitility) WaitForNoReconcileChurn(t *testing.T, obj client.Object, window time.Duration, maxUpdates int) error {
+	kind := reflect.TypeOf(obj).Elem().Name()
+	key := client.ObjectKeyFromObject(obj)
+	t.Logf("verifying that %s '%s' is not updated more than %d time(s) in %s", kind, key, maxUpdates, window)
+	var resourceVersion string
+	var generation int64
+	updates, generationChanges := 0, 0
+	start := time.Now()
+	for {
+		actual := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+		if err := a.Client.Get(context.TODO(), key, actual); err != nil {
+			return err
+		}
+		if resourceVersion != "" && actual.GetResourceVersion() != resourceVersion {
+			updates++
+			t.Logf("%s '%s' was updated after %s (resource version: '%s', generation: %d)", kind, key, time.Since(start).Round(time.Millisecond), actual.GetResourceVersion(), actual.GetGeneration())
+			if actual.GetGeneration() != generation {
+				generationChanges++
+			}
+		}
+		resourceVersion = actual.GetResourceVersion()
+		generation = actual.GetGeneration()
+		if updates > maxUpdates {
+			return &ErrReconcileChurn{
+				Kind:              kind,
+				Name:              key.String(),
+				Window:            window,
+				Updates:           updates,
+				GenerationChanges: generationChanges,
+				MaxUpdates:        maxUpdates,
+			}
+		}
+		if time.Since(start) >= window {
+			return nil
+		}
+		time.Sleep(a.RetryInterval)
+	}
+}
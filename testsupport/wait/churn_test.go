@@ -0,0 +1,106 @@
+package wait_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitForNoReconcileChurn(t *testing.T) {
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config",
+				Namespace: commontest.HostOperatorNs,
+			},
+		}
+	}
+	newAwaitility := func(t *testing.T, obj *corev1.ConfigMap) *wait.Awaitility {
+		cl := commontest.NewFakeClient(t)
+		require.NoError(t, cl.Create(context.TODO(), obj))
+		return &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: 5 * time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}
+	}
+	// update updates the ConfigMap every 10ms, until the given channel is closed
+	update := func(await *wait.Awaitility, done chan struct{}) {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				cm := &corev1.ConfigMap{}
+				if err := await.Client.Get(context.TODO(), commontest.NamespacedName(commontest.HostOperatorNs, "config"), cm); err != nil {
+					return
+				}
+				cm.Data = map[string]string{"count": strconv.Itoa(i)}
+				_ = await.Client.Update(context.TODO(), cm)
+			}
+		}
+	}
+
+	t.Run("no update", func(t *testing.T) {
+		// given
+		cm := newConfigMap()
+		await := newAwaitility(t, cm)
+
+		// when
+		start := time.Now()
+		err := await.WaitForNoReconcileChurn(t, cm, 50*time.Millisecond, 0)
+
+		// then
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("updates below the threshold", func(t *testing.T) {
+		// given
+		cm := newConfigMap()
+		await := newAwaitility(t, cm)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			cm := newConfigMap()
+			if err := await.Client.Get(context.TODO(), commontest.NamespacedName(commontest.HostOperatorNs, "config"), cm); err != nil {
+				return
+			}
+			cm.Data = map[string]string{"key": "value"}
+			_ = await.Client.Update(context.TODO(), cm)
+		}()
+
+		// when
+		err := await.WaitForNoReconcileChurn(t, cm, 50*time.Millisecond, 1)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("churn", func(t *testing.T) {
+		// given
+		cm := newConfigMap()
+		await := newAwaitility(t, cm)
+		done := make(chan struct{})
+		defer close(done)
+		go update(await, done)
+
+		// when
+		err := await.WaitForNoReconcileChurn(t, cm, 100*time.Millisecond, 2)
+
+		// then
+		require.Error(t, err)
+		churnErr := &wait.ErrReconcileChurn{}
+		require.ErrorAs(t, err, &churnErr)
+		assert.Equal(t, 3, churnErr.Updates)
+		assert.Equal(t, 2, churnErr.MaxUpdates)
+	})
+}
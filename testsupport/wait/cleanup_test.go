@@ -0,0 +1,44 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateWithCleanupManager(t *testing.T) {
+	// given
+	manager := cleanup.NewManager()
+	cl := commontest.NewFakeClient(t)
+	hostAwait := wait.NewHostAwaitility(nil, cl, commontest.HostOperatorNs, "registration-service")
+	hostAwait.CleanupManager = manager
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oddity",
+			Namespace: commontest.HostOperatorNs,
+		},
+	}
+
+	// when
+	t.Run("create", func(t *testing.T) {
+		require.NoError(t, hostAwait.CreateWithCleanup(t, space))
+	})
+
+	// then
+	// the object is deleted at the end of the subtest, by the manager of the awaitility
+	err := cl.Get(context.TODO(), client.ObjectKeyFromObject(space), &toolchainv1alpha1.Space{})
+	assert.True(t, errors.IsNotFound(err))
+	kinds, _ := manager.Timings()
+	require.Len(t, kinds, 1)
+	assert.Equal(t, "Space", kinds[0].Kind)
+	assert.Equal(t, 1, kinds[0].Count)
+}
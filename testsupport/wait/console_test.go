@@ -0,0 +1,80 @@
+package wait_test
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConsoleRoute(t *testing.T) {
+
+	t.Run("default route without config", func(t *testing.T) {
+		// when
+		route := wait.ConsoleRoute(nil)
+
+		// then
+		assert.Equal(t, types.NamespacedName{Namespace: "openshift-console", Name: "console"}, route)
+	})
+
+	t.Run("default route with empty config", func(t *testing.T) {
+		// when
+		route := wait.ConsoleRoute(&toolchainv1alpha1.MemberOperatorConfig{})
+
+		// then
+		assert.Equal(t, types.NamespacedName{Namespace: "openshift-console", Name: "console"}, route)
+	})
+
+	t.Run("configured route", func(t *testing.T) {
+		// given
+		namespace, name := "custom-console", "custom"
+		config := &toolchainv1alpha1.MemberOperatorConfig{}
+		config.Spec.Console.Namespace = &namespace
+		config.Spec.Console.RouteName = &name
+
+		// when
+		route := wait.ConsoleRoute(config)
+
+		// then
+		assert.Equal(t, types.NamespacedName{Namespace: "custom-console", Name: "custom"}, route)
+	})
+}
+
+func TestCheRoute(t *testing.T) {
+
+	t.Run("default route without config", func(t *testing.T) {
+		// when
+		route := wait.CheRoute(nil)
+
+		// then
+		assert.Equal(t, types.NamespacedName{Namespace: "codeready-workspaces-operator", Name: "codeready"}, route)
+	})
+
+	t.Run("configured route", func(t *testing.T) {
+		// given
+		namespace, name := "openshift-devspaces", "devspaces"
+		config := &toolchainv1alpha1.MemberOperatorConfig{}
+		config.Spec.Che.Namespace = &namespace
+		config.Spec.Che.RouteName = &name
+
+		// when
+		route := wait.CheRoute(config)
+
+		// then
+		assert.Equal(t, types.NamespacedName{Namespace: "openshift-devspaces", Name: "devspaces"}, route)
+	})
+}
+
+func TestConsoleURL(t *testing.T) {
+	// given
+	route := newRoute("console.apps.custom-domain.example.com", &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt})
+
+	// when
+	url := wait.ConsoleURL(route)
+
+	// then
+	assert.Equal(t, "https://console.apps.custom-domain.example.com/", url)
+}
@@ -24,3 +24,28 @@ func Diff(expected, actual interface{}) string {
 	msg.WriteString(cmp.Diff(expected, actual))
 	return msg.String()
 }
+
+// waitCriterion the common structure of the wait criteria of all the kinds of objects (eg, SpaceWaitCriterion)
+type waitCriterion[T any] struct {
+	Match func(*T) bool
+	Diff  func(*T) string
+}
+
+// criterionDiffs returns the diffs of the given criteria which are not matched by the given object of the given kind,
+// or a single diff if the object was not found
+func criterionDiffs[T any, C ~struct {
+	Match func(*T) bool
+	Diff  func(*T) string
+}](kind string, actual *T, criteria ...C) []string {
+	if actual == nil {
+		return []string{fmt.Sprintf("%s not found", kind)}
+	}
+	diffs := []string{}
+	for _, c := range criteria {
+		criterion := waitCriterion[T](c)
+		if !criterion.Match(actual) && criterion.Diff != nil {
+			diffs = append(diffs, criterion.Diff(actual))
+		}
+	}
+	return diffs
+}
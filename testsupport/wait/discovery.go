@@ -0,0 +1,59 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// SupportsAPI returns `true` if the API server of the cluster serves the given kind in the given group/version,
+// eg. if the CRD of an optional component (KubeVirt, AAP, Che, etc.) is installed
+func (a *Awaitility) SupportsAPI(gvk schema.GroupVersionKind) (bool, error) {
+	discoveryClient, err := a.discoveryClient()
+	if err != nil {
+		return false, err
+	}
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Kind == gvk.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// discoveryClient returns the discovery client of the awaitility, or a new one created from its rest config
+func (a *Awaitility) discoveryClient() (discovery.DiscoveryInterface, error) {
+	if a.DiscoveryClient != nil {
+		return a.DiscoveryClient, nil
+	}
+	return discovery.NewDiscoveryClientForConfig(a.RestConfig)
+}
+
+// WaitForCRD waits until the API server of the cluster serves the given kind in the given group/version
+// (ie, until the corresponding CRD is installed and established)
+func (a *Awaitility) WaitForCRD(t *testing.T, gvk schema.GroupVersionKind) {
+	t.Logf("waiting for API '%s' to be available in the %s cluster", gvk.String(), a.Type)
+	err := a.poll(func() (done bool, err error) {
+		return a.SupportsAPI(gvk)
+	})
+	require.NoError(t, err, "API '%s' not available in the %s cluster", gvk.String(), a.Type)
+}
+
+// SkipUnlessAPISupported skips the current test if the API server of the cluster does not serve the given kind in the given group/version
+func (a *Awaitility) SkipUnlessAPISupported(t *testing.T, gvk schema.GroupVersionKind) {
+	supported, err := a.SupportsAPI(gvk)
+	require.NoError(t, err)
+	if !supported {
+		t.Skipf("skipping test because API '%s' is not available in the %s cluster", gvk.String(), a.Type)
+	}
+}
@@ -0,0 +1,155 @@
+package wait_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var (
+	virtualMachineGVK = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachine"}
+	virtualMachines   = &metav1.APIResourceList{
+		GroupVersion: "kubevirt.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "virtualmachines", Kind: "VirtualMachine", Namespaced: true},
+			{Name: "virtualmachineinstances", Kind: "VirtualMachineInstance", Namespaced: true},
+		},
+	}
+)
+
+// failingDiscovery a fake discovery client which fails to return the resources of any group/version
+type failingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (d failingDiscovery) ServerResourcesForGroupVersion(string) (*metav1.APIResourceList, error) {
+	return nil, fmt.Errorf("mock error")
+}
+
+func TestSupportsAPI(t *testing.T) {
+	// newAwaitility returns an awaitility whose discovery client serves the given resources
+	newAwaitility := func(resources ...*metav1.APIResourceList) *wait.Awaitility {
+		return &wait.Awaitility{
+			Type: "member",
+			DiscoveryClient: &fakediscovery.FakeDiscovery{
+				Fake: &clienttesting.Fake{Resources: resources},
+			},
+		}
+	}
+
+	t.Run("supported", func(t *testing.T) {
+		// given
+		await := newAwaitility(virtualMachines)
+
+		// when
+		supported, err := await.SupportsAPI(virtualMachineGVK)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, supported)
+	})
+
+	t.Run("kind not served in the group/version", func(t *testing.T) {
+		// given
+		await := newAwaitility(virtualMachines)
+
+		// when
+		supported, err := await.SupportsAPI(virtualMachineGVK.GroupVersion().WithKind("VirtualMachineExport"))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, supported)
+	})
+
+	t.Run("group/version not served", func(t *testing.T) {
+		// given
+		await := newAwaitility(virtualMachines)
+
+		// when
+		supported, err := await.SupportsAPI(schema.GroupVersionKind{Group: "kubevirt.io", Version: "v2", Kind: "VirtualMachine"})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, supported)
+	})
+
+	t.Run("discovery fails", func(t *testing.T) {
+		// given
+		await := &wait.Awaitility{
+			DiscoveryClient: failingDiscovery{&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}},
+		}
+
+		// when
+		_, err := await.SupportsAPI(virtualMachineGVK)
+
+		// then
+		require.EqualError(t, err, "mock error")
+	})
+
+	t.Run("skip unless supported", func(t *testing.T) {
+		t.Run("supported", func(t *testing.T) {
+			// given
+			await := newAwaitility(virtualMachines)
+			var skipped bool
+
+			// when
+			t.Run("test", func(t *testing.T) {
+				defer func() { skipped = t.Skipped() }()
+				await.SkipUnlessAPISupported(t, virtualMachineGVK)
+			})
+
+			// then
+			assert.False(t, skipped)
+		})
+
+		t.Run("not supported", func(t *testing.T) {
+			// given
+			await := newAwaitility()
+			var skipped bool
+
+			// when
+			t.Run("test", func(t *testing.T) {
+				defer func() { skipped = t.Skipped() }()
+				await.SkipUnlessAPISupported(t, virtualMachineGVK)
+			})
+
+			// then
+			assert.True(t, skipped)
+		})
+	})
+}
+
+func TestWaitForCRD(t *testing.T) {
+	// given
+	discovery := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	calls := 0
+	// the CRD is installed after a few discoveries
+	discovery.AddReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls == 3 {
+			discovery.Resources = append(discovery.Resources, virtualMachines)
+		}
+		return false, nil, nil
+	})
+	await := &wait.Awaitility{
+		Type:            "member",
+		DiscoveryClient: discovery,
+		RetryInterval:   time.Millisecond,
+		Timeout:         time.Second,
+	}
+
+	// when
+	await.WaitForCRD(t, virtualMachineGVK)
+
+	// then
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,82 @@
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ErrTimeout the error returned by the waiters when the object did not match the criteria before the timeout.
+// It wraps `wait.ErrWaitTimeout`, so that `errors.Is(err, wait.ErrWaitTimeout)` still holds, and it can be retrieved
+// with `errors.As` to branch on the reason of the timeout (see `IsNotFoundYet` and `IsFoundButMismatched`).
+type ErrTimeout struct {
+	// Kind the kind of the object which was waited for (eg, `Space`)
+	Kind string
+	// Name the name of the object which was waited for (or a description of the lookup when the object is looked up by labels)
+	Name string
+	// Found whether the object was found at least once during the wait
+	Found bool
+	// LastState the last observed state of the object (eg, its conditions), or `not found` if the object was not found at the last check
+	LastState string
+	// UnmetCriteria the diffs of the criteria which were not met by the last observed object
+	UnmetCriteria []string
+	// Timeout the duration of the wait
+	Timeout time.Duration
+	// history the transitions of the states observed during the wait
+	history *stateHistory
+}
+
+var _ error = &ErrTimeout{}
+
+func (e *ErrTimeout) Error() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%s: %s '%s' did not match the criteria after %s", wait.ErrWaitTimeout, e.Kind, e.Name, e.Timeout)
+	if len(e.UnmetCriteria) > 0 {
+		fmt.Fprintf(buf, "\nunmet criteria:\n  %s", strings.Join(e.UnmetCriteria, "\n  "))
+	}
+	if e.history != nil {
+		fmt.Fprintf(buf, "\nobserved states:\n%s", e.history)
+	}
+	return buf.String()
+}
+
+// Unwrap returns `wait.ErrWaitTimeout`
+func (e *ErrTimeout) Unwrap() error {
+	return wait.ErrWaitTimeout
+}
+
+// NotFound returns true if the object was never found during the wait
+func (e *ErrTimeout) NotFound() bool {
+	return !e.Found
+}
+
+// IsNotFoundYet returns true if the given error is an ErrTimeout of a wait during which the object was never found
+func IsNotFoundYet(err error) bool {
+	timeoutErr := &ErrTimeout{}
+	return errors.As(err, &timeoutErr) && timeoutErr.NotFound()
+}
+
+// IsFoundButMismatched returns true if the given error is an ErrTimeout of a wait during which the object was found,
+// but did not match the criteria
+func IsFoundButMismatched(err error) bool {
+	timeoutErr := &ErrTimeout{}
+	return errors.As(err, &timeoutErr) && !timeoutErr.NotFound()
+}
+
+// TransientErrorKind returns the kind of the given error if it is a transient error of the API server (ie, a `429 Too Many Requests`
+// or a 5xx response), which can be retried instead of failing the wait
+func TransientErrorKind(err error) (report.ErrorKind, bool) {
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		return report.ErrorThrottled, true
+	case apierrors.IsInternalError(err), apierrors.IsServerTimeout(err), apierrors.IsServiceUnavailable(err), apierrors.IsTimeout(err):
+		return report.ErrorServer, true
+	default:
+		return "", false
+	}
+}
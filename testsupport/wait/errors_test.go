@@ -0,0 +1,154 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestErrTimeout(t *testing.T) {
+
+	t.Run("not found yet", func(t *testing.T) {
+		// given
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "unknown")
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+		timeoutErr := &wait.ErrTimeout{}
+		require.True(t, errors.As(err, &timeoutErr))
+		assert.Equal(t, "UserAccount", timeoutErr.Kind)
+		assert.Equal(t, "unknown", timeoutErr.Name)
+		assert.False(t, timeoutErr.Found)
+		assert.True(t, timeoutErr.NotFound())
+		assert.True(t, wait.IsNotFoundYet(err))
+		assert.False(t, wait.IsFoundButMismatched(err))
+	})
+
+	t.Run("found but mismatched", func(t *testing.T) {
+		// given
+		userAccount := &toolchainv1alpha1.UserAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: commontest.MemberOperatorNs,
+				Name:      "johnsmith",
+			},
+			Status: toolchainv1alpha1.UserAccountStatus{
+				Conditions: []toolchainv1alpha1.Condition{
+					{
+						Type:   toolchainv1alpha1.ConditionReady,
+						Status: corev1.ConditionFalse,
+						Reason: "Provisioning",
+					},
+				},
+			},
+		}
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t, userAccount), commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "johnsmith", wait.UntilUserAccountHasConditions(toolchainv1alpha1.Condition{
+			Type:   toolchainv1alpha1.ConditionReady,
+			Status: corev1.ConditionTrue,
+			Reason: "Provisioned",
+		}))
+
+		// then
+		timeoutErr := &wait.ErrTimeout{}
+		require.True(t, errors.As(err, &timeoutErr))
+		assert.Equal(t, "Ready=False(Provisioning)", timeoutErr.LastState)
+		assert.Len(t, timeoutErr.UnmetCriteria, 1)
+		assert.False(t, wait.IsNotFoundYet(err))
+		assert.True(t, wait.IsFoundButMismatched(err))
+	})
+
+	t.Run("found, then deleted", func(t *testing.T) {
+		// given
+		spaceBinding := &toolchainv1alpha1.SpaceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: commontest.HostOperatorNs,
+				Name:      "johnsmith-oddity",
+				Labels: map[string]string{
+					toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: "johnsmith",
+					toolchainv1alpha1.SpaceBindingSpaceLabelKey:            "oddity",
+				},
+			},
+			Spec: toolchainv1alpha1.SpaceBindingSpec{
+				SpaceRole: "viewer",
+			},
+		}
+		cl := commontest.NewFakeClient(t, spaceBinding)
+		// the SpaceBinding is deleted once it was listed
+		cl.MockList = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			if err := cl.Client.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			return client.IgnoreNotFound(cl.Client.Delete(ctx, spaceBinding))
+		}
+		await := wait.NewHostAwaitility(nil, cl, commontest.HostOperatorNs, "registration-service").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+
+		// when
+		_, err := await.WaitForSpaceBinding(t, "johnsmith", "oddity", wait.UntilSpaceBindingHasSpaceRole("admin"))
+
+		// then
+		timeoutErr := &wait.ErrTimeout{}
+		require.True(t, errors.As(err, &timeoutErr))
+		// the last observed state is the one of a missing object, but the object was found during the wait
+		assert.Equal(t, "not found", timeoutErr.LastState)
+		assert.True(t, timeoutErr.Found)
+		assert.False(t, wait.IsNotFoundYet(err))
+		assert.True(t, wait.IsFoundButMismatched(err))
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		// when
+		err := errors.New("mock error")
+
+		// then
+		assert.False(t, wait.IsNotFoundYet(err))
+		assert.False(t, wait.IsFoundButMismatched(err))
+	})
+}
+
+func TestTransientErrorKind(t *testing.T) {
+	gr := schema.GroupResource{Group: "toolchain.dev.openshift.com", Resource: "spaces"}
+	for name, tc := range map[string]struct {
+		err          error
+		expectedKind report.ErrorKind
+		transient    bool
+	}{
+		"too many requests":   {err: apierrors.NewTooManyRequests("slow down", 1), expectedKind: report.ErrorThrottled, transient: true},
+		"internal error":      {err: apierrors.NewInternalError(errors.New("oops")), expectedKind: report.ErrorServer, transient: true},
+		"server timeout":      {err: apierrors.NewServerTimeout(gr, "get", 1), expectedKind: report.ErrorServer, transient: true},
+		"service unavailable": {err: apierrors.NewServiceUnavailable("unavailable"), expectedKind: report.ErrorServer, transient: true},
+		"not found":           {err: apierrors.NewNotFound(gr, "oddity")},
+		"forbidden":           {err: apierrors.NewForbidden(gr, "oddity", errors.New("denied"))},
+		"no error":            {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			kind, transient := wait.TransientErrorKind(tc.err)
+
+			// then
+			assert.Equal(t, tc.transient, transient)
+			assert.Equal(t, tc.expectedKind, kind)
+		})
+	}
+}
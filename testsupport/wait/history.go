@@ -0,0 +1,88 @@
+package wait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+)
+
+const (
+	// stateHistorySize the max number of state transitions kept during a wait (the oldest ones are dropped)
+	stateHistorySize = 20
+	// notObservedState the state of an object which was not found (yet) during a wait
+	notObservedState = "not found"
+)
+
+// stateHistory a ring buffer of the (condensed) states of an object observed during a wait, in which a state is only
+// recorded when it differs from the previous one, so that the history shows the transitions (eg, the condition flips)
+type stateHistory struct {
+	start   time.Time
+	entries []observedState
+	next    int
+	total   int
+	last    string
+}
+
+type observedState struct {
+	elapsed time.Duration
+	state   string
+}
+
+func newStateHistory(size int) *stateHistory {
+	return &stateHistory{
+		start:   time.Now(),
+		entries: make([]observedState, size),
+	}
+}
+
+// observe records the given state, unless it is the same as the previously observed one
+func (h *stateHistory) observe(state string) {
+	if h.total > 0 && state == h.last {
+		return
+	}
+	h.entries[h.next] = observedState{
+		elapsed: time.Since(h.start),
+		state:   state,
+	}
+	h.next = (h.next + 1) % len(h.entries)
+	h.total++
+	h.last = state
+}
+
+// transitions returns the recorded states, from the oldest to the most recent one
+func (h *stateHistory) transitions() []observedState {
+	if h.total < len(h.entries) {
+		return h.entries[:h.total]
+	}
+	return append(append([]observedState{}, h.entries[h.next:]...), h.entries[:h.next]...)
+}
+
+func (h *stateHistory) String() string {
+	buf := &strings.Builder{}
+	if dropped := h.total - len(h.entries); dropped > 0 {
+		fmt.Fprintf(buf, "  (%d earlier states dropped)\n", dropped)
+	}
+	for _, s := range h.transitions() {
+		fmt.Fprintf(buf, "  +%s: %s\n", s.elapsed.Round(time.Millisecond), s.state)
+	}
+	return buf.String()
+}
+
+// conditionsState returns the condensed state of the given conditions, sorted by type (eg, `Ready=False(Provisioning)`)
+func conditionsState(conditions []toolchainv1alpha1.Condition) string {
+	if len(conditions) == 0 {
+		return "no conditions"
+	}
+	states := make([]string, len(conditions))
+	for i, c := range conditions {
+		states[i] = fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			states[i] += fmt.Sprintf("(%s)", c.Reason)
+		}
+	}
+	sort.Strings(states)
+	return strings.Join(states, ", ")
+}
@@ -13,10 +13,10 @@ import (
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
 	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
-	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	"github.com/codeready-toolchain/toolchain-common/pkg/spacebinding"
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/hash"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ghodss/yaml"
 	"github.com/redhat-cop/operator-utils/pkg/util"
@@ -45,12 +45,14 @@ type HostAwaitility struct {
 func NewHostAwaitility(cfg *rest.Config, cl client.Client, ns string, registrationServiceNs string) *HostAwaitility {
 	return &HostAwaitility{
 		Awaitility: &Awaitility{
-			Client:        cl,
-			RestConfig:    cfg,
-			Namespace:     ns,
-			Type:          cluster.Host,
-			RetryInterval: DefaultRetryInterval,
-			Timeout:       DefaultTimeout,
+			Client:           cl,
+			RestConfig:       cfg,
+			Namespace:        ns,
+			Type:             cluster.Host,
+			RetryInterval:    DefaultRetryInterval,
+			Timeout:          DefaultTimeout,
+			Backoff:          DefaultBackoff,
+			ProgressInterval: progressIntervalFromEnv(),
 		},
 		RegistrationServiceNs: registrationServiceNs,
 	}
@@ -157,7 +159,14 @@ func (a *HostAwaitility) allResources() ([]runtime.Object, error) {
 func (a *HostAwaitility) WaitForMasterUserRecord(t *testing.T, name string, criteria ...MasterUserRecordWaitCriterion) (*toolchainv1alpha1.MasterUserRecord, error) {
 	t.Logf("waiting for MasterUserRecord '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var mur *toolchainv1alpha1.MasterUserRecord
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("MasterUserRecord", mur, criteria...) }
+	observed := func() (string, bool) {
+		if mur == nil {
+			return notObservedState, false
+		}
+		return conditionsState(mur.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, a.Timeout, "MasterUserRecord", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -184,109 +193,48 @@ func (a *HostAwaitility) GetMasterUserRecord(name string) (*toolchainv1alpha1.Ma
 }
 
 // UpdateMasterUserRecordSpec tries to update the Spec of the given MasterUserRecord
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated MasterUserRecord
 func (a *HostAwaitility) UpdateMasterUserRecordSpec(t *testing.T, murName string, modifyMur func(mur *toolchainv1alpha1.MasterUserRecord)) (*toolchainv1alpha1.MasterUserRecord, error) {
 	return a.UpdateMasterUserRecord(t, false, murName, modifyMur)
 }
 
 // UpdateMasterUserRecordStatus tries to update the Status of the given MasterUserRecord
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated MasterUserRecord
 func (a *HostAwaitility) UpdateMasterUserRecordStatus(t *testing.T, murName string, modifyMur func(mur *toolchainv1alpha1.MasterUserRecord)) (*toolchainv1alpha1.MasterUserRecord, error) {
 	return a.UpdateMasterUserRecord(t, true, murName, modifyMur)
 }
 
 // UpdateMasterUserRecord tries to update the Spec or the Status of the given MasterUserRecord
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated MasterUserRecord
 func (a *HostAwaitility) UpdateMasterUserRecord(t *testing.T, status bool, murName string, modifyMur func(mur *toolchainv1alpha1.MasterUserRecord)) (*toolchainv1alpha1.MasterUserRecord, error) {
-	var m *toolchainv1alpha1.MasterUserRecord
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshMur := &toolchainv1alpha1.MasterUserRecord{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: murName}, freshMur); err != nil {
-			return true, err
-		}
-
-		modifyMur(freshMur)
-		if status {
-			// Update status
-			if err := a.Client.Status().Update(context.TODO(), freshMur); err != nil {
-				t.Logf("error updating MasterUserRecord.Status '%s': %s. Will retry again...", murName, err.Error())
-				return false, nil
-			}
-		} else if err := a.Client.Update(context.TODO(), freshMur); err != nil {
-			t.Logf("error updating MasterUserRecord.Spec '%s': %s. Will retry again...", murName, err.Error())
-			return false, nil
-		}
-		m = freshMur
-		return true, nil
-	})
-	return m, err
+	if status {
+		return UpdateStatus(t, a.Awaitility, murName, modifyMur)
+	}
+	return Update(t, a.Awaitility, murName, modifyMur)
 }
 
 // UpdateUserSignup tries to update the Spec of the given UserSignup
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated UserSignup
 func (a *HostAwaitility) UpdateUserSignup(t *testing.T, userSignupName string, modifyUserSignup func(us *toolchainv1alpha1.UserSignup)) (*toolchainv1alpha1.UserSignup, error) {
-	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshUserSignup := &toolchainv1alpha1.UserSignup{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: userSignupName}, freshUserSignup); err != nil {
-			return true, err
-		}
-
-		modifyUserSignup(freshUserSignup)
-		if err := a.Client.Update(context.TODO(), freshUserSignup); err != nil {
-			t.Logf("error updating UserSignup '%s': %s. Will retry again...", userSignupName, err.Error())
-			return false, nil
-		}
-		userSignup = freshUserSignup
-		return true, nil
-	})
-	return userSignup, err
+	return Update(t, a.Awaitility, userSignupName, modifyUserSignup)
 }
 
 // UpdateSpace tries to update the Spec of the given Space
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated Space
 func (a *HostAwaitility) UpdateSpace(t *testing.T, spaceName string, modifySpace func(s *toolchainv1alpha1.Space)) (*toolchainv1alpha1.Space, error) {
-	var s *toolchainv1alpha1.Space
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshSpace := &toolchainv1alpha1.Space{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: spaceName}, freshSpace); err != nil {
-			return true, err
-		}
-		modifySpace(freshSpace)
-		if err := a.Client.Update(context.TODO(), freshSpace); err != nil {
-			t.Logf("error updating Space '%s': %s. Will retry again...", spaceName, err.Error())
-			return false, nil
-		}
-		s = freshSpace
-		return true, nil
-	})
-	return s, err
+	return Update(t, a.Awaitility, spaceName, modifySpace)
 }
 
 // UpdateSpaceBinding tries to update the Spec of the given SpaceBinding
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated SpaceBinding
 func (a *HostAwaitility) UpdateSpaceBinding(t *testing.T, spaceBindingName string, modifySpaceBinding func(s *toolchainv1alpha1.SpaceBinding)) (*toolchainv1alpha1.SpaceBinding, error) {
-	var s *toolchainv1alpha1.SpaceBinding
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshSpaceBinding := &toolchainv1alpha1.SpaceBinding{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: spaceBindingName}, freshSpaceBinding); err != nil {
-			return true, err
-		}
-		modifySpaceBinding(freshSpaceBinding)
-		if err := a.Client.Update(context.TODO(), freshSpaceBinding); err != nil {
-			t.Logf("error updating SpaceBinding '%s': %s. Will retry again...", spaceBindingName, err.Error())
-			return false, nil
-		}
-		s = freshSpaceBinding
-		return true, nil
-	})
-	return s, err
+	return Update(t, a.Awaitility, spaceBindingName, modifySpaceBinding)
 }
 
 // MasterUserRecordWaitCriterion a struct to compare with an expected MasterUserRecord
@@ -316,11 +264,9 @@ func (a *HostAwaitility) printMasterUserRecordWaitCriterionDiffs(t *testing.T, a
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("MasterUserRecord", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	// also include other resources relevant in the host namespace, to help troubleshooting
@@ -361,7 +307,7 @@ func UntilMasterUserRecordHasConditions(expected ...toolchainv1alpha1.Condition)
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.MasterUserRecord) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -408,6 +354,47 @@ func UntilMasterUserRecordHasTierName(expected string) MasterUserRecordWaitCrite
 	}
 }
 
+// UntilMasterUserRecordIsRecreated returns a `MasterUserRecordWaitCriterion` which checks that the given
+// MasterUserRecord has a UID that is different from the given one, ie, that it was deleted and created again
+func UntilMasterUserRecordIsRecreated(previousUID types.UID) MasterUserRecordWaitCriterion {
+	return MasterUserRecordWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.MasterUserRecord) bool {
+			return actual.UID != previousUID
+		},
+		Diff: func(actual *toolchainv1alpha1.MasterUserRecord) string {
+			return fmt.Sprintf("expected MasterUserRecord to be recreated, but it still has the UID '%s'", previousUID)
+		},
+	}
+}
+
+// UntilMasterUserRecordIsNotReady returns a `MasterUserRecordWaitCriterion` which checks that the given
+// MasterUserRecord has a `Ready` condition with the `False` status and the given reason (regardless of its message)
+func UntilMasterUserRecordIsNotReady(reason string) MasterUserRecordWaitCriterion {
+	return MasterUserRecordWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.MasterUserRecord) bool {
+			c, found := condition.FindConditionByType(actual.Status.Conditions, toolchainv1alpha1.ConditionReady)
+			return found && c.Status == corev1.ConditionFalse && c.Reason == reason
+		},
+		Diff: func(actual *toolchainv1alpha1.MasterUserRecord) string {
+			return fmt.Sprintf("expected MasterUserRecord to be not ready with reason '%s', but had conditions: %s", reason, conditionsState(actual.Status.Conditions))
+		},
+	}
+}
+
+// UntilMasterUserRecordHasAnnotation returns a `MasterUserRecordWaitCriterion` which checks that the given
+// MasterUserRecord has an annotation with the given key and value
+func UntilMasterUserRecordHasAnnotation(key, value string) MasterUserRecordWaitCriterion {
+	return MasterUserRecordWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.MasterUserRecord) bool {
+			actualValue, exist := actual.Annotations[key]
+			return exist && actualValue == value
+		},
+		Diff: func(actual *toolchainv1alpha1.MasterUserRecord) string {
+			return fmt.Sprintf("expected MasterUserRecord annotation '%s' to be '%s' but it was '%s'", key, value, actual.Annotations[key])
+		},
+	}
+}
+
 func UntilMasterUserRecordHasNoTierHashLabel() MasterUserRecordWaitCriterion {
 	return MasterUserRecordWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.MasterUserRecord) bool {
@@ -447,11 +434,9 @@ func (a *HostAwaitility) printUserSignupWaitCriterionDiffs(t *testing.T, actual
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("UserSignup", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	// also include other resources relevant in the host namespace, to help troubleshooting
@@ -481,7 +466,7 @@ func UntilUserSignupHasConditions(expected ...toolchainv1alpha1.Condition) UserS
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.UserSignup) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -568,9 +553,9 @@ func UntilUserSignupHasCompliantUsername() UserSignupWaitCriterion {
 func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay time.Duration) error {
 	t.Logf("waiting for resource cleanup")
 	time.Sleep(initialDelay)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		usList := &toolchainv1alpha1.UserSignupList{}
-		if err := a.Client.List(context.TODO(), usList, client.InNamespace(a.Namespace)); err != nil {
+		if err := a.ListPaginated(usList); err != nil {
 			return false, err
 		}
 		for _, us := range usList.Items {
@@ -580,7 +565,7 @@ func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay
 		}
 
 		murList := &toolchainv1alpha1.MasterUserRecordList{}
-		if err := a.Client.List(context.TODO(), murList, client.InNamespace(a.Namespace)); err != nil {
+		if err := a.ListPaginated(murList); err != nil {
 			return false, err
 		}
 		for _, mur := range murList.Items {
@@ -590,7 +575,7 @@ func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay
 		}
 
 		spaceBindingList := &toolchainv1alpha1.SpaceBindingList{}
-		if err := a.Client.List(context.TODO(), spaceBindingList, client.InNamespace(a.Namespace)); err != nil {
+		if err := a.ListPaginated(spaceBindingList); err != nil {
 			return false, err
 		}
 		for _, spaceBinding := range spaceBindingList.Items {
@@ -600,7 +585,7 @@ func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay
 		}
 
 		spaceList := &toolchainv1alpha1.SpaceList{}
-		if err := a.Client.List(context.TODO(), spaceList, client.InNamespace(a.Namespace)); err != nil {
+		if err := a.ListPaginated(spaceList); err != nil {
 			return false, err
 		}
 		for _, space := range spaceList.Items {
@@ -636,7 +621,14 @@ func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay
 func (a *HostAwaitility) WaitForUserSignup(t *testing.T, name string, criteria ...UserSignupWaitCriterion) (*toolchainv1alpha1.UserSignup, error) {
 	t.Logf("waiting for UserSignup '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("UserSignup", userSignup, criteria...) }
+	observed := func() (string, bool) {
+		if userSignup == nil {
+			return notObservedState, false
+		}
+		return conditionsState(userSignup.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, a.Timeout, "UserSignup", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -659,7 +651,7 @@ func (a *HostAwaitility) WaitForUserSignupByUserIDAndUsername(t *testing.T, user
 	t.Logf("waiting for UserSignup '%s' or '%s' in namespace '%s' to match criteria", userID, username, a.Namespace)
 	encodedUsername := EncodeUserIdentifier(username)
 	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: userID}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -687,7 +679,7 @@ func (a *HostAwaitility) WaitForUserSignupByUserIDAndUsername(t *testing.T, user
 func (a *HostAwaitility) WaitAndVerifyThatUserSignupIsNotCreated(t *testing.T, name string) {
 	t.Logf("waiting and verifying that UserSignup '%s' in namespace '%s' is not created", name, a.Namespace)
 	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -707,8 +699,8 @@ func (a *HostAwaitility) WaitAndVerifyThatUserSignupIsNotCreated(t *testing.T, n
 func (a *HostAwaitility) WaitForBannedUser(t *testing.T, email string) (*toolchainv1alpha1.BannedUser, error) {
 	t.Logf("waiting for BannedUser for user '%s' in namespace '%s'", email, a.Namespace)
 	var bannedUser *toolchainv1alpha1.BannedUser
-	labels := map[string]string{toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(email)}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	labels := map[string]string{toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EmailHash(email)}
+	err := a.poll(func() (done bool, err error) {
 		bannedUserList := &toolchainv1alpha1.BannedUserList{}
 		if err = a.Client.List(context.TODO(), bannedUserList, client.MatchingLabels(labels), client.InNamespace(a.Namespace)); err != nil {
 			if len(bannedUserList.Items) == 0 {
@@ -742,7 +734,7 @@ func (a *HostAwaitility) DeleteToolchainStatus(t *testing.T, name string) error
 // WaitUntilBannedUserDeleted waits until the BannedUser with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilBannedUserDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until BannedUser '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		user := &toolchainv1alpha1.BannedUser{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -757,7 +749,7 @@ func (a *HostAwaitility) WaitUntilBannedUserDeleted(t *testing.T, name string) e
 // WaitUntilUserSignupDeleted waits until the UserSignup with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilUserSignupDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until UserSignup '%s' in namespace '%s is deleted", name, a.Namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		userSignup := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, userSignup); err != nil {
 			if errors.IsNotFound(err) {
@@ -772,7 +764,7 @@ func (a *HostAwaitility) WaitUntilUserSignupDeleted(t *testing.T, name string) e
 // WaitUntilMasterUserRecordAndSpaceBindingsDeleted waits until the MUR with the given name and its associated SpaceBindings are deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until MasterUserRecord '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		mur := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -791,7 +783,7 @@ func (a *HostAwaitility) WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t *tes
 // CheckMasterUserRecordIsDeleted checks that the MUR with the given name is not present and won't be created in the next 2 seconds
 func (a *HostAwaitility) CheckMasterUserRecordIsDeleted(t *testing.T, name string) {
 	t.Logf("checking that MasterUserRecord '%s' in namespace '%s' is deleted", name, a.Namespace)
-	err := wait.Poll(a.RetryInterval, 2*time.Second, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*time.Second, func() (done bool, err error) {
 		mur := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -818,7 +810,7 @@ func containsUserAccountStatus(uaStatuses []toolchainv1alpha1.UserAccountStatusE
 func (a *HostAwaitility) WaitForUserTier(t *testing.T, name string, criteria ...UserTierWaitCriterion) (*toolchainv1alpha1.UserTier, error) {
 	t.Logf("waiting until UserTier '%s' in namespace '%s' matches criteria", name, a.Namespace)
 	tier := &toolchainv1alpha1.UserTier{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.UserTier{}
 		err = a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj)
 		if err != nil && !errors.IsNotFound(err) {
@@ -902,7 +894,7 @@ func (a *HostAwaitility) WaitUntilBaseNSTemplateTierIsUpdated(t *testing.T) erro
 func (a *HostAwaitility) WaitForNSTemplateTier(t *testing.T, name string, criteria ...NSTemplateTierWaitCriterion) (*toolchainv1alpha1.NSTemplateTier, error) {
 	t.Logf("waiting until NSTemplateTier '%s' in namespace '%s' matches criteria", name, a.Namespace)
 	tier := &toolchainv1alpha1.NSTemplateTier{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.NSTemplateTier{}
 		err = a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj)
 		if err != nil && !errors.IsNotFound(err) {
@@ -955,7 +947,7 @@ func (a *HostAwaitility) WaitForNSTemplateTierAndCheckTemplates(t *testing.T, na
 func (a *HostAwaitility) WaitForTierTemplate(t *testing.T, name string) (*toolchainv1alpha1.TierTemplate, error) { // nolint:unparam
 	tierTemplate := &toolchainv1alpha1.TierTemplate{}
 	t.Logf("waiting until TierTemplate '%s' exists in namespace '%s'...", name, a.Namespace)
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.TierTemplate{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1125,7 +1117,7 @@ func (a *HostAwaitility) printNotificationWaitCriterionDiffs(t *testing.T, actua
 func (a *HostAwaitility) WaitForNotifications(t *testing.T, username, notificationType string, numberOfNotifications int, criteria ...NotificationWaitCriterion) ([]toolchainv1alpha1.Notification, error) {
 	t.Logf("waiting for notifications to match criteria for user '%s'", username)
 	var notifications []toolchainv1alpha1.Notification
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		labels := map[string]string{toolchainv1alpha1.NotificationUserNameLabelKey: username, toolchainv1alpha1.NotificationTypeLabelKey: notificationType}
 		opts := client.MatchingLabels(labels)
 		notificationList := &toolchainv1alpha1.NotificationList{}
@@ -1149,7 +1141,7 @@ func (a *HostAwaitility) WaitForNotifications(t *testing.T, username, notificati
 func (a *HostAwaitility) WaitForNotificationWithName(t *testing.T, notificationName, notificationType string, criteria ...NotificationWaitCriterion) (toolchainv1alpha1.Notification, error) {
 	t.Logf("waiting for notification with name '%s'", notificationName)
 	var notification toolchainv1alpha1.Notification
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: notificationName, Namespace: a.Namespace}, &notification); err != nil {
 			return false, err
 		}
@@ -1171,7 +1163,7 @@ func (a *HostAwaitility) WaitForNotificationWithName(t *testing.T, notificationN
 // WaitUntilNotificationsDeleted waits until the Notification for the given user is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilNotificationsDeleted(t *testing.T, username, notificationType string) error {
 	t.Logf("waiting until notifications have been deleted for user '%s'", username)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		labels := map[string]string{toolchainv1alpha1.NotificationUserNameLabelKey: username, toolchainv1alpha1.NotificationTypeLabelKey: notificationType}
 		opts := client.MatchingLabels(labels)
 		notificationList := &toolchainv1alpha1.NotificationList{}
@@ -1185,7 +1177,7 @@ func (a *HostAwaitility) WaitUntilNotificationsDeleted(t *testing.T, username, n
 // WaitUntilNotificationWithNameDeleted waits until the Notification with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilNotificationWithNameDeleted(t *testing.T, notificationName string) error {
 	t.Logf("waiting for notification with name '%s' to get deleted", notificationName)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		notification := &toolchainv1alpha1.Notification{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: notificationName, Namespace: a.Namespace}, notification); err != nil {
 			if errors.IsNotFound(err) {
@@ -1204,7 +1196,19 @@ func UntilNotificationHasConditions(expected ...toolchainv1alpha1.Condition) Not
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual toolchainv1alpha1.Notification) string {
-			return fmt.Sprintf("expected Notification conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected Notification conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
+		},
+	}
+}
+
+// UntilNotificationHasContextValue checks if Notification has the given value for the given key in its context
+func UntilNotificationHasContextValue(key, expected string) NotificationWaitCriterion {
+	return NotificationWaitCriterion{
+		Match: func(actual toolchainv1alpha1.Notification) bool {
+			return actual.Spec.Context[key] == expected
+		},
+		Diff: func(actual toolchainv1alpha1.Notification) string {
+			return fmt.Sprintf("expected Notification context to have value '%s' for key '%s'. Actual: '%s'", expected, key, actual.Spec.Context[key])
 		},
 	}
 }
@@ -1235,11 +1239,9 @@ func (a *HostAwaitility) printToolchainStatusWaitCriterionDiffs(t *testing.T, ac
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("ToolchainStatus", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	// also include other resources relevant in the host namespace, to help troubleshooting
@@ -1256,7 +1258,7 @@ func UntilToolchainStatusHasConditions(expected ...toolchainv1alpha1.Condition)
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.ToolchainStatus) string {
-			return fmt.Sprintf("expected ToolchainStatus conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected ToolchainStatus conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -1298,6 +1300,26 @@ func UntilAllMembersHaveUsageSet() ToolchainStatusWaitCriterion {
 	}
 }
 
+// UntilToolchainStatusMemberHasReadyCondition returns a `ToolchainStatusWaitCriterion` which checks that the given ToolchainStatus
+// has a member with the given cluster name, whose ready condition has the given status and reason
+func UntilToolchainStatusMemberHasReadyCondition(clusterName string, status corev1.ConditionStatus, reason string) ToolchainStatusWaitCriterion {
+	return ToolchainStatusWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.ToolchainStatus) bool {
+			for _, member := range actual.Status.Members {
+				if member.ClusterName == clusterName {
+					ready, found := condition.FindConditionByType(member.MemberStatus.Conditions, toolchainv1alpha1.ConditionReady)
+					return found && ready.Status == status && ready.Reason == reason
+				}
+			}
+			return false
+		},
+		Diff: func(actual *toolchainv1alpha1.ToolchainStatus) string {
+			a, _ := yaml.Marshal(actual.Status.Members)
+			return fmt.Sprintf("expected status member '%s' to have a ready condition with status '%s' and reason '%s'. Actual: %s", clusterName, status, reason, a)
+		},
+	}
+}
+
 func UntilAllMembersHaveAPIEndpoint(apiEndpoint string) ToolchainStatusWaitCriterion {
 	return ToolchainStatusWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.ToolchainStatus) bool {
@@ -1378,7 +1400,14 @@ func (a *HostAwaitility) WaitForToolchainStatus(t *testing.T, criteria ...Toolch
 	// there should only be one toolchain status with the name toolchain-status
 	name := "toolchain-status"
 	toolchainStatus := &toolchainv1alpha1.ToolchainStatus{}
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("ToolchainStatus", toolchainStatus, criteria...) }
+	observed := func() (string, bool) {
+		if toolchainStatus.ResourceVersion == "" {
+			return notObservedState, false
+		}
+		return conditionsState(toolchainStatus.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, 2*a.Timeout, "ToolchainStatus", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.ToolchainStatus{}
 		// retrieve the toolchainstatus from the host namespace
 		err = a.Client.Get(context.TODO(),
@@ -1415,6 +1444,16 @@ func (a *HostAwaitility) GetToolchainConfig(t *testing.T) *toolchainv1alpha1.Too
 	return config
 }
 
+// GetConfiguredRegistrationServiceURL returns the URL of the registration service set in the ToolchainConfig, ie, the URL used by the host
+// operator in the links of the notifications (which may have a custom domain), or an empty string if it is not set
+func (a *HostAwaitility) GetConfiguredRegistrationServiceURL(t *testing.T) string {
+	config := a.GetToolchainConfig(t)
+	if config == nil || config.Spec.Host.RegistrationService.RegistrationServiceURL == nil {
+		return ""
+	}
+	return *config.Spec.Host.RegistrationService.RegistrationServiceURL
+}
+
 // ToolchainConfigWaitCriterion a struct to compare with an expected ToolchainConfig
 type ToolchainConfigWaitCriterion struct {
 	Match func(*toolchainv1alpha1.ToolchainConfig) bool
@@ -1472,7 +1511,7 @@ func (a *HostAwaitility) WaitForToolchainConfig(t *testing.T, criteria ...Toolch
 	// there should only be one ToolchainConfig with the name "config"
 	name := "config"
 	var toolchainConfig *toolchainv1alpha1.ToolchainConfig
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.ToolchainConfig{}
 		// retrieve the ToolchainConfig from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -1563,7 +1602,7 @@ func (a *HostAwaitility) UpdateToolchainConfig(t *testing.T, options ...testconf
 // resource periodically which can cause errors like `Operation cannot be fulfilled on toolchainconfigs.toolchain.dev.openshift.com "config": the object has been modified; please apply your changes to the latest version and try again`
 // in some cases. Retrying mitigates the potential for test flakiness due to this behaviour.
 func (a *HostAwaitility) updateToolchainConfigWithRetry(t *testing.T, updatedConfig *toolchainv1alpha1.ToolchainConfig) error {
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		config := a.GetToolchainConfig(t)
 		config.Spec = updatedConfig.Spec
 		if err := a.Client.Update(context.TODO(), config); err != nil {
@@ -1608,7 +1647,7 @@ func (a *HostAwaitility) CreateAPIProxyClient(t *testing.T, usertoken, proxyURL
 	// updated yet and we try to create the client too quickly so retry to reduce flakiness.
 	var proxyCl client.Client
 	var initProxyClError error
-	waitErr := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	waitErr := a.poll(func() (done bool, err error) {
 		proxyCl, initProxyClError = client.New(proxyKubeConfig, client.Options{Scheme: s})
 		return initProxyClError == nil, nil
 	})
@@ -1644,7 +1683,14 @@ func matchSpaceWaitCriterion(actual *toolchainv1alpha1.Space, criteria ...SpaceW
 func (a *HostAwaitility) WaitForSpace(t *testing.T, name string, criteria ...SpaceWaitCriterion) (*toolchainv1alpha1.Space, error) {
 	t.Logf("waiting for Space '%s' with matching criteria", name)
 	var space *toolchainv1alpha1.Space
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("Space", space, criteria...) }
+	observed := func() (string, bool) {
+		if space == nil {
+			return notObservedState, false
+		}
+		return conditionsState(space.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, 2*a.Timeout, "Space", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.Space{}
 		// retrieve the Space from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -1671,7 +1717,7 @@ func (a *HostAwaitility) WaitForSpace(t *testing.T, name string, criteria ...Spa
 func (a *HostAwaitility) WaitForProxyPlugin(t *testing.T, name string) (*toolchainv1alpha1.ProxyPlugin, error) {
 	t.Logf("waiting for ProxyPlugin %q", name)
 	var proxyPlugin *toolchainv1alpha1.ProxyPlugin
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.ProxyPlugin{}
 		if err = a.Client.Get(context.TODO(),
 			types.NamespacedName{
@@ -1702,11 +1748,9 @@ func (a *HostAwaitility) printSpaceWaitCriterionDiffs(t *testing.T, actual *tool
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("Space", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	// also include Spaces resources in the host namespace, to help troubleshooting
@@ -1763,15 +1807,28 @@ func UntilSpaceHasTier(expected string) SpaceWaitCriterion {
 	}
 }
 
+// UntilSpaceIsRecreated returns a `SpaceWaitCriterion` which checks that the given
+// Space has a UID that is different from the given one, ie, that it was deleted and created again
+func UntilSpaceIsRecreated(previousUID types.UID) SpaceWaitCriterion {
+	return SpaceWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.Space) bool {
+			return actual.UID != previousUID
+		},
+		Diff: func(actual *toolchainv1alpha1.Space) string {
+			return fmt.Sprintf("expected Space to be recreated, but it still has the UID '%s'", previousUID)
+		},
+	}
+}
+
 // UntilSpaceHasTargetClusterRoles returns a `SpaceWaitCriterion` which checks that the given
 // Space has the expected target cluster roles set in its Spec
 func UntilSpaceHasTargetClusterRoles(expected []string) SpaceWaitCriterion {
 	return SpaceWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.Space) bool {
-			return reflect.DeepEqual(actual.Spec.TargetClusterRoles, expected)
+			return reflect.DeepEqual(SortedStrings(actual.Spec.TargetClusterRoles), SortedStrings(expected))
 		},
 		Diff: func(actual *toolchainv1alpha1.Space) string {
-			return fmt.Sprintf("expected target cluster roles to match:\n%s", Diff(expected, actual.Spec.TargetClusterRoles))
+			return fmt.Sprintf("expected target cluster roles to match:\n%s", Diff(SortedStrings(expected), SortedStrings(actual.Spec.TargetClusterRoles)))
 		},
 	}
 }
@@ -1784,7 +1841,7 @@ func UntilSpaceHasConditions(expected ...toolchainv1alpha1.Condition) SpaceWaitC
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.Space) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -1814,7 +1871,7 @@ func UntilSpaceHasConditionForTime(expected toolchainv1alpha1.Condition, duratio
 			return false
 		},
 		Diff: func(actual *toolchainv1alpha1.Space) string {
-			return fmt.Sprintf("expected conditions to match:\n%s\nAnd having the LastTransitionTime %s or older", Diff(expected, actual.Status.Conditions), time.Now().Add(-duration).String())
+			return fmt.Sprintf("expected conditions to match:\n%s\nAnd having the LastTransitionTime %s or older", Diff(expected, SortedConditions(actual.Status.Conditions)), time.Now().Add(-duration).String())
 		},
 	}
 }
@@ -1863,6 +1920,10 @@ func UntilSpaceHasAnyTierNameSet() SpaceWaitCriterion {
 func UntilSpaceHasProvisionedNamespaces(expectedProvisionedNamespaces []toolchainv1alpha1.SpaceNamespace) SpaceWaitCriterion {
 	return SpaceWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.Space) bool {
+			// no provisioned namespace is expected for the tiers without namespaces
+			if len(expectedProvisionedNamespaces) == 0 {
+				return len(actual.Status.ProvisionedNamespaces) == 0
+			}
 			return reflect.DeepEqual(expectedProvisionedNamespaces, actual.Status.ProvisionedNamespaces)
 		},
 		Diff: func(actual *toolchainv1alpha1.Space) string {
@@ -1888,7 +1949,7 @@ func UntilSpaceHasStatusTargetCluster(expected string) SpaceWaitCriterion {
 func (a *HostAwaitility) WaitUntilSpaceAndSpaceBindingsDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until Space '%s' in namespace '%s' is deleted", name, a.Namespace)
 	var s *toolchainv1alpha1.Space
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.Space{}
 		if err := a.Client.Get(context.TODO(),
 			types.NamespacedName{
@@ -1917,7 +1978,7 @@ func (a *HostAwaitility) WaitUntilSpaceAndSpaceBindingsDeleted(t *testing.T, nam
 
 // WaitUntilSpaceBindingDeleted waits until the SpaceBinding with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilSpaceBindingDeleted(name string) error {
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		mur := &toolchainv1alpha1.SpaceBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -1934,7 +1995,7 @@ func (a *HostAwaitility) WaitUntilSpaceBindingsWithLabelDeleted(t *testing.T, ke
 	labels := map[string]string{key: value}
 	t.Logf("waiting until SpaceBindings with labels '%v' in namespace '%s' are deleted", labels, a.Namespace)
 	var spaceBindingList *toolchainv1alpha1.SpaceBindingList
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		// retrieve the SpaceBinding from the host namespace
 		spaceBindingList = &toolchainv1alpha1.SpaceBindingList{}
 		if err = a.Client.List(context.TODO(), spaceBindingList, client.MatchingLabels(labels), client.InNamespace(a.Namespace)); err != nil {
@@ -1955,6 +2016,70 @@ func (a *HostAwaitility) WaitUntilSpaceBindingsWithLabelDeleted(t *testing.T, ke
 	return err
 }
 
+// ListOrphanSpaceBindings returns the SpaceBindings (which are not being deleted) referring to a MasterUserRecord or a Space that does not exist,
+// among the SpaceBindings matching the given options (if any)
+func (a *HostAwaitility) ListOrphanSpaceBindings(opts ...client.ListOption) ([]toolchainv1alpha1.SpaceBinding, error) {
+	spaceBindings := &toolchainv1alpha1.SpaceBindingList{}
+	if err := a.ListPaginated(spaceBindings, opts...); err != nil {
+		return nil, err
+	}
+	orphans := []toolchainv1alpha1.SpaceBinding{}
+	for _, sb := range spaceBindings.Items {
+		if sb.DeletionTimestamp != nil {
+			continue
+		}
+		murFound, err := a.exists(sb.Spec.MasterUserRecord, &toolchainv1alpha1.MasterUserRecord{})
+		if err != nil {
+			return nil, err
+		}
+		spaceFound, err := a.exists(sb.Spec.Space, &toolchainv1alpha1.Space{})
+		if err != nil {
+			return nil, err
+		}
+		if !murFound || !spaceFound {
+			orphans = append(orphans, sb)
+		}
+	}
+	return orphans, nil
+}
+
+func (a *HostAwaitility) exists(name string, obj client.Object) (bool, error) {
+	if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitUntilNoOrphanSpaceBindings waits until there is no SpaceBinding referring to a MasterUserRecord or a Space that does not exist.
+// Since the SpaceBindings are deleted asynchronously after their MasterUserRecord or Space, the orphans are only reported if they
+// still exist after the timeout. Only the SpaceBindings matching the given options (if any) are checked, so that a test running in parallel
+// with other tests can restrict the check to its own SpaceBindings.
+func (a *HostAwaitility) WaitUntilNoOrphanSpaceBindings(t *testing.T, opts ...client.ListOption) error {
+	t.Logf("waiting until there are no orphan SpaceBindings in namespace '%s'", a.Namespace)
+	var orphans []toolchainv1alpha1.SpaceBinding
+	err := a.poll(func() (done bool, err error) {
+		if orphans, err = a.ListOrphanSpaceBindings(opts...); err != nil {
+			return false, err
+		}
+		return len(orphans) == 0, nil
+	})
+	// print the orphan spacebindings
+	if err != nil {
+		buf := &strings.Builder{}
+		buf.WriteString(fmt.Sprintf("orphan spacebindings found in namespace '%s':\n", a.Namespace))
+		for _, sb := range orphans {
+			y, _ := yaml.Marshal(sb)
+			buf.Write(y)
+			buf.WriteString("\n")
+		}
+		t.Log(buf.String())
+	}
+	return err
+}
+
 type SpaceBindingWaitCriterion struct {
 	Match func(*toolchainv1alpha1.SpaceBinding) bool
 	Diff  func(*toolchainv1alpha1.SpaceBinding) string
@@ -1978,7 +2103,7 @@ func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRe
 		toolchainv1alpha1.ParentSpaceLabelKey:           parentSpaceName,
 	}
 
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		// retrieve the subSpace from the host namespace
 		spaceList := &toolchainv1alpha1.SpaceList{}
 		if err = a.Client.List(context.TODO(), spaceList, client.MatchingLabels(labels), client.InNamespace(a.Namespace)); err != nil {
@@ -2004,7 +2129,14 @@ func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRe
 func (a *HostAwaitility) WaitForSpaceBinding(t *testing.T, murName, spaceName string, criteria ...SpaceBindingWaitCriterion) (*toolchainv1alpha1.SpaceBinding, error) {
 	var spaceBinding *toolchainv1alpha1.SpaceBinding
 
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (bool, error) {
+	unmet := func() []string { return criterionDiffs("SpaceBinding", spaceBinding, criteria...) }
+	observed := func() (string, bool) {
+		if spaceBinding == nil {
+			return notObservedState, false
+		}
+		return fmt.Sprintf("role=%s", spaceBinding.Spec.SpaceRole), true
+	}
+	err := a.pollWithProgress(t, 2*a.Timeout, "SpaceBinding", fmt.Sprintf("%s/%s", murName, spaceName), unmet, observed, func() (bool, error) {
 		// retrieve the SpaceBinding from the host namespace
 		var err error
 		if spaceBinding, err = a.GetSpaceBindingByListing(murName, spaceName); err != nil {
@@ -2054,11 +2186,9 @@ func (a *HostAwaitility) printSpaceBindingWaitCriterionDiffs(t *testing.T, actua
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("SpaceBinding", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	// also include SpaceBindings resources in the host namespace, to help troubleshooting
@@ -2115,6 +2245,19 @@ func UntilSpaceBindingHasSpaceRole(expected string) SpaceBindingWaitCriterion {
 	}
 }
 
+// UntilSpaceBindingIsRecreated returns a `SpaceBindingWaitCriterion` which checks that the given
+// SpaceBinding has a UID that is different from the given one, ie, that it was deleted and created again
+func UntilSpaceBindingIsRecreated(previousUID types.UID) SpaceBindingWaitCriterion {
+	return SpaceBindingWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.SpaceBinding) bool {
+			return actual.UID != previousUID
+		},
+		Diff: func(actual *toolchainv1alpha1.SpaceBinding) string {
+			return fmt.Sprintf("expected SpaceBinding to be recreated, but it still has the UID '%s'", previousUID)
+		},
+	}
+}
+
 type SocialEventWaitCriterion struct {
 	Match func(*toolchainv1alpha1.SocialEvent) bool
 	Diff  func(*toolchainv1alpha1.SocialEvent) string
@@ -2132,7 +2275,7 @@ func matchSocialEventWaitCriterion(actual *toolchainv1alpha1.SocialEvent, criter
 func (a *HostAwaitility) WaitForSocialEvent(t *testing.T, name string, criteria ...SocialEventWaitCriterion) (*toolchainv1alpha1.SocialEvent, error) {
 	t.Logf("waiting for SocialEvent '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var event *toolchainv1alpha1.SocialEvent
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.SocialEvent{}
 		// retrieve the Space from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -2177,7 +2320,7 @@ func UntilSocialEventHasConditions(expected ...toolchainv1alpha1.Condition) Soci
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.SocialEvent) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -2242,7 +2385,7 @@ func EncodeUserIdentifier(subject string) string {
 func (a *HostAwaitility) CreateSpaceAndSpaceBinding(t *testing.T, mur *toolchainv1alpha1.MasterUserRecord, space *toolchainv1alpha1.Space, spaceRole string) (*toolchainv1alpha1.Space, *toolchainv1alpha1.SpaceBinding, error) {
 	var spaceBinding *toolchainv1alpha1.SpaceBinding
 	var spaceCreated *toolchainv1alpha1.Space
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		// create the space
 		spaceToCreate := space.DeepCopy()
 		if err := a.CreateWithCleanup(t, spaceToCreate); err != nil {
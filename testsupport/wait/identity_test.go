@@ -0,0 +1,53 @@
+package wait_test
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUntilIdentityHasProvider(t *testing.T) {
+	t.Run("plain user ID", func(t *testing.T) {
+		// given
+		identity := &userv1.Identity{
+			ObjectMeta:       metav1.ObjectMeta{Name: "rhd:f0ab0c0e"},
+			ProviderName:     "rhd",
+			ProviderUserName: "f0ab0c0e",
+		}
+
+		// then
+		assert.True(t, wait.UntilIdentityHasProvider("rhd", "f0ab0c0e").Match(identity))
+		c := wait.UntilIdentityHasProvider("other", "f0ab0c0e")
+		assert.False(t, c.Match(identity))
+		assert.Equal(t, "expected Identity 'other:f0ab0c0e' with provider 'other' and provider username 'f0ab0c0e'\n"+
+			"but it was 'rhd:f0ab0c0e' with provider 'rhd' and provider username 'f0ab0c0e'", c.Diff(identity))
+	})
+
+	t.Run("encoded user ID", func(t *testing.T) {
+		// given
+		identity := &userv1.Identity{
+			ObjectMeta:       metav1.ObjectMeta{Name: "rhd:b64:Z2l0aHViOjEyMzQ1"},
+			ProviderName:     "rhd",
+			ProviderUserName: "b64:Z2l0aHViOjEyMzQ1",
+		}
+
+		// then
+		assert.True(t, wait.UntilIdentityHasProvider("rhd", "github:12345").Match(identity))
+		assert.False(t, wait.UntilIdentityHasProvider("rhd", "github:67890").Match(identity))
+	})
+}
+
+func TestUntilIdentityIsMappedToUser(t *testing.T) {
+	// given
+	identity := &userv1.Identity{
+		User: corev1.ObjectReference{Name: "johnsmith"},
+	}
+
+	// then
+	assert.True(t, wait.UntilIdentityIsMappedToUser("johnsmith").Match(identity))
+	assert.False(t, wait.UntilIdentityIsMappedToUser("other").Match(identity))
+}
@@ -0,0 +1,104 @@
+package wait
+
+import (
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WithRestConfig returns a new Awaitility whose client uses the given config (with the scheme of the current client),
+// eg. to connect via the proxy. All the other settings (namespace, retry options, etc.) are kept.
+func (a *Awaitility) WithRestConfig(config *rest.Config) (*Awaitility, error) {
+	cl, err := client.New(config, client.Options{Scheme: a.Client.Scheme()})
+	if err != nil {
+		return nil, err
+	}
+	result := a.copy()
+	result.RestConfig = config
+	result.Client = cl
+	return result, nil
+}
+
+// Impersonate returns a new Awaitility whose client impersonates the given user (and groups), so that the waiters
+// and the other helpers can be used to verify what the end user can (or cannot) see and do
+func (a *Awaitility) Impersonate(username string, groups ...string) (*Awaitility, error) {
+	config := rest.CopyConfig(a.RestConfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: username,
+		Groups:   groups,
+	}
+	return a.WithRestConfig(config)
+}
+
+// WithUser returns a new Awaitility whose client authenticates with the given token of the end user (instead of the
+// credentials of the awaitility)
+func (a *Awaitility) WithUser(token string) (*Awaitility, error) {
+	config := rest.AnonymousClientConfig(a.RestConfig)
+	config.BearerToken = token
+	return a.WithRestConfig(config)
+}
+
+// Impersonate returns a new MemberAwaitility whose client impersonates the given user (and groups)
+func (a *MemberAwaitility) Impersonate(username string, groups ...string) (*MemberAwaitility, error) {
+	result, err := a.Awaitility.Impersonate(username, groups...)
+	if err != nil {
+		return nil, err
+	}
+	return &MemberAwaitility{Awaitility: result}, nil
+}
+
+// WithUser returns a new MemberAwaitility whose client authenticates with the given token of the end user
+func (a *MemberAwaitility) WithUser(token string) (*MemberAwaitility, error) {
+	result, err := a.Awaitility.WithUser(token)
+	if err != nil {
+		return nil, err
+	}
+	return &MemberAwaitility{Awaitility: result}, nil
+}
+
+// ViaProxy returns a new MemberAwaitility whose client connects to the member cluster via the proxy of the given host,
+// with the given token of the end user and in the context of the given workspace (ie, only the resources of the workspace
+// are accessible)
+func (a *MemberAwaitility) ViaProxy(hostAwait *HostAwaitility, token, workspace string) (*MemberAwaitility, error) {
+	config := rest.AnonymousClientConfig(hostAwait.RestConfig)
+	config.Host = hostAwait.ProxyURLWithWorkspaceContext(workspace)
+	config.BearerToken = token
+	var result *Awaitility
+	var clientErr error
+	// creating the client can fail until the proxy is aware of the workspace, hence the retries
+	err := a.poll(func() (done bool, err error) {
+		result, clientErr = a.Awaitility.WithRestConfig(config)
+		return clientErr == nil, nil
+	})
+	if err != nil {
+		return nil, clientErr
+	}
+	return &MemberAwaitility{Awaitility: result}, nil
+}
+
+// Impersonate returns a new HostAwaitility whose client impersonates the given user (and groups)
+func (a *HostAwaitility) Impersonate(username string, groups ...string) (*HostAwaitility, error) {
+	result, err := a.Awaitility.Impersonate(username, groups...)
+	if err != nil {
+		return nil, err
+	}
+	return &HostAwaitility{
+		Awaitility:             result,
+		RegistrationServiceNs:  a.RegistrationServiceNs,
+		RegistrationServiceURL: a.RegistrationServiceURL,
+		APIProxyURL:            a.APIProxyURL,
+	}, nil
+}
+
+// WithUser returns a new HostAwaitility whose client authenticates with the given token of the end user
+func (a *HostAwaitility) WithUser(token string) (*HostAwaitility, error) {
+	result, err := a.Awaitility.WithUser(token)
+	if err != nil {
+		return nil, err
+	}
+	return &HostAwaitility{
+		Awaitility:             result,
+		RegistrationServiceNs:  a.RegistrationServiceNs,
+		RegistrationServiceURL: a.RegistrationServiceURL,
+		APIProxyURL:            a.APIProxyURL,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetLeaderPod returns the operator pod currently holding the leader election lease in the namespace of the awaitility.
+// The holder identity of the lease is prefixed with the name of the pod (followed by `_` and a random ID).
+func (a *Awaitility) GetLeaderPod() (*corev1.Pod, error) {
+	leases := &coordinationv1.LeaseList{}
+	if err := a.Client.List(context.TODO(), leases, client.InNamespace(a.Namespace)); err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := a.Client.List(context.TODO(), pods, client.InNamespace(a.Namespace), client.MatchingLabels{"control-plane": "controller-manager"}); err != nil {
+		return nil, err
+	}
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity == nil {
+			continue
+		}
+		for i := range pods.Items {
+			if strings.HasPrefix(*lease.Spec.HolderIdentity, pods.Items[i].Name+"_") && pods.Items[i].DeletionTimestamp == nil {
+				return &pods.Items[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no operator pod holding a leader election lease in namespace '%s'", a.Namespace)
+}
+
+// WaitForNewLeaderPod waits until the leader election lease in the namespace of the awaitility is held by an operator pod
+// other than the one with the given name
+func (a *Awaitility) WaitForNewLeaderPod(t *testing.T, previousLeaderName string) *corev1.Pod {
+	t.Logf("waiting for a new leader other than pod '%s' in namespace '%s'", previousLeaderName, a.Namespace)
+	var leader *corev1.Pod
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
+		if leader, err = a.GetLeaderPod(); err != nil {
+			// the lease may be held by a deleted pod until it expires
+			return false, nil
+		}
+		return leader.Name != previousLeaderName, nil
+	})
+	require.NoError(t, err, "no new leader elected after pod '%s' in namespace '%s'", previousLeaderName, a.Namespace)
+	return leader
+}
+
+// DeleteLeaderPod deletes the operator pod currently holding the leader election lease in the namespace of the awaitility,
+// and returns the name of the deleted pod
+func (a *Awaitility) DeleteLeaderPod(t *testing.T) string {
+	leader, err := a.GetLeaderPod()
+	require.NoError(t, err)
+	t.Logf("deleting leader pod '%s' in namespace '%s'", leader.Name, a.Namespace)
+	require.NoError(t, a.Client.Delete(context.TODO(), leader))
+	return leader.Name
+}
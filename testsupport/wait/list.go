@@ -0,0 +1,72 @@
+package wait
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultListPageSize the max number of objects retrieved per request by the paginated list helpers
+	DefaultListPageSize = 500
+	// DefaultListQPS the max number of requests per second sent by the paginated list helpers
+	DefaultListQPS = 5
+	// DefaultListBurst the max burst of requests sent by the paginated list helpers
+	DefaultListBurst = 10
+)
+
+// listRateLimiter the client-side rate limiter shared by the paginated list helpers of all the awaitilities which have no
+// rate limiter of their own, so that listing thousands of resources in parallel tests does not overload the API server
+var listRateLimiter = flowcontrol.NewTokenBucketRateLimiter(DefaultListQPS, DefaultListBurst)
+
+// ListPaginated lists the resources of the type of the given list in the namespace of the awaitility, page by page
+// (each page being retrieved once the client-side rate limiter allows it), and sets all the retrieved items in the given list
+func (a *Awaitility) ListPaginated(list client.ObjectList, opts ...client.ListOption) error {
+	all := []runtime.Object{}
+	err := a.ForEachPage(list, func(page client.ObjectList) error {
+		items, err := meta.ExtractList(page)
+		if err != nil {
+			return err
+		}
+		all = append(all, items...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	return meta.SetList(list, all)
+}
+
+// ForEachPage lists the resources of the type of the given list in the namespace of the awaitility, page by page,
+// and calls the given function for each retrieved page. Requests are throttled by a client-side rate limiter.
+// The given list is only used as a prototype and is left unchanged.
+func (a *Awaitility) ForEachPage(list client.ObjectList, fn func(page client.ObjectList) error, opts ...client.ListOption) error {
+	continueToken := ""
+	for {
+		if err := a.pageRateLimiter().Wait(context.TODO()); err != nil {
+			return err
+		}
+		page := list.DeepCopyObject().(client.ObjectList)
+		listOpts := append([]client.ListOption{client.InNamespace(a.Namespace), client.Limit(DefaultListPageSize), client.Continue(continueToken)}, opts...)
+		if err := a.Client.List(context.TODO(), page, listOpts...); err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if continueToken = page.GetContinue(); continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// pageRateLimiter returns the rate limiter of the paginated list helpers of the awaitility
+func (a *Awaitility) pageRateLimiter() flowcontrol.RateLimiter {
+	if a.ListRateLimiter != nil {
+		return a.ListRateLimiter
+	}
+	return listRateLimiter
+}
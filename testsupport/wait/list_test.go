@@ -0,0 +1,54 @@
+package wait_test
+
+import (
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestListPaginated(t *testing.T) {
+	// given
+	objs := []client.Object{}
+	for i := 0; i < 5; i++ {
+		objs = append(objs, &toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("signup-%d", i),
+				Namespace: commontest.HostOperatorNs,
+				Labels: map[string]string{
+					"index": fmt.Sprintf("%d", i%2),
+				},
+			},
+		})
+	}
+	await := &wait.Awaitility{
+		Client:    commontest.NewFakeClient(t, objs...),
+		Namespace: commontest.HostOperatorNs,
+	}
+
+	t.Run("all items", func(t *testing.T) {
+		// when
+		signups := &toolchainv1alpha1.UserSignupList{}
+		err := await.ListPaginated(signups)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, signups.Items, 5)
+	})
+
+	t.Run("with list options", func(t *testing.T) {
+		// when
+		signups := &toolchainv1alpha1.UserSignupList{}
+		err := await.ListPaginated(signups, client.MatchingLabels{"index": "0"})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, signups.Items, 3)
+	})
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	identitypkg "github.com/codeready-toolchain/toolchain-common/pkg/identity"
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	appstudiov1 "github.com/codeready-toolchain/toolchain-e2e/testsupport/appstudio/api/v1alpha1"
 
@@ -32,7 +34,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -57,13 +58,15 @@ type MemberAwaitility struct {
 func NewMemberAwaitility(cfg *rest.Config, cl client.Client, ns, clusterName string) *MemberAwaitility {
 	return &MemberAwaitility{
 		Awaitility: &Awaitility{
-			Client:        cl,
-			RestConfig:    cfg,
-			ClusterName:   clusterName,
-			Namespace:     ns,
-			Type:          cluster.Member,
-			RetryInterval: DefaultRetryInterval,
-			Timeout:       DefaultTimeout,
+			Client:           cl,
+			RestConfig:       cfg,
+			ClusterName:      clusterName,
+			Namespace:        ns,
+			Type:             cluster.Member,
+			RetryInterval:    DefaultRetryInterval,
+			Timeout:          DefaultTimeout,
+			Backoff:          DefaultBackoff,
+			ProgressInterval: progressIntervalFromEnv(),
 		},
 	}
 }
@@ -102,11 +105,9 @@ func (a *MemberAwaitility) printUserAccountWaitCriterionDiffs(t *testing.T, actu
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("UserAccount", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	t.Log(buf.String())
@@ -185,7 +186,7 @@ func UntilUserAccountHasConditions(expected ...toolchainv1alpha1.Condition) User
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.UserAccount) string {
-			return fmt.Sprintf("expected conditions to match: %s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match: %s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -228,7 +229,14 @@ func UntilUserAccountIsCreatedAfter(timestamp metav1.Time) UserAccountWaitCriter
 // WaitForUserAccount waits until there is a UserAccount available with the given name, expected spec and the set of status conditions
 func (a *MemberAwaitility) WaitForUserAccount(t *testing.T, name string, criteria ...UserAccountWaitCriterion) (*toolchainv1alpha1.UserAccount, error) {
 	var userAccount *toolchainv1alpha1.UserAccount
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("UserAccount", userAccount, criteria...) }
+	observed := func() (string, bool) {
+		if userAccount == nil {
+			return notObservedState, false
+		}
+		return conditionsState(userAccount.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, a.Timeout, "UserAccount", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.UserAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -255,7 +263,7 @@ type SpaceRequestWaitCriterion struct {
 // WaitForSpaceRequest waits until there is a SpaceRequest available with the given name, namespace, spec and the set of status conditions
 func (a *MemberAwaitility) WaitForSpaceRequest(t *testing.T, namespacedName types.NamespacedName, criteria ...SpaceRequestWaitCriterion) (*toolchainv1alpha1.SpaceRequest, error) {
 	var spaceRequest *toolchainv1alpha1.SpaceRequest
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.SpaceRequest{}
 		if err := a.Client.Get(context.TODO(), namespacedName, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -320,7 +328,28 @@ func UntilSpaceRequestHasConditions(expected ...toolchainv1alpha1.Condition) Spa
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.SpaceRequest) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
+		},
+	}
+}
+
+// UntilSpaceRequestHasNamespaceAccess returns a `SpaceRequestWaitCriterion` which checks that the given
+// SpaceRequest has a secret reference to access each of its provisioned namespaces
+func UntilSpaceRequestHasNamespaceAccess() SpaceRequestWaitCriterion {
+	return SpaceRequestWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.SpaceRequest) bool {
+			if len(actual.Status.NamespaceAccess) == 0 {
+				return false
+			}
+			for _, access := range actual.Status.NamespaceAccess {
+				if access.SecretRef == "" {
+					return false
+				}
+			}
+			return true
+		},
+		Diff: func(actual *toolchainv1alpha1.SpaceRequest) string {
+			return fmt.Sprintf("expected namespace access with secret references, but it was:\n%v", actual.Status.NamespaceAccess)
 		},
 	}
 }
@@ -385,11 +414,9 @@ func (a *MemberAwaitility) printNSTemplateSetWaitCriterionDiffs(t *testing.T, ac
 		buf.Write(y)
 		buf.WriteString("\n----\n")
 		buf.WriteString("diffs:\n")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				buf.WriteString(c.Diff(actual))
-				buf.WriteString("\n")
-			}
+		for _, diff := range criterionDiffs("NSTemplateSet", actual, criteria...) {
+			buf.WriteString(diff)
+			buf.WriteString("\n")
 		}
 	}
 	t.Log(buf.String())
@@ -408,6 +435,26 @@ func UntilNSTemplateSetHasNoOwnerReferences() NSTemplateSetWaitCriterion {
 	}
 }
 
+// UntilObjectHasOwnerReferences returns a `LabelWaitCriterion` which checks that the given Object is owned by the
+// resources with the given kinds and names, regardless of the order of the owner references (see SortedOwnerReferences)
+func UntilObjectHasOwnerReferences(expected ...metav1.OwnerReference) LabelWaitCriterion {
+	kindsAndNames := func(refs []metav1.OwnerReference) []string {
+		result := []string{}
+		for _, ref := range SortedOwnerReferences(refs) {
+			result = append(result, ref.Kind+"/"+ref.Name)
+		}
+		return result
+	}
+	return LabelWaitCriterion{
+		Match: func(actual metav1.ObjectMeta) bool {
+			return reflect.DeepEqual(kindsAndNames(expected), kindsAndNames(actual.OwnerReferences))
+		},
+		Diff: func(actual metav1.ObjectMeta) string {
+			return fmt.Sprintf("expected owner references to match:\n%s", Diff(kindsAndNames(expected), kindsAndNames(actual.OwnerReferences)))
+		},
+	}
+}
+
 // UntilNSTemplateSetIsBeingDeleted returns a `NSTemplateSetWaitCriterion` which checks that the given
 // NSTemplateSet has Deletion Timestamp set
 func UntilNSTemplateSetIsBeingDeleted() NSTemplateSetWaitCriterion {
@@ -429,20 +476,20 @@ func UntilNSTemplateSetHasConditions(expected ...toolchainv1alpha1.Condition) NS
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
 
 // UntilNSTemplateSetHasSpaceRoles returns a `NSTemplateSetWaitCriterion` which checks that the given
-// NSTemlateSet has the expected roles for the given users
+// NSTemlateSet has the expected roles for the given users (see SortedSpaceRoles)
 func UntilNSTemplateSetHasSpaceRoles(expected ...toolchainv1alpha1.NSTemplateSetSpaceRole) NSTemplateSetWaitCriterion {
 	return NSTemplateSetWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.NSTemplateSet) bool {
-			return reflect.DeepEqual(expected, actual.Spec.SpaceRoles)
+			return reflect.DeepEqual(SortedSpaceRoles(expected), SortedSpaceRoles(actual.Spec.SpaceRoles))
 		},
 		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
-			return fmt.Sprintf("expected space roles to match:\n%s", Diff(expected, actual.Spec.SpaceRoles))
+			return fmt.Sprintf("expected space roles to match:\n%s", Diff(SortedSpaceRoles(expected), SortedSpaceRoles(actual.Spec.SpaceRoles)))
 		},
 	}
 }
@@ -467,10 +514,10 @@ func UntilNSTemplateSetHasSpaceRolesFromBindings(tier *toolchainv1alpha1.NSTempl
 	}
 	return NSTemplateSetWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.NSTemplateSet) bool {
-			return reflect.DeepEqual(expected, actual.Spec.SpaceRoles)
+			return reflect.DeepEqual(SortedSpaceRoles(expected), SortedSpaceRoles(actual.Spec.SpaceRoles))
 		},
 		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
-			return fmt.Sprintf("expected space roles to match:\n%s", Diff(expected, actual.Spec.SpaceRoles))
+			return fmt.Sprintf("expected space roles to match:\n%s", Diff(SortedSpaceRoles(expected), SortedSpaceRoles(actual.Spec.SpaceRoles)))
 		},
 	}
 }
@@ -482,6 +529,31 @@ func SpaceRole(templateRef string, usernames ...string) toolchainv1alpha1.NSTemp
 	}
 }
 
+// UntilNSTemplateSetHasSpaceRolesInAnyOrder returns a `NSTemplateSetWaitCriterion` which checks that the given
+// NSTemplateSet has the expected roles for the given users, regardless of the order of the roles and of the usernames
+func UntilNSTemplateSetHasSpaceRolesInAnyOrder(expected ...toolchainv1alpha1.NSTemplateSetSpaceRole) NSTemplateSetWaitCriterion {
+	return NSTemplateSetWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.NSTemplateSet) bool {
+			return reflect.DeepEqual(usernamesByTemplateRef(expected), usernamesByTemplateRef(actual.Spec.SpaceRoles))
+		},
+		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
+			return fmt.Sprintf("expected space roles to match (in any order):\n%s", Diff(usernamesByTemplateRef(expected), usernamesByTemplateRef(actual.Spec.SpaceRoles)))
+		},
+	}
+}
+
+// usernamesByTemplateRef returns the sorted usernames of the given space roles, by template ref
+func usernamesByTemplateRef(spaceRoles []toolchainv1alpha1.NSTemplateSetSpaceRole) map[string][]string {
+	result := map[string][]string{}
+	for _, r := range spaceRoles {
+		result[r.TemplateRef] = append(result[r.TemplateRef], r.Usernames...)
+	}
+	for _, usernames := range result {
+		sort.Strings(usernames)
+	}
+	return result
+}
+
 // UntilNSTemplateSetHasTier checks if the NSTemplateTier has the expected tierName
 func UntilNSTemplateSetHasTier(expected string) NSTemplateSetWaitCriterion {
 	return NSTemplateSetWaitCriterion{
@@ -499,6 +571,10 @@ func UntilNSTemplateSetHasTier(expected string) NSTemplateSetWaitCriterion {
 func UntilNSTemplateSetHasProvisionedNamespaces(expected []toolchainv1alpha1.SpaceNamespace) NSTemplateSetWaitCriterion {
 	return NSTemplateSetWaitCriterion{
 		Match: func(actual *toolchainv1alpha1.NSTemplateSet) bool {
+			// no provisioned namespace is expected for the tiers without namespaces
+			if len(expected) == 0 {
+				return len(actual.Status.ProvisionedNamespaces) == 0
+			}
 			return reflect.DeepEqual(actual.Status.ProvisionedNamespaces, expected)
 		},
 		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
@@ -511,7 +587,14 @@ func UntilNSTemplateSetHasProvisionedNamespaces(expected []toolchainv1alpha1.Spa
 func (a *MemberAwaitility) WaitForNSTmplSet(t *testing.T, name string, criteria ...NSTemplateSetWaitCriterion) (*toolchainv1alpha1.NSTemplateSet, error) {
 	t.Logf("waiting for NSTemplateSet '%s' to match criteria", name)
 	var nsTmplSet *toolchainv1alpha1.NSTemplateSet
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	unmet := func() []string { return criterionDiffs("NSTemplateSet", nsTmplSet, criteria...) }
+	observed := func() (string, bool) {
+		if nsTmplSet == nil {
+			return notObservedState, false
+		}
+		return conditionsState(nsTmplSet.Status.Conditions), true
+	}
+	err := a.pollWithProgress(t, a.Timeout, "NSTemplateSet", name, unmet, observed, func() (done bool, err error) {
 		obj := &toolchainv1alpha1.NSTemplateSet{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -532,7 +615,7 @@ func (a *MemberAwaitility) WaitForNSTmplSet(t *testing.T, name string, criteria
 // WaitUntilNSTemplateSetDeleted waits until the NSTemplateSet with the given name is deleted (ie, is not found)
 func (a *MemberAwaitility) WaitUntilNSTemplateSetDeleted(t *testing.T, name string) error {
 	t.Logf("waiting for until NSTemplateSet '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		nsTmplSet := &toolchainv1alpha1.NSTemplateSet{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, nsTmplSet); err != nil {
 			if errors.IsNotFound(err) {
@@ -598,6 +681,32 @@ func UntilHasLastAppliedSpaceRoles(expected []toolchainv1alpha1.NSTemplateSetSpa
 	}
 }
 
+// UntilObjectHasLastAppliedSpaceRolesInAnyOrder returns a `LabelWaitCriterion` which checks that the space roles last applied
+// in the given Object (eg, a Namespace) match the expected ones, regardless of the order of the roles and of the usernames
+func UntilObjectHasLastAppliedSpaceRolesInAnyOrder(expected ...toolchainv1alpha1.NSTemplateSetSpaceRole) LabelWaitCriterion {
+	lastApplied := func(actual metav1.ObjectMeta) (map[string][]string, bool) {
+		value, found := actual.Annotations[toolchainv1alpha1.LastAppliedSpaceRolesAnnotationKey]
+		if !found {
+			return nil, false
+		}
+		spaceRoles := []toolchainv1alpha1.NSTemplateSetSpaceRole{}
+		if err := json.Unmarshal([]byte(value), &spaceRoles); err != nil {
+			return nil, false
+		}
+		return usernamesByTemplateRef(spaceRoles), true
+	}
+	return LabelWaitCriterion{
+		Match: func(actual metav1.ObjectMeta) bool {
+			spaceRoles, found := lastApplied(actual)
+			return found && reflect.DeepEqual(usernamesByTemplateRef(expected), spaceRoles)
+		},
+		Diff: func(actual metav1.ObjectMeta) string {
+			spaceRoles, _ := lastApplied(actual)
+			return fmt.Sprintf("expected last applied space roles to match (in any order):\n%s", Diff(usernamesByTemplateRef(expected), spaceRoles))
+		},
+	}
+}
+
 func matchNamespaceWaitCriteria(actual *corev1.Namespace, criteria ...NamespaceWaitCriterion) bool {
 	for _, c := range criteria {
 		if !c.Match(actual) {
@@ -622,7 +731,7 @@ func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierNa
 	}
 	t.Logf("waiting for namespace with custom criteria and labels %v", labels)
 	var ns *corev1.Namespace
-	err = wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err = a.poll(func() (done bool, err error) {
 		nss := &corev1.NamespaceList{}
 		opts := client.MatchingLabels(labels)
 		if err := a.Client.List(context.TODO(), nss, opts); err != nil {
@@ -655,7 +764,7 @@ func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierNa
 // WaitForNamespaceWithName waits until a namespace with the given name
 func (a *MemberAwaitility) WaitForNamespaceWithName(t *testing.T, name string, criteria ...LabelWaitCriterion) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.Namespace{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -709,7 +818,7 @@ func (a *MemberAwaitility) printNamespaceLabelCriterionDiffs(t *testing.T, actua
 // WaitForNamespaceInTerminating waits until a namespace with the given name has a deletion timestamp and in Terminating Phase
 func (a *MemberAwaitility) WaitForNamespaceInTerminating(t *testing.T, nsName string) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.Namespace{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: nsName}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -731,7 +840,7 @@ func (a *MemberAwaitility) WaitForNamespaceInTerminating(t *testing.T, nsName st
 func (a *MemberAwaitility) WaitForRoleBinding(t *testing.T, namespace *corev1.Namespace, name string) (*rbacv1.RoleBinding, error) {
 	t.Logf("waiting for RoleBinding '%s' in namespace '%s'", name, namespace.Name)
 	roleBinding := &rbacv1.RoleBinding{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &rbacv1.RoleBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -756,7 +865,7 @@ func (a *MemberAwaitility) WaitForRoleBinding(t *testing.T, namespace *corev1.Na
 // WaitUntilRoleBindingDeleted waits until a RoleBinding with the given name does not exist anymore in the given namespace
 func (a *MemberAwaitility) WaitUntilRoleBindingDeleted(t *testing.T, namespace *corev1.Namespace, name string) error {
 	t.Logf("waiting for RoleBinding '%s' in namespace '%s' to be deleted", name, namespace.Name)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		roleBinding := &rbacv1.RoleBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, roleBinding); err != nil {
 			if errors.IsNotFound(err) {
@@ -771,7 +880,7 @@ func (a *MemberAwaitility) WaitUntilRoleBindingDeleted(t *testing.T, namespace *
 func (a *MemberAwaitility) WaitForServiceAccount(t *testing.T, namespace string, name string) (*corev1.ServiceAccount, error) {
 	t.Logf("waiting for ServiceAccount '%s' in namespace '%s'", name, namespace)
 	serviceAccount := &corev1.ServiceAccount{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.ServiceAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -793,7 +902,7 @@ func (a *MemberAwaitility) WaitForServiceAccount(t *testing.T, namespace string,
 func (a *MemberAwaitility) WaitForLimitRange(t *testing.T, namespace *corev1.Namespace, name string) (*corev1.LimitRange, error) {
 	t.Logf("waiting for LimitRange '%s' in namespace '%s'", name, namespace.Name)
 	lr := &corev1.LimitRange{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.LimitRange{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -818,7 +927,7 @@ func (a *MemberAwaitility) WaitForLimitRange(t *testing.T, namespace *corev1.Nam
 func (a *MemberAwaitility) WaitForNetworkPolicy(t *testing.T, namespace *corev1.Namespace, name string) (*netv1.NetworkPolicy, error) {
 	t.Logf("waiting for NetworkPolicy '%s' in namespace '%s'", name, namespace.Name)
 	np := &netv1.NetworkPolicy{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &netv1.NetworkPolicy{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -843,7 +952,7 @@ func (a *MemberAwaitility) WaitForNetworkPolicy(t *testing.T, namespace *corev1.
 func (a *MemberAwaitility) WaitForRole(t *testing.T, namespace *corev1.Namespace, name string) (*rbacv1.Role, error) {
 	t.Logf("waiting for Role '%s' in namespace '%s'", name, namespace.Name)
 	role := &rbacv1.Role{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &rbacv1.Role{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -867,7 +976,7 @@ func (a *MemberAwaitility) WaitForRole(t *testing.T, namespace *corev1.Namespace
 // WaitUntilRoleDeleted waits until a Role with the given name does not exist anymore in the given namespace
 func (a *MemberAwaitility) WaitUntilRoleDeleted(t *testing.T, namespace *corev1.Namespace, name string) error {
 	t.Logf("waiting for Role '%s' in namespace '%s' to be deleted", name, namespace.Name)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		role := &rbacv1.Role{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, role); err != nil {
 			if errors.IsNotFound(err) {
@@ -921,7 +1030,7 @@ func (a *MemberAwaitility) printClusterResourceQuotaWaitCriterionDiffs(t *testin
 func (a *MemberAwaitility) WaitForClusterResourceQuota(t *testing.T, name string, criteria ...ClusterResourceQuotaWaitCriterion) (*quotav1.ClusterResourceQuota, error) {
 	t.Logf("waiting for ClusterResourceQuota '%s' to match criteria", name)
 	quota := &quotav1.ClusterResourceQuota{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &quotav1.ClusterResourceQuota{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -986,7 +1095,7 @@ func (a *MemberAwaitility) printResourceQuotaWaitCriterionDiffs(t *testing.T, ac
 func (a *MemberAwaitility) WaitForResourceQuota(t *testing.T, namespace, name string, criteria ...ResourceQuotaWaitCriterion) (*corev1.ResourceQuota, error) {
 	t.Logf("waiting for ResourceQuota '%s' in %s to match criteria", name, namespace)
 	quota := &corev1.ResourceQuota{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.ResourceQuota{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1053,7 +1162,7 @@ func IdlerConditions(expected ...toolchainv1alpha1.Condition) IdlerWaitCriterion
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.Idler) string {
-			return fmt.Sprintf("expected conditions to match: %s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match: %s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -1086,7 +1195,7 @@ func IdlerHasTier(expected string) IdlerWaitCriterion {
 func (a *MemberAwaitility) WaitForIdler(t *testing.T, name string, criteria ...IdlerWaitCriterion) (*toolchainv1alpha1.Idler, error) {
 	t.Logf("waiting for Idler '%s' to match criteria", name)
 	idler := &toolchainv1alpha1.Idler{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.Idler{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1107,7 +1216,7 @@ func (a *MemberAwaitility) WaitForIdler(t *testing.T, name string, criteria ...I
 // UpdateIdlerSpec tries to update the Idler.Spec until success
 func (a *MemberAwaitility) UpdateIdlerSpec(t *testing.T, idler *toolchainv1alpha1.Idler) (*toolchainv1alpha1.Idler, error) {
 	var result *toolchainv1alpha1.Idler
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &toolchainv1alpha1.Idler{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: idler.Name}, obj); err != nil {
 			return false, err
@@ -1124,51 +1233,24 @@ func (a *MemberAwaitility) UpdateIdlerSpec(t *testing.T, idler *toolchainv1alpha
 }
 
 // UpdateNSTemplateSet tries to update the Spec of the given NSTemplateSet
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated NSTemplateSet
 func (a *MemberAwaitility) UpdateNSTemplateSet(t *testing.T, spaceName string, modifyNSTemplateSet func(nsTmplSet *toolchainv1alpha1.NSTemplateSet)) (*toolchainv1alpha1.NSTemplateSet, error) {
-	var nsTmplSet *toolchainv1alpha1.NSTemplateSet
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshNSTmplSet := &toolchainv1alpha1.NSTemplateSet{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: spaceName}, freshNSTmplSet); err != nil {
-			return true, err
-		}
-		modifyNSTemplateSet(freshNSTmplSet)
-		if err := a.Client.Update(context.TODO(), freshNSTmplSet); err != nil {
-			t.Logf("error updating NSTemplateSet '%s': %s. Will retry again...", spaceName, err.Error())
-			return false, nil
-		}
-		nsTmplSet = freshNSTmplSet
-		return true, nil
-	})
-	return nsTmplSet, err
+	return Update(t, a.Awaitility, spaceName, modifyNSTemplateSet)
 }
 
 // UpdateSpaceRequest tries to update the Spec of the given SpaceRequest
-// If it fails with an error (for example if the object has been modified) then it retrieves the latest version and tries again
+// If it fails with a conflict error (ie, if the object has been modified) then it retrieves the latest version and tries again
 // Returns the updated SpaceRequest
 func (a *MemberAwaitility) UpdateSpaceRequest(t *testing.T, spaceRequestNamespacedName types.NamespacedName, modifySpaceRequest func(s *toolchainv1alpha1.SpaceRequest)) (*toolchainv1alpha1.SpaceRequest, error) {
-	var sr *toolchainv1alpha1.SpaceRequest
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshSpaceRequest := &toolchainv1alpha1.SpaceRequest{}
-		if err := a.Client.Get(context.TODO(), spaceRequestNamespacedName, freshSpaceRequest); err != nil {
-			return true, err
-		}
-		modifySpaceRequest(freshSpaceRequest)
-		if err := a.Client.Update(context.TODO(), freshSpaceRequest); err != nil {
-			t.Logf("error updating SpaceRequest '%s' in namespace '%s': %s. Will retry again...", spaceRequestNamespacedName.Name, spaceRequestNamespacedName.Name, err.Error())
-			return false, nil
-		}
-		sr = freshSpaceRequest
-		return true, nil
-	})
-	return sr, err
+	return UpdateInNamespace(t, a.Awaitility, spaceRequestNamespacedName.Namespace, spaceRequestNamespacedName.Name, modifySpaceRequest)
 }
 
 // Create tries to create the object until success
 // Workaround for https://github.com/kubernetes/kubernetes/issues/67761
 func (a *MemberAwaitility) Create(t *testing.T, obj client.Object) error {
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	AddRunLabels(t, obj)
+	return a.poll(func() (done bool, err error) {
 		if err := a.Client.Create(context.TODO(), obj); err != nil {
 			t.Logf("trying to create %+v. Error: %s. Will try to create again.", obj, err.Error())
 			return false, nil
@@ -1213,7 +1295,7 @@ func (a *MemberAwaitility) printPodWaitCriterionDiffs(t *testing.T, actual *core
 func (a *MemberAwaitility) WaitForPod(t *testing.T, namespace, name string, criteria ...PodWaitCriterion) (*corev1.Pod, error) {
 	t.Logf("waiting for Pod '%s' in namespace '%s' with matching criteria", name, namespace)
 	var pod *corev1.Pod
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.Pod{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
@@ -1240,7 +1322,7 @@ func (a *MemberAwaitility) WaitForPod(t *testing.T, namespace, name string, crit
 func (a *MemberAwaitility) WaitForConfigMap(t *testing.T, namespace, name string) (*corev1.ConfigMap, error) {
 	t.Logf("waiting for ConfigMap '%s' in namespace '%s'", name, namespace)
 	var cm *corev1.ConfigMap
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.ConfigMap{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
@@ -1263,7 +1345,7 @@ func (a *MemberAwaitility) WaitForConfigMap(t *testing.T, namespace, name string
 func (a *MemberAwaitility) WaitForSecret(t *testing.T, name string) (*corev1.Secret, error) {
 	t.Logf("waiting for Secret '%s' in namespace '%s'", name, a.Namespace)
 	var cm *corev1.Secret
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &corev1.Secret{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: a.Namespace,
@@ -1286,7 +1368,7 @@ func (a *MemberAwaitility) WaitForSecret(t *testing.T, name string) (*corev1.Sec
 func (a *MemberAwaitility) WaitForPods(t *testing.T, namespace string, n int, criteria ...PodWaitCriterion) ([]corev1.Pod, error) {
 	t.Logf("waiting for Pods in namespace '%s' with matching criteria", namespace)
 	pods := make([]corev1.Pod, 0, n)
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		pds := make([]corev1.Pod, 0, n)
 		foundPods := &corev1.PodList{}
 		if err := a.Client.List(context.TODO(), foundPods, client.InNamespace(namespace)); err != nil {
@@ -1313,7 +1395,7 @@ func (a *MemberAwaitility) WaitForPods(t *testing.T, namespace string, n int, cr
 // WaitUntilPodsDeleted waits until the pods are deleted from the given namespace
 func (a *MemberAwaitility) WaitUntilPodsDeleted(t *testing.T, namespace string, criteria ...PodWaitCriterion) error {
 	t.Logf("waiting until Pods with matching criteria in namespace '%s' are deleted", namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		foundPods := &corev1.PodList{}
 		if err := a.Client.List(context.TODO(), foundPods, &client.ListOptions{Namespace: namespace}); err != nil {
 			return false, err
@@ -1331,10 +1413,31 @@ func (a *MemberAwaitility) WaitUntilPodsDeleted(t *testing.T, namespace string,
 	})
 }
 
+// WaitUntilPersistentVolumeClaimBound waits until the PersistentVolumeClaim with the given name in the given namespace is bound to a volume
+func (a *MemberAwaitility) WaitUntilPersistentVolumeClaimBound(t *testing.T, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	t.Logf("waiting until PersistentVolumeClaim '%s' in namespace '%s' is bound", name, namespace)
+	var pvc *corev1.PersistentVolumeClaim
+	err := a.poll(func() (done bool, err error) {
+		obj := &corev1.PersistentVolumeClaim{}
+		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		pvc = obj
+		return obj.Status.Phase == corev1.ClaimBound, nil
+	})
+	if err != nil && pvc != nil {
+		t.Logf("PersistentVolumeClaim '%s' in namespace '%s' is in phase '%s'", name, namespace, pvc.Status.Phase)
+	}
+	return pvc, err
+}
+
 // WaitUntilPodDeleted waits until the pod with the given name is deleted from the given namespace
 func (a *MemberAwaitility) WaitUntilPodDeleted(t *testing.T, namespace, name string) error {
 	t.Logf("waiting until Pod '%s' in namespace '%s' is deleted", name, namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		obj := &corev1.Pod{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1361,6 +1464,18 @@ func PodRunning() PodWaitCriterion {
 	}
 }
 
+// PodCompleted checks if the Pod is in the succeeded or failed phase (ie, all its containers terminated and will not be restarted)
+func PodCompleted() PodWaitCriterion {
+	return PodWaitCriterion{
+		Match: func(actual *corev1.Pod) bool {
+			return actual.Status.Phase == corev1.PodSucceeded || actual.Status.Phase == corev1.PodFailed
+		},
+		Diff: func(actual *corev1.Pod) string {
+			return fmt.Sprintf("expected Pod to be 'Succeeded' or 'Failed'\nbut it was '%s'", actual.Status.Phase)
+		},
+	}
+}
+
 // WithPodName checks if the Pod has the expected name
 func WithPodName(expected string) PodWaitCriterion {
 	return PodWaitCriterion{
@@ -1420,7 +1535,7 @@ func checkPriorityClass(pod *corev1.Pod, name string, priority int) bool {
 // WaitUntilNamespaceDeleted waits until the namespace with the given name is deleted (ie, is not found)
 func (a *MemberAwaitility) WaitUntilNamespaceDeleted(t *testing.T, username, typeName string) error {
 	t.Logf("waiting until namespace for user '%s' and type '%s' is deleted", username, typeName)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		labels := map[string]string{
 			"toolchain.dev.openshift.com/owner": username,
 			"toolchain.dev.openshift.com/type":  typeName,
@@ -1473,7 +1588,7 @@ func (a *MemberAwaitility) printUserWaitCriterionDiffs(t *testing.T, actual *use
 func (a *MemberAwaitility) WaitForUser(t *testing.T, name string, criteria ...UserWaitCriterion) (*userv1.User, error) {
 	t.Logf("waiting for User '%s'", name)
 	user := &userv1.User{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		user = &userv1.User{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -1539,7 +1654,7 @@ func matchIdentityWaitCriterion(actual *userv1.Identity, criteria ...IdentityWai
 func (a *MemberAwaitility) WaitForIdentity(t *testing.T, name string, criteria ...IdentityWaitCriterion) (*userv1.Identity, error) {
 	t.Logf("waiting for Identity '%s'", name)
 	identity := &userv1.Identity{}
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		identity = &userv1.Identity{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, identity); err != nil {
 			if errors.IsNotFound(err) {
@@ -1580,10 +1695,40 @@ func UntilIdentityHasLabel(key, value string) IdentityWaitCriterion {
 	}
 }
 
+// UntilIdentityHasProvider checks if the Identity was created for the given user ID and identity provider, following the naming standard
+// (ie, the user ID is base64-encoded in the name and the provider username of the Identity when it contains special characters, such as `:` or `/`)
+func UntilIdentityHasProvider(provider, userID string) IdentityWaitCriterion {
+	expected := &userv1.Identity{}
+	identitypkg.NewIdentityNamingStandard(userID, provider).ApplyToIdentity(expected)
+	return IdentityWaitCriterion{
+		Match: func(actual *userv1.Identity) bool {
+			return actual.Name == expected.Name &&
+				actual.ProviderName == expected.ProviderName &&
+				actual.ProviderUserName == expected.ProviderUserName
+		},
+		Diff: func(actual *userv1.Identity) string {
+			return fmt.Sprintf("expected Identity '%s' with provider '%s' and provider username '%s'\nbut it was '%s' with provider '%s' and provider username '%s'",
+				expected.Name, expected.ProviderName, expected.ProviderUserName, actual.Name, actual.ProviderName, actual.ProviderUserName)
+		},
+	}
+}
+
+// UntilIdentityIsMappedToUser checks if the Identity is mapped to the User with the given name
+func UntilIdentityIsMappedToUser(name string) IdentityWaitCriterion {
+	return IdentityWaitCriterion{
+		Match: func(actual *userv1.Identity) bool {
+			return actual.User.Name == name
+		},
+		Diff: func(actual *userv1.Identity) string {
+			return fmt.Sprintf("expected Identity to be mapped to User '%s'\nbut it was mapped to '%s'", name, actual.User.Name)
+		},
+	}
+}
+
 // WaitUntilUserAccountDeleted waits until the UserAccount with the given name is not found
 func (a *MemberAwaitility) WaitUntilUserAccountDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until UserAccount '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		ua := &toolchainv1alpha1.UserAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, ua); err != nil {
 			if errors.IsNotFound(err) {
@@ -1598,7 +1743,7 @@ func (a *MemberAwaitility) WaitUntilUserAccountDeleted(t *testing.T, name string
 // WaitUntilUserDeleted waits until the User with the given name is not found
 func (a *MemberAwaitility) WaitUntilUserDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until User is deleted '%s'", name)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		user := &userv1.User{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -1616,7 +1761,7 @@ func (a *MemberAwaitility) WaitUntilUserDeleted(t *testing.T, name string) error
 // WaitUntilIdentityDeleted waits until the Identity with the given name is not found
 func (a *MemberAwaitility) WaitUntilIdentityDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until Identity is deleted '%s'", name)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		identity := &userv1.Identity{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, identity); err != nil {
 			if errors.IsNotFound(err) {
@@ -1631,19 +1776,88 @@ func (a *MemberAwaitility) WaitUntilIdentityDeleted(t *testing.T, name string) e
 	})
 }
 
-// GetConsoleURL retrieves Web Console Route and returns its URL
+const (
+	// DefaultConsoleNamespace the namespace of the Web Console route used by the member operator when it is not set in the MemberOperatorConfig
+	DefaultConsoleNamespace = "openshift-console"
+	// DefaultConsoleRouteName the name of the Web Console route used by the member operator when it is not set in the MemberOperatorConfig
+	DefaultConsoleRouteName = "console"
+	// DefaultCheNamespace the namespace of the Che route used by the member operator when it is not set in the MemberOperatorConfig
+	DefaultCheNamespace = "codeready-workspaces-operator"
+	// DefaultCheRouteName the name of the Che route used by the member operator when it is not set in the MemberOperatorConfig
+	DefaultCheRouteName = "codeready"
+)
+
+// ConsoleRoute returns the namespace and name of the Web Console route configured in the given MemberOperatorConfig
+// (which may be nil), or the ones of the default route of the Web Console
+func ConsoleRoute(config *toolchainv1alpha1.MemberOperatorConfig) types.NamespacedName {
+	route := types.NamespacedName{Namespace: DefaultConsoleNamespace, Name: DefaultConsoleRouteName}
+	if config == nil {
+		return route
+	}
+	if config.Spec.Console.Namespace != nil {
+		route.Namespace = *config.Spec.Console.Namespace
+	}
+	if config.Spec.Console.RouteName != nil {
+		route.Name = *config.Spec.Console.RouteName
+	}
+	return route
+}
+
+// CheRoute returns the namespace and name of the Che route configured in the given MemberOperatorConfig (which may be nil),
+// or the ones of the default route of Che
+func CheRoute(config *toolchainv1alpha1.MemberOperatorConfig) types.NamespacedName {
+	route := types.NamespacedName{Namespace: DefaultCheNamespace, Name: DefaultCheRouteName}
+	if config == nil {
+		return route
+	}
+	if config.Spec.Che.Namespace != nil {
+		route.Namespace = *config.Spec.Che.Namespace
+	}
+	if config.Spec.Che.RouteName != nil {
+		route.Name = *config.Spec.Che.RouteName
+	}
+	return route
+}
+
+// ConsoleURL returns the URL of the Web Console, as computed by the member operator from the given route, ie, from the host in its spec,
+// so that the clusters with a custom apps domain (or a custom certificate for the Web Console) are supported.
+// The URL of the Che dashboard is computed the same way from the Che route.
+func ConsoleURL(route routev1.Route) string {
+	return fmt.Sprintf("https://%s/%s", route.Spec.Host, route.Spec.Path)
+}
+
+// GetConsoleURL retrieves the Web Console route configured in the MemberOperatorConfig (or the default route) and returns its URL
 func (a *MemberAwaitility) GetConsoleURL(t *testing.T) string {
+	namespacedName := ConsoleRoute(a.GetMemberOperatorConfig(t))
+	url, err := a.getRouteURL(namespacedName)
+	require.NoError(t, err, "unable to get the Web Console route '%s' in namespace '%s'", namespacedName.Name, namespacedName.Namespace)
+	return url
+}
+
+// GetCheDashboardURL retrieves the Che route configured in the MemberOperatorConfig (or the default route) and returns the URL
+// of the Che dashboard, or an error if the route cannot be retrieved (eg, if Che is not installed)
+func (a *MemberAwaitility) GetCheDashboardURL(t *testing.T) (string, error) {
+	namespacedName := CheRoute(a.GetMemberOperatorConfig(t))
+	url, err := a.getRouteURL(namespacedName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get the Che route '%s' in namespace '%s': %w", namespacedName.Name, namespacedName.Namespace, err)
+	}
+	return url, nil
+}
+
+// getRouteURL returns the URL of the route with the given namespace and name, computed as the member operator does (see ConsoleURL)
+func (a *MemberAwaitility) getRouteURL(namespacedName types.NamespacedName) (string, error) {
 	route := &routev1.Route{}
-	namespacedName := types.NamespacedName{Namespace: "openshift-console", Name: "console"}
-	err := a.Client.Get(context.TODO(), namespacedName, route)
-	require.NoError(t, err)
-	return fmt.Sprintf("https://%s/%s", route.Spec.Host, route.Spec.Path)
+	if err := a.Client.Get(context.TODO(), namespacedName, route); err != nil {
+		return "", err
+	}
+	return ConsoleURL(*route), nil
 }
 
 // WaitUntilClusterResourceQuotasDeleted waits until all ClusterResourceQuotas with the given owner label are deleted (ie, none is found)
 func (a *MemberAwaitility) WaitUntilClusterResourceQuotasDeleted(t *testing.T, username string) error {
 	t.Logf("waiting for deletion of ClusterResourceQuotas for user '%s'", username)
-	return wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	return a.poll(func() (done bool, err error) {
 		labels := map[string]string{"toolchain.dev.openshift.com/owner": username}
 		opts := client.MatchingLabels(labels)
 		quotaList := &quotav1.ClusterResourceQuotaList{}
@@ -1704,7 +1918,7 @@ func UntilMemberStatusHasConditions(expected ...toolchainv1alpha1.Condition) Mem
 			return test.ConditionsMatch(actual.Status.Conditions, expected...)
 		},
 		Diff: func(actual *toolchainv1alpha1.MemberStatus) string {
-			return fmt.Sprintf("expected conditions to match:\n%s", Diff(expected, actual.Status.Conditions))
+			return fmt.Sprintf("expected conditions to match:\n%s", Diff(SortedConditions(expected), SortedConditions(actual.Status.Conditions)))
 		},
 	}
 }
@@ -1753,7 +1967,7 @@ func (a *MemberAwaitility) WaitForMemberStatus(t *testing.T, criteria ...MemberS
 	t.Logf("waiting for MemberStatus '%s' to match criteria", name)
 	// there should only be one member status with the name toolchain-member-status
 	var memberStatus *toolchainv1alpha1.MemberStatus
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		// retrieve the memberstatus from the member namespace
 		obj := &toolchainv1alpha1.MemberStatus{}
 		err = a.Client.Get(context.TODO(),
@@ -1806,7 +2020,7 @@ func (a *MemberAwaitility) WaitForMemberOperatorConfig(t *testing.T, hostAwait *
 	name := "config"
 	t.Logf("waiting for MemberOperatorConfig '%s'", name)
 	memberOperatorConfig := &toolchainv1alpha1.MemberOperatorConfig{}
-	err := wait.Poll(a.RetryInterval, 2*a.Timeout, func() (done bool, err error) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
 		memberOperatorConfig = &toolchainv1alpha1.MemberOperatorConfig{}
 		// retrieve the MemberOperatorConfig from the member namespace
 		err = a.Client.Get(context.TODO(),
@@ -1864,7 +2078,7 @@ func (a *MemberAwaitility) waitForUsersPodPriorityClass(t *testing.T) {
 }
 
 func (a *MemberAwaitility) waitForResource(t *testing.T, namespace, name string, object client.Object) {
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		if err := a.Client.Get(context.TODO(), test.NamespacedName(namespace, name), object); err != nil {
 			if errors.IsNotFound(err) {
 				return false, nil
@@ -2019,15 +2233,66 @@ func (a *MemberAwaitility) verifyUsersRolebindingsWebhookConfig(t *testing.T, ca
 
 }
 
+const (
+	// AutoscalingBufferName the name of the autoscaling buffer Deployment
+	AutoscalingBufferName              = "autoscaling-buffer"
+	autoscalingBufferPriorityClassName = "member-operator-autoscaling-buffer"
+	// the default values used by the member operator when they are not set in the MemberOperatorConfig
+	defaultAutoscalingBufferMemory   = "50Mi"
+	defaultAutoscalingBufferReplicas = 2
+)
+
 func (a *MemberAwaitility) WaitForAutoscalingBufferApp(t *testing.T) {
 	a.verifyAutoscalingBufferPriorityClass(t)
-	a.verifyAutoscalingBufferDeployment(t)
+	a.verifyAutoscalingBufferDeployment(t, defaultAutoscalingBufferReplicas, defaultAutoscalingBufferMemory)
+}
+
+// WaitForAutoscalingBufferAppMatchingConfig waits until the autoscaling buffer app (PriorityClass and Deployment) matches
+// the given autoscaler configuration of the MemberOperatorConfig: if the buffer is not to be deployed, then it waits until the
+// PriorityClass and Deployment are deleted, otherwise it verifies that the Deployment has the expected number of replicas and memory
+// (with the same default values as the member operator)
+func (a *MemberAwaitility) WaitForAutoscalingBufferAppMatchingConfig(t *testing.T, config toolchainv1alpha1.AutoscalerConfig) {
+	if config.Deploy == nil || !*config.Deploy {
+		err := a.WaitUntilAutoscalingBufferAppDeleted(t)
+		require.NoError(t, err)
+		return
+	}
+	replicas := AutoscalingBufferReplicas(config)
+	memory := defaultAutoscalingBufferMemory
+	if config.BufferMemory != nil {
+		memory = *config.BufferMemory
+	}
+	a.verifyAutoscalingBufferPriorityClass(t)
+	a.verifyAutoscalingBufferDeployment(t, replicas, memory)
+}
+
+// AutoscalingBufferReplicas returns the number of replicas of the autoscaling buffer Deployment for the given autoscaler configuration
+// of the MemberOperatorConfig (with the same default value as the member operator)
+func AutoscalingBufferReplicas(config toolchainv1alpha1.AutoscalerConfig) int {
+	if config.BufferReplicas != nil {
+		return *config.BufferReplicas
+	}
+	return defaultAutoscalingBufferReplicas
+}
+
+// WaitUntilAutoscalingBufferAppDeleted waits until the autoscaling buffer Deployment and PriorityClass are deleted (ie, not found)
+func (a *MemberAwaitility) WaitUntilAutoscalingBufferAppDeleted(t *testing.T) error {
+	t.Logf("waiting until Deployment '%s' in namespace '%s' and PriorityClass '%s' are deleted", AutoscalingBufferName, a.Namespace, autoscalingBufferPriorityClassName)
+	return a.poll(func() (done bool, err error) {
+		if err := a.Client.Get(context.TODO(), test.NamespacedName(a.Namespace, AutoscalingBufferName), &appsv1.Deployment{}); err == nil || !errors.IsNotFound(err) {
+			return false, client.IgnoreNotFound(err)
+		}
+		if err := a.Client.Get(context.TODO(), test.NamespacedName("", autoscalingBufferPriorityClassName), &schedulingv1.PriorityClass{}); err == nil || !errors.IsNotFound(err) {
+			return false, client.IgnoreNotFound(err)
+		}
+		return true, nil
+	})
 }
 
 func (a *MemberAwaitility) verifyAutoscalingBufferPriorityClass(t *testing.T) {
-	t.Logf("checking PrioritiyClass '%s'", "member-operator-autoscaling-buffer")
+	t.Logf("checking PrioritiyClass '%s'", autoscalingBufferPriorityClassName)
 	actualPrioClass := &schedulingv1.PriorityClass{}
-	a.waitForResource(t, "", "member-operator-autoscaling-buffer", actualPrioClass)
+	a.waitForResource(t, "", autoscalingBufferPriorityClassName, actualPrioClass)
 
 	assert.Equal(t, codereadyToolchainProviderLabel, actualPrioClass.Labels)
 	assert.Equal(t, int32(-5), actualPrioClass.Value)
@@ -2035,22 +2300,36 @@ func (a *MemberAwaitility) verifyAutoscalingBufferPriorityClass(t *testing.T) {
 	assert.Equal(t, "This priority class is to be used by the autoscaling buffer pod only", actualPrioClass.Description)
 }
 
-func (a *MemberAwaitility) verifyAutoscalingBufferDeployment(t *testing.T) {
-	t.Logf("checking Deployment '%s' in namespace '%s'", "autoscaling-buffer", a.Namespace)
+func (a *MemberAwaitility) verifyAutoscalingBufferDeployment(t *testing.T, replicas int, memory string) {
+	t.Logf("checking Deployment '%s' in namespace '%s'", AutoscalingBufferName, a.Namespace)
+	expectedMemory, err := resource.ParseQuantity(memory)
+	require.NoError(t, err)
+
+	// the deployment may be resized at runtime, hence the wait until it has the expected replicas and memory
 	actualDeployment := &appsv1.Deployment{}
-	a.waitForResource(t, a.Namespace, "autoscaling-buffer", actualDeployment)
+	err = a.poll(func() (done bool, err error) {
+		if err := a.Client.Get(context.TODO(), test.NamespacedName(a.Namespace, AutoscalingBufferName), actualDeployment); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		containers := actualDeployment.Spec.Template.Spec.Containers
+		return actualDeployment.Spec.Replicas != nil && int(*actualDeployment.Spec.Replicas) == replicas &&
+			len(containers) == 1 && containers[0].Resources.Requests.Memory().Equal(expectedMemory), nil
+	})
+	require.NoError(t, err, "Deployment '%s' doesn't have the expected %d replicas with %s memory: %+v", AutoscalingBufferName, replicas, memory, actualDeployment)
 
 	assert.Equal(t, map[string]string{
 		"app":                                  "autoscaling-buffer",
 		"toolchain.dev.openshift.com/provider": "codeready-toolchain",
 	}, actualDeployment.Labels)
-	assert.Equal(t, int32(2), *actualDeployment.Spec.Replicas)
 	assert.Equal(t, map[string]string{"app": "autoscaling-buffer"}, actualDeployment.Spec.Selector.MatchLabels)
 
 	template := actualDeployment.Spec.Template
 	assert.Equal(t, map[string]string{"app": "autoscaling-buffer"}, template.ObjectMeta.Labels)
 
-	assert.Equal(t, "member-operator-autoscaling-buffer", template.Spec.PriorityClassName)
+	assert.Equal(t, autoscalingBufferPriorityClassName, template.Spec.PriorityClassName)
 	assert.Equal(t, int64(0), *template.Spec.TerminationGracePeriodSeconds)
 
 	require.Len(t, template.Spec.Containers, 1)
@@ -2059,12 +2338,10 @@ func (a *MemberAwaitility) verifyAutoscalingBufferDeployment(t *testing.T) {
 	assert.Equal(t, "gcr.io/google_containers/pause-amd64:3.2", container.Image)
 	assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
 
-	expectedMemory, err := resource.ParseQuantity("50Mi")
-	require.NoError(t, err)
 	assert.True(t, container.Resources.Requests.Memory().Equal(expectedMemory))
 	assert.True(t, container.Resources.Limits.Memory().Equal(expectedMemory))
 
-	a.WaitForDeploymentToGetReady(t, "autoscaling-buffer", 2)
+	a.WaitForDeploymentToGetReady(t, AutoscalingBufferName, replicas)
 }
 
 // WaitForExpectedNumberOfResources waits until the number of resources matches the expected count
@@ -2087,7 +2364,7 @@ func (a *MemberAwaitility) WaitForExpectedNumberOfClusterResources(t *testing.T,
 
 func (a *MemberAwaitility) waitForExpectedNumberOfResources(expected int, list func() (int, error)) (int, error) {
 	var actual int
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		a, err := list()
 		if err != nil {
 			return false, err
@@ -2099,49 +2376,17 @@ func (a *MemberAwaitility) waitForExpectedNumberOfResources(expected int, list f
 }
 
 func (a *MemberAwaitility) UpdatePod(t *testing.T, namespace, podName string, modifyPod func(pod *corev1.Pod)) (*corev1.Pod, error) {
-	var m *corev1.Pod
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		freshPod := &corev1.Pod{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: podName}, freshPod); err != nil {
-			return true, err
-		}
-
-		modifyPod(freshPod)
-		if err := a.Client.Update(context.TODO(), freshPod); err != nil {
-			t.Logf("error updating Pod '%s' Will retry again...", podName)
-			return false, nil // nolint:nilerr
-		}
-		m = freshPod
-		return true, nil
-	})
-	return m, err
+	return UpdateInNamespace(t, a.Awaitility, namespace, podName, modifyPod)
 }
 
 func (a *MemberAwaitility) UpdateConfigMap(t *testing.T, namespace, cmName string, modifyCM func(*corev1.ConfigMap)) (*corev1.ConfigMap, error) {
-	var cm *corev1.ConfigMap
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
-		obj := &corev1.ConfigMap{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{
-			Namespace: namespace,
-			Name:      cmName},
-			obj); err != nil {
-			return true, err
-		}
-		modifyCM(obj)
-		if err := a.Client.Update(context.TODO(), obj); err != nil {
-			t.Logf("error updating ConfigMap '%s' Will retry again...", cmName)
-			return false, nil // nolint:nilerr
-		}
-		cm = obj
-		return true, nil
-	})
-	return cm, err
+	return UpdateInNamespace(t, a.Awaitility, namespace, cmName, modifyCM)
 }
 
 func (a *MemberAwaitility) WaitForEnvironment(t *testing.T, namespace, name string) (*appstudiov1.Environment, error) {
 	t.Logf("waiting for Environment resource '%s' to exist in namespace '%s'", name, namespace)
 	var env *appstudiov1.Environment
-	err := wait.Poll(a.RetryInterval, a.Timeout, func() (done bool, err error) {
+	err := a.poll(func() (done bool, err error) {
 		obj := &appstudiov1.Environment{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
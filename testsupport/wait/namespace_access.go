@@ -0,0 +1,105 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// NamespaceManagerServiceAccountName the name of the ServiceAccount which is provisioned in the namespaces of a Space
+	// to manage their content (eg. on behalf of a SpaceRequest)
+	NamespaceManagerServiceAccountName = "namespace-manager"
+
+	// NamespaceAccessKubeconfigKey the key of the kubeconfig in the secrets referenced by the `status.namespaceAccess` of a SpaceRequest
+	NamespaceAccessKubeconfigKey = "kubeconfig"
+
+	serviceAccountTokenExpirationSeconds int64 = 600
+)
+
+// WaitForServiceAccountToken waits until the ServiceAccount with the given name exists in the given namespace, and
+// returns a (short-lived) token for this ServiceAccount, obtained via the TokenRequest API
+func (a *MemberAwaitility) WaitForServiceAccountToken(t *testing.T, namespace, name string) (string, error) {
+	if _, err := a.WaitForServiceAccount(t, namespace, name); err != nil {
+		return "", err
+	}
+	clientset, err := kubernetes.NewForConfig(a.RestConfig)
+	if err != nil {
+		return "", err
+	}
+	expiration := serviceAccountTokenExpirationSeconds
+	var token string
+	err = a.poll(func() (done bool, err error) {
+		tokenRequest, err := clientset.CoreV1().ServiceAccounts(namespace).CreateToken(context.TODO(), name, &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: &expiration,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			t.Logf("failed to request a token for ServiceAccount '%s' in namespace '%s': %s", name, namespace, err.Error())
+			return false, nil
+		}
+		token = tokenRequest.Status.Token
+		return token != "", nil
+	})
+	return token, err
+}
+
+// NewRestConfigWithToken returns a config to the API server of the member cluster, which authenticates with the given token
+// (instead of the credentials of the awaitility)
+func (a *MemberAwaitility) NewRestConfigWithToken(token string) *rest.Config {
+	config := rest.AnonymousClientConfig(a.RestConfig)
+	config.BearerToken = token
+	return config
+}
+
+// WaitForNamespaceAccessSecret waits until the secret referenced by the SpaceRequest to access the given provisioned namespace
+// exists and contains a kubeconfig. Returns the config built from this kubeconfig.
+func (a *MemberAwaitility) WaitForNamespaceAccessSecret(t *testing.T, spaceRequest types.NamespacedName, provisionedNamespace string) (*rest.Config, error) {
+	t.Logf("waiting for secret to access namespace '%s' provisioned for SpaceRequest '%s'", provisionedNamespace, spaceRequest.Name)
+	request, err := a.WaitForSpaceRequest(t, spaceRequest, UntilSpaceRequestHasNamespaceAccess())
+	if err != nil {
+		return nil, err
+	}
+	secretName := ""
+	for _, access := range request.Status.NamespaceAccess {
+		if access.Name == provisionedNamespace {
+			secretName = access.SecretRef
+		}
+	}
+	if secretName == "" {
+		return nil, fmt.Errorf("no namespace access for namespace '%s' in SpaceRequest '%s'", provisionedNamespace, spaceRequest.Name)
+	}
+	var secret *corev1.Secret
+	err = a.poll(func() (done bool, err error) {
+		obj := &corev1.Secret{}
+		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: spaceRequest.Namespace, Name: secretName}, obj); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		secret = obj
+		return len(secret.Data[NamespaceAccessKubeconfigKey]) > 0, nil
+	})
+	if err != nil {
+		t.Logf("failed to wait for secret '%s' with a kubeconfig in namespace '%s'", secretName, spaceRequest.Namespace)
+		return nil, err
+	}
+	return clientcmd.RESTConfigFromKubeConfig(secret.Data[NamespaceAccessKubeconfigKey])
+}
+
+// NewClientWithConfig returns a client using the given config and the scheme of the awaitility client
+func (a *MemberAwaitility) NewClientWithConfig(config *rest.Config) (client.Client, error) {
+	return client.New(config, client.Options{Scheme: a.Client.Scheme()})
+}
@@ -0,0 +1,76 @@
+package wait
+
+import (
+	"sort"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The functions below return sorted copies of the lists whose order is not deterministic (eg, because they are built
+// from maps or from the results of List calls), so that they can be compared (and diffed) in the wait criteria,
+// including in the custom criteria of the tests.
+
+// SortedConditions returns a copy of the given conditions, sorted by type (and then by status and reason)
+func SortedConditions(conditions []toolchainv1alpha1.Condition) []toolchainv1alpha1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	result := make([]toolchainv1alpha1.Condition, len(conditions))
+	copy(result, conditions)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		if result[i].Status != result[j].Status {
+			return result[i].Status < result[j].Status
+		}
+		return result[i].Reason < result[j].Reason
+	})
+	return result
+}
+
+// SortedSpaceRoles returns a copy of the given space roles, sorted by template ref, and with sorted usernames
+func SortedSpaceRoles(spaceRoles []toolchainv1alpha1.NSTemplateSetSpaceRole) []toolchainv1alpha1.NSTemplateSetSpaceRole {
+	if spaceRoles == nil {
+		return nil
+	}
+	result := make([]toolchainv1alpha1.NSTemplateSetSpaceRole, len(spaceRoles))
+	for i, r := range spaceRoles {
+		result[i] = toolchainv1alpha1.NSTemplateSetSpaceRole{
+			TemplateRef: r.TemplateRef,
+			Usernames:   SortedStrings(r.Usernames),
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TemplateRef < result[j].TemplateRef
+	})
+	return result
+}
+
+// SortedOwnerReferences returns a copy of the given owner references, sorted by kind and name
+func SortedOwnerReferences(refs []metav1.OwnerReference) []metav1.OwnerReference {
+	if refs == nil {
+		return nil
+	}
+	result := make([]metav1.OwnerReference, len(refs))
+	copy(result, refs)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// SortedStrings returns a sorted copy of the given values (eg, usernames, or the names of the owners of a resource)
+func SortedStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	copy(result, values)
+	sort.Strings(result)
+	return result
+}
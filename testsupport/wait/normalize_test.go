@@ -0,0 +1,107 @@
+package wait_test
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortedConditions(t *testing.T) {
+	// given
+	conditions := []toolchainv1alpha1.Condition{
+		{Type: toolchainv1alpha1.ConditionReady, Status: corev1.ConditionTrue},
+		{Type: "Complete", Status: corev1.ConditionFalse, Reason: "Provisioning"},
+	}
+
+	// when
+	sorted := wait.SortedConditions(conditions)
+
+	// then
+	assert.Equal(t, []toolchainv1alpha1.Condition{
+		{Type: "Complete", Status: corev1.ConditionFalse, Reason: "Provisioning"},
+		{Type: toolchainv1alpha1.ConditionReady, Status: corev1.ConditionTrue},
+	}, sorted)
+	// the given conditions are not modified
+	assert.Equal(t, toolchainv1alpha1.ConditionReady, conditions[0].Type)
+}
+
+func TestSortedSpaceRoles(t *testing.T) {
+	// given
+	spaceRoles := []toolchainv1alpha1.NSTemplateSetSpaceRole{
+		wait.SpaceRole("base-viewer-123", "zorro", "alice"),
+		wait.SpaceRole("base-admin-123", "john", "bob"),
+	}
+
+	// when
+	sorted := wait.SortedSpaceRoles(spaceRoles)
+
+	// then
+	assert.Equal(t, []toolchainv1alpha1.NSTemplateSetSpaceRole{
+		wait.SpaceRole("base-admin-123", "bob", "john"),
+		wait.SpaceRole("base-viewer-123", "alice", "zorro"),
+	}, sorted)
+	// the given space roles are not modified
+	assert.Equal(t, []string{"zorro", "alice"}, spaceRoles[0].Usernames)
+}
+
+func TestSortedOwnerReferences(t *testing.T) {
+	// given
+	refs := []metav1.OwnerReference{
+		{Kind: "Space", Name: "john"},
+		{Kind: "MasterUserRecord", Name: "john"},
+		{Kind: "Space", Name: "alice"},
+	}
+
+	// when
+	sorted := wait.SortedOwnerReferences(refs)
+
+	// then
+	assert.Equal(t, []metav1.OwnerReference{
+		{Kind: "MasterUserRecord", Name: "john"},
+		{Kind: "Space", Name: "alice"},
+		{Kind: "Space", Name: "john"},
+	}, sorted)
+}
+
+func TestSortedStrings(t *testing.T) {
+	t.Run("sorted copy", func(t *testing.T) {
+		// given
+		values := []string{"john", "alice"}
+
+		// when
+		sorted := wait.SortedStrings(values)
+
+		// then
+		assert.Equal(t, []string{"alice", "john"}, sorted)
+		assert.Equal(t, []string{"john", "alice"}, values)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, wait.SortedStrings(nil))
+	})
+}
+
+func TestUntilNSTemplateSetHasSpaceRolesInDifferentOrder(t *testing.T) {
+	// given
+	nsTmplSet := &toolchainv1alpha1.NSTemplateSet{
+		Spec: toolchainv1alpha1.NSTemplateSetSpec{
+			SpaceRoles: []toolchainv1alpha1.NSTemplateSetSpaceRole{
+				wait.SpaceRole("base-viewer-123", "zorro", "alice"),
+				wait.SpaceRole("base-admin-123", "john"),
+			},
+		},
+	}
+
+	// when
+	criterion := wait.UntilNSTemplateSetHasSpaceRoles(
+		wait.SpaceRole("base-admin-123", "john"),
+		wait.SpaceRole("base-viewer-123", "alice", "zorro"),
+	)
+
+	// then
+	assert.True(t, criterion.Match(nsTmplSet))
+}
@@ -0,0 +1,92 @@
+package wait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectCounts the number of objects per kind
+type ObjectCounts map[string]int
+
+// String returns the counts sorted by kind, for a stable output in the logs
+func (c ObjectCounts) String() string {
+	kinds := make([]string, 0, len(c))
+	for kind := range c {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	counts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		counts[i] = fmt.Sprintf("%s=%d", kind, c[kind])
+	}
+	return strings.Join(counts, ", ")
+}
+
+// CountObjects returns the number of objects of the type of the given list in the namespace of the awaitility.
+// The objects are retrieved page by page, so that the count does not require loading all the objects in a single request
+func (a *Awaitility) CountObjects(list client.ObjectList, opts ...client.ListOption) (int, error) {
+	count := 0
+	err := a.ForEachPage(list, func(page client.ObjectList) error {
+		count += meta.LenList(page)
+		return nil
+	}, opts...)
+	return count, err
+}
+
+// CountToolchainObjects returns the number of UserSignups, MasterUserRecords, Spaces, SpaceBindings and Notifications in the host operator namespace
+func (a *HostAwaitility) CountToolchainObjects(t *testing.T) ObjectCounts {
+	return a.countObjectsPerKind(t, map[string]client.ObjectList{
+		"UserSignup":       &toolchainv1alpha1.UserSignupList{},
+		"MasterUserRecord": &toolchainv1alpha1.MasterUserRecordList{},
+		"Space":            &toolchainv1alpha1.SpaceList{},
+		"SpaceBinding":     &toolchainv1alpha1.SpaceBindingList{},
+		"Notification":     &toolchainv1alpha1.NotificationList{},
+	})
+}
+
+// CountToolchainObjects returns the number of UserAccounts and NSTemplateSets in the member operator namespace
+func (a *MemberAwaitility) CountToolchainObjects(t *testing.T) ObjectCounts {
+	return a.countObjectsPerKind(t, map[string]client.ObjectList{
+		"UserAccount":   &toolchainv1alpha1.UserAccountList{},
+		"NSTemplateSet": &toolchainv1alpha1.NSTemplateSetList{},
+	})
+}
+
+func (a *Awaitility) countObjectsPerKind(t *testing.T, lists map[string]client.ObjectList) ObjectCounts {
+	counts := ObjectCounts{}
+	for kind, list := range lists {
+		count, err := a.CountObjects(list)
+		require.NoError(t, err, "failed to count the %s resources in namespace '%s'", kind, a.Namespace)
+		counts[kind] = count
+	}
+	t.Logf("number of objects in namespace '%s' of the %s cluster: %s", a.Namespace, a.Type, counts)
+	return counts
+}
+
+// AssertObjectCountsAtMost verifies that the number of objects of each kind in the given counts does not exceed the given upper bounds.
+// It is meant to be used after large cleanup operations, to catch leaks of resources that should have been deleted.
+// The kinds that have no upper bound are ignored.
+func AssertObjectCountsAtMost(t *testing.T, counts ObjectCounts, upperBounds ObjectCounts) {
+	for kind, max := range upperBounds {
+		count, found := counts[kind]
+		require.True(t, found, "no count for the %s resources", kind)
+		require.LessOrEqualf(t, count, max, "too many %s resources remaining: %d (max allowed: %d)", kind, count, max)
+	}
+}
+
+// AssertObjectCountsNotIncreased verifies that the number of objects of each kind in the given counts did not increase
+// by more than the given tolerance compared to the baseline counts (typically, captured before the test created its resources)
+func AssertObjectCountsNotIncreased(t *testing.T, baseline ObjectCounts, counts ObjectCounts, tolerance int) {
+	upperBounds := ObjectCounts{}
+	for kind, count := range baseline {
+		upperBounds[kind] = count + tolerance
+	}
+	AssertObjectCountsAtMost(t, counts, upperBounds)
+}
@@ -0,0 +1,71 @@
+package wait_test
+
+import (
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCountObjects(t *testing.T) {
+	// given
+	objs := []client.Object{}
+	for i := 0; i < 3; i++ {
+		objs = append(objs, &toolchainv1alpha1.Notification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("notification-%d", i),
+				Namespace: commontest.HostOperatorNs,
+			},
+		})
+	}
+	objs = append(objs, &toolchainv1alpha1.Notification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "notification-elsewhere",
+			Namespace: "other",
+		},
+	})
+	await := &wait.Awaitility{
+		Client:    commontest.NewFakeClient(t, objs...),
+		Namespace: commontest.HostOperatorNs,
+	}
+
+	// when
+	count, err := await.CountObjects(&toolchainv1alpha1.NotificationList{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestObjectCountsString(t *testing.T) {
+	assert.Equal(t, "NSTemplateSet=2, Notification=0, SpaceBinding=10", wait.ObjectCounts{
+		"SpaceBinding":  10,
+		"NSTemplateSet": 2,
+		"Notification":  0,
+	}.String())
+}
+
+func TestAssertObjectCountsAtMost(t *testing.T) {
+	t.Run("counts within upper bounds", func(t *testing.T) {
+		wait.AssertObjectCountsAtMost(t, wait.ObjectCounts{
+			"Notification": 2,
+			"SpaceBinding": 5,
+		}, wait.ObjectCounts{
+			"Notification": 2,
+		})
+	})
+
+	t.Run("counts within tolerance", func(t *testing.T) {
+		wait.AssertObjectCountsNotIncreased(t, wait.ObjectCounts{
+			"SpaceBinding": 5,
+		}, wait.ObjectCounts{
+			"SpaceBinding": 6,
+		}, 1)
+	})
+}
@@ -0,0 +1,60 @@
+package wait
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+// The `*OrFail` variants of the waiters fail the test when the wait returns an error, for the tests which do not need
+// to branch on the reason of the timeout (see ErrTimeout).
+
+// WaitForMasterUserRecordOrFail is the same as WaitForMasterUserRecord, but fails the test if the wait returns an error
+func (a *HostAwaitility) WaitForMasterUserRecordOrFail(t *testing.T, name string, criteria ...MasterUserRecordWaitCriterion) *toolchainv1alpha1.MasterUserRecord {
+	mur, err := a.WaitForMasterUserRecord(t, name, criteria...)
+	require.NoError(t, err)
+	return mur
+}
+
+// WaitForUserSignupOrFail is the same as WaitForUserSignup, but fails the test if the wait returns an error
+func (a *HostAwaitility) WaitForUserSignupOrFail(t *testing.T, name string, criteria ...UserSignupWaitCriterion) *toolchainv1alpha1.UserSignup {
+	userSignup, err := a.WaitForUserSignup(t, name, criteria...)
+	require.NoError(t, err)
+	return userSignup
+}
+
+// WaitForToolchainStatusOrFail is the same as WaitForToolchainStatus, but fails the test if the wait returns an error
+func (a *HostAwaitility) WaitForToolchainStatusOrFail(t *testing.T, criteria ...ToolchainStatusWaitCriterion) *toolchainv1alpha1.ToolchainStatus {
+	toolchainStatus, err := a.WaitForToolchainStatus(t, criteria...)
+	require.NoError(t, err)
+	return toolchainStatus
+}
+
+// WaitForSpaceOrFail is the same as WaitForSpace, but fails the test if the wait returns an error
+func (a *HostAwaitility) WaitForSpaceOrFail(t *testing.T, name string, criteria ...SpaceWaitCriterion) *toolchainv1alpha1.Space {
+	space, err := a.WaitForSpace(t, name, criteria...)
+	require.NoError(t, err)
+	return space
+}
+
+// WaitForSpaceBindingOrFail is the same as WaitForSpaceBinding, but fails the test if the wait returns an error
+func (a *HostAwaitility) WaitForSpaceBindingOrFail(t *testing.T, murName, spaceName string, criteria ...SpaceBindingWaitCriterion) *toolchainv1alpha1.SpaceBinding {
+	spaceBinding, err := a.WaitForSpaceBinding(t, murName, spaceName, criteria...)
+	require.NoError(t, err)
+	return spaceBinding
+}
+
+// WaitForUserAccountOrFail is the same as WaitForUserAccount, but fails the test if the wait returns an error
+func (a *MemberAwaitility) WaitForUserAccountOrFail(t *testing.T, name string, criteria ...UserAccountWaitCriterion) *toolchainv1alpha1.UserAccount {
+	userAccount, err := a.WaitForUserAccount(t, name, criteria...)
+	require.NoError(t, err)
+	return userAccount
+}
+
+// WaitForNSTmplSetOrFail is the same as WaitForNSTmplSet, but fails the test if the wait returns an error
+func (a *MemberAwaitility) WaitForNSTmplSetOrFail(t *testing.T, name string, criteria ...NSTemplateSetWaitCriterion) *toolchainv1alpha1.NSTemplateSet {
+	nsTmplSet, err := a.WaitForNSTmplSet(t, name, criteria...)
+	require.NoError(t, err)
+	return nsTmplSet
+}
@@ -0,0 +1,90 @@
+package wait
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// PendingWaiter a wait which is in progress, ie, whose criteria are not met yet
+type PendingWaiter struct {
+	Test string `json:"test"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Since the time at which the wait started
+	Since time.Time `json:"since"`
+	// Timeout the timeout of the wait
+	Timeout time.Duration `json:"timeout"`
+	// LastState the last observed state of the object (eg, its conditions)
+	LastState string `json:"lastState"`
+	// registry the registry in which the wait is registered
+	registry *WaiterRegistry
+}
+
+// WaiterRegistry the waits in progress, so that they can be inspected while the suite is running (eg, when it hangs).
+// The awaitilities share a default registry (see PendingWaiters), unless they are given their own registry.
+type WaiterRegistry struct {
+	sync.Mutex
+	waiters map[*PendingWaiter]struct{}
+}
+
+// NewWaiterRegistry returns a new WaiterRegistry, without any wait in progress
+func NewWaiterRegistry() *WaiterRegistry {
+	return &WaiterRegistry{
+		waiters: map[*PendingWaiter]struct{}{},
+	}
+}
+
+var defaultWaiterRegistry = NewWaiterRegistry()
+
+// PendingWaiters returns the waits which are in progress in the default registry, sorted by start time (oldest first)
+func PendingWaiters() []PendingWaiter {
+	return defaultWaiterRegistry.PendingWaiters()
+}
+
+// PendingWaiters returns the waits which are in progress, sorted by start time (oldest first)
+func (r *WaiterRegistry) PendingWaiters() []PendingWaiter {
+	r.Lock()
+	defer r.Unlock()
+	result := make([]PendingWaiter, 0, len(r.waiters))
+	for w := range r.waiters {
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Since.Before(result[j].Since)
+	})
+	return result
+}
+
+// add registers a wait in progress and returns it, so that its observed state can be updated and it can be removed once done
+func (r *WaiterRegistry) add(t *testing.T, kind, name string, timeout time.Duration) *PendingWaiter {
+	w := &PendingWaiter{
+		Kind:     kind,
+		Name:     name,
+		Since:    time.Now(),
+		Timeout:  timeout,
+		registry: r,
+	}
+	if t != nil {
+		w.Test = t.Name()
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.waiters[w] = struct{}{}
+	return w
+}
+
+// observe updates the last observed state of the pending wait
+func (w *PendingWaiter) observe(state string) {
+	w.registry.Lock()
+	defer w.registry.Unlock()
+	w.LastState = state
+}
+
+// done removes the wait from the waits in progress
+func (w *PendingWaiter) done() {
+	w.registry.Lock()
+	defer w.registry.Unlock()
+	delete(w.registry.waiters, w)
+}
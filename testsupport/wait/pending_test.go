@@ -0,0 +1,75 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingWaiters(t *testing.T) {
+	// given
+	await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member").
+		WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(500*time.Millisecond))
+	done := make(chan error)
+
+	// when
+	go func() {
+		_, err := await.WaitForUserAccount(t, "pending")
+		done <- err
+	}()
+
+	// then
+	assert.Eventually(t, func() bool {
+		return findPendingWaiter("UserAccount", "pending") != nil
+	}, 400*time.Millisecond, 10*time.Millisecond)
+	pending := findPendingWaiter("UserAccount", "pending")
+	require.NotNil(t, pending)
+	assert.Equal(t, "TestPendingWaiters", pending.Test)
+	assert.Equal(t, 500*time.Millisecond, pending.Timeout)
+
+	t.Run("removed once done", func(t *testing.T) {
+		// when
+		require.Error(t, <-done)
+
+		// then
+		assert.Nil(t, findPendingWaiter("UserAccount", "pending"))
+	})
+}
+
+func TestPendingWaitersInOwnRegistry(t *testing.T) {
+	// given
+	registry := wait.NewWaiterRegistry()
+	await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member").
+		WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(500*time.Millisecond))
+	await.WaiterRegistry = registry
+	done := make(chan error)
+
+	// when
+	go func() {
+		_, err := await.WaitForUserAccount(t, "registered")
+		done <- err
+	}()
+
+	// then
+	assert.Eventually(t, func() bool {
+		return len(registry.PendingWaiters()) == 1
+	}, 400*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, "registered", registry.PendingWaiters()[0].Name)
+	assert.Nil(t, findPendingWaiter("UserAccount", "registered")) // not in the default registry
+	require.Error(t, <-done)
+	assert.Empty(t, registry.PendingWaiters())
+}
+
+func findPendingWaiter(kind, name string) *wait.PendingWaiter {
+	waiters := wait.PendingWaiters()
+	for i := range waiters {
+		if waiters[i].Kind == kind && waiters[i].Name == name {
+			return &waiters[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package wait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaitUntilPodsReplaced waits until the given number of pods match the given criteria, all of them ready and none of them being deleted
+// or having one of the given UIDs (ie, the pods with these UIDs were replaced, eg, by their deployment after they were deleted).
+// Returns the new pods.
+func (a *Awaitility) WaitUntilPodsReplaced(t *testing.T, replacedUIDs []types.UID, replicas int, criteria ...client.ListOption) ([]corev1.Pod, error) {
+	t.Logf("waiting until %d pods in namespace '%s' replaced the pods with UIDs %v", replicas, a.Namespace, replacedUIDs)
+	replaced := make(map[types.UID]bool, len(replacedUIDs))
+	for _, uid := range replacedUIDs {
+		replaced[uid] = true
+	}
+	var pods []corev1.Pod
+	err := a.poll(func() (done bool, err error) {
+		list := &corev1.PodList{}
+		if err := a.Client.List(context.TODO(), list, criteria...); err != nil {
+			return false, err
+		}
+		pods = list.Items
+		if len(pods) != replicas {
+			return false, nil
+		}
+		for i := range pods {
+			if replaced[pods[i].UID] || util.IsBeingDeleted(&pods[i]) || !podutils.IsPodReady(&pods[i]) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		for _, p := range pods {
+			t.Logf("pod '%s' (UID '%s', replaced: %t, phase: %s, ready: %t)", p.Name, p.UID, replaced[p.UID], p.Status.Phase, podutils.IsPodReady(&p)) // nolint:gosec
+		}
+	}
+	return pods, err
+}
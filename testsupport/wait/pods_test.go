@@ -0,0 +1,93 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWaitUntilPodsReplaced(t *testing.T) {
+	newPod := func(name string, uid types.UID, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: commontest.HostOperatorNs,
+				Name:      name,
+				UID:       uid,
+				Labels:    map[string]string{"control-plane": "controller-manager"},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	newAwaitility := func(t *testing.T, pods ...*corev1.Pod) *wait.Awaitility {
+		objs := make([]client.Object, len(pods))
+		for i := range pods {
+			objs[i] = pods[i]
+		}
+		return &wait.Awaitility{
+			Client:        commontest.NewFakeClient(t, objs...),
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: time.Millisecond,
+			Timeout:       50 * time.Millisecond,
+		}
+	}
+	criteria := []client.ListOption{client.InNamespace(commontest.HostOperatorNs), client.MatchingLabels{"control-plane": "controller-manager"}}
+
+	t.Run("replaced", func(t *testing.T) {
+		// given
+		await := newAwaitility(t, newPod("host-operator-new", "uid-2", corev1.ConditionTrue))
+
+		// when
+		pods, err := await.WaitUntilPodsReplaced(t, []types.UID{"uid-1"}, 1, criteria...)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.Equal(t, types.UID("uid-2"), pods[0].UID)
+	})
+
+	t.Run("not replaced", func(t *testing.T) {
+		// given
+		await := newAwaitility(t, newPod("host-operator-old", "uid-1", corev1.ConditionTrue))
+
+		// when
+		_, err := await.WaitUntilPodsReplaced(t, []types.UID{"uid-1"}, 1, criteria...)
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+	})
+
+	t.Run("replaced but not ready", func(t *testing.T) {
+		// given
+		await := newAwaitility(t, newPod("host-operator-new", "uid-2", corev1.ConditionFalse))
+
+		// when
+		_, err := await.WaitUntilPodsReplaced(t, []types.UID{"uid-1"}, 1, criteria...)
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+	})
+
+	t.Run("replaced pod still running", func(t *testing.T) {
+		// given
+		await := newAwaitility(t,
+			newPod("host-operator-old", "uid-1", corev1.ConditionTrue),
+			newPod("host-operator-new", "uid-2", corev1.ConditionTrue))
+
+		// when
+		_, err := await.WaitUntilPodsReplaced(t, []types.UID{"uid-1"}, 1, criteria...)
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+	})
+}
@@ -0,0 +1,103 @@
+package wait
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultBackoff the default backoff policy of the awaitilities: the first checks happen shortly after the retry interval
+// (to detect fast transitions sooner), then the interval between two checks increases up to a cap of 2 seconds
+// (to reduce the pressure on the API server during long waits)
+var DefaultBackoff = BackoffPolicy{
+	Factor: 1.5,
+	Jitter: 0.1,
+	Cap:    2 * time.Second,
+}
+
+// BackoffPolicy the policy to compute the interval between two checks of a condition, starting with the retry interval of the awaitility.
+// The zero value means that the condition is checked at a fixed interval.
+type BackoffPolicy struct {
+	// Factor the factor by which the interval is multiplied after each check (ignored if lower than or equal to 1)
+	Factor float64
+	// Jitter the maximum fraction of the interval that is randomly added to it (ignored if lower than or equal to 0)
+	Jitter float64
+	// Cap the maximum interval between two checks (ignored if 0)
+	Cap time.Duration
+}
+
+var _ RetryOption = BackoffPolicy{}
+
+func (o BackoffPolicy) apply(a *Awaitility) {
+	a.Backoff = o
+}
+
+// FixedInterval an option to check the conditions at a fixed interval (ie, the retry interval of the awaitility)
+func FixedInterval() BackoffPolicy {
+	return BackoffPolicy{}
+}
+
+// next returns the interval following the given one
+func (p BackoffPolicy) next(interval time.Duration) time.Duration {
+	if p.Factor > 1 {
+		interval = time.Duration(float64(interval) * p.Factor)
+	}
+	if p.Cap > 0 && interval > p.Cap {
+		interval = p.Cap
+	}
+	return interval
+}
+
+// jitter returns the given interval with some random jitter, if configured
+func (p BackoffPolicy) jitter(interval time.Duration) time.Duration {
+	if p.Jitter > 0 {
+		return wait.Jitter(interval, p.Jitter)
+	}
+	return interval
+}
+
+// poll checks the given condition according to the retry interval and backoff policy of the awaitility,
+// until it is met, it returns an error or the timeout of the awaitility is reached
+func (a *Awaitility) poll(condition wait.ConditionFunc) error {
+	return a.pollWithTimeout(a.Timeout, condition)
+}
+
+// pollWithTimeout is the same as poll, but with the given timeout. As with `wait.Poll`, the first check happens
+// after the retry interval, and `wait.ErrWaitTimeout` is returned if the condition is not met before the timeout.
+func (a *Awaitility) pollWithTimeout(timeout time.Duration, condition wait.ConditionFunc) error {
+	if a.Backoff == (BackoffPolicy{}) {
+		return wait.Poll(a.RetryInterval, timeout, condition)
+	}
+	deadline := time.Now().Add(timeout)
+	interval := a.RetryInterval
+	for {
+		sleep := a.Backoff.jitter(interval)
+		if remaining := time.Until(deadline); sleep > remaining {
+			sleep = remaining
+		}
+		time.Sleep(sleep)
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return wait.ErrWaitTimeout
+		}
+		interval = a.Backoff.next(interval)
+	}
+}
+
+// Poll checks the given condition according to the retry interval and backoff policy of the awaitility,
+// until it is met, it returns an error or the timeout of the awaitility is reached.
+// It is meant for the helpers outside of this package which wait for a condition that no waiter covers yet.
+func (a *Awaitility) Poll(condition wait.ConditionFunc) error {
+	return a.poll(condition)
+}
+
+// PollWithTimeout is the same as Poll, but with the given timeout
+func (a *Awaitility) PollWithTimeout(timeout time.Duration, condition wait.ConditionFunc) error {
+	return a.pollWithTimeout(timeout, condition)
+}
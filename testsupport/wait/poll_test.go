@@ -0,0 +1,65 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestPollWithBackoff(t *testing.T) {
+	// given
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "metrics",
+			Namespace: commontest.HostOperatorNs,
+		},
+	}
+	await := &wait.Awaitility{
+		Client:        commontest.NewFakeClient(t, svc),
+		Namespace:     commontest.HostOperatorNs,
+		RetryInterval: time.Millisecond,
+		Timeout:       100 * time.Millisecond,
+		Backoff: wait.BackoffPolicy{
+			Factor: 2,
+			Jitter: 0.1,
+			Cap:    10 * time.Millisecond,
+		},
+	}
+
+	t.Run("condition met", func(t *testing.T) {
+		// when
+		_, err := await.WaitForService(t, "metrics")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		// when
+		start := time.Now()
+		_, err := await.WaitForService(t, "unknown")
+
+		// then
+		require.Equal(t, k8swait.ErrWaitTimeout, err)
+		assert.GreaterOrEqual(t, time.Since(start), await.Timeout)
+		assert.Less(t, time.Since(start), 2*await.Timeout)
+	})
+
+	t.Run("fixed interval", func(t *testing.T) {
+		// when
+		fixed := await.WithRetryOptions(wait.FixedInterval())
+
+		// then
+		assert.Equal(t, wait.BackoffPolicy{}, fixed.Backoff)
+		assert.Equal(t, 2.0, await.Backoff.Factor) // original awaitility is unchanged
+		_, err := fixed.WaitForService(t, "unknown")
+		require.Equal(t, k8swait.ErrWaitTimeout, err)
+	})
+}
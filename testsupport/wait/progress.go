@@ -0,0 +1,103 @@
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultProgressInterval the default interval between two progress logs during a long wait
+	DefaultProgressInterval = 30 * time.Second
+	// ProgressIntervalVar the name of the env var to override the interval between two progress logs during a long wait (eg, `10s`).
+	// Setting it to `0` disables the progress logs.
+	ProgressIntervalVar = "E2E_WAIT_PROGRESS_INTERVAL"
+	// MaxConsecutiveTransientErrors the max number of consecutive transient errors which are retried during a wait, after which
+	// the last error is returned, so that a persistently failing API server does not silently consume the whole timeout
+	MaxConsecutiveTransientErrors = 10
+)
+
+// ProgressInterval an option to configure the interval between two progress logs during a long wait (0 disables the progress logs)
+type ProgressInterval time.Duration
+
+var _ RetryOption = ProgressInterval(0)
+
+func (o ProgressInterval) apply(a *Awaitility) {
+	a.ProgressInterval = time.Duration(o)
+}
+
+// progressIntervalFromEnv returns the interval set with the `E2E_WAIT_PROGRESS_INTERVAL` env var, or the default interval
+// if the env var is not set or invalid
+func progressIntervalFromEnv() time.Duration {
+	if value, found := os.LookupEnv(ProgressIntervalVar); found {
+		if interval, err := time.ParseDuration(value); err == nil {
+			return interval
+		}
+	}
+	return DefaultProgressInterval
+}
+
+// pollWithProgress is the same as pollWithTimeout (hence with the retry interval and backoff policy of the awaitility),
+// for a wait on the object of the given kind and name. In addition:
+//   - progress: in verbose mode (`go test -v`), the unmet criteria (see `unmet`) are logged at each progress interval of the awaitility
+//   - timeout: the returned error is an ErrTimeout with the last state (see `observed`), whether the object was found at all during
+//     the wait, the unmet criteria and the history of the state transitions
+//   - registry: the wait is registered as pending in the registry of the awaitility while in progress (see PendingWaiters)
+//   - transient errors: the transient errors of the API server (see TransientErrorKind) are recorded and retried at the next check,
+//     unless more than MaxConsecutiveTransientErrors were returned in a row, in which case the last error is returned
+func (a *Awaitility) pollWithProgress(t *testing.T, timeout time.Duration, kind, name string, unmet func() []string, observed func() (state string, found bool), condition wait.ConditionFunc) error {
+	verbose := a.ProgressInterval > 0 && testing.Verbose()
+	history := newStateHistory(stateHistorySize)
+	start := time.Now()
+	lastLog := start
+	pending := a.waiterRegistry().add(t, kind, name, timeout)
+	defer pending.done()
+	transientErrors := 0
+	found := false
+	err := a.pollWithTimeout(timeout, func() (bool, error) {
+		done, err := condition()
+		if errKind, transient := TransientErrorKind(err); transient {
+			// recorded so that the transient errors of the run can be reported
+			report.RecordTransientError(t, errKind, kind)
+			transientErrors++
+			if transientErrors > MaxConsecutiveTransientErrors {
+				return false, fmt.Errorf("too many consecutive transient errors while waiting for %s '%s': %w", kind, name, err)
+			}
+			// retried at the next check
+			t.Logf("transient error while waiting for %s '%s': %s. Will retry again...", kind, name, err.Error())
+			done, err = false, nil
+		} else {
+			transientErrors = 0
+		}
+		state, observedFound := observed()
+		found = found || observedFound
+		history.observe(state)
+		pending.observe(state)
+		if verbose && !done && err == nil && time.Since(lastLog) >= a.ProgressInterval {
+			t.Logf("still waiting for %s '%s' after %s (timeout: %s), unmet criteria: %s", kind, name, time.Since(start).Round(time.Second), timeout,
+				strings.Join(unmet(), "\n"))
+			lastLog = time.Now()
+		}
+		return done, err
+	})
+	report.RecordPhase(t, report.PhaseWait, time.Since(start))
+	report.RecordResource(t, kind, name)
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return &ErrTimeout{
+			Kind:          kind,
+			Name:          name,
+			Found:         found,
+			LastState:     history.last,
+			UnmetCriteria: unmet(),
+			Timeout:       timeout,
+			history:       history,
+		}
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package wait_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestProgressInterval(t *testing.T) {
+
+	t.Run("default", func(t *testing.T) {
+		// when
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member")
+
+		// then
+		assert.Equal(t, wait.DefaultProgressInterval, await.ProgressInterval)
+	})
+
+	t.Run("from env var", func(t *testing.T) {
+		// given
+		t.Setenv(wait.ProgressIntervalVar, "10s")
+
+		// when
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member")
+
+		// then
+		assert.Equal(t, 10*time.Second, await.ProgressInterval)
+	})
+
+	t.Run("disabled from env var", func(t *testing.T) {
+		// given
+		t.Setenv(wait.ProgressIntervalVar, "0")
+
+		// when
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member")
+
+		// then
+		assert.Equal(t, time.Duration(0), await.ProgressInterval)
+	})
+
+	t.Run("invalid env var", func(t *testing.T) {
+		// given
+		t.Setenv(wait.ProgressIntervalVar, "soon")
+
+		// when
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member")
+
+		// then
+		assert.Equal(t, wait.DefaultProgressInterval, await.ProgressInterval)
+	})
+
+	t.Run("with progress during wait", func(t *testing.T) {
+		// given
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(50*time.Millisecond), wait.ProgressInterval(10*time.Millisecond))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "unknown")
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+		assert.Equal(t, 10*time.Millisecond, await.ProgressInterval)
+	})
+}
+
+func TestStateHistoryInTimeoutError(t *testing.T) {
+
+	t.Run("object not found", func(t *testing.T) {
+		// given
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t), commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "unknown")
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+		assert.Contains(t, err.Error(), "UserAccount 'unknown' did not match the criteria")
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("conditions not matching", func(t *testing.T) {
+		// given
+		userAccount := &toolchainv1alpha1.UserAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: commontest.MemberOperatorNs,
+				Name:      "johnsmith",
+			},
+			Status: toolchainv1alpha1.UserAccountStatus{
+				Conditions: []toolchainv1alpha1.Condition{
+					{
+						Type:   toolchainv1alpha1.ConditionReady,
+						Status: corev1.ConditionFalse,
+						Reason: "Provisioning",
+					},
+				},
+			},
+		}
+		await := wait.NewMemberAwaitility(nil, commontest.NewFakeClient(t, userAccount), commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(20*time.Millisecond))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "johnsmith", wait.UntilUserAccountHasConditions(toolchainv1alpha1.Condition{
+			Type:   toolchainv1alpha1.ConditionReady,
+			Status: corev1.ConditionTrue,
+			Reason: "Provisioned",
+		}))
+
+		// then
+		require.ErrorIs(t, err, k8swait.ErrWaitTimeout)
+		assert.Contains(t, err.Error(), "Ready=False(Provisioning)")
+		// the same state is only recorded once
+		assert.Equal(t, 1, strings.Count(err.Error(), "Ready=False(Provisioning)"))
+	})
+}
+
+func TestTransientErrorsDuringWait(t *testing.T) {
+	userAccount := &toolchainv1alpha1.UserAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: commontest.MemberOperatorNs,
+			Name:      "johnsmith",
+		},
+	}
+
+	t.Run("retried until the object is found", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, userAccount)
+		calls := 0
+		cl.MockGet = func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			calls++
+			if calls <= 3 {
+				return apierrors.NewTooManyRequests("slow down", 1)
+			}
+			return cl.Client.Get(ctx, key, obj, opts...)
+		}
+		await := wait.NewMemberAwaitility(nil, cl, commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(time.Second))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "johnsmith")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("too many consecutive transient errors", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, userAccount)
+		calls := 0
+		cl.MockGet = func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			calls++
+			return apierrors.NewServiceUnavailable("unavailable")
+		}
+		await := wait.NewMemberAwaitility(nil, cl, commontest.MemberOperatorNs, "member").
+			WithRetryOptions(wait.RetryInterval(time.Millisecond), wait.TimeoutOption(time.Second))
+
+		// when
+		_, err := await.WaitForUserAccount(t, "johnsmith")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+		assert.NotErrorIs(t, err, k8swait.ErrWaitTimeout)
+		assert.Contains(t, err.Error(), "too many consecutive transient errors while waiting for UserAccount 'johnsmith'")
+		assert.Equal(t, wait.MaxConsecutiveTransientErrors+1, calls)
+	})
+}
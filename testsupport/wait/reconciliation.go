@@ -0,0 +1,120 @@
+package wait
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// csvListGVK the kind of the list of ClusterServiceVersions, which is used as unstructured since the OLM types are not in the scheme
+var csvListGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersionList"}
+
+// OperatorDeploymentName returns the name of the deployment of the operator in the namespace of the awaitility
+func (a *Awaitility) OperatorDeploymentName() string {
+	if a.Type == cluster.Host {
+		return "host-operator-controller-manager"
+	}
+	return "member-operator-controller-manager"
+}
+
+// PauseReconciliation stops the reconciliation by the operator in the namespace of the awaitility, so that the test can make
+// deterministic assertions on the intermediate state of a resource (eg, before its status is updated by the operator).
+// The operators have no support to pause the reconciliation of a single resource, so the whole operator is scaled down
+// to zero replicas (via its ClusterServiceVersion when it is managed by OLM, since OLM would revert a change of the deployment).
+// As a consequence, the tests which pause the reconciliation must not run in parallel with other tests.
+// The returned func scales the operator up again and waits until it is ready. It is also called when the test completes
+// (if the test did not call it before), so that the reconciliation is never left paused.
+func (a *Awaitility) PauseReconciliation(t *testing.T) (resume func()) {
+	name := a.OperatorDeploymentName()
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, a.Client.Get(context.TODO(), client.ObjectKey{Namespace: a.Namespace, Name: name}, deployment))
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0 {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	t.Logf("pausing the reconciliation by scaling down deployment '%s' in namespace '%s'", name, a.Namespace)
+	a.scaleOperator(t, name, 0)
+	a.waitForNoOperatorPod(t, deployment)
+
+	var once sync.Once
+	resume = func() {
+		once.Do(func() {
+			t.Logf("resuming the reconciliation by scaling up deployment '%s' in namespace '%s' to %d replicas", name, a.Namespace, replicas)
+			a.scaleOperator(t, name, replicas)
+			a.WaitForDeploymentToGetReady(t, name, int(replicas))
+		})
+	}
+	t.Cleanup(resume)
+	return resume
+}
+
+// scaleOperator sets the given number of replicas in the spec of the deployment with the given name, in the ClusterServiceVersion
+// which manages the deployment if any, otherwise in the deployment itself
+func (a *Awaitility) scaleOperator(t *testing.T, name string, replicas int32) {
+	err := retry.RetryOnConflict(a.conflictBackoff(), func() error {
+		csv, index, err := a.findOperatorCSV(name)
+		if err != nil {
+			return err
+		}
+		if csv == nil {
+			_, err := Update(t, a, name, func(d *appsv1.Deployment) {
+				d.Spec.Replicas = &replicas
+			})
+			return err
+		}
+		deployments, _, _ := unstructured.NestedSlice(csv.Object, "spec", "install", "spec", "deployments")
+		if err := unstructured.SetNestedField(deployments[index].(map[string]interface{}), int64(replicas), "spec", "replicas"); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedSlice(csv.Object, deployments, "spec", "install", "spec", "deployments"); err != nil {
+			return err
+		}
+		return a.Client.Update(context.TODO(), csv)
+	})
+	require.NoError(t, err, "unable to scale deployment '%s' in namespace '%s' to %d replicas", name, a.Namespace, replicas)
+}
+
+// findOperatorCSV returns the ClusterServiceVersion in the namespace of the awaitility which manages the deployment
+// with the given name (and the index of the deployment in the CSV), or nil if the operator is not managed by OLM
+func (a *Awaitility) findOperatorCSV(name string) (*unstructured.Unstructured, int, error) {
+	csvs := &unstructured.UnstructuredList{}
+	csvs.SetGroupVersionKind(csvListGVK)
+	if err := a.Client.List(context.TODO(), csvs, client.InNamespace(a.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	for i := range csvs.Items {
+		deployments, _, _ := unstructured.NestedSlice(csvs.Items[i].Object, "spec", "install", "spec", "deployments")
+		for j, d := range deployments {
+			if deploymentName, _, _ := unstructured.NestedString(d.(map[string]interface{}), "name"); deploymentName == name {
+				return &csvs.Items[i], j, nil
+			}
+		}
+	}
+	return nil, 0, nil
+}
+
+// waitForNoOperatorPod waits until all the pods of the given deployment are gone
+func (a *Awaitility) waitForNoOperatorPod(t *testing.T, deployment *appsv1.Deployment) {
+	err := a.pollWithTimeout(2*a.Timeout, func() (done bool, err error) {
+		pods := &corev1.PodList{}
+		if err := a.Client.List(context.TODO(), pods, client.InNamespace(a.Namespace), client.MatchingLabels(deployment.Spec.Selector.MatchLabels)); err != nil {
+			return false, err
+		}
+		return len(pods.Items) == 0, nil
+	})
+	require.NoError(t, err, "pods of deployment '%s' in namespace '%s' still running", deployment.Name, a.Namespace)
+}
@@ -0,0 +1,191 @@
+package wait
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ScenarioRecorder records the mutating API calls made by a test (via the clients of the awaitilities returned by `WithRecorder`),
+// in the order in which they were made, so that they can be saved as a replay script
+type ScenarioRecorder struct {
+	mu    sync.Mutex
+	steps []ReplayStep
+}
+
+// NewScenarioRecorder returns a new, empty ScenarioRecorder
+func NewScenarioRecorder() *ScenarioRecorder {
+	return &ScenarioRecorder{}
+}
+
+// Script returns the replay script with the calls recorded so far for the given test
+func (r *ScenarioRecorder) Script(testName string) ReplayScript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return ReplayScript{
+		Test:  testName,
+		Steps: append([]ReplayStep{}, r.steps...),
+	}
+}
+
+func (r *ScenarioRecorder) record(step ReplayStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+// WithRecorder returns a new Awaitilities whose clients record their successful mutating calls in the given recorder.
+// The original Awaitilities is left unchanged, so that the recording only applies to the test which uses the returned one.
+func (a Awaitilities) WithRecorder(r *ScenarioRecorder) Awaitilities {
+	hostAwait := *a.hostAwaitility
+	hostAwait.Awaitility = a.hostAwaitility.withRecorder(r)
+	members := make([]*MemberAwaitility, len(a.memberAwaitilities))
+	for i, m := range a.memberAwaitilities {
+		members[i] = &MemberAwaitility{Awaitility: m.withRecorder(r)}
+	}
+	return NewAwaitilities(&hostAwait, members...)
+}
+
+func (a *Awaitility) withRecorder(r *ScenarioRecorder) *Awaitility {
+	result := a.copy()
+	cluster := a.ClusterName
+	if cluster == "" {
+		cluster = string(a.Type)
+	}
+	result.Client = &recordingClient{
+		Client:   a.Client,
+		cluster:  cluster,
+		recorder: r,
+	}
+	return result
+}
+
+// recordingClient a client which records its successful mutating calls
+type recordingClient struct {
+	client.Client
+	cluster  string
+	recorder *ScenarioRecorder
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordObject(ReplayCreate, obj)
+	return nil
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordObject(ReplayUpdate, obj)
+	return nil
+}
+
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	// the patch data must be computed before the call, since the object is updated with the response
+	data, dataErr := patch.Data(obj)
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.recordPatch(ReplayPatch, obj, patch, data, dataErr)
+	return nil
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.recordObject(ReplayDelete, obj)
+	return nil
+}
+
+func (c *recordingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.Client.DeleteAllOf(ctx, obj, opts...); err != nil {
+		return err
+	}
+	options := &client.DeleteAllOfOptions{}
+	options.ApplyOptions(opts)
+	step := c.newStep(ReplayDeleteAllOf, obj)
+	step.Namespace = options.Namespace
+	if options.LabelSelector != nil {
+		step.LabelSelector = options.LabelSelector.String()
+	}
+	c.recorder.record(step)
+	return nil
+}
+
+func (c *recordingClient) Status() client.StatusWriter {
+	return &recordingStatusWriter{
+		StatusWriter: c.Client.Status(),
+		client:       c,
+	}
+}
+
+// recordingStatusWriter a status writer which records its successful calls
+type recordingStatusWriter struct {
+	client.StatusWriter
+	client *recordingClient
+}
+
+func (w *recordingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.StatusWriter.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.client.recordObject(ReplayUpdateStatus, obj)
+	return nil
+}
+
+func (w *recordingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	data, dataErr := patch.Data(obj)
+	if err := w.StatusWriter.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	w.client.recordPatch(ReplayPatchStatus, obj, patch, data, dataErr)
+	return nil
+}
+
+func (c *recordingClient) recordObject(operation ReplayOperation, obj client.Object) {
+	c.recorder.record(c.newStep(operation, obj))
+}
+
+func (c *recordingClient) recordPatch(operation ReplayOperation, obj client.Object, patch client.Patch, data []byte, dataErr error) {
+	step := c.newStep(operation, obj)
+	step.PatchType = string(patch.Type())
+	step.Patch = string(data)
+	if dataErr != nil {
+		step.Error = dataErr.Error()
+	}
+	c.recorder.record(step)
+}
+
+// newStep returns a step with the given operation and a copy of the given object, in which the fields set by the API server
+// (eg, `resourceVersion` or `uid`) are removed, so that it can be applied against another cluster
+func (c *recordingClient) newStep(operation ReplayOperation, obj client.Object) ReplayStep {
+	step := ReplayStep{
+		Cluster:   c.cluster,
+		Operation: operation,
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		step.Error = err.Error()
+		return step
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		u.SetGroupVersionKind(gvk)
+	}
+	for _, field := range []string{"resourceVersion", "uid", "creationTimestamp", "generation", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	if operation != ReplayUpdateStatus && operation != ReplayPatchStatus {
+		unstructured.RemoveNestedField(u.Object, "status")
+	}
+	step.Object = u.Object
+	return step
+}
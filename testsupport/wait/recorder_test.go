@@ -0,0 +1,108 @@
+package wait_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestScenarioRecorder(t *testing.T) {
+	// given
+	recorder := wait.NewScenarioRecorder()
+	hostAwait := wait.NewHostAwaitility(nil, commontest.NewFakeClient(t), commontest.HostOperatorNs, "registration-service")
+	cl := wait.NewAwaitilities(hostAwait).WithRecorder(recorder).Host().Client
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oddity",
+			Namespace: commontest.HostOperatorNs,
+		},
+		Spec: toolchainv1alpha1.SpaceSpec{
+			TierName: "base",
+		},
+	}
+
+	// when
+	require.NoError(t, cl.Create(context.TODO(), space))
+	space.Spec.TierName = "advanced"
+	require.NoError(t, cl.Update(context.TODO(), space))
+	require.NoError(t, cl.Patch(context.TODO(), space, client.RawPatch(types.MergePatchType, []byte(`{"spec":{"targetCluster":"member-cluster"}}`))))
+	// the calls which fail are not recorded
+	require.Error(t, cl.Create(context.TODO(), space))
+
+	// then
+	script := recorder.Script("TestScenarioRecorder")
+	assert.Equal(t, "TestScenarioRecorder", script.Test)
+	require.Len(t, script.Steps, 3)
+	assert.Equal(t, wait.ReplayCreate, script.Steps[0].Operation)
+	assert.Equal(t, wait.ReplayUpdate, script.Steps[1].Operation)
+	assert.Equal(t, wait.ReplayPatch, script.Steps[2].Operation)
+	assert.Equal(t, string(types.MergePatchType), script.Steps[2].PatchType)
+	for _, step := range script.Steps {
+		assert.Equal(t, "host", step.Cluster)
+		assert.Equal(t, "Space", step.Object["kind"])
+		assert.NotContains(t, step.Object["metadata"], "resourceVersion")
+	}
+
+	t.Run("calls with the original awaitilities are not recorded", func(t *testing.T) {
+		// when
+		err := hostAwait.Client.Delete(context.TODO(), space)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, recorder.Script("TestScenarioRecorder").Steps, 3)
+	})
+
+	t.Run("save, load and replay", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "replay.yaml")
+		require.NoError(t, script.Save(path))
+		target := commontest.NewFakeClient(t)
+
+		// when
+		loaded, err := wait.LoadReplayScript(path)
+		require.NoError(t, err)
+		var replayed []wait.ReplayOperation
+		err = wait.Replay(context.TODO(), map[string]client.Client{"host": target}, loaded, func(_ int, step wait.ReplayStep) {
+			replayed = append(replayed, step.Operation)
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []wait.ReplayOperation{wait.ReplayCreate, wait.ReplayUpdate, wait.ReplayPatch}, replayed)
+		actual := &toolchainv1alpha1.Space{}
+		require.NoError(t, target.Get(context.TODO(), client.ObjectKeyFromObject(space), actual))
+		assert.Equal(t, "advanced", actual.Spec.TierName)
+		assert.Equal(t, "member-cluster", actual.Spec.TargetCluster)
+	})
+
+	t.Run("replay fails when cluster is unknown", func(t *testing.T) {
+		// when
+		err := wait.Replay(context.TODO(), map[string]client.Client{}, script, func(int, wait.ReplayStep) {})
+
+		// then
+		require.EqualError(t, err, "step 0: no client for cluster 'host'")
+	})
+
+	t.Run("replay stops at first failure", func(t *testing.T) {
+		// given
+		target := commontest.NewFakeClient(t)
+
+		// when
+		err := wait.Replay(context.TODO(), map[string]client.Client{"host": target}, wait.ReplayScript{Steps: script.Steps[1:]}, func(int, wait.ReplayStep) {})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+		assert.Contains(t, err.Error(), "step 0: unable to update Space 'oddity'")
+	})
+}
@@ -0,0 +1,138 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReplayOperation the type of a mutating API call in a replay script
+type ReplayOperation string
+
+const (
+	ReplayCreate       ReplayOperation = "create"
+	ReplayUpdate       ReplayOperation = "update"
+	ReplayUpdateStatus ReplayOperation = "update-status"
+	ReplayPatch        ReplayOperation = "patch"
+	ReplayPatchStatus  ReplayOperation = "patch-status"
+	ReplayDelete       ReplayOperation = "delete"
+	ReplayDeleteAllOf  ReplayOperation = "delete-all-of"
+)
+
+// ReplayScript the ordered list of the mutating API calls made by a test, which can be replayed against another cluster
+type ReplayScript struct {
+	Test  string       `json:"test"`
+	Steps []ReplayStep `json:"steps"`
+}
+
+// ReplayStep a mutating API call made by a test
+type ReplayStep struct {
+	// Cluster the name of the cluster on which the call was made (eg, `host` or `member-cluster`)
+	Cluster   string          `json:"cluster"`
+	Operation ReplayOperation `json:"operation"`
+	// Object the object sent to the API server, without the fields set by the server (the object is only used to identify
+	// the target resource in the case of a patch or a deletion)
+	Object map[string]interface{} `json:"object,omitempty"`
+	// PatchType and Patch the type and the content of the patch (for the `patch` and `patch-status` operations)
+	PatchType string `json:"patchType,omitempty"`
+	Patch     string `json:"patch,omitempty"`
+	// Namespace and LabelSelector the options of a `delete-all-of` operation
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Error the reason why the call could not be fully recorded (such a step cannot be replayed)
+	Error string `json:"error,omitempty"`
+}
+
+func (s ReplayStep) String() string {
+	u := &unstructured.Unstructured{Object: s.Object}
+	return fmt.Sprintf("%s %s '%s' in namespace '%s' on cluster '%s'", s.Operation, u.GetKind(), u.GetName(), u.GetNamespace(), s.Cluster)
+}
+
+// Save writes the replay script in the given file, in YAML
+func (s ReplayScript) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadReplayScript reads the replay script from the given (YAML) file
+func LoadReplayScript(path string) (ReplayScript, error) {
+	script := ReplayScript{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return script, err
+	}
+	err = yaml.Unmarshal(data, &script)
+	return script, err
+}
+
+// Replay applies the steps of the given script in order, using the client of the cluster of each step (by cluster name).
+// The given func is called before each step, and the replay stops at the first step which fails.
+func Replay(ctx context.Context, clients map[string]client.Client, script ReplayScript, before func(i int, step ReplayStep)) error {
+	for i, step := range script.Steps {
+		before(i, step)
+		cl, ok := clients[step.Cluster]
+		if !ok {
+			return fmt.Errorf("step %d: no client for cluster '%s'", i, step.Cluster)
+		}
+		if err := replayStep(ctx, cl, step); err != nil {
+			return fmt.Errorf("step %d: unable to %s: %w", i, step, err)
+		}
+	}
+	return nil
+}
+
+func replayStep(ctx context.Context, cl client.Client, step ReplayStep) error {
+	if step.Error != "" {
+		return fmt.Errorf("the call was not fully recorded: %s", step.Error)
+	}
+	obj := &unstructured.Unstructured{Object: step.Object}
+	switch step.Operation {
+	case ReplayCreate:
+		return cl.Create(ctx, obj)
+	case ReplayUpdate:
+		if err := setCurrentResourceVersion(ctx, cl, obj); err != nil {
+			return err
+		}
+		return cl.Update(ctx, obj)
+	case ReplayUpdateStatus:
+		if err := setCurrentResourceVersion(ctx, cl, obj); err != nil {
+			return err
+		}
+		return cl.Status().Update(ctx, obj)
+	case ReplayPatch:
+		return cl.Patch(ctx, obj, client.RawPatch(types.PatchType(step.PatchType), []byte(step.Patch)))
+	case ReplayPatchStatus:
+		return cl.Status().Patch(ctx, obj, client.RawPatch(types.PatchType(step.PatchType), []byte(step.Patch)))
+	case ReplayDelete:
+		return client.IgnoreNotFound(cl.Delete(ctx, obj))
+	case ReplayDeleteAllOf:
+		selector, err := labels.Parse(step.LabelSelector)
+		if err != nil {
+			return err
+		}
+		return cl.DeleteAllOf(ctx, obj, client.InNamespace(step.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	default:
+		return fmt.Errorf("unknown operation '%s'", step.Operation)
+	}
+}
+
+// setCurrentResourceVersion sets the resource version of the object in the cluster on the given object, since the recorded
+// resource version is meaningless in another cluster (the update overwrites the object as it was sent by the test)
+func setCurrentResourceVersion(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	return nil
+}
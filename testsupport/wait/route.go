@@ -0,0 +1,124 @@
+package wait
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+// RouteHost returns the host of the given route, as admitted by the router (or the host in its spec, if it is not admitted yet)
+func RouteHost(route routev1.Route) string {
+	if len(route.Status.Ingress) > 0 && route.Status.Ingress[0].Host != "" {
+		return route.Status.Ingress[0].Host
+	}
+	return route.Spec.Host
+}
+
+// RouteURL returns the base URL of the given route, ie, with the `https` scheme if the route is secured, and with its path (if any)
+func RouteURL(route routev1.Route) string {
+	scheme := "http"
+	if route.Spec.TLS != nil {
+		scheme = "https"
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%s://%s%s", scheme, RouteHost(route), route.Spec.Path), "/")
+}
+
+// ProbeRoute sends a GET request on the given path of the route (with the given bearer token, if not empty) and verifies that:
+// - the response has a `200 OK` status,
+// - if the route is secured, the certificate served for its host is valid for this host (the certificate chain is not verified,
+// since the clusters used to run the e2e tests usually have self-signed certificates),
+// - if the route redirects the insecure traffic, a plain HTTP request is redirected to the same path with the `https` scheme.
+func ProbeRoute(route routev1.Route, path, token string) error {
+	host := RouteHost(route)
+	if host == "" {
+		return fmt.Errorf("route '%s' in namespace '%s' has no host", route.Name, route.Namespace)
+	}
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, // nolint:gosec // the hostname is verified in VerifyConnection
+				VerifyConnection: func(state tls.ConnectionState) error {
+					if len(state.PeerCertificates) == 0 {
+						return fmt.Errorf("no certificate served for host '%s'", host)
+					}
+					hostname, _, err := net.SplitHostPort(host)
+					if err != nil {
+						hostname = host
+					}
+					return state.PeerCertificates[0].VerifyHostname(hostname)
+				},
+			},
+		},
+		// do not follow the redirections, so they can be verified
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	url := RouteURL(route) + path
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	resp, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to probe '%s': %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code when probing '%s': %d", url, resp.StatusCode)
+	}
+
+	if route.Spec.TLS != nil && route.Spec.TLS.InsecureEdgeTerminationPolicy == routev1.InsecureEdgeTerminationPolicyRedirect {
+		insecureURL := "http://" + strings.TrimPrefix(url, "https://")
+		resp, err := client.Get(insecureURL) // nolint:noctx
+		if err != nil {
+			return fmt.Errorf("unable to probe '%s': %w", insecureURL, err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+			return fmt.Errorf("expected a redirection when probing '%s', but got status code %d", insecureURL, resp.StatusCode)
+		}
+		if location := resp.Header.Get("Location"); !strings.HasPrefix(location, "https://"+host) {
+			return fmt.Errorf("expected a redirection to '%s' when probing '%s', but got '%s'", url, insecureURL, location)
+		}
+	}
+	return nil
+}
+
+// WaitForRouteToBeReachable waits until the given route is admitted and successfully probed on the given path (see ProbeRoute),
+// which verifies that the service behind the route is actually reachable, not only that its Deployment is ready
+func (a *Awaitility) WaitForRouteToBeReachable(t *testing.T, ns, name, path string) (routev1.Route, error) {
+	route, err := a.WaitForRouteToBeAvailable(t, ns, name, path)
+	if err != nil {
+		return route, err
+	}
+	t.Logf("probing route '%s' in namespace '%s' on path '%s'", name, ns, path)
+	token := ""
+	if a.RestConfig != nil {
+		token = a.RestConfig.BearerToken
+	}
+	var probeErr error
+	err = a.poll(func() (done bool, err error) {
+		probeErr = ProbeRoute(route, path, token)
+		return probeErr == nil, nil
+	})
+	if err != nil && probeErr != nil {
+		t.Logf("failed to probe route '%s' in namespace '%s': %s", name, ns, probeErr)
+	}
+	return route, err
+}
@@ -0,0 +1,123 @@
+package wait_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRouteURL(t *testing.T) {
+
+	t.Run("secured route with path", func(t *testing.T) {
+		// given
+		route := newRoute("api.example.com", &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge})
+		route.Spec.Path = "/proxy"
+
+		// when
+		url := wait.RouteURL(route)
+
+		// then
+		assert.Equal(t, "https://api.example.com/proxy", url)
+	})
+
+	t.Run("insecure route", func(t *testing.T) {
+		// given
+		route := newRoute("registration.example.com", nil)
+
+		// when
+		url := wait.RouteURL(route)
+
+		// then
+		assert.Equal(t, "http://registration.example.com", url)
+	})
+}
+
+func TestProbeRoute(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("secured route", func(t *testing.T) {
+		// given
+		server := httptest.NewTLSServer(handler)
+		defer server.Close()
+		route := newRoute(strings.TrimPrefix(server.URL, "https://"), &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge})
+
+		// when
+		err := wait.ProbeRoute(route, "/health", "secret")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("insecure route", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(handler)
+		defer server.Close()
+		route := newRoute(strings.TrimPrefix(server.URL, "http://"), nil)
+
+		// when
+		err := wait.ProbeRoute(route, "/health", "secret")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(handler)
+		defer server.Close()
+		route := newRoute(strings.TrimPrefix(server.URL, "http://"), nil)
+
+		// when
+		err := wait.ProbeRoute(route, "/unknown", "")
+
+		// then
+		require.EqualError(t, err, "unexpected status code when probing '"+server.URL+"/unknown': 503")
+	})
+
+	t.Run("no host", func(t *testing.T) {
+		// given
+		route := newRoute("", nil)
+
+		// when
+		err := wait.ProbeRoute(route, "/health", "")
+
+		// then
+		require.EqualError(t, err, "route 'registration-service' in namespace 'toolchain-host-operator' has no host")
+	})
+}
+
+func newRoute(host string, tlsConfig *routev1.TLSConfig) routev1.Route {
+	route := routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "toolchain-host-operator",
+			Name:      "registration-service",
+		},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			TLS:  tlsConfig,
+		},
+	}
+	if host != "" {
+		route.Status.Ingress = []routev1.RouteIngress{
+			{
+				Host: host,
+			},
+		}
+	}
+	return route
+}
@@ -0,0 +1,132 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RunIDVar the name of the env var that can be used to set the ID of the current suite run
+	RunIDVar = "E2E_RUN_ID"
+	// RunIDLabelKey the label set on all resources created by the testsupport helpers, with the ID of the current suite run as the value
+	RunIDLabelKey = "toolchain.dev.openshift.com/e2e-run-id"
+	// TestNameLabelKey the label set on all resources created by the testsupport helpers, with the (sanitized) name of the test as the value
+	TestNameLabelKey = "toolchain.dev.openshift.com/e2e-test-name"
+	// WatchFilterVar the name of the env var to set when the operators only watch the resources with a given label (eg, so that
+	// two suites can share a cluster), with this label as the value (eg, `toolchain.dev.openshift.com/e2e-suite=suite-a`)
+	WatchFilterVar = "E2E_WATCH_FILTER"
+
+	labelValueMaxLength = 63
+)
+
+var (
+	runID     string
+	runIDOnce sync.Once
+
+	labelValueNotAllowedChars  = regexp.MustCompile("[^-A-Za-z0-9_.]")
+	labelValueNotAllowedBounds = regexp.MustCompile("^[^A-Za-z0-9]+|[^A-Za-z0-9]+$")
+)
+
+// RunID returns the ID of the current suite run. The value is read from the `E2E_RUN_ID` env var,
+// or generated once if the env var is not set.
+func RunID() string {
+	runIDOnce.Do(func() {
+		runID = SanitizeLabelValue(os.Getenv(RunIDVar))
+		if runID == "" {
+			runID = uuid.Must(uuid.NewV4()).String()
+		}
+	})
+	return runID
+}
+
+// SanitizeLabelValue converts the given value into a valid label value: all invalid characters are replaced with `_`,
+// the value is trimmed to 63 chars and the leading and trailing non-alphanumeric characters are removed
+func SanitizeLabelValue(value string) string {
+	sanitized := labelValueNotAllowedChars.ReplaceAllString(value, "_")
+	if len(sanitized) > labelValueMaxLength {
+		sanitized = sanitized[0:labelValueMaxLength]
+	}
+	return labelValueNotAllowedBounds.ReplaceAllString(sanitized, "")
+}
+
+// WatchFilterLabel returns the key and the value of the label set in the `E2E_WATCH_FILTER` env var (in the `key=value` form),
+// or empty strings if the env var is not set. Returns an error if the label is invalid.
+func WatchFilterLabel() (string, string, error) {
+	filter := os.Getenv(WatchFilterVar)
+	if filter == "" {
+		return "", "", nil
+	}
+	key, value, found := strings.Cut(filter, "=")
+	if !found {
+		return "", "", fmt.Errorf("invalid value of '%s': expected 'key=value' but got '%s'", WatchFilterVar, filter)
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid label key in '%s': %s", WatchFilterVar, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid label value in '%s': %s", WatchFilterVar, strings.Join(errs, ", "))
+	}
+	return key, value, nil
+}
+
+// RunLabels returns the labels identifying the resources created by the given test during the current suite run,
+// along with the watch filter label (if any) so that the resources are visible to the operators configured with this filter
+func RunLabels(t *testing.T) map[string]string {
+	labels := map[string]string{
+		RunIDLabelKey:    RunID(),
+		TestNameLabelKey: SanitizeLabelValue(t.Name()),
+	}
+	if key, value, err := WatchFilterLabel(); err == nil && key != "" {
+		labels[key] = value
+	}
+	return labels
+}
+
+// AddRunLabels sets the run ID and test name labels on the given object (the other labels are preserved)
+func AddRunLabels(t *testing.T, obj client.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range RunLabels(t) {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+}
+
+// HasRunLabels returns `true` if the given object has the run ID label of the current suite run
+func HasRunLabels(obj client.Object) bool {
+	return obj.GetLabels()[RunIDLabelKey] == RunID()
+}
+
+// CreatedByTest returns `true` if the given object was created during the current suite run by the test with the given name
+// or by one of its subtests. Since the `/` separators of the subtests are sanitized as `_` in the test name label, the label must either
+// equal the (sanitized) test name or start with the test name followed by `_` (so that, eg, the objects of `TestFoo10` are not
+// attributed to `TestFoo1`)
+func CreatedByTest(obj client.Object, testName string) bool {
+	if !HasRunLabels(obj) {
+		return false
+	}
+	name := SanitizeLabelValue(testName)
+	label := obj.GetLabels()[TestNameLabelKey]
+	return label == name || strings.HasPrefix(label, name+"_")
+}
+
+// ListCreatedInRun lists all the resources of the given type that were created by the testsupport helpers during the current suite run
+func (a *Awaitility) ListCreatedInRun(list client.ObjectList, opts ...client.ListOption) error {
+	return a.Client.List(context.TODO(), list, append(opts, client.MatchingLabels{RunIDLabelKey: RunID()})...)
+}
+
+// ListCreatedByTest lists all the resources of the given type that were created by the testsupport helpers during the given test
+func (a *Awaitility) ListCreatedByTest(t *testing.T, list client.ObjectList, opts ...client.ListOption) error {
+	return a.Client.List(context.TODO(), list, append(opts, client.MatchingLabels(RunLabels(t)))...)
+}
@@ -0,0 +1,147 @@
+package wait_test
+
+import (
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSanitizeLabelValue(t *testing.T) {
+	t.Run("valid value is unchanged", func(t *testing.T) {
+		assert.Equal(t, "TestSpaceCreation", wait.SanitizeLabelValue("TestSpaceCreation"))
+	})
+
+	t.Run("invalid chars are replaced", func(t *testing.T) {
+		assert.Equal(t, "TestSpace_with_target_cluster", wait.SanitizeLabelValue("TestSpace/with target/cluster"))
+	})
+
+	t.Run("leading and trailing non-alphanumeric chars are removed", func(t *testing.T) {
+		assert.Equal(t, "TestSpace", wait.SanitizeLabelValue("/_TestSpace_/"))
+	})
+
+	t.Run("long value is trimmed", func(t *testing.T) {
+		result := wait.SanitizeLabelValue(strings.Repeat("a", 60) + "/bbbbbb")
+		assert.Equal(t, strings.Repeat("a", 60), result)
+	})
+}
+
+func TestAddRunLabels(t *testing.T) {
+	// given
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "oddity",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		},
+	}
+
+	// when
+	wait.AddRunLabels(t, space)
+
+	// then
+	require.NotEmpty(t, wait.RunID())
+	assert.Equal(t, map[string]string{
+		"foo":                 "bar",
+		wait.RunIDLabelKey:    wait.RunID(),
+		wait.TestNameLabelKey: "TestAddRunLabels",
+	}, space.Labels)
+	assert.True(t, wait.HasRunLabels(space))
+}
+
+func TestCreatedByTest(t *testing.T) {
+	newSpace := func(runID, testName string) *toolchainv1alpha1.Space {
+		return &toolchainv1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "oddity",
+				Labels: map[string]string{
+					wait.RunIDLabelKey:    runID,
+					wait.TestNameLabelKey: testName,
+				},
+			},
+		}
+	}
+
+	t.Run("created by the test", func(t *testing.T) {
+		assert.True(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo1"), "TestFoo1"))
+	})
+
+	t.Run("created by a subtest", func(t *testing.T) {
+		assert.True(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo1_with_space"), "TestFoo1"))
+		assert.True(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo1_with_space"), "TestFoo1/with_space"))
+	})
+
+	t.Run("created by another test with the same prefix", func(t *testing.T) {
+		assert.False(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo10"), "TestFoo1"))
+		assert.False(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo1_with_space"), "TestFoo1/with"))
+	})
+
+	t.Run("created by the parent test", func(t *testing.T) {
+		assert.False(t, wait.CreatedByTest(newSpace(wait.RunID(), "TestFoo1"), "TestFoo1/with_space"))
+	})
+
+	t.Run("created during another run", func(t *testing.T) {
+		assert.False(t, wait.CreatedByTest(newSpace("another-run", "TestFoo1"), "TestFoo1"))
+	})
+}
+
+func TestWatchFilterLabel(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		// given
+		t.Setenv(wait.WatchFilterVar, "")
+
+		// when
+		key, value, err := wait.WatchFilterLabel()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, key)
+		assert.Empty(t, value)
+	})
+
+	t.Run("valid label", func(t *testing.T) {
+		// given
+		t.Setenv(wait.WatchFilterVar, "toolchain.dev.openshift.com/e2e-suite=suite-a")
+
+		// when
+		key, value, err := wait.WatchFilterLabel()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "toolchain.dev.openshift.com/e2e-suite", key)
+		assert.Equal(t, "suite-a", value)
+	})
+
+	t.Run("label is added to the run labels", func(t *testing.T) {
+		// given
+		t.Setenv(wait.WatchFilterVar, "e2e-suite=suite-b")
+
+		// when
+		labels := wait.RunLabels(t)
+
+		// then
+		assert.Equal(t, "suite-b", labels["e2e-suite"])
+		assert.Equal(t, wait.RunID(), labels[wait.RunIDLabelKey])
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, filter := range []string{"e2e-suite", "e2e suite=a", "e2e-suite=invalid value"} {
+			t.Run(filter, func(t *testing.T) {
+				// given
+				t.Setenv(wait.WatchFilterVar, filter)
+
+				// when
+				_, _, err := wait.WatchFilterLabel()
+
+				// then
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), wait.WatchFilterVar)
+			})
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package wait
+
+import (
+	"os"
+	"strconv"
+	"testing"
+)
+
+const (
+	// SingleClusterVar the name of the env var to set to `true` when the host and a single member operator are deployed in the
+	// same small cluster (eg, a kind cluster), in which there are no routes and no second member operator
+	SingleClusterVar = "E2E_SINGLE_CLUSTER"
+	// RegistrationServiceURLVar the name of the env var with the URL of the registration service, in single-cluster mode
+	RegistrationServiceURLVar = "E2E_REGISTRATION_SERVICE_URL"
+	// APIProxyURLVar the name of the env var with the URL of the API proxy, in single-cluster mode
+	APIProxyURLVar = "E2E_API_PROXY_URL"
+	// HostMetricsURLVar the name of the env var with the host (and port) of the metrics service of the host operator, in single-cluster mode
+	HostMetricsURLVar = "E2E_HOST_METRICS_URL"
+	// MemberMetricsURLVar the name of the env var with the host (and port) of the metrics service of the member operator, in single-cluster mode
+	MemberMetricsURLVar = "E2E_MEMBER_METRICS_URL"
+)
+
+// SingleClusterMode returns `true` if the `E2E_SINGLE_CLUSTER` env var is set to `true`
+func SingleClusterMode() bool {
+	singleCluster, _ := strconv.ParseBool(os.Getenv(SingleClusterVar))
+	return singleCluster
+}
+
+// SingleClusterURL returns the value of the given env var, or the given default value (which matches the local port
+// forwarded by `make kind-port-forward`) if the env var is not set
+func SingleClusterURL(envVar, defaultValue string) string {
+	if url := os.Getenv(envVar); url != "" {
+		return url
+	}
+	return defaultValue
+}
+
+// SkipUnlessMultipleMembers skips the current test if there is a single member operator (eg, in single-cluster mode)
+func (a Awaitilities) SkipUnlessMultipleMembers(t *testing.T) {
+	if len(a.memberAwaitilities) < 2 {
+		t.Skipf("skipping test because it requires two member operators but only %d is deployed", len(a.memberAwaitilities))
+	}
+}
@@ -0,0 +1,104 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrConditionFlapped the error returned by WaitForConditionStable when the condition did not keep the expected status
+// (and reason) during the whole stability window
+type ErrConditionFlapped struct {
+	// Kind the kind of the object (eg, `Space`)
+	Kind string
+	// Name the name of the object
+	Name string
+	// Expected the expected condition
+	Expected toolchainv1alpha1.Condition
+	// After the duration after which the condition was observed as changed, since the beginning of the window
+	After time.Duration
+	// history the states of the conditions observed during the window
+	history *stateHistory
+}
+
+var _ error = &ErrConditionFlapped{}
+
+func (e *ErrConditionFlapped) Error() string {
+	return fmt.Sprintf("condition '%s' of %s '%s' did not stay '%s' (changed after %s)\nobserved states:\n%s", e.Expected.Type, e.Kind, e.Name, conditionsState([]toolchainv1alpha1.Condition{e.Expected}), e.After, e.history)
+}
+
+// WaitForConditionStable verifies that the condition of the given object with the type of the expected condition keeps
+// the expected status (and reason, if set) during the whole window, to catch the reconciliation loops which oscillate
+// (eg, Ready true/false churn) and that a plain waiter would not notice. Besides checking the condition at every retry
+// interval, a change of its last transition time is also considered as a flip, since the condition may have flipped
+// back and forth between two checks. The object is looked up by the namespace and name of the given object, and with its type.
+// Returns an ErrConditionFlapped if the condition changed during the window.
+func (a *Awaitility) WaitForConditionStable(t *testing.T, obj client.Object, expected toolchainv1alpha1.Condition, window time.Duration) error {
+	kind := reflect.TypeOf(obj).Elem().Name()
+	key := client.ObjectKeyFromObject(obj)
+	t.Logf("verifying that condition '%s' of %s '%s' stays '%s' for %s", expected.Type, kind, key, conditionsState([]toolchainv1alpha1.Condition{expected}), window)
+	history := newStateHistory(stateHistorySize)
+	start := time.Now()
+	var lastTransitionTime *time.Time
+	for {
+		actual := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+		if err := a.Client.Get(context.TODO(), key, actual); err != nil {
+			return err
+		}
+		conditions, err := conditionsOf(actual)
+		if err != nil {
+			return err
+		}
+		history.observe(conditionsState(conditions))
+		c, found := condition.FindConditionByType(conditions, expected.Type)
+		flipped := !found || c.Status != expected.Status || (expected.Reason != "" && c.Reason != expected.Reason)
+		if found && lastTransitionTime != nil && !c.LastTransitionTime.Time.Equal(*lastTransitionTime) {
+			flipped = true
+		}
+		if flipped {
+			return &ErrConditionFlapped{
+				Kind:     kind,
+				Name:     key.String(),
+				Expected: expected,
+				After:    time.Since(start).Round(time.Millisecond),
+				history:  history,
+			}
+		}
+		lastTransitionTime = &c.LastTransitionTime.Time
+		if time.Since(start) >= window {
+			return nil
+		}
+		time.Sleep(a.RetryInterval)
+	}
+}
+
+// conditionsOf returns the `status.conditions` of the given object
+func conditionsOf(obj client.Object) ([]toolchainv1alpha1.Condition, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	values, found, err := unstructured.NestedSlice(content, "status", "conditions")
+	if err != nil || !found {
+		return nil, err
+	}
+	conditions := make([]toolchainv1alpha1.Condition, len(values))
+	for i, v := range values {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected condition in the status of '%s': %v", obj.GetName(), v)
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &conditions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return conditions, nil
+}
@@ -0,0 +1,106 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitForConditionStable(t *testing.T) {
+	ready := toolchainv1alpha1.Condition{
+		Type:   toolchainv1alpha1.ConditionReady,
+		Status: corev1.ConditionTrue,
+		Reason: toolchainv1alpha1.SpaceProvisionedReason,
+	}
+	newSpace := func(conditions ...toolchainv1alpha1.Condition) *toolchainv1alpha1.Space {
+		return &toolchainv1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "oddity",
+				Namespace: commontest.HostOperatorNs,
+			},
+			Status: toolchainv1alpha1.SpaceStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+	newAwaitility := func(t *testing.T, space *toolchainv1alpha1.Space) *wait.Awaitility {
+		cl := commontest.NewFakeClient(t)
+		require.NoError(t, cl.Create(context.TODO(), space))
+		return &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: 5 * time.Millisecond,
+			Timeout:       100 * time.Millisecond,
+		}
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		// given
+		space := newSpace(ready)
+		await := newAwaitility(t, space)
+
+		// when
+		start := time.Now()
+		err := await.WaitForConditionStable(t, space, ready, 50*time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("not in the expected status", func(t *testing.T) {
+		// given
+		space := newSpace(toolchainv1alpha1.Condition{
+			Type:   toolchainv1alpha1.ConditionReady,
+			Status: corev1.ConditionFalse,
+			Reason: toolchainv1alpha1.SpaceProvisioningReason,
+		})
+		await := newAwaitility(t, space)
+
+		// when
+		err := await.WaitForConditionStable(t, space, ready, 50*time.Millisecond)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Ready=False(Provisioning)")
+	})
+
+	t.Run("flapping during the window", func(t *testing.T) {
+		// given
+		space := newSpace(ready)
+		await := newAwaitility(t, space)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			flapped := &toolchainv1alpha1.Space{}
+			if err := await.Client.Get(context.TODO(), commontest.NamespacedName(commontest.HostOperatorNs, "oddity"), flapped); err != nil {
+				return
+			}
+			flapped.Status.Conditions = []toolchainv1alpha1.Condition{
+				{
+					Type:   toolchainv1alpha1.ConditionReady,
+					Status: corev1.ConditionFalse,
+					Reason: toolchainv1alpha1.SpaceUpdatingReason,
+				},
+			}
+			_ = await.Client.Status().Update(context.TODO(), flapped)
+		}()
+
+		// when
+		err := await.WaitForConditionStable(t, space, ready, 100*time.Millisecond)
+
+		// then
+		require.Error(t, err)
+		flappedErr := &wait.ErrConditionFlapped{}
+		require.ErrorAs(t, err, &flappedErr)
+		assert.Equal(t, toolchainv1alpha1.ConditionReady, flappedErr.Expected.Type)
+		assert.Contains(t, err.Error(), "Ready=False(Updating)")
+	})
+}
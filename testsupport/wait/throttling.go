@@ -0,0 +1,115 @@
+package wait
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WithThrottling returns a new Awaitilities whose clients are throttled by a client-side rate limiter with the given QPS and burst
+// (one rate limiter per cluster), and whose timeouts are multiplied by the given factor, so that the helpers can be verified
+// to still succeed when the API server throttles the requests of the tests.
+// The original Awaitilities is left unchanged, so that the throttling only applies to the test which uses the returned one.
+func (a Awaitilities) WithThrottling(qps float32, burst int, timeoutFactor float64) Awaitilities {
+	hostAwait := *a.hostAwaitility
+	hostAwait.Awaitility = a.hostAwaitility.withThrottling(qps, burst, timeoutFactor)
+	members := make([]*MemberAwaitility, len(a.memberAwaitilities))
+	for i, m := range a.memberAwaitilities {
+		members[i] = &MemberAwaitility{Awaitility: m.withThrottling(qps, burst, timeoutFactor)}
+	}
+	return NewAwaitilities(&hostAwait, members...)
+}
+
+func (a *Awaitility) withThrottling(qps float32, burst int, timeoutFactor float64) *Awaitility {
+	result := a.copy()
+	result.Timeout = time.Duration(float64(a.Timeout) * timeoutFactor)
+	result.Client = &throttlingClient{
+		Client:      a.Client,
+		rateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+	}
+	return result
+}
+
+// throttlingClient a client which waits until its rate limiter allows it before each call
+type throttlingClient struct {
+	client.Client
+	rateLimiter flowcontrol.RateLimiter
+}
+
+func (c *throttlingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *throttlingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *throttlingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *throttlingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *throttlingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *throttlingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *throttlingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *throttlingClient) Status() client.StatusWriter {
+	return &throttlingStatusWriter{
+		StatusWriter: c.Client.Status(),
+		rateLimiter:  c.rateLimiter,
+	}
+}
+
+// throttlingStatusWriter a status writer which shares the rate limiter of its client
+type throttlingStatusWriter struct {
+	client.StatusWriter
+	rateLimiter flowcontrol.RateLimiter
+}
+
+func (w *throttlingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *throttlingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := w.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,43 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWithThrottling(t *testing.T) {
+	// given
+	hostAwait := wait.NewHostAwaitility(nil, commontest.NewFakeClient(t), commontest.HostOperatorNs, "registration-service")
+	hostAwait.Timeout = 10 * time.Second
+	throttled := wait.NewAwaitilities(hostAwait).WithThrottling(10, 1, 3).Host()
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oddity",
+			Namespace: commontest.HostOperatorNs,
+		},
+	}
+
+	// when
+	start := time.Now()
+	require.NoError(t, throttled.Client.Create(context.TODO(), space))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, throttled.Client.Get(context.TODO(), client.ObjectKeyFromObject(space), space))
+	}
+
+	// then
+	// with a burst of 1, the 5 calls after the first one are delayed by 100ms each
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+	assert.Equal(t, 30*time.Second, throttled.Timeout)
+	// the original awaitility is left unchanged
+	assert.Equal(t, 10*time.Second, hostAwait.Timeout)
+	assert.NotSame(t, hostAwait.Client, throttled.Client)
+}
@@ -0,0 +1,212 @@
+package wait
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ToolchainClusterTokenKey the key of the token in the secret referenced by a ToolchainCluster
+	ToolchainClusterTokenKey = "token"
+
+	rotatedTokenExpirationSeconds int64 = 3600
+)
+
+// GetToolchainClusterConfig returns the config built from the ToolchainCluster (and its secret) which points to the cluster of the other awaitility
+func (a *Awaitility) GetToolchainClusterConfig(t *testing.T, otherAwait *Awaitility) *cluster.Config {
+	toolchainCluster, found, err := a.GetToolchainCluster(t, otherAwait.Type, otherAwait.Namespace, nil)
+	require.NoError(t, err)
+	require.True(t, found, "no ToolchainCluster for cluster type '%s' and namespace '%s'", otherAwait.Type, otherAwait.Namespace)
+	config, err := cluster.NewClusterConfig(a.Client, &toolchainCluster, a.Timeout)
+	require.NoError(t, err)
+	return config
+}
+
+// RotateToolchainClusterToken requests a new token for the ServiceAccount used by the ToolchainCluster (in the cluster of the awaitility)
+// which points to the cluster of the other awaitility, and stores it in the secret of the ToolchainCluster.
+// The ToolchainCluster is then touched so that the operator reloads its secret, and the function waits until the ToolchainCluster is ready again.
+// The original token is restored at the end of the test.
+func (a *Awaitility) RotateToolchainClusterToken(t *testing.T, otherAwait *Awaitility) string {
+	toolchainCluster, secret := a.getToolchainClusterAndSecret(t, otherAwait)
+	originalToken := secret.Data[ToolchainClusterTokenKey]
+	token := requestServiceAccountToken(t, otherAwait, toolchainCluster.Name, string(originalToken))
+
+	rotatedAt := time.Now()
+	a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, []byte(token))
+	t.Cleanup(func() {
+		a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, originalToken)
+	})
+
+	_, err := a.WaitForToolchainCluster(t,
+		UntilToolchainClusterHasName(toolchainCluster.Name),
+		UntilToolchainClusterHasReadyConditionProbedAfter(rotatedAt))
+	require.NoError(t, err)
+	return token
+}
+
+// ExpireToolchainClusterToken simulates the expiry of the token used by the ToolchainCluster (in the cluster of the awaitility) which points
+// to the cluster of the other awaitility: the token is replaced with a copy whose signature is invalid, which is rejected by the API server
+// just like an expired token. The function waits until the ToolchainCluster is reported as not ready (or offline) by a probe which occurred
+// after the expiry, and returns the degraded ToolchainCluster.
+// The original token is restored at the end of the test, and the ToolchainCluster is then expected to be ready again.
+func (a *Awaitility) ExpireToolchainClusterToken(t *testing.T, otherAwait *Awaitility) *toolchainv1alpha1.ToolchainCluster {
+	toolchainCluster, secret := a.getToolchainClusterAndSecret(t, otherAwait)
+	originalToken := secret.Data[ToolchainClusterTokenKey]
+	parts := strings.Split(string(originalToken), ".")
+	require.Len(t, parts, 3, "the token of ToolchainCluster '%s' is not a JWT", toolchainCluster.Name)
+	expiredToken := strings.Join([]string{parts[0], parts[1], base64.RawURLEncoding.EncodeToString([]byte("expired"))}, ".")
+
+	t.Logf("simulating the expiry of the token used by ToolchainCluster '%s'", toolchainCluster.Name)
+	expiredAt := time.Now()
+	a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, []byte(expiredToken))
+	t.Cleanup(func() {
+		restoredAt := time.Now()
+		a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, originalToken)
+		_, err := a.WaitForToolchainCluster(t,
+			UntilToolchainClusterHasName(toolchainCluster.Name),
+			UntilToolchainClusterHasReadyConditionProbedAfter(restoredAt))
+		require.NoError(t, err)
+	})
+
+	degraded, err := a.WithRetryOptions(TimeoutOption(ToolchainClusterConditionTimeout)).WaitForToolchainCluster(t,
+		UntilToolchainClusterHasName(toolchainCluster.Name),
+		UntilToolchainClusterIsNotReadyProbedAfter(expiredAt))
+	require.NoError(t, err, "ToolchainCluster '%s' was not degraded after the expiry of its token", toolchainCluster.Name)
+	return degraded
+}
+
+// RecreateToolchainClusterSecret recreates the secret of the ToolchainCluster (in the cluster of the awaitility) which points to the cluster
+// of the other awaitility, with a newly generated token for the same ServiceAccount (as when the cluster is registered again after the expiry
+// of its token), and waits until the ToolchainCluster is ready again. The original token is restored at the end of the test.
+func (a *Awaitility) RecreateToolchainClusterSecret(t *testing.T, otherAwait *Awaitility) string {
+	toolchainCluster, secret := a.getToolchainClusterAndSecret(t, otherAwait)
+	originalToken := secret.Data[ToolchainClusterTokenKey]
+	token := requestServiceAccountToken(t, otherAwait, toolchainCluster.Name, string(originalToken))
+
+	t.Logf("recreating secret '%s' of ToolchainCluster '%s'", secret.Name, toolchainCluster.Name)
+	recreatedAt := time.Now()
+	require.NoError(t, a.Client.Delete(context.TODO(), secret))
+	recreated := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			Labels:          secret.Labels,
+			Annotations:     secret.Annotations,
+			OwnerReferences: secret.OwnerReferences,
+		},
+		Type: secret.Type,
+		Data: map[string][]byte{},
+	}
+	for key, value := range secret.Data {
+		recreated.Data[key] = value
+	}
+	recreated.Data[ToolchainClusterTokenKey] = []byte(token)
+	require.NoError(t, a.Client.Create(context.TODO(), recreated))
+	a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, []byte(token))
+	t.Cleanup(func() {
+		a.updateToolchainClusterToken(t, toolchainCluster.Name, secret.Name, originalToken)
+	})
+
+	_, err := a.WaitForToolchainCluster(t,
+		UntilToolchainClusterHasName(toolchainCluster.Name),
+		UntilToolchainClusterHasReadyConditionProbedAfter(recreatedAt))
+	require.NoError(t, err)
+	return token
+}
+
+// getToolchainClusterAndSecret returns the ToolchainCluster (in the cluster of the awaitility) which points to the cluster of the other awaitility,
+// and its secret
+func (a *Awaitility) getToolchainClusterAndSecret(t *testing.T, otherAwait *Awaitility) (toolchainv1alpha1.ToolchainCluster, *corev1.Secret) {
+	toolchainCluster, found, err := a.GetToolchainCluster(t, otherAwait.Type, otherAwait.Namespace, nil)
+	require.NoError(t, err)
+	require.True(t, found, "no ToolchainCluster for cluster type '%s' and namespace '%s'", otherAwait.Type, otherAwait.Namespace)
+	secret := &corev1.Secret{}
+	err = a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: toolchainCluster.Spec.SecretRef.Name}, secret)
+	require.NoError(t, err)
+	return toolchainCluster, secret
+}
+
+// requestServiceAccountToken requests a new token (in the cluster of the other awaitility) for the ServiceAccount which is the subject
+// of the given token of the ToolchainCluster
+func requestServiceAccountToken(t *testing.T, otherAwait *Awaitility, toolchainClusterName, currentToken string) string {
+	saNamespace, saName, err := serviceAccountFromToken(currentToken)
+	require.NoError(t, err)
+
+	// request a new token for the same ServiceAccount in the other cluster
+	t.Logf("requesting a new token of ServiceAccount '%s' in namespace '%s' used by ToolchainCluster '%s'", saName, saNamespace, toolchainClusterName)
+	clientset, err := kubernetes.NewForConfig(otherAwait.RestConfig)
+	require.NoError(t, err)
+	expiration := rotatedTokenExpirationSeconds
+	tokenRequest, err := clientset.CoreV1().ServiceAccounts(saNamespace).CreateToken(context.TODO(), saName, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expiration,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	return tokenRequest.Status.Token
+}
+
+// updateToolchainClusterToken sets the given token in the secret of the ToolchainCluster, and annotates the ToolchainCluster
+// so that it is reconciled (and the cached cluster config is refreshed)
+func (a *Awaitility) updateToolchainClusterToken(t *testing.T, toolchainClusterName, secretName string, token []byte) {
+	_, err := Update(t, a, secretName, func(s *corev1.Secret) {
+		s.Data[ToolchainClusterTokenKey] = token
+	})
+	require.NoError(t, err)
+	_, err = a.UpdateToolchainCluster(t, toolchainClusterName, func(tc *toolchainv1alpha1.ToolchainCluster) {
+		if tc.Annotations == nil {
+			tc.Annotations = map[string]string{}
+		}
+		tc.Annotations["toolchain-e2e.dev.openshift.com/token-rotated-at"] = time.Now().Format(time.RFC3339Nano)
+	})
+	require.NoError(t, err)
+}
+
+// VerifyToolchainClusterAccess verifies that the config of the ToolchainCluster pointing to the cluster of the other awaitility
+// grants access to the ToolchainClusters in the namespace of the other operator
+func (a *Awaitility) VerifyToolchainClusterAccess(t *testing.T, otherAwait *Awaitility) {
+	config := a.GetToolchainClusterConfig(t, otherAwait)
+	cl, err := client.New(config.RestConfig, client.Options{Scheme: otherAwait.Client.Scheme()})
+	require.NoError(t, err)
+	err = cl.List(context.TODO(), &toolchainv1alpha1.ToolchainClusterList{}, client.InNamespace(otherAwait.Namespace))
+	require.NoError(t, err, "the token of ToolchainCluster '%s' does not grant access to namespace '%s'", config.Name, otherAwait.Namespace)
+}
+
+// serviceAccountFromToken returns the namespace and name of the ServiceAccount which is the subject of the given (JWT) token
+func serviceAccountFromToken(token string) (string, string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("invalid token: expected 3 parts but got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", "", err
+	}
+	// the subject of a ServiceAccount token is `system:serviceaccount:<namespace>:<name>`
+	subject := strings.Split(claims.Subject, ":")
+	if len(subject) != 4 || subject[0] != "system" || subject[1] != "serviceaccount" {
+		return "", "", fmt.Errorf("the subject of the token is not a ServiceAccount: '%s'", claims.Subject)
+	}
+	return subject[2], subject[3], nil
+}
@@ -0,0 +1,97 @@
+package wait
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Update retrieves the object with the given name in the namespace of the given awaitility, applies the given mutator
+// and updates it. In case of conflict (ie, if the object has been modified in the meantime) or of transient error of the
+// API server (see TransientErrorKind), the latest version of the object is retrieved and the mutator is applied again,
+// until the update succeeds or the timeout of the awaitility is reached. Any other error (eg, a rejection by a webhook)
+// is returned immediately. Returns the updated object.
+func Update[T client.Object](t *testing.T, a *Awaitility, name string, mutate func(T)) (T, error) {
+	return UpdateInNamespace(t, a, a.Namespace, name, mutate)
+}
+
+// UpdateInNamespace is the same as Update, for an object in the given namespace
+func UpdateInNamespace[T client.Object](t *testing.T, a *Awaitility, namespace, name string, mutate func(T)) (T, error) {
+	return update(t, a, types.NamespacedName{Namespace: namespace, Name: name}, mutate, false)
+}
+
+// UpdateStatus is the same as Update, for the status of the object
+func UpdateStatus[T client.Object](t *testing.T, a *Awaitility, name string, mutate func(T)) (T, error) {
+	return update(t, a, types.NamespacedName{Namespace: a.Namespace, Name: name}, mutate, true)
+}
+
+func update[T client.Object](t *testing.T, a *Awaitility, namespacedName types.NamespacedName, mutate func(T), status bool) (T, error) {
+	var obj T
+	kind := reflect.TypeOf(obj).Elem().Name()
+	err := retry.OnError(a.conflictBackoff(), isRetriableUpdateError, func() error {
+		// retrieve a fresh object at each attempt, so that no field of the previous attempt is left over
+		obj = newObject[T]()
+		if err := a.Client.Get(context.TODO(), namespacedName, obj); err != nil {
+			return recordUpdateError(t, kind, namespacedName.Name, err)
+		}
+		mutate(obj)
+		var err error
+		if status {
+			err = a.Client.Status().Update(context.TODO(), obj)
+		} else {
+			err = a.Client.Update(context.TODO(), obj)
+		}
+		return recordUpdateError(t, kind, namespacedName.Name, err)
+	})
+	return obj, err
+}
+
+// isRetriableUpdateError returns `true` if the given error is a conflict or a transient error of the API server
+func isRetriableUpdateError(err error) bool {
+	_, transient := TransientErrorKind(err)
+	return transient || apierrors.IsConflict(err)
+}
+
+// recordUpdateError logs and records the given error if it is retried, and returns it as-is
+func recordUpdateError(t *testing.T, kind, name string, err error) error {
+	if apierrors.IsConflict(err) {
+		t.Logf("conflict while updating %s '%s': %s. Will retry again...", kind, name, err.Error())
+		report.RecordTransientError(t, report.ErrorConflict, kind)
+	} else if errKind, transient := TransientErrorKind(err); transient {
+		t.Logf("transient error while updating %s '%s': %s. Will retry again...", kind, name, err.Error())
+		report.RecordTransientError(t, errKind, kind)
+	}
+	return err
+}
+
+// conflictBackoff returns the backoff used when retrying on conflicts, based on the retry interval and timeout of the awaitility
+// (or on the default retry interval if the awaitility has none)
+func (a *Awaitility) conflictBackoff() wait.Backoff {
+	interval := a.RetryInterval
+	if interval <= 0 {
+		interval = DefaultRetryInterval
+	}
+	steps := int(a.Timeout / interval)
+	if steps < 1 {
+		steps = 1
+	}
+	return wait.Backoff{
+		Steps:    steps,
+		Duration: interval,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+}
+
+// newObject returns a new, empty instance of the type pointed to by T
+func newObject[T client.Object]() T {
+	var obj T
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(T)
+}
@@ -0,0 +1,176 @@
+package wait_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdate(t *testing.T) {
+	// given
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oddity",
+			Namespace: commontest.HostOperatorNs,
+		},
+		Spec: toolchainv1alpha1.SpaceSpec{
+			TierName: "base",
+		},
+	}
+	await := &wait.Awaitility{
+		Client:        commontest.NewFakeClient(t, space),
+		Namespace:     commontest.HostOperatorNs,
+		RetryInterval: time.Millisecond,
+		Timeout:       time.Second,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// when
+		updated, err := wait.Update(t, await, "oddity", func(s *toolchainv1alpha1.Space) {
+			s.Spec.TierName = "advanced"
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "advanced", updated.Spec.TierName)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// when
+		_, err := wait.Update(t, await, "unknown", func(s *toolchainv1alpha1.Space) {
+			s.Spec.TierName = "advanced"
+		})
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("retry on conflict with a fresh object", func(t *testing.T) {
+		// given
+		space := &toolchainv1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "oddity",
+				Namespace: commontest.HostOperatorNs,
+				Labels: map[string]string{
+					"first":  "true",
+					"second": "true",
+				},
+			},
+		}
+		cl := commontest.NewFakeClient(t, space)
+		attempts := 0
+		cl.MockUpdate = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+			attempts++
+			if attempts == 1 {
+				// a concurrent update removes a label before the first attempt is applied
+				current := &toolchainv1alpha1.Space{}
+				require.NoError(t, cl.Client.Get(ctx, client.ObjectKeyFromObject(obj), current))
+				delete(current.Labels, "second")
+				require.NoError(t, cl.Client.Update(ctx, current))
+				return apierrors.NewConflict(schema.GroupResource{Resource: "spaces"}, obj.GetName(), assert.AnError)
+			}
+			return cl.Client.Update(ctx, obj, opts...)
+		}
+		await := &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: time.Millisecond,
+			Timeout:       time.Second,
+		}
+
+		// when
+		updated, err := wait.Update(t, await, "oddity", func(s *toolchainv1alpha1.Space) {
+			s.Labels["third"] = "true"
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.Equal(t, map[string]string{
+			"first": "true",
+			"third": "true",
+		}, updated.Labels)
+	})
+
+	t.Run("retry on transient error", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, space.DeepCopy())
+		attempts := 0
+		cl.MockGet = func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			attempts++
+			if attempts == 1 {
+				return apierrors.NewTooManyRequests("slow down", 1)
+			}
+			return cl.Client.Get(ctx, key, obj, opts...)
+		}
+		await := &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: time.Millisecond,
+			Timeout:       time.Second,
+		}
+
+		// when
+		updated, err := wait.Update(t, await, "oddity", func(s *toolchainv1alpha1.Space) {
+			s.Spec.TierName = "advanced"
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.Equal(t, "advanced", updated.Spec.TierName)
+	})
+
+	t.Run("no retry on other errors", func(t *testing.T) {
+		// given
+		cl := commontest.NewFakeClient(t, space.DeepCopy())
+		attempts := 0
+		cl.MockUpdate = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+			attempts++
+			return apierrors.NewBadRequest("rejected by the webhook")
+		}
+		await := &wait.Awaitility{
+			Client:        cl,
+			Namespace:     commontest.HostOperatorNs,
+			RetryInterval: time.Millisecond,
+			Timeout:       time.Second,
+		}
+
+		// when
+		_, err := wait.Update(t, await, "oddity", func(s *toolchainv1alpha1.Space) {
+			s.Spec.TierName = "advanced"
+		})
+
+		// then
+		require.EqualError(t, err, "rejected by the webhook")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("no retry interval", func(t *testing.T) {
+		// given
+		await := &wait.Awaitility{
+			Client:    commontest.NewFakeClient(t, space.DeepCopy()),
+			Namespace: commontest.HostOperatorNs,
+			Timeout:   time.Second,
+		}
+
+		// when
+		updated, err := wait.Update(t, await, "oddity", func(s *toolchainv1alpha1.Space) {
+			s.Spec.TierName = "advanced"
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "advanced", updated.Spec.TierName)
+	})
+}
@@ -0,0 +1,82 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// WebhookCertsSecretName the name of the Secret which contains the serving certificate of the member webhook and its CA
+	WebhookCertsSecretName = "webhook-certs"
+	// MutatingWebhookConfigurationName the name of the MutatingWebhookConfiguration of the member webhook
+	MutatingWebhookConfigurationName = "member-operator-webhook"
+	// ValidatingWebhookConfigurationName the name of the ValidatingWebhookConfiguration of the member webhook
+	ValidatingWebhookConfigurationName = "member-operator-validating-webhook"
+)
+
+// GetWebhookCA returns the CA of the serving certificate of the member webhook
+func (a *MemberAwaitility) GetWebhookCA() ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: WebhookCertsSecretName}, secret); err != nil {
+		return nil, err
+	}
+	return secret.Data["ca-cert.pem"], nil
+}
+
+// WaitForWebhookCertsRotated waits until the Secret with the serving certificate of the member webhook exists with a CA
+// which is different from the given one, and returns the new CA
+func (a *MemberAwaitility) WaitForWebhookCertsRotated(t *testing.T, previousCA []byte) ([]byte, error) {
+	t.Logf("waiting for Secret '%s' in namespace '%s' to be recreated with a new CA", WebhookCertsSecretName, a.Namespace)
+	var ca []byte
+	err := a.poll(func() (done bool, err error) {
+		secret := &corev1.Secret{}
+		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: WebhookCertsSecretName}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		ca = secret.Data["ca-cert.pem"]
+		return len(ca) > 0 && len(secret.Data["server-cert.pem"]) > 0 && len(secret.Data["server-key.pem"]) > 0 && !bytes.Equal(ca, previousCA), nil
+	})
+	return ca, err
+}
+
+// WaitForWebhookConfigurationsCABundle waits until all the webhooks of the mutating and validating webhook configurations
+// of the member webhook have the given CA bundle
+func (a *MemberAwaitility) WaitForWebhookConfigurationsCABundle(t *testing.T, ca []byte) error {
+	t.Logf("waiting for the webhook configurations '%s' and '%s' to have the new CA bundle", MutatingWebhookConfigurationName, ValidatingWebhookConfigurationName)
+	var unmatched []string
+	err := a.poll(func() (done bool, err error) {
+		unmatched = []string{}
+		mutating := &admv1.MutatingWebhookConfiguration{}
+		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: MutatingWebhookConfigurationName}, mutating); err != nil {
+			return false, err
+		}
+		for _, w := range mutating.Webhooks {
+			if !bytes.Equal(w.ClientConfig.CABundle, ca) {
+				unmatched = append(unmatched, w.Name)
+			}
+		}
+		validating := &admv1.ValidatingWebhookConfiguration{}
+		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: ValidatingWebhookConfigurationName}, validating); err != nil {
+			return false, err
+		}
+		for _, w := range validating.Webhooks {
+			if !bytes.Equal(w.ClientConfig.CABundle, ca) {
+				unmatched = append(unmatched, w.Name)
+			}
+		}
+		return len(unmatched) == 0, nil
+	})
+	if err != nil {
+		t.Logf("webhooks with an outdated CA bundle: %v", unmatched)
+	}
+	return err
+}
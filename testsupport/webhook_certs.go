@@ -0,0 +1,145 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WebhookEnforcementTimeout the time to wait until the member webhook serves the rotated certificate, which depends on
+// the propagation of the Secret to the volume mounted in its pod (ie, up to the sync period of the kubelet)
+const WebhookEnforcementTimeout = 3 * time.Minute
+
+const webhookRestrictionMsg = "this is a Dev Sandbox enforced restriction"
+
+// RotateWebhookCerts forces the rotation of the serving certificate of the member webhook: the Secret with the certificate is deleted,
+// then the MemberOperatorConfig is modified so that the member operator reconciles the webhook (ie, generates a new certificate
+// and updates the CA bundle of the webhook configurations). It waits until the webhook configurations have the new CA bundle, and returns it.
+func RotateWebhookCerts(t *testing.T, hostAwait *wait.HostAwaitility, memberAwait *wait.MemberAwaitility) []byte {
+	previousCA, err := memberAwait.GetWebhookCA()
+	require.NoError(t, err)
+
+	t.Logf("deleting Secret '%s' in namespace '%s'", wait.WebhookCertsSecretName, memberAwait.Namespace)
+	err = memberAwait.Client.Delete(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: memberAwait.Namespace,
+			Name:      wait.WebhookCertsSecretName,
+		},
+	})
+	require.NoError(t, err)
+
+	// any change in the MemberOperatorConfig triggers the reconcile of the webhook: the refresh period of the member status is changed
+	// since it has no effect on the webhook (the original config is restored at the end of the test)
+	memberConfig := memberAwait.GetMemberOperatorConfig(t)
+	require.NotNil(t, memberConfig, "MemberOperatorConfig not found in namespace '%s'", memberAwait.Namespace)
+	refreshPeriod := "6s"
+	if memberConfig.Spec.MemberStatus.RefreshPeriod != nil && *memberConfig.Spec.MemberStatus.RefreshPeriod == refreshPeriod {
+		refreshPeriod = "7s"
+	}
+	memberConfig = testconfig.ModifyMemberOperatorConfigObj(memberConfig, testconfig.MemberStatus().RefreshPeriod(refreshPeriod))
+	hostAwait.UpdateToolchainConfig(t, testconfig.Members().Default(memberConfig.Spec))
+	VerifyMemberOperatorConfig(t, hostAwait, memberAwait, wait.UntilMemberConfigMatches(memberConfig.Spec))
+
+	ca, err := memberAwait.WaitForWebhookCertsRotated(t, previousCA)
+	require.NoError(t, err)
+	err = memberAwait.WaitForWebhookConfigurationsCABundle(t, ca)
+	require.NoError(t, err)
+	return ca
+}
+
+// GetWebhookAndOperatorPodRestarts returns the number of restarts of the containers of the pods of the member webhook and member operator,
+// by pod name, so that it can be verified that they were not restarted (ie, the pods are the same and their containers did not restart)
+func GetWebhookAndOperatorPodRestarts(t *testing.T, memberAwait *wait.MemberAwaitility) map[string]int32 {
+	restarts := map[string]int32{}
+	for _, labels := range []client.MatchingLabels{
+		{"app": "member-operator-webhook"},
+		{"control-plane": "controller-manager"},
+	} {
+		pods := &corev1.PodList{}
+		err := memberAwait.Client.List(context.TODO(), pods, client.InNamespace(memberAwait.Namespace), labels)
+		require.NoError(t, err)
+		require.NotEmpty(t, pods.Items, "no pod with labels %v in namespace '%s'", labels, memberAwait.Namespace)
+		for _, p := range pods.Items {
+			for _, s := range p.Status.ContainerStatuses {
+				restarts[p.Name] += s.RestartCount
+			}
+		}
+	}
+	return restarts
+}
+
+// VerifyWebhooksEnforced verifies that the member webhook mutates the pods created in the given user namespace (by setting the sandbox
+// priority class) and rejects a RoleBinding created by the given user which gives access to all authenticated users. Since the webhooks
+// are ignored when they fail, the checks are retried until they pass or the WebhookEnforcementTimeout is reached.
+func VerifyWebhooksEnforced(t *testing.T, memberAwait *wait.MemberAwaitility, namespace, username string) {
+	t.Run("mutating webhook sets the priority class of the pods", func(t *testing.T) {
+		var priorityClassName string
+		err := memberAwait.PollWithTimeout(WebhookEnforcementTimeout, func() (done bool, err error) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "webhook-check-" + uuid.Must(uuid.NewV4()).String()[:8],
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "sleep",
+						Image:   "busybox",
+						Command: []string{"sleep", "36000"},
+					}},
+				},
+			}
+			if err := memberAwait.Client.Create(context.TODO(), pod); err != nil {
+				return false, err
+			}
+			defer func() {
+				_ = memberAwait.Client.Delete(context.TODO(), pod)
+			}()
+			priorityClassName = pod.Spec.PriorityClassName
+			return priorityClassName == "sandbox-users-pods", nil
+		})
+		require.NoError(t, err, "pod was not mutated by the webhook: priority class is '%s'", priorityClassName)
+	})
+
+	t.Run("validating webhook rejects the rolebindings giving access to all users", func(t *testing.T) {
+		userAwait, err := memberAwait.Impersonate(username)
+		require.NoError(t, err)
+		cl := userAwait.Client
+		var createErr error
+		err = memberAwait.PollWithTimeout(WebhookEnforcementTimeout, func() (done bool, err error) {
+			rb := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "webhook-check-" + uuid.Must(uuid.NewV4()).String()[:8],
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+				Subjects: []rbacv1.Subject{{
+					Kind:     "Group",
+					APIGroup: "rbac.authorization.k8s.io",
+					Name:     "system:authenticated",
+				}},
+			}
+			createErr = cl.Create(context.TODO(), rb)
+			if createErr == nil {
+				// the webhook was ignored: delete the rolebinding and try again
+				_ = memberAwait.Client.Delete(context.TODO(), rb)
+				return false, nil
+			}
+			return true, nil
+		})
+		require.NoError(t, err, "rolebinding was not rejected by the webhook")
+		require.ErrorContains(t, createErr, webhookRestrictionMsg)
+	})
+}
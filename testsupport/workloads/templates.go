@@ -0,0 +1,447 @@
+package workloads
+
+import (
+	openshiftappsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AppLabelKey the label set on all the resources (and pods) of a workload, with the name of the workload as the value
+	AppLabelKey = "app"
+	// NginxPort the port on which the nginx containers listen (the unprivileged image does not bind port 80, so that it can
+	// run with the `restricted` security context constraints and pod security standard)
+	NginxPort = 8080
+
+	nginxImage = "nginxinc/nginx-unprivileged:1.25-alpine"
+	sleepImage = "busybox"
+	pauseImage = "gcr.io/google_containers/pause-amd64:3.2"
+)
+
+// Option an option to configure the workload of a template
+type Option func(*options)
+
+type options struct {
+	replicas          int32
+	labels            map[string]string
+	priorityClassName string
+	storage           string
+	nodeSelector      map[string]string
+}
+
+// WithReplicas sets the number of replicas of the Deployment of the workload (1 by default)
+func WithReplicas(replicas int32) Option {
+	return func(o *options) {
+		o.replicas = replicas
+	}
+}
+
+// WithLabels sets the given labels on the pods of the workload, in addition to the `app` label
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		for k, v := range labels {
+			o.labels[k] = v
+		}
+	}
+}
+
+// WithPriorityClassName sets the priority class of the pods of the workload
+func WithPriorityClassName(name string) Option {
+	return func(o *options) {
+		o.priorityClassName = name
+	}
+}
+
+// WithStorage sets the requested storage of the PersistentVolumeClaim of the workload (`10Mi` by default)
+func WithStorage(storage string) Option {
+	return func(o *options) {
+		o.storage = storage
+	}
+}
+
+// WithNodeSelector sets the node selector of the pods of the workload
+func WithNodeSelector(nodeSelector map[string]string) Option {
+	return func(o *options) {
+		o.nodeSelector = nodeSelector
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		replicas: 1,
+		labels:   map[string]string{},
+		storage:  "10Mi",
+	}
+	for _, apply := range opts {
+		apply(o)
+	}
+	return o
+}
+
+// Nginx a Deployment of nginx (with a single replica by default) and a Service which exposes it on the NginxPort
+func Nginx(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		container := newContainer("nginx", nginxImage)
+		container.Ports = []corev1.ContainerPort{{ContainerPort: NginxPort, Protocol: corev1.ProtocolTCP}}
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt(NginxPort)},
+			},
+		}
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				newDeployment(namespace, name, o, container),
+				newService(namespace, name),
+			},
+			Pods: int(o.replicas),
+		}
+	}
+}
+
+// Sleep a Deployment of idle containers (with a single replica by default), eg. for the workloads to idle or to count in the quotas
+func Sleep(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		container := newSleepContainer()
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				newDeployment(namespace, name, o, container),
+			},
+			Pods: int(o.replicas),
+		}
+	}
+}
+
+// SleepPod a standalone Pod with an idle container
+func SleepPod(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				newPod(namespace, name, o, newSleepContainer()),
+			},
+			Pods: 1,
+		}
+	}
+}
+
+// SleepReplicaSet a standalone ReplicaSet of idle containers (with a single replica by default)
+func SleepReplicaSet(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		replicas := o.replicas
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				&appsv1.ReplicaSet{
+					ObjectMeta: newObjectMeta(namespace, name),
+					Spec: appsv1.ReplicaSetSpec{
+						Replicas: &replicas,
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{AppLabelKey: name}},
+						Template: newPodTemplate(name, o, newSleepContainer()),
+					},
+				},
+			},
+			Pods: int(o.replicas),
+		}
+	}
+}
+
+// SleepReplicationController a standalone ReplicationController of idle containers (with a single replica by default)
+func SleepReplicationController(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		replicas := o.replicas
+		template := newPodTemplate(name, o, newSleepContainer())
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				&corev1.ReplicationController{
+					ObjectMeta: newObjectMeta(namespace, name),
+					Spec: corev1.ReplicationControllerSpec{
+						Replicas: &replicas,
+						Selector: map[string]string{AppLabelKey: name},
+						Template: &template,
+					},
+				},
+			},
+			Pods: int(o.replicas),
+		}
+	}
+}
+
+// SleepDeploymentConfig a DeploymentConfig of idle containers (with a single replica by default)
+func SleepDeploymentConfig(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		template := newPodTemplate(name, o, newSleepContainer())
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				&openshiftappsv1.DeploymentConfig{
+					ObjectMeta: newObjectMeta(namespace, name),
+					Spec: openshiftappsv1.DeploymentConfigSpec{
+						Replicas: o.replicas,
+						Selector: map[string]string{AppLabelKey: name},
+						Template: &template,
+					},
+				},
+			},
+			Pods: int(o.replicas),
+		}
+	}
+}
+
+// SleepDaemonSet a DaemonSet of idle containers. Since the DaemonSet runs a pod on each (schedulable) node of the cluster,
+// the number of pods of the workload is unknown and the readiness of the workload does not wait for its pods.
+func SleepDaemonSet(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				&appsv1.DaemonSet{
+					ObjectMeta: newObjectMeta(namespace, name),
+					Spec: appsv1.DaemonSetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{AppLabelKey: name}},
+						Template: newPodTemplate(name, o, newSleepContainer()),
+					},
+				},
+			},
+		}
+	}
+}
+
+// SleepJob a Job with an idle container (which does not complete before 10 hours)
+func SleepJob(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		template := newPodTemplate(name, o, newSleepContainer())
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				&batchv1.Job{
+					ObjectMeta: newObjectMeta(namespace, name),
+					Spec: batchv1.JobSpec{
+						Template: template,
+					},
+				},
+			},
+			Pods: 1,
+		}
+	}
+}
+
+// Probe a standalone Pod which runs the given command once (eg, to probe the network), and which is never restarted.
+// Since the pod completes, the readiness of the workload does not wait for it (see `wait.PodCompleted`).
+func Probe(command ...string) Template {
+	return func(namespace, name string) Workload {
+		container := newContainer("probe", sleepImage)
+		container.Command = command
+		pod := newPod(namespace, name, newOptions(), container)
+		pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects:   []client.Object{pod},
+		}
+	}
+}
+
+// Pause a standalone Pod which does nothing but reserve the given memory (as its request and limit), eg. to fill a node
+func Pause(memory resource.Quantity, opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(opts...)
+		container := newContainer("pause", pauseImage)
+		container.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: memory},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: memory},
+		}
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects: []client.Object{
+				newPod(namespace, name, o, container),
+			},
+			Pods: 1,
+		}
+	}
+}
+
+// PVCConsumer a PersistentVolumeClaim (of `10Mi` by default) and a Deployment with a single replica which mounts it
+func PVCConsumer(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		o := newOptions(append(opts, WithReplicas(1))...)
+		container := newContainer("consumer", sleepImage)
+		container.Command = []string{"sh", "-c", "date > /data/started && sleep 36000"}
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+		deployment := newDeployment(namespace, name, o, container)
+		deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+			},
+		}}
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: newObjectMeta(namespace, name),
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(o.storage),
+					},
+				},
+			},
+		}
+		return Workload{
+			Name:      name,
+			Namespace: namespace,
+			Objects:   []client.Object{pvc, deployment},
+			Pods:      1,
+			ready:     []readyCheck{persistentVolumeClaimBound},
+		}
+	}
+}
+
+// RouteExposedApp the Nginx workload, exposed with an edge-terminated Route (with the same name as the workload)
+func RouteExposedApp(opts ...Option) Template {
+	return func(namespace, name string) Workload {
+		w := Nginx(opts...)(namespace, name)
+		w.Objects = append(w.Objects, &routev1.Route{
+			ObjectMeta: newObjectMeta(namespace, name),
+			Spec: routev1.RouteSpec{
+				To: routev1.RouteTargetReference{
+					Kind: "Service",
+					Name: name,
+				},
+				Port: &routev1.RoutePort{
+					TargetPort: intstr.FromInt(NginxPort),
+				},
+				TLS: &routev1.TLSConfig{
+					Termination:                   routev1.TLSTerminationEdge,
+					InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+				},
+			},
+		})
+		w.ready = append(w.ready, routeAvailable("/"))
+		return w
+	}
+}
+
+func newObjectMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		Labels: map[string]string{
+			AppLabelKey: name,
+		},
+	}
+}
+
+func newDeployment(namespace, name string, o *options, container corev1.Container) *appsv1.Deployment {
+	replicas := o.replicas
+	return &appsv1.Deployment{
+		ObjectMeta: newObjectMeta(namespace, name),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{AppLabelKey: name},
+			},
+			Template: newPodTemplate(name, o, container),
+		},
+	}
+}
+
+func newPod(namespace, name string, o *options, container corev1.Container) *corev1.Pod {
+	template := newPodTemplate(name, o, container)
+	template.ObjectMeta.Namespace = namespace
+	template.ObjectMeta.Name = name
+	return &corev1.Pod{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+}
+
+// newPodTemplate returns the template of the pods of the workload with the given name, with the `app` label and the labels of the options
+func newPodTemplate(name string, o *options, container corev1.Container) corev1.PodTemplateSpec {
+	podLabels := map[string]string{}
+	for k, v := range o.labels {
+		podLabels[k] = v
+	}
+	podLabels[AppLabelKey] = name
+	zero := int64(0)
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec: corev1.PodSpec{
+			TerminationGracePeriodSeconds: &zero,
+			PriorityClassName:             o.priorityClassName,
+			NodeSelector:                  o.nodeSelector,
+			Containers:                    []corev1.Container{container},
+		},
+	}
+}
+
+func newService(namespace, name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: newObjectMeta(namespace, name),
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{AppLabelKey: name},
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       NginxPort,
+				TargetPort: intstr.FromInt(NginxPort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// newSleepContainer returns an idle container
+func newSleepContainer() corev1.Container {
+	container := newContainer("sleep", sleepImage)
+	container.Command = []string{"sleep", "36000"} // 10 hours
+	return container
+}
+
+// newContainer returns a container with small resource requests and limits, which fit in the quotas of the user namespaces
+func newContainer(name, image string) corev1.Container {
+	return corev1.Container{
+		Name:  name,
+		Image: image,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1m"),
+				corev1.ResourceMemory: resource.MustParse("8Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("80Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: new(bool),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Workload the resources of a workload in a user namespace, along with the number of pods which must be running
+// (and the other conditions which must be met) for the workload to be considered as ready
+type Workload struct {
+	Name      string
+	Namespace string
+	Objects   []client.Object
+	// Pods the number of pods of the workload
+	Pods int
+	// ready the additional conditions which must be met for the workload to be ready (eg, the PVC is bound)
+	ready []readyCheck
+}
+
+type readyCheck func(t *testing.T, memberAwait *wait.MemberAwaitility, w Workload) error
+
+// Template returns the workload with the given name in the given namespace
+type Template func(namespace, name string) Workload
+
+// Apply creates the resources of the workload from the given template in the given namespace.
+// The resources are deleted at the end of the test.
+func Apply(t *testing.T, memberAwait *wait.MemberAwaitility, namespace, name string, template Template) Workload {
+	w := template(namespace, name)
+	for _, obj := range w.Objects {
+		err := memberAwait.CreateWithCleanup(t, obj)
+		require.NoError(t, err, "failed to create the %T '%s' of workload '%s' in namespace '%s'", obj, obj.GetName(), name, namespace)
+	}
+	return w
+}
+
+// ApplyAndWaitUntilReady creates the resources of the workload from the given template in the given namespace
+// and waits until the workload is ready. It returns the pods of the workload.
+func ApplyAndWaitUntilReady(t *testing.T, memberAwait *wait.MemberAwaitility, namespace, name string, template Template, criteria ...wait.PodWaitCriterion) []corev1.Pod {
+	return Apply(t, memberAwait, namespace, name, template).WaitUntilReady(t, memberAwait, criteria...)
+}
+
+// WaitUntilReady waits until all the pods of the workload are running (and match the given criteria), and until
+// the other resources of the workload are ready. It returns the pods of the workload.
+func (w Workload) WaitUntilReady(t *testing.T, memberAwait *wait.MemberAwaitility, criteria ...wait.PodWaitCriterion) []corev1.Pod {
+	t.Logf("waiting until workload '%s' in namespace '%s' is ready", w.Name, w.Namespace)
+	var pods []corev1.Pod
+	if w.Pods > 0 {
+		var err error
+		pods, err = memberAwait.WaitForPods(t, w.Namespace, w.Pods, append(criteria, wait.PodRunning(), wait.WithPodLabel(AppLabelKey, w.Name))...)
+		require.NoError(t, err, "the pods of workload '%s' in namespace '%s' are not running", w.Name, w.Namespace)
+	}
+	// the other conditions are checked once the pods are running, since a PVC may only be bound when its consumer is scheduled
+	for _, ready := range w.ready {
+		require.NoError(t, ready(t, memberAwait, w), "workload '%s' in namespace '%s' is not ready", w.Name, w.Namespace)
+	}
+	return pods
+}
+
+func persistentVolumeClaimBound(t *testing.T, memberAwait *wait.MemberAwaitility, w Workload) error {
+	_, err := memberAwait.WaitUntilPersistentVolumeClaimBound(t, w.Namespace, w.Name)
+	return err
+}
+
+func routeAvailable(path string) readyCheck {
+	return func(t *testing.T, memberAwait *wait.MemberAwaitility, w Workload) error {
+		_, err := memberAwait.WaitForRouteToBeAvailable(t, w.Namespace, w.Name, path)
+		return err
+	}
+}
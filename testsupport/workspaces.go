@@ -0,0 +1,118 @@
+package testsupport
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// WorkspacesListingStats the durations of the calls to list the workspaces via the proxy
+type WorkspacesListingStats struct {
+	Count   int
+	Average time.Duration
+	Median  time.Duration
+	Max     time.Duration
+}
+
+// WaitForWorkspaces waits until the list of workspaces returned by the proxy for the user with the given token
+// contains the expected number of workspaces (the proxy relies on caches which are eventually consistent)
+func WaitForWorkspaces(t *testing.T, hostAwait *wait.HostAwaitility, token string, expectedCount int) []toolchainv1alpha1.Workspace {
+	t.Logf("waiting for %d workspaces to be listed via the proxy", expectedCount)
+	proxyCl, err := hostAwait.CreateAPIProxyClient(t, token, hostAwait.APIProxyURL)
+	require.NoError(t, err)
+	workspaces := &toolchainv1alpha1.WorkspaceList{}
+	err = hostAwait.PollWithTimeout(2*hostAwait.Timeout, func() (done bool, err error) {
+		workspaces = &toolchainv1alpha1.WorkspaceList{}
+		if err := proxyCl.List(context.TODO(), workspaces); err != nil {
+			return false, err
+		}
+		return len(workspaces.Items) == expectedCount, nil
+	})
+	require.NoError(t, err, "expected %d workspaces but got %d", expectedCount, len(workspaces.Items))
+	return workspaces.Items
+}
+
+// MeasureWorkspacesListing lists the workspaces via the proxy the given number of times on behalf of the user with the given token,
+// verifies that each call returns the expected number of workspaces and returns the stats about the duration of the calls
+func MeasureWorkspacesListing(t *testing.T, hostAwait *wait.HostAwaitility, token string, expectedCount, iterations int) WorkspacesListingStats {
+	proxyCl, err := hostAwait.CreateAPIProxyClient(t, token, hostAwait.APIProxyURL)
+	require.NoError(t, err)
+	durations := make([]time.Duration, 0, iterations)
+	var total time.Duration
+	for i := 0; i < iterations; i++ {
+		workspaces := &toolchainv1alpha1.WorkspaceList{}
+		start := time.Now()
+		err := proxyCl.List(context.TODO(), workspaces)
+		duration := time.Since(start)
+		require.NoError(t, err)
+		require.Len(t, workspaces.Items, expectedCount)
+		durations = append(durations, duration)
+		total += duration
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	stats := WorkspacesListingStats{
+		Count:   expectedCount,
+		Average: total / time.Duration(iterations),
+		Median:  durations[iterations/2],
+		Max:     durations[iterations-1],
+	}
+	t.Logf("listed %d workspaces %d times: average=%s median=%s max=%s", expectedCount, iterations, stats.Average, stats.Median, stats.Max)
+	return stats
+}
+
+// WorkspaceAccessDecision a function to verify the outcome of a request to get a workspace via the proxy
+type WorkspaceAccessDecision struct {
+	Description string
+	Match       func(workspace *toolchainv1alpha1.Workspace, err error) bool
+}
+
+// WorkspaceHasRole verifies that the workspace is accessible, with the given role for the user
+func WorkspaceHasRole(role string) WorkspaceAccessDecision {
+	return WorkspaceAccessDecision{
+		Description: "workspace accessible with role " + role,
+		Match: func(workspace *toolchainv1alpha1.Workspace, err error) bool {
+			return err == nil && workspace.Status.Role == role
+		},
+	}
+}
+
+// WorkspaceIsNotAccessible verifies that the workspace is not accessible (anymore) by the user,
+// either because the workspace is not found or because the user is not allowed to use the proxy at all
+func WorkspaceIsNotAccessible() WorkspaceAccessDecision {
+	return WorkspaceAccessDecision{
+		Description: "workspace not accessible",
+		Match: func(_ *toolchainv1alpha1.Workspace, err error) bool {
+			return apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err)
+		},
+	}
+}
+
+// WaitForWorkspaceAccessDecision gets the workspace with the given name via the proxy, on behalf of the user with the given token,
+// until the outcome matches the given decision. Returns the time it took, ie, the delay for a change to propagate through the caches of the proxy.
+// Fails the test if the outcome does not match within the given max delay.
+func WaitForWorkspaceAccessDecision(t *testing.T, hostAwait *wait.HostAwaitility, token, workspaceName string, maxDelay time.Duration, decision WorkspaceAccessDecision) time.Duration {
+	t.Logf("waiting for '%s' for workspace '%s' via the proxy", decision.Description, workspaceName)
+	proxyCl, err := hostAwait.CreateAPIProxyClient(t, token, hostAwait.APIProxyURL)
+	require.NoError(t, err)
+	start := time.Now()
+	var lastErr error
+	workspace := &toolchainv1alpha1.Workspace{}
+	err = hostAwait.PollWithTimeout(maxDelay, func() (done bool, err error) {
+		workspace = &toolchainv1alpha1.Workspace{}
+		lastErr = proxyCl.Get(context.TODO(), types.NamespacedName{Name: workspaceName}, workspace)
+		return decision.Match(workspace, lastErr), nil
+	})
+	delay := time.Since(start)
+	require.NoError(t, err, "no '%s' for workspace '%s' within %s (last error: %v, last status: %+v)", decision.Description, workspaceName, maxDelay, lastErr, workspace.Status)
+	t.Logf("'%s' for workspace '%s' after %s", decision.Description, workspaceName, delay)
+	return delay
+}